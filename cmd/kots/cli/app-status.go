@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/replicatedhq/kots/pkg/auth"
+	"github.com/replicatedhq/kots/pkg/handlers"
 	"github.com/replicatedhq/kots/pkg/k8sutil"
 	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/print"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -41,6 +45,10 @@ func AppStatusCmd() *cobra.Command {
 
 			log := logger.NewCLILogger(cmd.OutOrStdout())
 
+			if v.GetBool("history") {
+				return printAppStatusHistory(appSlug, v, log)
+			}
+
 			stopCh := make(chan struct{})
 			defer close(stopCh)
 
@@ -113,6 +121,49 @@ func AppStatusCmd() *cobra.Command {
 
 	cmd.Flags().StringP("namespace", "n", "default", "namespace in which kots/kotsadm is installed")
 	cmd.Flags().String("slug", "", "the application slug to get the status of")
+	cmd.Flags().Bool("history", false, "show the state transitions of the app and its resources, and how long they spent in each state")
+	cmd.Flags().Duration("since", 7*24*time.Hour, "how far back to show the history when --history is set")
+	cmd.Flags().StringP("output", "o", "", "output format when --history is set. supported values: json")
 
 	return cmd
 }
+
+func printAppStatusHistory(appSlug string, v *viper.Viper, log *logger.CLILogger) error {
+	output := v.GetString("output")
+	if output != "json" && output != "" {
+		return errors.Errorf("output format %s not supported (allowed formats are: json)", output)
+	}
+
+	since := v.GetDuration("since")
+	if since <= 0 {
+		return errors.New("--since must be positive")
+	}
+
+	namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+	if err != nil {
+		return errors.Wrap(err, "failed to get namespace")
+	}
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+	if err != nil {
+		return err
+	}
+
+	end := time.Now()
+	query := url.Values{}
+	query.Set("start", end.Add(-since).Format(time.RFC3339))
+	query.Set("end", end.Format(time.RFC3339))
+
+	historyURL := fmt.Sprintf("http://localhost:%d/api/v1/app/%s/status/history?%s", localPort, url.PathEscape(appSlug), query.Encode())
+	response := handlers.GetAppStatusHistoryResponse{}
+	if err := doAdminConsoleRequest(http.MethodGet, historyURL, authSlug, nil, &response); err != nil {
+		return errors.Wrap(err, "failed to get app status history")
+	}
+
+	print.AppStatusHistory(&response.AppStatusHistory, output)
+
+	return nil
+}
@@ -15,8 +15,11 @@ import (
 	cursor "github.com/ahmetalpbalkan/go-cursor"
 	"github.com/pkg/errors"
 	"github.com/replicatedhq/kots/pkg/auth"
+	"github.com/replicatedhq/kots/pkg/handlers"
 	"github.com/replicatedhq/kots/pkg/k8sutil"
 	"github.com/replicatedhq/kots/pkg/logger"
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+	"github.com/replicatedhq/kots/pkg/print"
 	"github.com/replicatedhq/kots/pkg/upstream"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -39,6 +42,22 @@ func DeployCmd() *cobra.Command {
 				os.Exit(1)
 			}
 
+			if v.GetBool("plan") {
+				return runDeployPlan(v, cmd, args[0])
+			}
+
+			if v.GetBool("queue") {
+				return runDeployQueue(v, cmd, args[0])
+			}
+
+			if v.GetString("cancel") != "" {
+				return runDeployCancel(v, cmd, args[0])
+			}
+
+			if v.GetString("config-values") == "" {
+				return errors.New("--config-values is required")
+			}
+
 			// Validate flag requirements: either airgap-bundle OR (channel-id AND channel-sequence)
 			license := v.GetString("license")
 			airgapBundle := v.GetString("airgap-bundle")
@@ -142,14 +161,125 @@ func DeployCmd() *cobra.Command {
 	cmd.Flags().String("config-values", "", "path to config values file")
 	cmd.Flags().Bool("skip-preflights", false, "set to true to skip preflight checks if no strict preflights exist; when strict preflights are present, all preflights still run, but non-strict failures are ignored")
 	cmd.Flags().Bool("disable-image-push", false, "disable pushing images from airgap bundle")
-
-	cmd.MarkFlagRequired("config-values")
+	cmd.Flags().Bool("plan", false, "show the changes that deploying --sequence would make to the cluster, without deploying it")
+	cmd.Flags().Int64("sequence", -1, "the app sequence to plan. only used with --plan")
+	cmd.Flags().StringP("output", "o", "", "output format (currently supported: json). only used with --plan and --queue")
+	cmd.Flags().Bool("queue", false, "list the running and queued deploys of the app")
+	cmd.Flags().String("cancel", "", "id of a queued or running deploy to cancel, as listed by --queue")
 
 	registryFlags(cmd.Flags())
 
 	return cmd
 }
 
+// runDeployPlan prints the changes that deploying a sequence would make to the cluster
+func runDeployPlan(v *viper.Viper, cmd *cobra.Command, appSlug string) error {
+	sequence := v.GetInt64("sequence")
+	if sequence < 0 {
+		return errors.New("--sequence is required with --plan")
+	}
+
+	output := v.GetString("output")
+	if output != "json" && output != "" {
+		return errors.Errorf("output format %s not supported (allowed formats are: json)", output)
+	}
+
+	namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+	if err != nil {
+		return errors.Wrap(err, "failed to get namespace")
+	}
+
+	log := logger.NewCLILogger(cmd.OutOrStdout())
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+	if err != nil {
+		return err
+	}
+
+	planURL := fmt.Sprintf("http://localhost:%d/api/v1/app/%s/sequence/%d/plan", localPort, url.PathEscape(appSlug), sequence)
+
+	plan := operatortypes.DeployPlan{}
+	if err := doAdminConsoleRequest(http.MethodGet, planURL, authSlug, nil, &plan); err != nil {
+		return errors.Wrap(err, "failed to get deploy plan")
+	}
+
+	print.DeployPlan(&plan, output)
+
+	return nil
+}
+
+// runDeployQueue prints the running and queued deploys of an app
+func runDeployQueue(v *viper.Viper, cmd *cobra.Command, appSlug string) error {
+	output := v.GetString("output")
+	if output != "json" && output != "" {
+		return errors.Errorf("output format %s not supported (allowed formats are: json)", output)
+	}
+
+	namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+	if err != nil {
+		return errors.Wrap(err, "failed to get namespace")
+	}
+
+	log := logger.NewCLILogger(cmd.OutOrStdout())
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+	if err != nil {
+		return err
+	}
+
+	deploysURL := fmt.Sprintf("http://localhost:%d/api/v1/app/%s/deploys", localPort, url.PathEscape(appSlug))
+
+	response := handlers.ListAppDeploysResponse{}
+	if err := doAdminConsoleRequest(http.MethodGet, deploysURL, authSlug, nil, &response); err != nil {
+		return errors.Wrap(err, "failed to list deploys")
+	}
+
+	print.DeployQueue(response.Deploys, output)
+
+	return nil
+}
+
+// runDeployCancel cancels a queued deploy, or requests cancellation of the running deploy
+func runDeployCancel(v *viper.Viper, cmd *cobra.Command, appSlug string) error {
+	deployID := v.GetString("cancel")
+
+	namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+	if err != nil {
+		return errors.Wrap(err, "failed to get namespace")
+	}
+
+	log := logger.NewCLILogger(cmd.OutOrStdout())
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+	if err != nil {
+		return err
+	}
+
+	cancelURL := fmt.Sprintf("http://localhost:%d/api/v1/app/%s/deploys/%s/cancel", localPort, url.PathEscape(appSlug), url.PathEscape(deployID))
+
+	response := handlers.CancelAppDeployResponse{}
+	if err := doAdminConsoleRequest(http.MethodPost, cancelURL, authSlug, nil, &response); err != nil {
+		return errors.Wrap(err, "failed to cancel deploy")
+	}
+
+	if response.Deploy.State == operatortypes.DeployStateCancelling {
+		log.ActionWithoutSpinner("Cancellation of the running %s of sequence %d was requested", response.Deploy.Reason, response.Deploy.Sequence)
+	} else {
+		log.ActionWithoutSpinner("Cancelled the queued %s of sequence %d", response.Deploy.Reason, response.Deploy.Sequence)
+	}
+
+	return nil
+}
+
 func handleLicenseSync(v *viper.Viper, appSlug string, localPort int, authSlug string, log *logger.CLILogger) error {
 	log.ActionWithoutSpinner("Syncing license...")
 
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	audittypes "github.com/replicatedhq/kots/pkg/audit/types"
+	"github.com/replicatedhq/kots/pkg/handlers"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/print"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+const auditLogPageSize = 500
+
+func GetAuditLogCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit-log",
+		Short: "Get the admin console audit log",
+		Long: `Get the audit log of write actions performed through the admin console api, newest first.
+
+Examples:
+kubectl kots get audit-log --since 24h
+kubectl kots get audit-log --since 2024-01-01T00:00:00Z --app my-app -o json`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+
+			output := v.GetString("output")
+			if output != "json" && output != "" {
+				return errors.Errorf("output format %s not supported (allowed formats are: json)", output)
+			}
+
+			since, err := parseSince(v.GetString("since"), time.Now())
+			if err != nil {
+				return err
+			}
+
+			namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+			if err != nil {
+				return errors.Wrap(err, "failed to get namespace")
+			}
+
+			log := logger.NewCLILogger(cmd.OutOrStdout())
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+			if err != nil {
+				return err
+			}
+
+			events := []*audittypes.Event{}
+			for currentPage := 0; ; currentPage++ {
+				query := url.Values{}
+				query.Set("pageSize", fmt.Sprintf("%d", auditLogPageSize))
+				query.Set("currentPage", fmt.Sprintf("%d", currentPage))
+				if since != nil {
+					query.Set("since", since.Format(time.RFC3339))
+				}
+				if app := v.GetString("app"); app != "" {
+					query.Set("appSlug", app)
+				}
+
+				url := fmt.Sprintf("http://localhost:%d/api/v1/audit?%s", localPort, query.Encode())
+				response := handlers.GetAuditLogResponse{}
+				if err := doAdminConsoleRequest(http.MethodGet, url, authSlug, nil, &response); err != nil {
+					return errors.Wrap(err, "failed to get audit log")
+				}
+
+				events = append(events, response.Events...)
+				if len(response.Events) < auditLogPageSize || len(events) >= response.TotalCount {
+					break
+				}
+			}
+
+			print.AuditEvents(events, output)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("since", "", "only show events newer than a relative duration like 24h, or an RFC3339 timestamp")
+	cmd.Flags().String("app", "", "only show events for the app with this slug")
+	cmd.Flags().StringP("output", "o", "", "output format. supported values: json")
+
+	return cmd
+}
+
+// parseSince parses either a duration relative to now or an RFC3339 timestamp
+func parseSince(since string, now time.Time) (*time.Time, error) {
+	if since == "" {
+		return nil, nil
+	}
+
+	if d, err := time.ParseDuration(since); err == nil {
+		if d < 0 {
+			return nil, errors.New("--since duration cannot be negative")
+		}
+		t := now.Add(-d)
+		return &t, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, since)
+	if err != nil {
+		return nil, errors.Errorf("--since must be a duration like 24h or an RFC3339 timestamp, got %q", since)
+	}
+	return &t, nil
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseSince(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	got, err := parseSince("", now)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	got, err = parseSince("24h", now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-24*time.Hour), *got)
+
+	got, err = parseSince("2024-01-01T00:00:00Z", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), got.UTC())
+
+	_, err = parseSince("-1h", now)
+	assert.Error(t, err)
+
+	_, err = parseSince("yesterday", now)
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+	handlertypes "github.com/replicatedhq/kots/pkg/api/handlers/types"
+	"github.com/replicatedhq/kots/pkg/handlers"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/print"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func GetDeployResultsCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deploy-results [appSlug]",
+		Short: "Get the per-resource results of deploying an app version",
+		Long: `Get the result of applying or deleting each resource, and of each helm release, when an app version was deployed.
+The currently deployed version is used if --sequence is not set.
+
+Examples:
+kubectl kots get deploy-results my-app
+kubectl kots get deploy-results my-app --sequence 5 --failed`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+
+			if len(args) != 1 {
+				cmd.Help()
+				return errors.New("app slug is required")
+			}
+			appSlug := args[0]
+
+			output := v.GetString("output")
+			if output != "json" && output != "" {
+				return errors.Errorf("output format %s not supported (allowed formats are: json)", output)
+			}
+
+			namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+			if err != nil {
+				return errors.Wrap(err, "failed to get namespace")
+			}
+
+			log := logger.NewCLILogger(cmd.OutOrStdout())
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+			if err != nil {
+				return err
+			}
+
+			appURL := fmt.Sprintf("http://localhost:%d/api/v1/app/%s", localPort, url.PathEscape(appSlug))
+			app := handlertypes.ResponseApp{}
+			if err := doAdminConsoleRequest(http.MethodGet, appURL, authSlug, nil, &app); err != nil {
+				return errors.Wrap(err, "failed to get app")
+			}
+
+			sequence := v.GetInt64("sequence")
+			if sequence < 0 {
+				if app.Downstream.CurrentVersion == nil {
+					return errors.Errorf("app %s has no deployed version, use --sequence to select a version", appSlug)
+				}
+				sequence = app.Downstream.CurrentVersion.Sequence
+			}
+
+			outputURL := fmt.Sprintf("http://localhost:%d/api/v1/app/%s/cluster/%s/sequence/%d/downstreamoutput", localPort, url.PathEscape(appSlug), url.PathEscape(app.Downstream.Cluster.ID), sequence)
+			response := handlers.GetDownstreamOutputResponse{}
+			if err := doAdminConsoleRequest(http.MethodGet, outputURL, authSlug, nil, &response); err != nil {
+				return errors.Wrap(err, "failed to get deploy results")
+			}
+
+			if response.Results == nil {
+				return errors.Errorf("no deploy results were recorded for sequence %d", sequence)
+			}
+
+			print.DeployResults(response.Results, output, v.GetBool("failed"))
+
+			return nil
+		},
+	}
+
+	cmd.Flags().Int64("sequence", -1, "sequence of the app version. defaults to the currently deployed version")
+	cmd.Flags().Bool("failed", false, "only show resources and helm releases that failed")
+	cmd.Flags().StringP("output", "o", "", "output format. supported values: json")
+
+	return cmd
+}
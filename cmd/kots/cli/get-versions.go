@@ -129,6 +129,7 @@ func getVersionsCmd(cmd *cobra.Command, args []string) error {
 			Source:          version.Source,
 			ChannelID:       version.ChannelID,
 			ChannelSequence: channelSequence,
+			EligibleAt:      version.EligibleAt,
 		}
 
 		appVersionResponse = append(appVersionResponse, response)
@@ -25,6 +25,8 @@ kubectl kots get apps`,
 	cmd.AddCommand(GetConfigCmd())
 	cmd.AddCommand(GetRestoresCmd())
 	cmd.AddCommand(GetJoinCmd())
+	cmd.AddCommand(GetAuditLogCmd())
+	cmd.AddCommand(GetDeployResultsCmd())
 
 	return cmd
 }
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/k8sutil"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/twofactor"
+	"github.com/replicatedhq/kots/pkg/user"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func ResetTwoFactorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "reset-2fa [namespace]",
+		Short:         "Remove two-factor authentication from an admin console login",
+		Long:          `Remove two-factor authentication from the shared admin console password, or from a local user with --user. Use this when the authenticator and recovery codes have been lost.`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+
+			log := logger.NewCLILogger(cmd.OutOrStdout())
+
+			// use namespace-as-arg if provided, else use namespace from -n/--namespace
+			namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+			if err != nil {
+				return errors.Wrap(err, "failed to get namespace")
+			}
+			if len(args) == 1 {
+				namespace = args[0]
+			} else if len(args) > 1 {
+				fmt.Printf("more than one argument supplied: %+v\n", args)
+				os.Exit(1)
+			}
+
+			if namespace == "" {
+				fmt.Printf("a namespace must be provided as an argument or via -n/--namespace\n")
+				os.Exit(1)
+			}
+
+			userID := user.SharedPasswordUserID
+			target := "the admin console password"
+			if email := v.GetString("user"); email != "" {
+				stopCh := make(chan struct{})
+				defer close(stopCh)
+
+				localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+				if err != nil {
+					return err
+				}
+
+				foundUser, err := findUserByEmail(localPort, authSlug, email)
+				if err != nil {
+					return err
+				}
+				userID = foundUser.ID
+				target = foundUser.Email
+			}
+
+			clientset, err := k8sutil.GetClientset()
+			if err != nil {
+				return errors.Wrap(err, "failed to create k8s client")
+			}
+
+			if err := twofactor.Reset(clientset, namespace, userID); err != nil {
+				return errors.Wrap(err, "failed to reset two-factor authentication")
+			}
+
+			log.ActionWithoutSpinner("Two-factor authentication has been removed from %s in %s", target, namespace)
+			return nil
+		},
+	}
+
+	cmd.Flags().String("user", "", "email address of the local user to reset two-factor authentication for. the shared admin password is reset when not set")
+
+	return cmd
+}
@@ -55,7 +55,7 @@ func ResetPasswordCmd() *cobra.Command {
 				return errors.Wrap(err, "failed to set new password")
 			}
 
-			log.ActionWithoutSpinner("The admin console password has been reset")
+			log.ActionWithoutSpinner("The admin console password has been reset and all sessions have been signed out")
 			return nil
 		},
 	}
@@ -41,6 +41,10 @@ func RootCmd() *cobra.Command {
 	cmd.AddCommand(RemoveCmd())
 	cmd.AddCommand(AdminConsoleCmd())
 	cmd.AddCommand(ResetPasswordCmd())
+	cmd.AddCommand(ResetTwoFactorCmd())
+	cmd.AddCommand(UserCmd())
+	cmd.AddCommand(TokenCmd())
+	cmd.AddCommand(SessionCmd())
 	cmd.AddCommand(ResetTLSCmd())
 	cmd.AddCommand(VersionCmd())
 	cmd.AddCommand(VeleroCmd())
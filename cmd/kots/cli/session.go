@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/handlers"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/print"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func SessionCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "session",
+		Short: "Manage admin console login sessions",
+	}
+
+	cmd.AddCommand(SessionListCmd())
+	cmd.AddCommand(SessionRevokeCmd())
+
+	return cmd
+}
+
+func SessionListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "list",
+		Aliases:       []string{"ls"},
+		Short:         "List active admin console sessions",
+		Long:          "",
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+
+			output := v.GetString("output")
+			if output != "json" && output != "" {
+				return errors.Errorf("output format %s not supported (allowed formats are: json)", output)
+			}
+
+			namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+			if err != nil {
+				return errors.Wrap(err, "failed to get namespace")
+			}
+
+			log := logger.NewCLILogger(cmd.OutOrStdout())
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+			if err != nil {
+				return err
+			}
+
+			url := fmt.Sprintf("http://localhost:%d/api/v1/sessions", localPort)
+			response := handlers.ListSessionsResponse{}
+			if err := doAdminConsoleRequest(http.MethodGet, url, authSlug, nil, &response); err != nil {
+				return errors.Wrap(err, "failed to list sessions")
+			}
+
+			print.Sessions(response.Sessions, output)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("namespace", "n", "", "the namespace in which kots/kotsadm is installed")
+	cmd.Flags().StringP("output", "o", "", "output format. supported values: json")
+
+	return cmd
+}
+
+func SessionRevokeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "revoke [id]",
+		Short:         "Revoke admin console sessions",
+		Long:          `Revoke a single session by id, or every session with --all. Revoked sessions must log in again. API tokens are not affected.`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		Args:          cobra.MaximumNArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+
+			all := v.GetBool("all")
+			if all && len(args) > 0 {
+				return errors.New("a session id cannot be used with --all")
+			}
+			if !all && len(args) == 0 {
+				return errors.New("a session id or --all is required")
+			}
+
+			namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+			if err != nil {
+				return errors.Wrap(err, "failed to get namespace")
+			}
+
+			log := logger.NewCLILogger(cmd.OutOrStdout())
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+			if err != nil {
+				return err
+			}
+
+			if all {
+				url := fmt.Sprintf("http://localhost:%d/api/v1/sessions", localPort)
+				if err := doAdminConsoleRequest(http.MethodDelete, url, authSlug, nil, nil); err != nil {
+					return errors.Wrap(err, "failed to revoke sessions")
+				}
+				log.ActionWithoutSpinner("All sessions have been revoked")
+				return nil
+			}
+
+			url := fmt.Sprintf("http://localhost:%d/api/v1/sessions/%s", localPort, args[0])
+			if err := doAdminConsoleRequest(http.MethodDelete, url, authSlug, nil, nil); err != nil {
+				return errors.Wrap(err, "failed to revoke session")
+			}
+			log.ActionWithoutSpinner("Session %s has been revoked", args[0])
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("namespace", "n", "", "the namespace in which kots/kotsadm is installed")
+	cmd.Flags().Bool("all", false, "revoke every session")
+
+	return cmd
+}
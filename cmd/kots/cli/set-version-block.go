@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/handlers"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/print"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func SetVersionBlockCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "version-block [appSlug]",
+		Short: "Block a version of an application from being deployed",
+		Long: `Block a version of an application, identified by its version label or sequence, from being deployed.
+Blocked versions are skipped by automatic deployments and rejected when deployed manually.
+Required versions cannot be skipped, so blocking one prevents later versions from being deployed until the block is removed.
+
+Use --list to list the blocks of an application and --remove to remove a block.`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		Args:          cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+
+			appSlug := args[0]
+
+			output := v.GetString("output")
+			if output != "json" && output != "" {
+				return errors.Errorf("output format %s not supported (allowed formats are: json)", output)
+			}
+
+			list := v.GetBool("list")
+			remove := v.GetString("remove")
+			versionLabel := v.GetString("version-label")
+			sequence := v.GetInt64("sequence")
+			reason := v.GetString("reason")
+
+			if list && remove != "" {
+				return errors.New("only one of --list or --remove can be specified")
+			}
+			if !list && remove == "" {
+				if (versionLabel == "") == (sequence < 0) {
+					return errors.New("exactly one of --version-label or --sequence must be specified")
+				}
+				if reason == "" {
+					return errors.New("--reason is required")
+				}
+			}
+
+			namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+			if err != nil {
+				return errors.Wrap(err, "failed to get namespace")
+			}
+
+			log := logger.NewCLILogger(cmd.OutOrStdout())
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+			if err != nil {
+				return err
+			}
+
+			blocksURL := fmt.Sprintf("http://localhost:%d/api/v1/app/%s/version-blocks", localPort, url.PathEscape(appSlug))
+
+			if list {
+				response := handlers.ListVersionBlocksResponse{}
+				if err := doAdminConsoleRequest(http.MethodGet, blocksURL, authSlug, nil, &response); err != nil {
+					return errors.Wrap(err, "failed to list version blocks")
+				}
+				print.VersionBlocks(response.Blocks, output)
+				return nil
+			}
+
+			if remove != "" {
+				removeURL := fmt.Sprintf("%s/%s", blocksURL, url.PathEscape(remove))
+				if err := doAdminConsoleRequest(http.MethodDelete, removeURL, authSlug, nil, nil); err != nil {
+					return errors.Wrap(err, "failed to remove version block")
+				}
+				log.ActionWithoutSpinner("Version block %s has been removed", remove)
+				return nil
+			}
+
+			payload := handlers.CreateVersionBlockRequest{
+				VersionLabel: versionLabel,
+				Reason:       reason,
+			}
+			if sequence >= 0 {
+				payload.Sequence = &sequence
+			}
+
+			response := handlers.CreateVersionBlockResponse{}
+			if err := doAdminConsoleRequest(http.MethodPost, blocksURL, authSlug, payload, &response); err != nil {
+				return errors.Wrap(err, "failed to create version block")
+			}
+
+			if output == "json" {
+				outputJSON, err := json.MarshalIndent(response, "", "    ")
+				if err != nil {
+					return errors.Wrap(err, "failed to marshal json")
+				}
+				fmt.Println(string(outputJSON))
+				return nil
+			}
+
+			if versionLabel != "" {
+				log.ActionWithoutSpinner("Version %s of %s has been blocked (id %s)", versionLabel, appSlug, response.Block.ID)
+			} else {
+				log.ActionWithoutSpinner("Sequence %d of %s has been blocked (id %s)", sequence, appSlug, response.Block.ID)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("namespace", "n", "", "the namespace in which kots/kotsadm is installed")
+	cmd.Flags().String("version-label", "", "the version label to block")
+	cmd.Flags().Int64("sequence", -1, "the sequence to block")
+	cmd.Flags().String("reason", "", "why the version is blocked")
+	cmd.Flags().Bool("list", false, "list the version blocks of the application")
+	cmd.Flags().String("remove", "", "the id of a version block to remove")
+	cmd.Flags().StringP("output", "o", "", "output format. supported values: json")
+
+	return cmd
+}
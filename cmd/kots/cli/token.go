@@ -0,0 +1,246 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+	apitokentypes "github.com/replicatedhq/kots/pkg/apitoken/types"
+	"github.com/replicatedhq/kots/pkg/handlers"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/print"
+	"github.com/replicatedhq/kots/pkg/rbac"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TokenCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Manage api tokens for automation against the admin console api",
+	}
+
+	cmd.AddCommand(TokenCreateCmd())
+	cmd.AddCommand(TokenListCmd())
+	cmd.AddCommand(TokenRevokeCmd())
+
+	return cmd
+}
+
+func TokenCreateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create [name]",
+		Short: "Create an api token",
+		Long: `Create a long-lived api token with the given roles.
+The token is only printed once and should be passed to the admin console api as "Authorization: Bearer <token>".`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		Args:          cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+
+			output := v.GetString("output")
+			if output != "json" && output != "" {
+				return errors.Errorf("output format %s not supported (allowed formats are: json)", output)
+			}
+
+			ttl := v.GetDuration("ttl")
+			if ttl < 0 {
+				return errors.New("--ttl cannot be negative")
+			}
+
+			namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+			if err != nil {
+				return errors.Wrap(err, "failed to get namespace")
+			}
+
+			log := logger.NewCLILogger(cmd.OutOrStdout())
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+			if err != nil {
+				return err
+			}
+
+			payload := handlers.CreateAPITokenRequest{
+				Name:  args[0],
+				Roles: v.GetStringSlice("role"),
+			}
+			if ttl > 0 {
+				expiresAt := time.Now().Add(ttl)
+				payload.ExpiresAt = &expiresAt
+			}
+
+			url := fmt.Sprintf("http://localhost:%d/api/v1/tokens", localPort)
+			response := handlers.CreateAPITokenResponse{}
+			if err := doAdminConsoleRequest(http.MethodPost, url, authSlug, payload, &response); err != nil {
+				return errors.Wrap(err, "failed to create api token")
+			}
+
+			if output == "json" {
+				outputJSON, err := json.MarshalIndent(response, "", "    ")
+				if err != nil {
+					return errors.Wrap(err, "failed to marshal json")
+				}
+				fmt.Println(string(outputJSON))
+				return nil
+			}
+
+			log.ActionWithoutSpinner("API token %s has been created. It will not be shown again:", response.APIToken.Name)
+			fmt.Println(response.Token)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("namespace", "n", "", "the namespace in which kots/kotsadm is installed")
+	cmd.Flags().StringSlice("role", []string{rbac.ClusterAdminRole.ID}, "the roles to grant to the token")
+	cmd.Flags().Duration("ttl", 0, "how long the token is valid for, e.g. 720h. the token does not expire if not set")
+	cmd.Flags().StringP("output", "o", "", "output format. supported values: json")
+
+	return cmd
+}
+
+func TokenListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "list",
+		Aliases:       []string{"ls"},
+		Short:         "List api tokens",
+		Long:          "",
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+
+			output := v.GetString("output")
+			if output != "json" && output != "" {
+				return errors.Errorf("output format %s not supported (allowed formats are: json)", output)
+			}
+
+			namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+			if err != nil {
+				return errors.Wrap(err, "failed to get namespace")
+			}
+
+			log := logger.NewCLILogger(cmd.OutOrStdout())
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+			if err != nil {
+				return err
+			}
+
+			tokens, err := listAPITokens(localPort, authSlug)
+			if err != nil {
+				return err
+			}
+
+			print.APITokens(tokens, output)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("namespace", "n", "", "the namespace in which kots/kotsadm is installed")
+	cmd.Flags().StringP("output", "o", "", "output format. supported values: json")
+
+	return cmd
+}
+
+func TokenRevokeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "revoke [id or name]",
+		Short:         "Revoke an api token",
+		Long:          `Revoke an api token so that it can no longer be used to authenticate.`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		Args:          cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+
+			namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+			if err != nil {
+				return errors.Wrap(err, "failed to get namespace")
+			}
+
+			log := logger.NewCLILogger(cmd.OutOrStdout())
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+			if err != nil {
+				return err
+			}
+
+			tokens, err := listAPITokens(localPort, authSlug)
+			if err != nil {
+				return err
+			}
+
+			token, err := findAPIToken(tokens, args[0])
+			if err != nil {
+				return err
+			}
+
+			url := fmt.Sprintf("http://localhost:%d/api/v1/tokens/%s", localPort, token.ID)
+			if err := doAdminConsoleRequest(http.MethodDelete, url, authSlug, nil, nil); err != nil {
+				return errors.Wrap(err, "failed to revoke api token")
+			}
+
+			log.ActionWithoutSpinner("API token %s has been revoked", token.Name)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("namespace", "n", "", "the namespace in which kots/kotsadm is installed")
+
+	return cmd
+}
+
+func listAPITokens(localPort int, authSlug string) ([]*apitokentypes.APIToken, error) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/tokens", localPort)
+	response := handlers.ListAPITokensResponse{}
+	if err := doAdminConsoleRequest(http.MethodGet, url, authSlug, nil, &response); err != nil {
+		return nil, errors.Wrap(err, "failed to list api tokens")
+	}
+	return response.Tokens, nil
+}
+
+// findAPIToken finds a token by id, or by name if the name is unique
+func findAPIToken(tokens []*apitokentypes.APIToken, idOrName string) (*apitokentypes.APIToken, error) {
+	var byName []*apitokentypes.APIToken
+	for _, token := range tokens {
+		if token.ID == idOrName {
+			return token, nil
+		}
+		if token.Name == idOrName {
+			byName = append(byName, token)
+		}
+	}
+
+	switch len(byName) {
+	case 0:
+		return nil, errors.Errorf("api token %s not found", idOrName)
+	case 1:
+		return byName[0], nil
+	default:
+		return nil, errors.Errorf("more than one api token is named %s, revoke by id instead", idOrName)
+	}
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"testing"
+
+	apitokentypes "github.com/replicatedhq/kots/pkg/apitoken/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_findAPIToken(t *testing.T) {
+	tokens := []*apitokentypes.APIToken{
+		{ID: "id-1", Name: "ci"},
+		{ID: "id-2", Name: "dup"},
+		{ID: "id-3", Name: "dup"},
+	}
+
+	got, err := findAPIToken(tokens, "id-2")
+	require.NoError(t, err)
+	assert.Equal(t, "id-2", got.ID)
+
+	got, err = findAPIToken(tokens, "ci")
+	require.NoError(t, err)
+	assert.Equal(t, "id-1", got.ID)
+
+	_, err = findAPIToken(tokens, "dup")
+	assert.Error(t, err)
+
+	_, err = findAPIToken(tokens, "missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,280 @@
+package cli
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/handlers"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/print"
+	"github.com/replicatedhq/kots/pkg/rbac"
+	usertypes "github.com/replicatedhq/kots/pkg/user/types"
+	"github.com/replicatedhq/kots/pkg/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func UserCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "user",
+		Short: "Manage named admin console users",
+	}
+
+	cmd.AddCommand(UserCreateCmd())
+	cmd.AddCommand(UserListCmd())
+	cmd.AddCommand(UserDeleteCmd())
+	cmd.AddCommand(UserResetPasswordCmd())
+
+	return cmd
+}
+
+func UserCreateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "create",
+		Short:         "Create a named admin console user",
+		Long:          `Create a named admin console user that can log in with their email address and password.`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+
+			email := v.GetString("email")
+			if email == "" {
+				return errors.New("--email is required")
+			}
+
+			namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+			if err != nil {
+				return errors.Wrap(err, "failed to get namespace")
+			}
+
+			newPassword, err := util.PromptForNewPassword()
+			if err != nil {
+				return errors.Wrap(err, "failed to prompt for password")
+			}
+
+			log := logger.NewCLILogger(cmd.OutOrStdout())
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+			if err != nil {
+				return err
+			}
+
+			payload := handlers.CreateUserRequest{
+				Email:     email,
+				FirstName: v.GetString("first-name"),
+				LastName:  v.GetString("last-name"),
+				Password:  newPassword,
+				Roles:     v.GetStringSlice("role"),
+			}
+			url := fmt.Sprintf("http://localhost:%d/api/v1/users", localPort)
+			response := handlers.CreateUserResponse{}
+			if err := doAdminConsoleRequest(http.MethodPost, url, authSlug, payload, &response); err != nil {
+				return errors.Wrap(err, "failed to create user")
+			}
+
+			log.ActionWithoutSpinner("User %s has been created", response.User.Email)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("namespace", "n", "", "the namespace in which kots/kotsadm is installed")
+	cmd.Flags().String("email", "", "the email address the user will log in with")
+	cmd.Flags().String("first-name", "", "the user's first name")
+	cmd.Flags().String("last-name", "", "the user's last name")
+	cmd.Flags().StringSlice("role", []string{rbac.ClusterAdminRole.ID}, "the roles to assign to the user")
+
+	return cmd
+}
+
+func UserListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "list",
+		Aliases:       []string{"ls"},
+		Short:         "List named admin console users",
+		Long:          "",
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+
+			output := v.GetString("output")
+			if output != "json" && output != "" {
+				return errors.Errorf("output format %s not supported (allowed formats are: json)", output)
+			}
+
+			namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+			if err != nil {
+				return errors.Wrap(err, "failed to get namespace")
+			}
+
+			log := logger.NewCLILogger(cmd.OutOrStdout())
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+			if err != nil {
+				return err
+			}
+
+			users, err := listUsers(localPort, authSlug)
+			if err != nil {
+				return err
+			}
+
+			print.Users(users, output)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("namespace", "n", "", "the namespace in which kots/kotsadm is installed")
+	cmd.Flags().StringP("output", "o", "", "output format. supported values: json")
+
+	return cmd
+}
+
+func UserDeleteCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "delete [email]",
+		Short:         "Delete a named admin console user",
+		Long:          `Delete a named admin console user and sign out all of their sessions.`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		Args:          cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+
+			namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+			if err != nil {
+				return errors.Wrap(err, "failed to get namespace")
+			}
+
+			log := logger.NewCLILogger(cmd.OutOrStdout())
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+			if err != nil {
+				return err
+			}
+
+			foundUser, err := findUserByEmail(localPort, authSlug, args[0])
+			if err != nil {
+				return err
+			}
+
+			url := fmt.Sprintf("http://localhost:%d/api/v1/user/%s", localPort, foundUser.ID)
+			if err := doAdminConsoleRequest(http.MethodDelete, url, authSlug, nil, nil); err != nil {
+				return errors.Wrap(err, "failed to delete user")
+			}
+
+			log.ActionWithoutSpinner("User %s has been deleted", foundUser.Email)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("namespace", "n", "", "the namespace in which kots/kotsadm is installed")
+
+	return cmd
+}
+
+func UserResetPasswordCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:           "reset-password [email]",
+		Short:         "Reset the password of a named admin console user",
+		Long:          `Set a new password for a named admin console user. This also unlocks the account and signs out all of its sessions.`,
+		SilenceUsage:  true,
+		SilenceErrors: false,
+		Args:          cobra.ExactArgs(1),
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+
+			namespace, err := getNamespaceOrDefault(v.GetString("namespace"))
+			if err != nil {
+				return errors.Wrap(err, "failed to get namespace")
+			}
+
+			log := logger.NewCLILogger(cmd.OutOrStdout())
+
+			stopCh := make(chan struct{})
+			defer close(stopCh)
+
+			localPort, authSlug, err := portForwardAdminConsole(namespace, log, stopCh)
+			if err != nil {
+				return err
+			}
+
+			foundUser, err := findUserByEmail(localPort, authSlug, args[0])
+			if err != nil {
+				return err
+			}
+
+			newPassword, err := util.PromptForNewPassword()
+			if err != nil {
+				return errors.Wrap(err, "failed to prompt for password")
+			}
+
+			payload := handlers.ResetUserPasswordRequest{
+				NewPassword: newPassword,
+			}
+			url := fmt.Sprintf("http://localhost:%d/api/v1/user/%s/password", localPort, foundUser.ID)
+			if err := doAdminConsoleRequest(http.MethodPut, url, authSlug, payload, nil); err != nil {
+				return errors.Wrap(err, "failed to reset user password")
+			}
+
+			log.ActionWithoutSpinner("The password for %s has been reset", foundUser.Email)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringP("namespace", "n", "", "the namespace in which kots/kotsadm is installed")
+
+	return cmd
+}
+
+func listUsers(localPort int, authSlug string) ([]*usertypes.User, error) {
+	url := fmt.Sprintf("http://localhost:%d/api/v1/users", localPort)
+	response := handlers.ListUsersResponse{}
+	if err := doAdminConsoleRequest(http.MethodGet, url, authSlug, nil, &response); err != nil {
+		return nil, errors.Wrap(err, "failed to list users")
+	}
+	return response.Users, nil
+}
+
+func findUserByEmail(localPort int, authSlug string, email string) (*usertypes.User, error) {
+	users, err := listUsers(localPort, authSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		if strings.EqualFold(user.Email, strings.TrimSpace(email)) {
+			return user, nil
+		}
+	}
+
+	return nil, errors.Errorf("user %s not found", email)
+}
@@ -1,12 +1,19 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
 	"net/url"
 	"path/filepath"
 	"strings"
 
 	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/auth"
+	"github.com/replicatedhq/kots/pkg/k8sutil"
 	"github.com/replicatedhq/kots/pkg/logger"
 	"github.com/replicatedhq/kots/pkg/replicatedapp"
 	"github.com/replicatedhq/kots/pkg/util"
@@ -74,3 +81,88 @@ func extractPreferredChannelSlug(log *logger.CLILogger, upstreamURI string) (str
 	}
 	return "stable", nil
 }
+
+// portForwardAdminConsole opens a port forward to the kotsadm pod and returns the local port along with
+// an auth slug that can be used to authenticate requests to the admin console api
+func portForwardAdminConsole(namespace string, log *logger.CLILogger, stopCh chan struct{}) (int, string, error) {
+	clientset, err := k8sutil.GetClientset()
+	if err != nil {
+		return 0, "", errors.Wrap(err, "failed to get clientset")
+	}
+
+	getPodName := func() (string, error) {
+		return k8sutil.FindKotsadm(clientset, namespace)
+	}
+
+	localPort, errChan, err := k8sutil.PortForward(0, 3000, namespace, getPodName, false, stopCh, log)
+	if err != nil {
+		return 0, "", errors.Wrap(err, "failed to start port forwarding")
+	}
+
+	go func() {
+		select {
+		case err := <-errChan:
+			if err != nil {
+				log.Error(err)
+			}
+		case <-stopCh:
+		}
+	}()
+
+	authSlug, err := auth.GetOrCreateAuthSlug(clientset, namespace)
+	if err != nil {
+		return 0, "", errors.Wrap(err, "failed to get kotsadm auth slug")
+	}
+
+	return localPort, authSlug, nil
+}
+
+// doAdminConsoleRequest sends a json request to the admin console api and decodes the json response into
+// response when it is not nil. non-2xx responses are returned as errors that include the server error message.
+func doAdminConsoleRequest(method string, url string, authSlug string, payload interface{}, response interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal request json")
+		}
+		body = bytes.NewBuffer(b)
+	}
+
+	newReq, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	newReq.Header.Add("Content-Type", "application/json")
+	newReq.Header.Add("Authorization", authSlug)
+
+	resp, err := http.DefaultClient.Do(newReq)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read server response")
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		errorResponse := struct {
+			Error string `json:"error"`
+		}{}
+		_ = json.Unmarshal(b, &errorResponse)
+		if errorResponse.Error != "" {
+			return errors.Errorf("unexpected status code %d: %s", resp.StatusCode, errorResponse.Error)
+		}
+		return errors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	if response != nil && len(b) > 0 {
+		if err := json.Unmarshal(b, response); err != nil {
+			return errors.Wrap(err, "failed to unmarshal response")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/agent"
+	"github.com/replicatedhq/kots/pkg/binaries"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/util"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func AgentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "agent",
+		Short: "Deploys apps to a remote cluster on behalf of an Admin Console",
+		Long: `Runs in a remote cluster and authenticates with the cluster's deploy token.
+The agent pulls the rendered manifests of the apps assigned to the cluster,
+applies them, and reports deploy results and app statuses back to the Admin Console.`,
+		// the agent does not have access to the admin console's database
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+		PreRun: func(cmd *cobra.Command, args []string) {
+			viper.BindPFlags(cmd.Flags())
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			v := viper.GetViper()
+
+			if v.GetString("log-level") == "debug" {
+				logger.SetDebug()
+			}
+
+			endpoint := v.GetString("endpoint")
+			if endpoint == "" {
+				return errors.New("--endpoint is required")
+			}
+			token := v.GetString("token")
+			if token == "" {
+				return errors.New("--token is required")
+			}
+			pollInterval := v.GetDuration("poll-interval")
+			if pollInterval <= 0 {
+				return errors.New("--poll-interval must be greater than zero")
+			}
+
+			util.PodNamespace = os.Getenv("POD_NAMESPACE")
+			targetNamespace := v.GetString("namespace")
+			if targetNamespace == "" {
+				targetNamespace = util.PodNamespace
+			}
+			util.KotsadmTargetNamespace = targetNamespace
+
+			if err := binaries.InitKubectl(); err != nil {
+				return errors.Wrap(err, "failed to init kubectl binaries")
+			}
+			if err := binaries.InitKustomize(); err != nil {
+				return errors.Wrap(err, "failed to init kustomize binaries")
+			}
+
+			ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer cancel()
+
+			a := agent.New(endpoint, token, targetNamespace, pollInterval)
+			if err := a.Run(ctx); err != nil {
+				return errors.Wrap(err, "failed to run agent")
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().String("endpoint", "", "the url of the Admin Console that manages this cluster")
+	cmd.Flags().String("token", "", "the deploy token of this cluster")
+	cmd.Flags().String("namespace", "", "the namespace to deploy apps to (defaults to the namespace the agent runs in)")
+	cmd.Flags().Duration("poll-interval", 10*time.Second, "how often to check for app versions to deploy")
+
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
+
+	return cmd
+}
@@ -34,6 +34,7 @@ func RootCmd() *cobra.Command {
 
 	cmd.AddCommand(APICmd())
 	cmd.AddCommand(MigrateCmd())
+	cmd.AddCommand(AgentCmd())
 	cmd.AddCommand(CompletionCmd())
 
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
@@ -492,6 +492,9 @@ func getHttpsServer(upstream, dexUpstream *url.URL, tlsSecretName string, secret
 	r.POST("/api/v1/app/custom-metrics", func(c *gin.Context) {
 		c.AbortWithStatus(http.StatusForbidden)
 	})
+	r.GET("/metrics", func(c *gin.Context) {
+		c.AbortWithStatus(http.StatusForbidden)
+	})
 
 	if dexUpstream != nil {
 		r.Any("/dex/*path", gin.WrapH(httputil.NewSingleHostReverseProxy(dexUpstream)))
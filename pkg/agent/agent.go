@@ -0,0 +1,210 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
+	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	operatorclient "github.com/replicatedhq/kots/pkg/operator/client"
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+	"github.com/replicatedhq/kots/pkg/util"
+)
+
+// Agent deploys the apps assigned to a remote cluster. It polls the admin console for app versions to deploy,
+// applies them with the operator client, and reports deploy results and app statuses back to the admin console.
+type Agent struct {
+	// Endpoint is the url of the admin console api
+	Endpoint string
+	// Token is the deploy token of the remote cluster
+	Token string
+	// PollInterval is how often the admin console is polled for app versions to deploy
+	PollInterval time.Duration
+
+	client     operatorclient.ClientInterface
+	httpClient *http.Client
+}
+
+// New returns an agent that deploys to the target namespace of the cluster it runs in
+func New(endpoint string, token string, targetNamespace string, pollInterval time.Duration) *Agent {
+	a := &Agent{
+		Endpoint:     strings.TrimSuffix(endpoint, "/"),
+		Token:        token,
+		PollInterval: pollInterval,
+		httpClient:   util.DefaultHTTPClient.StandardClient(),
+	}
+	a.client = &operatorclient.Client{
+		TargetNamespace:       targetNamespace,
+		Reporter:              &httpReporter{agent: a},
+		ExistingHookInformers: map[string]bool{},
+		HookStopChans:         []chan struct{}{},
+	}
+	return a
+}
+
+// Run polls for app versions to deploy until ctx is done
+func (a *Agent) Run(ctx context.Context) error {
+	if err := a.client.Init(); err != nil {
+		return errors.Wrap(err, "failed to init operator client")
+	}
+	defer a.client.Shutdown()
+
+	// status informers are requested on start, and again whenever an app is deployed
+	needInformers := true
+
+	ticker := time.NewTicker(a.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		deployed, err := a.reconcile(ctx, needInformers)
+		if err != nil {
+			logger.Error(errors.Wrap(err, "failed to reconcile desired state"))
+		} else {
+			needInformers = deployed
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// reconcile deploys the app versions that are waiting to be deployed to the cluster, and starts the status
+// informers if requested. It returns true if any app versions were deployed.
+func (a *Agent) reconcile(ctx context.Context, includeInformers bool) (bool, error) {
+	desiredState, err := a.getDesiredState(includeInformers)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get desired state")
+	}
+
+	for _, informersArgs := range desiredState.Informers {
+		a.client.ApplyAppInformers(informersArgs)
+	}
+
+	for _, deployArgs := range desiredState.Deploys {
+		if ctx.Err() != nil {
+			return false, nil
+		}
+		a.deploy(ctx, deployArgs)
+	}
+
+	return len(desiredState.Deploys) > 0, nil
+}
+
+func (a *Agent) deploy(ctx context.Context, deployArgs operatortypes.DeployAppArgs) {
+	logger.Infof("deploying sequence %d of app %s", deployArgs.Sequence, deployArgs.AppSlug)
+
+	a.client.ApplyNamespacesInformer(deployArgs.AdditionalNamespaces, deployArgs.ImagePullSecrets)
+	a.client.ApplyHooksInformer(deployArgs.AdditionalNamespaces)
+
+	status := operatortypes.AgentDeployStatus{
+		AppID:    deployArgs.AppID,
+		Sequence: deployArgs.Sequence,
+	}
+
+	deployed, err := a.client.DeployApp(ctx, deployArgs)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "failed to deploy sequence %d of app %s", deployArgs.Sequence, deployArgs.AppSlug))
+		status.Error = err.Error()
+	}
+	status.Deployed = deployed
+
+	if err := a.doRequest("PUT", "/api/v1/agent/deploy/status", status, nil); err != nil {
+		logger.Error(errors.Wrapf(err, "failed to report deploy status of app %s", deployArgs.AppSlug))
+	}
+}
+
+func (a *Agent) getDesiredState(includeInformers bool) (*operatortypes.AgentDesiredState, error) {
+	path := "/api/v1/agent/desired-state"
+	if includeInformers {
+		path = fmt.Sprintf("%s?informers=true", path)
+	}
+
+	desiredState := operatortypes.AgentDesiredState{}
+	if err := a.doRequest("GET", path, nil, &desiredState); err != nil {
+		return nil, err
+	}
+
+	return &desiredState, nil
+}
+
+func (a *Agent) doRequest(method string, path string, payload interface{}, response interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		b, err := json.Marshal(payload)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal payload")
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, fmt.Sprintf("%s%s", a.Endpoint, path), body)
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", a.Token))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := a.httpClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to execute request")
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return errors.Wrap(err, "failed to read response body")
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		return errors.New("the deploy token was rejected by the admin console")
+	}
+	if resp.StatusCode >= 400 {
+		return errors.Errorf("unexpected status code %d: %s", resp.StatusCode, respBody)
+	}
+
+	if response != nil {
+		if err := json.Unmarshal(respBody, response); err != nil {
+			return errors.Wrap(err, "failed to unmarshal response")
+		}
+	}
+
+	return nil
+}
+
+// httpReporter reports the operator client's deploy results and app statuses to the admin console
+type httpReporter struct {
+	agent *Agent
+}
+
+var _ operatorclient.Reporter = &httpReporter{}
+
+func (r *httpReporter) SetDeployResults(args operatortypes.DeployAppArgs, isError bool, output downstreamtypes.DownstreamOutput) error {
+	results := operatortypes.AgentDeployResults{
+		AppID:    args.AppID,
+		Sequence: args.Sequence,
+		IsError:  isError,
+		Output:   output,
+	}
+	if err := r.agent.doRequest("PUT", "/api/v1/agent/deploy/results", results, nil); err != nil {
+		return errors.Wrap(err, "failed to report deploy results")
+	}
+	return nil
+}
+
+func (r *httpReporter) SetAppStatus(appStatus appstatetypes.AppStatus) error {
+	if err := r.agent.doRequest("PUT", "/api/v1/agent/app-status", appStatus, nil); err != nil {
+		return errors.Wrap(err, "failed to report app status")
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
+	mock_client "github.com/replicatedhq/kots/pkg/operator/client/mock"
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_reconcile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	deployArgs := operatortypes.DeployAppArgs{
+		AppID:                "app-id",
+		AppSlug:              "app-slug",
+		Sequence:             3,
+		AdditionalNamespaces: []string{"extra"},
+	}
+	informersArgs := operatortypes.AppInformersArgs{
+		AppID:    "app-id",
+		Sequence: 2,
+	}
+
+	var reportedStatus operatortypes.AgentDeployStatus
+	var reportedResults operatortypes.AgentDeployResults
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "Bearer deploy-token", r.Header.Get("Authorization"))
+
+		switch r.URL.Path {
+		case "/api/v1/agent/desired-state":
+			require.Equal(t, "true", r.URL.Query().Get("informers"))
+			json.NewEncoder(w).Encode(operatortypes.AgentDesiredState{
+				Deploys:   []operatortypes.DeployAppArgs{deployArgs},
+				Informers: []operatortypes.AppInformersArgs{informersArgs},
+			})
+		case "/api/v1/agent/deploy/results":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&reportedResults))
+			w.Write([]byte("{}"))
+		case "/api/v1/agent/deploy/status":
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&reportedStatus))
+			w.Write([]byte("{}"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	a := &Agent{
+		Endpoint:   server.URL,
+		Token:      "deploy-token",
+		httpClient: server.Client(),
+	}
+	reporter := &httpReporter{agent: a}
+
+	mockClient := mock_client.NewMockClientInterface(ctrl)
+	mockClient.EXPECT().ApplyAppInformers(informersArgs)
+	mockClient.EXPECT().ApplyNamespacesInformer([]string{"extra"}, gomock.Any())
+	mockClient.EXPECT().ApplyHooksInformer([]string{"extra"})
+	mockClient.EXPECT().DeployApp(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, args operatortypes.DeployAppArgs) (bool, error) {
+		require.Equal(t, int64(3), args.Sequence)
+		err := reporter.SetDeployResults(args, false, downstreamtypes.DownstreamOutput{ApplyStdout: "applied"})
+		return true, err
+	})
+	a.client = mockClient
+
+	deployed, err := a.reconcile(context.Background(), true)
+	require.NoError(t, err)
+	require.True(t, deployed)
+
+	require.Equal(t, operatortypes.AgentDeployResults{
+		AppID:    "app-id",
+		Sequence: 3,
+		Output:   downstreamtypes.DownstreamOutput{ApplyStdout: "applied"},
+	}, reportedResults)
+	require.Equal(t, operatortypes.AgentDeployStatus{
+		AppID:    "app-id",
+		Sequence: 3,
+		Deployed: true,
+	}, reportedStatus)
+}
+
+func Test_doRequestUnauthorized(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	a := &Agent{
+		Endpoint:   server.URL,
+		Token:      "bad-token",
+		httpClient: server.Client(),
+	}
+
+	_, err := a.getDesiredState(false)
+	require.EqualError(t, err, "the deploy token was rejected by the admin console")
+}
@@ -21,6 +21,17 @@ type Downstream struct {
 	SnapshotTTL      string `json:"snapshotTtl,omitempty"`
 }
 
+// AgentCluster is a remote cluster whose apps are deployed by an agent running in the cluster
+type AgentCluster struct {
+	ID         string     `json:"id"`
+	Slug       string     `json:"slug"`
+	Title      string     `json:"title"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastSeenAt *time.Time `json:"lastSeenAt,omitempty"`
+	// Apps are the slugs of the apps assigned to the cluster
+	Apps []string `json:"apps"`
+}
+
 type DownstreamVersion struct {
 	VersionLabel       string                             `json:"versionLabel"`
 	Semver             *semver.Version                    `json:"semver,omitempty"`
@@ -38,6 +49,8 @@ type DownstreamVersion struct {
 	CommitURL          string                             `json:"commitUrl,omitempty"`
 	GitDeployable      bool                               `json:"gitDeployable,omitempty"`
 	UpstreamReleasedAt *time.Time                         `json:"upstreamReleasedAt,omitempty"`
+	IsBlocked          bool                               `json:"isBlocked,omitempty"`
+	BlockedReason      string                             `json:"blockedReason,omitempty"`
 
 	// The following fields are not queried by default and are only added as additional details when needed
 	// because they make the queries really slow when there is a large number of versions
@@ -56,6 +69,7 @@ type DownstreamVersion struct {
 	DownloadStatus             DownloadStatus                  `json:"downloadStatus,omitempty"`
 	AppTitle                   string                          `json:"appTitle,omitempty"`
 	AppIconURI                 string                          `json:"appIconUri,omitempty"`
+	EligibleAt                 *time.Time                      `json:"eligibleAt,omitempty"`
 }
 
 type DownloadStatus struct {
@@ -77,6 +91,26 @@ type DownstreamVersionHistory struct {
 	NumOfRemainingVersions int                  `json:"numOfRemainingVersions"`
 }
 
+// FirstUnblockedVersion returns the first version in the list that is not blocked, or nil if they all are
+func FirstUnblockedVersion(versions []*DownstreamVersion) *DownstreamVersion {
+	for _, v := range versions {
+		if !v.IsBlocked {
+			return v
+		}
+	}
+	return nil
+}
+
+// LastUnblockedVersion returns the last version in the list that is not blocked, or nil if they all are
+func LastUnblockedVersion(versions []*DownstreamVersion) *DownstreamVersion {
+	for i := len(versions) - 1; i >= 0; i-- {
+		if !versions[i].IsBlocked {
+			return versions[i]
+		}
+	}
+	return nil
+}
+
 // SemverSortable interface implementations
 
 type bySemver []*DownstreamVersion
@@ -158,4 +192,70 @@ type DownstreamOutput struct {
 	HelmStdout   string `json:"helmStdout"`
 	HelmStderr   string `json:"helmStderr"`
 	RenderError  string `json:"renderError"`
+	// Results are the per-resource results of the deploy. They are nil for deploys made before they were recorded.
+	Results *DeployResults `json:"results,omitempty"`
+}
+
+type DeployResourceAction string
+
+const (
+	DeployResourceCreated    DeployResourceAction = "created"
+	DeployResourceConfigured DeployResourceAction = "configured"
+	DeployResourceUnchanged  DeployResourceAction = "unchanged"
+	DeployResourceDeleted    DeployResourceAction = "deleted"
+	DeployResourceFailed     DeployResourceAction = "failed"
+)
+
+type HelmReleaseAction string
+
+const (
+	HelmReleaseDeployed    HelmReleaseAction = "deployed"
+	HelmReleaseUninstalled HelmReleaseAction = "uninstalled"
+	HelmReleaseFailed      HelmReleaseAction = "failed"
+)
+
+// DeployResults are the structured results of deploying a downstream version
+type DeployResults struct {
+	Resources    []DeployResourceResult `json:"resources"`
+	HelmReleases []HelmReleaseResult    `json:"helmReleases"`
+}
+
+// DeployResourceResult is the result of applying or deleting a single resource
+type DeployResourceResult struct {
+	Group     string               `json:"group"`
+	Version   string               `json:"version"`
+	Kind      string               `json:"kind"`
+	Namespace string               `json:"namespace,omitempty"`
+	Name      string               `json:"name"`
+	Action    DeployResourceAction `json:"action"`
+	Error     string               `json:"error,omitempty"`
+}
+
+// HelmReleaseResult is the result of installing, upgrading or uninstalling a single helm release
+type HelmReleaseResult struct {
+	ReleaseName  string            `json:"releaseName"`
+	Namespace    string            `json:"namespace,omitempty"`
+	ChartName    string            `json:"chartName"`
+	ChartVersion string            `json:"chartVersion,omitempty"`
+	Action       HelmReleaseAction `json:"action"`
+	Error        string            `json:"error,omitempty"`
+}
+
+// Failed returns the resources and helm releases that failed to deploy
+func (r DeployResults) Failed() DeployResults {
+	failed := DeployResults{
+		Resources:    []DeployResourceResult{},
+		HelmReleases: []HelmReleaseResult{},
+	}
+	for _, resource := range r.Resources {
+		if resource.Action == DeployResourceFailed {
+			failed.Resources = append(failed.Resources, resource)
+		}
+	}
+	for _, release := range r.HelmReleases {
+		if release.Action == HelmReleaseFailed {
+			failed.HelmReleases = append(failed.HelmReleases, release)
+		}
+	}
+	return failed
 }
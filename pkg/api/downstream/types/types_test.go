@@ -753,3 +753,44 @@ func cursorMustParseForTest(str string) *cursor.Cursor {
 	c := cursor.MustParse(str)
 	return &c
 }
+
+func Test_DeployResultsFailed(t *testing.T) {
+	results := DeployResults{
+		Resources: []DeployResourceResult{
+			{Kind: "ConfigMap", Name: "config", Action: DeployResourceUnchanged},
+			{Kind: "Deployment", Name: "web", Action: DeployResourceFailed, Error: "invalid spec"},
+			{Kind: "Secret", Name: "old", Action: DeployResourceDeleted},
+		},
+		HelmReleases: []HelmReleaseResult{
+			{ReleaseName: "db", Action: HelmReleaseDeployed},
+			{ReleaseName: "cache", Action: HelmReleaseFailed, Error: "timed out"},
+		},
+	}
+
+	want := DeployResults{
+		Resources: []DeployResourceResult{
+			{Kind: "Deployment", Name: "web", Action: DeployResourceFailed, Error: "invalid spec"},
+		},
+		HelmReleases: []HelmReleaseResult{
+			{ReleaseName: "cache", Action: HelmReleaseFailed, Error: "timed out"},
+		},
+	}
+
+	require.Equal(t, want, results.Failed())
+}
+
+func Test_UnblockedVersions(t *testing.T) {
+	versions := []*DownstreamVersion{
+		{Sequence: 4, IsBlocked: true},
+		{Sequence: 3},
+		{Sequence: 2},
+		{Sequence: 1, IsBlocked: true},
+	}
+
+	require.Equal(t, int64(3), FirstUnblockedVersion(versions).Sequence)
+	require.Equal(t, int64(2), LastUnblockedVersion(versions).Sequence)
+
+	blocked := []*DownstreamVersion{{Sequence: 1, IsBlocked: true}}
+	require.Nil(t, FirstUnblockedVersion(blocked))
+	require.Nil(t, LastUnblockedVersion(blocked))
+}
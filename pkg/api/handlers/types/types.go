@@ -18,22 +18,23 @@ type AppStatusResponse struct {
 }
 
 type ResponseApp struct {
-	ID                string              `json:"id"`
-	Slug              string              `json:"slug"`
-	Name              string              `json:"name"`
-	IsAirgap          bool                `json:"isAirgap"`
-	CurrentSequence   int64               `json:"currentSequence"`
-	UpstreamURI       string              `json:"upstreamUri"`
-	IconURI           string              `json:"iconUri"`
-	CreatedAt         time.Time           `json:"createdAt"`
-	UpdatedAt         *time.Time          `json:"updatedAt"`
-	LastUpdateCheckAt *time.Time          `json:"lastUpdateCheckAt"`
-	HasPreflight      bool                `json:"hasPreflight"`
-	IsConfigurable    bool                `json:"isConfigurable"`
-	UpdateCheckerSpec string              `json:"updateCheckerSpec"`
-	AutoDeploy        apptypes.AutoDeploy `json:"autoDeploy"`
-	Namespace         string              `json:"namespace"`
-	AppState          string              `json:"appState"`
+	ID                string                    `json:"id"`
+	Slug              string                    `json:"slug"`
+	Name              string                    `json:"name"`
+	IsAirgap          bool                      `json:"isAirgap"`
+	CurrentSequence   int64                     `json:"currentSequence"`
+	UpstreamURI       string                    `json:"upstreamUri"`
+	IconURI           string                    `json:"iconUri"`
+	CreatedAt         time.Time                 `json:"createdAt"`
+	UpdatedAt         *time.Time                `json:"updatedAt"`
+	LastUpdateCheckAt *time.Time                `json:"lastUpdateCheckAt"`
+	HasPreflight      bool                      `json:"hasPreflight"`
+	IsConfigurable    bool                      `json:"isConfigurable"`
+	UpdateCheckerSpec string                    `json:"updateCheckerSpec"`
+	AutoDeploy        apptypes.AutoDeploy       `json:"autoDeploy"`
+	ScheduledDeploy   *apptypes.ScheduledDeploy `json:"scheduledDeploy,omitempty"`
+	Namespace         string                    `json:"namespace"`
+	AppState          string                    `json:"appState"`
 
 	IsGitOpsSupported                 bool   `json:"isGitOpsSupported"`
 	IsIdentityServiceSupported        bool   `json:"isIdentityServiceSupported"`
@@ -66,18 +67,22 @@ type ResponseDownstream struct {
 }
 
 type ResponseGitOps struct {
-	Enabled     bool   `json:"enabled"`
-	Provider    string `json:"provider"`
-	Uri         string `json:"uri"`
-	Hostname    string `json:"hostname"`
-	HTTPPort    string `json:"httpPort"`
-	SSHPort     string `json:"sshPort"`
-	Path        string `json:"path"`
-	Branch      string `json:"branch"`
-	Format      string `json:"format"`
-	Action      string `json:"action"`
-	DeployKey   string `json:"deployKey"`
-	IsConnected bool   `json:"isConnected"`
+	Enabled           bool   `json:"enabled"`
+	Provider          string `json:"provider"`
+	Uri               string `json:"uri"`
+	Hostname          string `json:"hostname"`
+	HTTPPort          string `json:"httpPort"`
+	SSHPort           string `json:"sshPort"`
+	Path              string `json:"path"`
+	Branch            string `json:"branch"`
+	Format            string `json:"format"`
+	Action            string `json:"action"`
+	DeployKey         string `json:"deployKey"`
+	AuthType          string `json:"authType"`
+	Username          string `json:"username"`
+	CloneURL          string `json:"cloneUrl"`
+	CommitURLTemplate string `json:"commitUrlTemplate"`
+	IsConnected       bool   `json:"isConnected"`
 }
 
 type ResponseCluster struct {
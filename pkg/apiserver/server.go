@@ -11,17 +11,20 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	appstatehistory "github.com/replicatedhq/kots/pkg/appstate/history"
+	"github.com/replicatedhq/kots/pkg/audit"
 	"github.com/replicatedhq/kots/pkg/automation"
 	"github.com/replicatedhq/kots/pkg/binaries"
 	"github.com/replicatedhq/kots/pkg/handlers"
 	identitymigrate "github.com/replicatedhq/kots/pkg/identity/migrate"
 	"github.com/replicatedhq/kots/pkg/k8sutil"
+	"github.com/replicatedhq/kots/pkg/kotsadmmetrics"
+	"github.com/replicatedhq/kots/pkg/notifications"
 	"github.com/replicatedhq/kots/pkg/operator"
 	operatorclient "github.com/replicatedhq/kots/pkg/operator/client"
 	"github.com/replicatedhq/kots/pkg/persistence"
 	"github.com/replicatedhq/kots/pkg/policy"
 	"github.com/replicatedhq/kots/pkg/prune"
-	"github.com/replicatedhq/kots/pkg/rbac"
 	"github.com/replicatedhq/kots/pkg/reporting"
 	"github.com/replicatedhq/kots/pkg/session"
 	"github.com/replicatedhq/kots/pkg/snapshotscheduler"
@@ -96,6 +99,10 @@ func Start(params *APIServerParams) {
 		log.Println("Failed to clean up legacy support bundle spec configmaps: ", err)
 	}
 
+	if err := notifications.Init(); err != nil {
+		log.Println("Failed to initialize notifications:", err)
+	}
+
 	op := operator.Init(operatorClient, kotsStore, params.AutocreateClusterToken, k8sClientset)
 	if err := op.Start(); err != nil {
 		log.Println("error starting the operator")
@@ -142,6 +149,16 @@ func Start(params *APIServerParams) {
 		log.Println("Failed to start session purge cron job:", err)
 	}
 
+	if err := audit.StartRetentionCronJob(); err != nil {
+		log.Println("Failed to start audit log retention cron job:", err)
+	}
+
+	if err := appstatehistory.StartRetentionCronJob(); err != nil {
+		log.Println("Failed to start app status history retention cron job:", err)
+	}
+
+	kotsadmmetrics.Start()
+
 	if err := prune.Start(); err != nil {
 		log.Println("Failed to start prune job:", err)
 	}
@@ -160,6 +177,7 @@ func Start(params *APIServerParams) {
 
 	r.Use(handlers.SecurityHeadersMiddleware)
 	r.Use(handlers.CorsMiddleware)
+	r.Use(handlers.MetricsMiddleware)
 	r.Methods("OPTIONS").HandlerFunc(handlers.CORS)
 
 	debugRouter := r.NewRoute().Subrouter()
@@ -186,7 +204,8 @@ func Start(params *APIServerParams) {
 	* Session auth routes
 	**********************************************************************/
 
-	policyMiddleware := policy.NewMiddleware(kotsStore, rbac.DefaultRoles())
+	policyMiddleware := policy.NewMiddleware(kotsStore, nil)
+	policyMiddleware.AuditLog = true
 
 	sessionAuthQuietRouter := r.PathPrefix("").Subrouter()
 	sessionAuthQuietRouter.Use(handlers.RequireValidSessionQuietMiddleware(kotsStore))
@@ -0,0 +1,91 @@
+package apitoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/apitoken/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/store"
+	"github.com/replicatedhq/kots/pkg/user"
+)
+
+const (
+	// TokenPrefix identifies api tokens in an authorization header so they can be told apart from session jwts
+	TokenPrefix = "kots_"
+
+	// lastUsedAtResolution limits how often the last used timestamp is written for a token that is used repeatedly
+	lastUsedAtResolution = time.Minute
+)
+
+var (
+	ErrNameRequired = errors.New("a token name is required")
+	ErrInvalidToken = errors.New("invalid api token")
+	ErrTokenExpired = errors.New("api token expired")
+)
+
+// IsAPIToken returns true if the value has the format of an api token
+func IsAPIToken(value string) bool {
+	return strings.HasPrefix(value, TokenPrefix)
+}
+
+// HashToken returns the hex encoded sha256 of the token, which is what is persisted.
+// Tokens are random with 256 bits of entropy, so a slow hash is not needed.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Create generates a new api token with the given roles. The plaintext token is returned
+// and cannot be retrieved again.
+func Create(name string, roles []string, createdBy string, expiresAt *time.Time) (*types.APIToken, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", ErrNameRequired
+	}
+
+	if err := user.ValidateRoles(roles); err != nil {
+		return nil, "", err
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", errors.Wrap(err, "failed to generate token")
+	}
+	plaintext := TokenPrefix + base64.RawURLEncoding.EncodeToString(b)
+
+	token, err := store.GetStore().CreateAPIToken(name, HashToken(plaintext), roles, createdBy, expiresAt)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "failed to create api token")
+	}
+
+	return token, plaintext, nil
+}
+
+// Authenticate looks up the api token matching the plaintext value and verifies that it has not expired
+func Authenticate(kotsStore store.Store, plaintext string) (*types.APIToken, error) {
+	token, err := kotsStore.GetAPITokenBySHA256(HashToken(plaintext))
+	if kotsStore.IsNotFound(err) {
+		return nil, ErrInvalidToken
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to get api token")
+	}
+
+	if token.IsExpired() {
+		return nil, ErrTokenExpired
+	}
+
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) > lastUsedAtResolution {
+		if err := kotsStore.UpdateAPITokenLastUsedAt(token.ID, now); err != nil {
+			logger.Error(errors.Wrapf(err, "failed to update last used at for api token %s", token.ID))
+		}
+	}
+
+	return token, nil
+}
@@ -0,0 +1,67 @@
+package apitoken
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/replicatedhq/kots/pkg/apitoken/types"
+	"github.com/replicatedhq/kots/pkg/store/kotsstore"
+	mock_store "github.com/replicatedhq/kots/pkg/store/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthenticate(t *testing.T) {
+	expired := time.Now().Add(-time.Hour)
+	recentlyUsed := time.Now().Add(-time.Second)
+
+	tests := []struct {
+		name     string
+		token    *types.APIToken
+		getErr   error
+		wantErr  error
+		wantSeen bool
+	}{
+		{
+			name:     "valid token",
+			token:    &types.APIToken{ID: "token-id", Roles: []string{"cluster-admin"}},
+			wantSeen: true,
+		},
+		{
+			name:  "recently used token does not update last used",
+			token: &types.APIToken{ID: "token-id", Roles: []string{"cluster-admin"}, LastUsedAt: &recentlyUsed},
+		},
+		{
+			name:    "expired token",
+			token:   &types.APIToken{ID: "token-id", Roles: []string{"cluster-admin"}, ExpiresAt: &expired},
+			wantErr: ErrTokenExpired,
+		},
+		{
+			name:    "unknown token",
+			getErr:  kotsstore.ErrNotFound,
+			wantErr: ErrInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mock_store.NewMockStore(ctrl)
+			mockStore.EXPECT().GetAPITokenBySHA256(HashToken("kots_abc")).Return(tt.token, tt.getErr)
+			mockStore.EXPECT().IsNotFound(tt.getErr).Return(tt.getErr == kotsstore.ErrNotFound)
+			if tt.wantSeen {
+				mockStore.EXPECT().UpdateAPITokenLastUsedAt(tt.token.ID, gomock.Any()).Return(nil)
+			}
+
+			got, err := Authenticate(mockStore, "kots_abc")
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.token, got)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/app/types"
+)
+
+const (
+	DefaultDriftDetectionInterval = 15 * time.Minute
+	minDriftDetectionInterval     = time.Minute
+)
+
+// ParseDriftDetection returns how often drift should be checked for. A zero interval means drift detection is disabled,
+// which is the case for apps that have not configured it.
+func ParseDriftDetection(driftDetection *types.DriftDetection) (time.Duration, error) {
+	if driftDetection == nil {
+		return 0, nil
+	}
+	if driftDetection.Interval == "" {
+		return DefaultDriftDetectionInterval, nil
+	}
+
+	if driftDetection.Interval == "0" {
+		return 0, nil
+	}
+
+	interval, err := time.ParseDuration(driftDetection.Interval)
+	if err != nil {
+		return 0, errors.Errorf("invalid drift detection interval %q", driftDetection.Interval)
+	}
+
+	if interval == 0 {
+		return 0, nil
+	}
+	if interval < minDriftDetectionInterval {
+		return 0, errors.Errorf("drift detection interval cannot be shorter than %s", minDriftDetectionInterval)
+	}
+
+	return interval, nil
+}
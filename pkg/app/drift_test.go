@@ -0,0 +1,66 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/replicatedhq/kots/pkg/app/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDriftDetection(t *testing.T) {
+	tests := []struct {
+		name           string
+		driftDetection *types.DriftDetection
+		want           time.Duration
+		wantErr        bool
+	}{
+		{
+			name:           "not configured",
+			driftDetection: nil,
+			want:           0,
+		},
+		{
+			name:           "auto heal with the default interval",
+			driftDetection: &types.DriftDetection{AutoHeal: true},
+			want:           DefaultDriftDetectionInterval,
+		},
+		{
+			name:           "custom interval",
+			driftDetection: &types.DriftDetection{Interval: "1h"},
+			want:           time.Hour,
+		},
+		{
+			name:           "disabled",
+			driftDetection: &types.DriftDetection{Interval: "0"},
+			want:           0,
+		},
+		{
+			name:           "disabled with a unit",
+			driftDetection: &types.DriftDetection{Interval: "0s"},
+			want:           0,
+		},
+		{
+			name:           "too short",
+			driftDetection: &types.DriftDetection{Interval: "10s"},
+			wantErr:        true,
+		},
+		{
+			name:           "invalid",
+			driftDetection: &types.DriftDetection{Interval: "often"},
+			wantErr:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDriftDetection(tt.driftDetection)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package app
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/app/types"
+)
+
+// ParseHealthGate parses the period and threshold of a post-deploy health gate.
+// The threshold must be positive and cannot be longer than the period.
+func ParseHealthGate(healthGate *types.HealthGate) (period time.Duration, threshold time.Duration, err error) {
+	if healthGate == nil {
+		return 0, 0, nil
+	}
+
+	period, err = time.ParseDuration(healthGate.Period)
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid health gate period %q", healthGate.Period)
+	}
+
+	threshold, err = time.ParseDuration(healthGate.Threshold)
+	if err != nil {
+		return 0, 0, errors.Errorf("invalid health gate threshold %q", healthGate.Threshold)
+	}
+
+	if threshold <= 0 {
+		return 0, 0, errors.New("health gate threshold must be greater than zero")
+	}
+	if threshold > period {
+		return 0, 0, errors.Errorf("health gate threshold %s cannot be longer than the period %s", threshold, period)
+	}
+
+	return period, threshold, nil
+}
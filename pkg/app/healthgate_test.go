@@ -0,0 +1,58 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/replicatedhq/kots/pkg/app/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseHealthGate(t *testing.T) {
+	tests := []struct {
+		name          string
+		healthGate    *types.HealthGate
+		wantPeriod    time.Duration
+		wantThreshold time.Duration
+		wantErr       bool
+	}{
+		{
+			name:       "disabled",
+			healthGate: nil,
+		},
+		{
+			name:          "valid",
+			healthGate:    &types.HealthGate{Period: "15m", Threshold: "5m"},
+			wantPeriod:    15 * time.Minute,
+			wantThreshold: 5 * time.Minute,
+		},
+		{
+			name:       "threshold longer than period",
+			healthGate: &types.HealthGate{Period: "5m", Threshold: "15m"},
+			wantErr:    true,
+		},
+		{
+			name:       "zero threshold",
+			healthGate: &types.HealthGate{Period: "5m", Threshold: "0s"},
+			wantErr:    true,
+		},
+		{
+			name:       "invalid period",
+			healthGate: &types.HealthGate{Period: "soon", Threshold: "5m"},
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			period, threshold, err := ParseHealthGate(tt.healthGate)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPeriod, period)
+			assert.Equal(t, tt.wantThreshold, threshold)
+		})
+	}
+}
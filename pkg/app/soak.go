@@ -0,0 +1,49 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ParseSoakDuration parses how long a release must have been published before it can be deployed automatically.
+// Go durations such as "36h" are supported, as well as whole days such as "3d". An empty string means no soak time.
+func ParseSoakDuration(soakDuration string) (time.Duration, error) {
+	soakDuration = strings.TrimSpace(soakDuration)
+	if soakDuration == "" {
+		return 0, nil
+	}
+
+	var d time.Duration
+	if days, ok := strings.CutSuffix(soakDuration, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, errors.Errorf("invalid soak duration %q", soakDuration)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(soakDuration)
+		if err != nil {
+			return 0, errors.Errorf("invalid soak duration %q", soakDuration)
+		}
+		d = parsed
+	}
+
+	if d < 0 {
+		return 0, errors.Errorf("soak duration %q cannot be negative", soakDuration)
+	}
+
+	return d, nil
+}
+
+// GetEligibleAt returns when a release published at releasedAt can be deployed automatically.
+// Returns nil when there is no soak time or the release time is unknown.
+func GetEligibleAt(releasedAt *time.Time, soakDuration time.Duration) *time.Time {
+	if soakDuration == 0 || releasedAt == nil {
+		return nil
+	}
+	eligibleAt := releasedAt.Add(soakDuration)
+	return &eligibleAt
+}
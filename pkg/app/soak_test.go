@@ -0,0 +1,47 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSoakDuration(t *testing.T) {
+	tests := []struct {
+		soakDuration string
+		want         time.Duration
+		wantErr      bool
+	}{
+		{soakDuration: "", want: 0},
+		{soakDuration: "36h", want: 36 * time.Hour},
+		{soakDuration: "3d", want: 72 * time.Hour},
+		{soakDuration: "0d", want: 0},
+		{soakDuration: "1.5d", wantErr: true},
+		{soakDuration: "-1h", wantErr: true},
+		{soakDuration: "soon", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.soakDuration, func(t *testing.T) {
+			got, err := ParseSoakDuration(tt.soakDuration)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetEligibleAt(t *testing.T) {
+	releasedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Nil(t, GetEligibleAt(&releasedAt, 0))
+	assert.Nil(t, GetEligibleAt(nil, time.Hour))
+
+	eligibleAt := GetEligibleAt(&releasedAt, 48*time.Hour)
+	require.NotNil(t, eligibleAt)
+	assert.Equal(t, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), *eligibleAt)
+}
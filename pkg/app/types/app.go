@@ -7,30 +7,35 @@ import (
 )
 
 type App struct {
-	ID                    string         `json:"id"`
-	Slug                  string         `json:"slug"`
-	Name                  string         `json:"name"`
-	License               string         `json:"license"`
-	IsAirgap              bool           `json:"isAirgap"`
-	CurrentSequence       int64          `json:"currentSequence"`
-	UpstreamURI           string         `json:"upstreamUri"`
-	IconURI               string         `json:"iconUri"`
-	UpdatedAt             *time.Time     `json:"updatedAt"`
-	CreatedAt             time.Time      `json:"createdAt"`
-	LastUpdateCheckAt     *time.Time     `json:"lastUpdateCheckAt"`
-	HasPreflight          bool           `json:"hasPreflight"`
-	IsConfigurable        bool           `json:"isConfigurable"`
-	SnapshotTTL           string         `json:"snapshotTtl"`
-	SnapshotSchedule      string         `json:"snapshotSchedule"`
-	RestoreInProgressName string         `json:"restoreInProgressName"`
-	RestoreUndeployStatus UndeployStatus `json:"restoreUndeloyStatus"`
-	UpdateCheckerSpec     string         `json:"updateCheckerSpec"`
-	AutoDeploy            AutoDeploy     `json:"autoDeploy"`
-	IsGitOps              bool           `json:"isGitOps"`
-	InstallState          string         `json:"installState"`
-	LastLicenseSync       string         `json:"lastLicenseSync"`
-	ChannelChanged        bool           `json:"channelChanged"`
-	SelectedChannelID     string         `json:"selected_channel_id"`
+	ID                    string           `json:"id"`
+	Slug                  string           `json:"slug"`
+	Name                  string           `json:"name"`
+	License               string           `json:"license"`
+	IsAirgap              bool             `json:"isAirgap"`
+	CurrentSequence       int64            `json:"currentSequence"`
+	UpstreamURI           string           `json:"upstreamUri"`
+	IconURI               string           `json:"iconUri"`
+	UpdatedAt             *time.Time       `json:"updatedAt"`
+	CreatedAt             time.Time        `json:"createdAt"`
+	LastUpdateCheckAt     *time.Time       `json:"lastUpdateCheckAt"`
+	HasPreflight          bool             `json:"hasPreflight"`
+	IsConfigurable        bool             `json:"isConfigurable"`
+	SnapshotTTL           string           `json:"snapshotTtl"`
+	SnapshotSchedule      string           `json:"snapshotSchedule"`
+	RestoreInProgressName string           `json:"restoreInProgressName"`
+	RestoreUndeployStatus UndeployStatus   `json:"restoreUndeloyStatus"`
+	UpdateCheckerSpec     string           `json:"updateCheckerSpec"`
+	AutoDeploy            AutoDeploy       `json:"autoDeploy"`
+	DeployWindow          *DeployWindow    `json:"deployWindow,omitempty"`
+	SoakDuration          string           `json:"soakDuration,omitempty"`
+	ScheduledDeploy       *ScheduledDeploy `json:"scheduledDeploy,omitempty"`
+	HealthGate            *HealthGate      `json:"healthGate,omitempty"`
+	DriftDetection        *DriftDetection  `json:"driftDetection,omitempty"`
+	IsGitOps              bool             `json:"isGitOps"`
+	InstallState          string           `json:"installState"`
+	LastLicenseSync       string           `json:"lastLicenseSync"`
+	ChannelChanged        bool             `json:"channelChanged"`
+	SelectedChannelID     string           `json:"selected_channel_id"`
 }
 
 func (a *App) GetID() string {
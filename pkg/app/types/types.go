@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 type UndeployStatus string
 
 const (
@@ -18,3 +20,67 @@ const (
 	AutoDeploySemverMajorMinorPatch AutoDeploy = "semver-major-minor-patch"
 	AutoDeploySequence              AutoDeploy = "sequence"
 )
+
+// DeployWindow restricts automatic deployments to the minutes matched by a cron schedule.
+// For example, "* 1-4 * * 6,0" allows automatic deployments from 01:00 to 04:59 on weekends.
+type DeployWindow struct {
+	Schedule string `json:"schedule"`
+	// Timezone is an IANA time zone name, e.g. "America/New_York". Defaults to UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// HealthGate watches the app after a deploy and rolls back to the previously deployed version
+// if the app stays degraded or unavailable for longer than the threshold.
+type HealthGate struct {
+	// Period is how long the app is watched after a deploy, e.g. "15m".
+	Period string `json:"period"`
+	// Threshold is how long the app can be degraded or unavailable during the period before it is rolled back, e.g. "5m".
+	Threshold string `json:"threshold"`
+}
+
+// DriftDetection configures how the live resources of an app are compared against the manifests of the deployed version.
+// Drift is not checked for if an app does not configure it.
+type DriftDetection struct {
+	// Interval is how often drift is checked for, e.g. "1h". Defaults to 15 minutes, and drift detection is disabled if "0".
+	Interval string `json:"interval,omitempty"`
+	// AutoHeal re-applies the deployed version when drift is detected.
+	AutoHeal bool `json:"autoHeal,omitempty"`
+}
+
+// ScheduledDeploy is a version that was selected for automatic deployment outside of the deploy window.
+// It is deployed when the next window opens.
+type ScheduledDeploy struct {
+	Sequence     int64     `json:"sequence"`
+	VersionLabel string    `json:"versionLabel"`
+	QueuedAt     time.Time `json:"queuedAt"`
+	DeployAt     time.Time `json:"deployAt"`
+}
+
+// VersionBlock prevents a version from being deployed. It matches either a version label or a sequence.
+type VersionBlock struct {
+	ID           string    `json:"id"`
+	VersionLabel string    `json:"versionLabel,omitempty"`
+	Sequence     *int64    `json:"sequence,omitempty"`
+	Reason       string    `json:"reason"`
+	CreatedBy    string    `json:"createdBy,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+func (b VersionBlock) Matches(versionLabel string, sequence int64) bool {
+	if b.Sequence != nil {
+		return *b.Sequence == sequence
+	}
+	return b.VersionLabel != "" && b.VersionLabel == versionLabel
+}
+
+type VersionBlocks []VersionBlock
+
+// Find returns the first block that matches the version, or nil if the version is not blocked
+func (bs VersionBlocks) Find(versionLabel string, sequence int64) *VersionBlock {
+	for i := range bs {
+		if bs[i].Matches(versionLabel, sequence) {
+			return &bs[i]
+		}
+	}
+	return nil
+}
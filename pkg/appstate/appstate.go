@@ -213,17 +213,23 @@ func (m *AppMonitor) runInformers(ctx context.Context, informers []types.StatusI
 	}
 
 	kindImpls := map[string]runControllerFunc{
-		DaemonSetResourceKind:             runDaemonSetController,
-		DeploymentResourceKind:            runDeploymentController,
-		IngressResourceKind:               runIngressController,
-		PersistentVolumeClaimResourceKind: runPersistentVolumeClaimController,
-		ServiceResourceKind:               runServiceController,
-		StatefulSetResourceKind:           runStatefulSetController,
+		DaemonSetResourceKind:               runDaemonSetController,
+		DeploymentResourceKind:              runDeploymentController,
+		IngressResourceKind:                 runIngressController,
+		PersistentVolumeClaimResourceKind:   runPersistentVolumeClaimController,
+		ServiceResourceKind:                 runServiceController,
+		StatefulSetResourceKind:             runStatefulSetController,
+		HelmReleaseResourceKind:             runHelmReleaseController,
+		JobResourceKind:                     runJobController,
+		CronJobResourceKind:                 runCronJobController,
+		HorizontalPodAutoscalerResourceKind: runHorizontalPodAutoscalerController,
 	}
 	for namespace, kinds := range namespaceKinds {
 		for kind, informers := range kinds {
 			if impl, ok := kindImpls[kind]; ok {
 				goRun(impl, namespace, informers)
+			} else if isCustomResourceKind(kind) {
+				goRun(runCustomResourceController, namespace, informers)
 			} else {
 				log.Printf("Informer requested for unsupported resource kind %v", kind)
 			}
@@ -0,0 +1,194 @@
+package appstate
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/replicatedhq/kots/pkg/appstate/types"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	CronJobResourceKind = "cronjob"
+	CronJobOwnerKind    = "CronJob"
+
+	// cronJobOwnerIndex indexes jobs by the namespace/name of the cronjob that owns them
+	cronJobOwnerIndex = "cronJobOwner"
+)
+
+type cronJobEventHandler struct {
+	informers       []types.StatusInformer
+	resourceStateCh chan<- types.ResourceState
+	jobs            cache.Indexer
+}
+
+func init() {
+	registerResourceKindNames(CronJobResourceKind, "cronjobs", "cj")
+}
+
+func runCronJobController(
+	ctx context.Context, clientset kubernetes.Interface, targetNamespace string,
+	informers []types.StatusInformer, resourceStateCh chan<- types.ResourceState,
+) {
+	jobListwatch := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return clientset.BatchV1().Jobs(targetNamespace).List(context.TODO(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return clientset.BatchV1().Jobs(targetNamespace).Watch(context.TODO(), options)
+		},
+	}
+	jobInformer := cache.NewSharedIndexInformer(
+		jobListwatch,
+		&batchv1.Job{},
+		time.Minute,
+		cache.Indexers{cronJobOwnerIndex: indexJobByCronJobOwner},
+	)
+	go jobInformer.Run(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), jobInformer.HasSynced) {
+		return
+	}
+
+	listwatch := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return clientset.BatchV1().CronJobs(targetNamespace).List(context.TODO(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return clientset.BatchV1().CronJobs(targetNamespace).Watch(context.TODO(), options)
+		},
+	}
+	informer := cache.NewSharedInformer(
+		listwatch,
+		&batchv1.CronJob{},
+		time.Minute,
+	)
+
+	eventHandler := &cronJobEventHandler{
+		informers:       informers,
+		resourceStateCh: resourceStateCh,
+		jobs:            jobInformer.GetIndexer(),
+	}
+
+	runInformer(ctx, informer, eventHandler)
+	return
+}
+
+func (h *cronJobEventHandler) ObjectCreated(obj interface{}) {
+	r := h.cast(obj)
+	if _, ok := h.getInformer(r); !ok {
+		return
+	}
+	state, reason := CalculateCronJobState(h.jobs, r)
+	h.resourceStateCh <- makeCronJobResourceState(r, state, reason)
+}
+
+func (h *cronJobEventHandler) ObjectUpdated(obj interface{}) {
+	r := h.cast(obj)
+	if _, ok := h.getInformer(r); !ok {
+		return
+	}
+	state, reason := CalculateCronJobState(h.jobs, r)
+	h.resourceStateCh <- makeCronJobResourceState(r, state, reason)
+}
+
+func (h *cronJobEventHandler) ObjectDeleted(obj interface{}) {
+	r := h.cast(obj)
+	if _, ok := h.getInformer(r); !ok {
+		return
+	}
+	h.resourceStateCh <- makeCronJobResourceState(r, types.StateMissing, deletedReason)
+}
+
+func (h *cronJobEventHandler) cast(obj interface{}) *batchv1.CronJob {
+	r, _ := obj.(*batchv1.CronJob)
+	return r
+}
+
+func (h *cronJobEventHandler) getInformer(r *batchv1.CronJob) (types.StatusInformer, bool) {
+	if r != nil {
+		for _, informer := range h.informers {
+			if r.Namespace == informer.Namespace && r.Name == informer.Name {
+				return informer, true
+			}
+		}
+	}
+	return types.StatusInformer{}, false
+}
+
+func makeCronJobResourceState(r *batchv1.CronJob, state types.State, reason types.StateReason) types.ResourceState {
+	return types.ResourceState{
+		Kind:      CronJobResourceKind,
+		Name:      r.Name,
+		Namespace: r.Namespace,
+		State:     state,
+		Reason:    reason.Reason,
+		Message:   reason.Message,
+	}
+}
+
+// CalculateCronJobState returns the result of the most recent job of the cronjob that finished. The cronjob is
+// degraded if that job failed, and ready if it succeeded or no job has finished yet.
+func CalculateCronJobState(jobs cache.Indexer, r *batchv1.CronJob) (types.State, types.StateReason) {
+	if r == nil {
+		return types.StateMissing, types.StateReason{}
+	}
+
+	objs, err := jobs.ByIndex(cronJobOwnerIndex, fmt.Sprintf("%s/%s", r.Namespace, r.Name))
+	if err != nil {
+		log.Printf("failed to get cronjob job list: %s", err)
+		return types.StateUnavailable, types.StateReason{
+			Reason:  "JobListFailed",
+			Message: fmt.Sprintf("failed to list jobs: %s", err),
+		}
+	}
+
+	var lastFinished *batchv1.Job
+	lastFinishedState := types.StateReady
+	for _, obj := range objs {
+		job, ok := obj.(*batchv1.Job)
+		if !ok {
+			continue
+		}
+
+		state, _ := CalculateJobState(job)
+		if state == types.StateUpdating {
+			// still running
+			continue
+		}
+
+		if lastFinished == nil || job.CreationTimestamp.After(lastFinished.CreationTimestamp.Time) {
+			lastFinished = job
+			lastFinishedState = state
+		}
+	}
+
+	if lastFinishedState == types.StateUnavailable {
+		return types.StateDegraded, types.StateReason{
+			Reason:  "LastJobFailed",
+			Message: fmt.Sprintf("the last job %s failed", lastFinished.Name),
+		}
+	}
+	return types.StateReady, types.StateReason{}
+}
+
+// indexJobByCronJobOwner returns the namespace/name of the cronjobs that own the job
+func indexJobByCronJobOwner(obj interface{}) ([]string, error) {
+	job, ok := obj.(*batchv1.Job)
+	if !ok {
+		return nil, nil
+	}
+	keys := []string{}
+	for _, owner := range job.OwnerReferences {
+		if owner.Kind == CronJobOwnerKind {
+			keys = append(keys, fmt.Sprintf("%s/%s", job.Namespace, owner.Name))
+		}
+	}
+	return keys, nil
+}
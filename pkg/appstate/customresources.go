@@ -0,0 +1,175 @@
+package appstate
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/replicatedhq/kots/pkg/k8sutil"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/kubernetes"
+)
+
+type customResourceEventHandler struct {
+	informers       []types.StatusInformer
+	resourceStateCh chan<- types.ResourceState
+}
+
+// isCustomResourceKind returns true if the kind of a status informer is a fully qualified resource,
+// such as certificates.v1.cert-manager.io
+func isCustomResourceKind(kind string) bool {
+	_, ok := parseCustomResourceKind(kind)
+	return ok
+}
+
+// parseCustomResourceKind parses a fully qualified resource of the form resource.version[.group]
+func parseCustomResourceKind(kind string) (schema.GroupVersionResource, bool) {
+	parts := strings.SplitN(kind, ".", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return schema.GroupVersionResource{}, false
+	}
+
+	gvr := schema.GroupVersionResource{
+		Resource: strings.ToLower(parts[0]),
+		Version:  parts[1],
+	}
+	if len(parts) == 3 {
+		gvr.Group = parts[2]
+	}
+	return gvr, true
+}
+
+func runCustomResourceController(
+	ctx context.Context, clientset kubernetes.Interface, targetNamespace string,
+	informers []types.StatusInformer, resourceStateCh chan<- types.ResourceState,
+) {
+	if len(informers) == 0 {
+		return
+	}
+
+	gvr, ok := parseCustomResourceKind(informers[0].Kind)
+	if !ok {
+		log.Printf("Informer requested for invalid custom resource kind %v", informers[0].Kind)
+		return
+	}
+
+	dynamicClient, err := k8sutil.GetDynamicClient()
+	if err != nil {
+		log.Printf("failed to get dynamic client for custom resource informer: %s", err)
+		return
+	}
+
+	runCustomResourceInformer(ctx, dynamicClient, gvr, targetNamespace, informers, resourceStateCh)
+}
+
+func runCustomResourceInformer(
+	ctx context.Context, dynamicClient dynamic.Interface, gvr schema.GroupVersionResource, targetNamespace string,
+	informers []types.StatusInformer, resourceStateCh chan<- types.ResourceState,
+) {
+	informer := dynamicinformer.NewFilteredDynamicInformer(
+		dynamicClient,
+		gvr,
+		targetNamespace,
+		time.Minute,
+		nil,
+		nil,
+	).Informer()
+
+	eventHandler := &customResourceEventHandler{
+		informers:       informers,
+		resourceStateCh: resourceStateCh,
+	}
+
+	runInformer(ctx, informer, eventHandler)
+}
+
+func (h *customResourceEventHandler) ObjectCreated(obj interface{}) {
+	r := h.cast(obj)
+	informer, ok := h.getInformer(r)
+	if !ok {
+		return
+	}
+	state, reason := CalculateCustomResourceState(r, informer)
+	h.resourceStateCh <- makeCustomResourceState(informer, state, reason)
+}
+
+func (h *customResourceEventHandler) ObjectUpdated(obj interface{}) {
+	r := h.cast(obj)
+	informer, ok := h.getInformer(r)
+	if !ok {
+		return
+	}
+	state, reason := CalculateCustomResourceState(r, informer)
+	h.resourceStateCh <- makeCustomResourceState(informer, state, reason)
+}
+
+func (h *customResourceEventHandler) ObjectDeleted(obj interface{}) {
+	r := h.cast(obj)
+	informer, ok := h.getInformer(r)
+	if !ok {
+		return
+	}
+	h.resourceStateCh <- makeCustomResourceState(informer, types.StateMissing, deletedReason)
+}
+
+func (h *customResourceEventHandler) cast(obj interface{}) *unstructured.Unstructured {
+	r, _ := obj.(*unstructured.Unstructured)
+	return r
+}
+
+func (h *customResourceEventHandler) getInformer(r *unstructured.Unstructured) (types.StatusInformer, bool) {
+	if r != nil {
+		for _, informer := range h.informers {
+			if r.GetNamespace() == informer.Namespace && r.GetName() == informer.Name {
+				return informer, true
+			}
+		}
+	}
+	return types.StatusInformer{}, false
+}
+
+func makeCustomResourceState(informer types.StatusInformer, state types.State, reason types.StateReason) types.ResourceState {
+	return types.ResourceState{
+		Kind:      informer.Kind,
+		Name:      informer.Name,
+		Namespace: informer.Namespace,
+		State:     state,
+		Reason:    reason.Reason,
+		Message:   reason.Message,
+	}
+}
+
+// CalculateCustomResourceState returns ready if the resource matches the readiness condition of the informer,
+// or if the informer does not have one. Otherwise the resource is unavailable.
+func CalculateCustomResourceState(r *unstructured.Unstructured, informer types.StatusInformer) (types.State, types.StateReason) {
+	if r == nil {
+		return types.StateMissing, types.StateReason{}
+	}
+
+	if informer.JSONPath == "" {
+		return types.StateReady, types.StateReason{}
+	}
+
+	matches, err := resourcePropertyMatchesValue(r, informer.JSONPath, informer.Value)
+	if err != nil {
+		log.Printf("failed to check readiness condition of %s %s: %s", informer.Kind, informer.Name, err)
+		return types.StateUnavailable, types.StateReason{
+			Reason:  "ReadinessConditionFailed",
+			Message: fmt.Sprintf("failed to check readiness condition: %s", err),
+		}
+	}
+	if matches {
+		return types.StateReady, types.StateReason{}
+	}
+
+	return types.StateUnavailable, types.StateReason{
+		Reason:  "ReadinessConditionNotMet",
+		Message: fmt.Sprintf("%s is not %s", informer.JSONPath, informer.Value),
+	}
+}
@@ -0,0 +1,80 @@
+package appstate
+
+import (
+	"testing"
+
+	"github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func Test_parseCustomResourceKind(t *testing.T) {
+	tests := []struct {
+		kind   string
+		want   schema.GroupVersionResource
+		wantOk bool
+	}{
+		{
+			kind:   "certificates.v1.cert-manager.io",
+			want:   schema.GroupVersionResource{Group: "cert-manager.io", Version: "v1", Resource: "certificates"},
+			wantOk: true,
+		},
+		{
+			kind:   "Widgets.v1",
+			want:   schema.GroupVersionResource{Version: "v1", Resource: "widgets"},
+			wantOk: true,
+		},
+		{
+			kind: "deployment",
+		},
+		{
+			kind: ".v1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			got, ok := parseCustomResourceKind(tt.kind)
+			require.Equal(t, tt.wantOk, ok)
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCalculateCustomResourceState(t *testing.T) {
+	certificate := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Issuing", "status": "False"},
+					map[string]interface{}{"type": "Ready", "status": "True"},
+				},
+			},
+		},
+	}
+
+	informer := types.StatusInformer{
+		Kind:     "certificates.v1.cert-manager.io",
+		Name:     "my-cert",
+		JSONPath: `.status.conditions[?(@.type=="Ready")].status`,
+		Value:    "True",
+	}
+	state, reason := CalculateCustomResourceState(certificate, informer)
+	require.Equal(t, types.StateReady, state)
+	require.Equal(t, types.StateReason{}, reason)
+
+	informer.Value = "False"
+	state, reason = CalculateCustomResourceState(certificate, informer)
+	require.Equal(t, types.StateUnavailable, state)
+	require.Equal(t, types.StateReason{
+		Reason:  "ReadinessConditionNotMet",
+		Message: `.status.conditions[?(@.type=="Ready")].status is not False`,
+	}, reason)
+
+	informer.JSONPath = ""
+	state, _ = CalculateCustomResourceState(certificate, informer)
+	require.Equal(t, types.StateReady, state)
+
+	state, _ = CalculateCustomResourceState(nil, informer)
+	require.Equal(t, types.StateMissing, state)
+}
@@ -2,6 +2,7 @@ package appstate
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
@@ -62,7 +63,8 @@ func (h *daemonSetEventHandler) ObjectCreated(obj interface{}) {
 		return
 	}
 
-	h.resourceStateCh <- makeDaemonSetResourceState(r, CalculateDaemonSetState(h.clientset, h.targetNamespace, r))
+	state, reason := CalculateDaemonSetState(h.clientset, h.targetNamespace, r)
+	h.resourceStateCh <- makeDaemonSetResourceState(r, state, reason)
 }
 
 func (h *daemonSetEventHandler) ObjectDeleted(obj interface{}) {
@@ -71,7 +73,7 @@ func (h *daemonSetEventHandler) ObjectDeleted(obj interface{}) {
 		return
 	}
 
-	h.resourceStateCh <- makeDaemonSetResourceState(r, types.StateMissing)
+	h.resourceStateCh <- makeDaemonSetResourceState(r, types.StateMissing, deletedReason)
 }
 
 func (h *daemonSetEventHandler) ObjectUpdated(obj interface{}) {
@@ -80,7 +82,8 @@ func (h *daemonSetEventHandler) ObjectUpdated(obj interface{}) {
 		return
 	}
 
-	h.resourceStateCh <- makeDaemonSetResourceState(r, CalculateDaemonSetState(h.clientset, h.targetNamespace, r))
+	state, reason := CalculateDaemonSetState(h.clientset, h.targetNamespace, r)
+	h.resourceStateCh <- makeDaemonSetResourceState(r, state, reason)
 }
 
 func (h *daemonSetEventHandler) getInformer(r *appsv1.DaemonSet) (types.StatusInformer, bool) {
@@ -103,13 +106,16 @@ func (h *daemonSetEventHandler) cast(obj interface{}) *appsv1.DaemonSet {
 // The pods in a daemonset can be identified by the match label set in the daemonset and the
 // "controller-revision-hash" can be used to determine if they are all the in the same daemonset
 // version.
-func CalculateDaemonSetState(clientset kubernetes.Interface, targetNamespace string, r *appsv1.DaemonSet) types.State {
+func CalculateDaemonSetState(clientset kubernetes.Interface, targetNamespace string, r *appsv1.DaemonSet) (types.State, types.StateReason) {
 	if r == nil {
-		return types.StateUnavailable
+		return types.StateUnavailable, types.StateReason{}
 	}
 
 	if r.Status.ObservedGeneration != r.ObjectMeta.Generation {
-		return types.StateUpdating
+		return types.StateUpdating, types.StateReason{
+			Reason:  "RolloutPending",
+			Message: "the latest spec has not been observed by the daemonset controller",
+		}
 	}
 
 	listOptions := metav1.ListOptions{LabelSelector: labels.SelectorFromSet(r.Spec.Selector.MatchLabels).String()}
@@ -117,7 +123,10 @@ func CalculateDaemonSetState(clientset kubernetes.Interface, targetNamespace str
 	pods, err := clientset.CoreV1().Pods(targetNamespace).List(context.TODO(), listOptions)
 	if err != nil {
 		log.Printf("failed to get daemonset pod list: %s", err)
-		return types.StateUnavailable
+		return types.StateUnavailable, types.StateReason{
+			Reason:  "PodListFailed",
+			Message: fmt.Sprintf("failed to list pods: %s", err),
+		}
 	}
 
 	// If the pod version labels are not all the same, then the daemonset is updating.
@@ -139,44 +148,66 @@ func CalculateDaemonSetState(clientset kubernetes.Interface, targetNamespace str
 		version, exists := pod.Labels[DaemonSetPodVersionLabel]
 		if !exists {
 			log.Printf("failed to find %s label for pod %s", DaemonSetPodVersionLabel, pod.Name)
-			return types.StateUnavailable
+			return types.StateUnavailable, types.StateReason{
+				Reason:  "PodRevisionUnknown",
+				Message: fmt.Sprintf("pod %s has no %s label", pod.Name, DaemonSetPodVersionLabel),
+			}
 		}
 
 		if len(currentVersion) == 0 {
 			currentVersion = version
 		} else if version != currentVersion {
-			return types.StateUpdating
+			return types.StateUpdating, types.StateReason{
+				Reason:  "RolloutInProgress",
+				Message: "pods are running more than one revision",
+			}
 		}
 	}
 
 	if r.Status.NumberUnavailable > 0 {
-		return types.StateDegraded
+		return types.StateDegraded, types.StateReason{
+			Reason:  "PodsUnavailable",
+			Message: fmt.Sprintf("%d/%d pods unavailable", r.Status.NumberUnavailable, r.Status.DesiredNumberScheduled),
+		}
 	}
 
 	if r.Status.NumberMisscheduled > 0 {
-		return types.StateDegraded
+		return types.StateDegraded, types.StateReason{
+			Reason:  "PodsMisscheduled",
+			Message: fmt.Sprintf("%d pods are running on nodes they should not run on", r.Status.NumberMisscheduled),
+		}
 	}
 
 	if r.Status.CurrentNumberScheduled != r.Status.DesiredNumberScheduled {
-		return types.StateDegraded
+		return types.StateDegraded, types.StateReason{
+			Reason:  "PodsNotScheduled",
+			Message: fmt.Sprintf("%d/%d pods scheduled", r.Status.CurrentNumberScheduled, r.Status.DesiredNumberScheduled),
+		}
 	}
 
 	if r.Status.NumberReady >= r.Status.DesiredNumberScheduled {
-		return types.StateReady
+		return types.StateReady, types.StateReason{}
+	}
+
+	notReadyReason := types.StateReason{
+		Reason:  "PodsNotReady",
+		Message: fmt.Sprintf("%d/%d pods ready", r.Status.NumberReady, r.Status.DesiredNumberScheduled),
 	}
 
 	if r.Status.NumberReady > 0 {
-		return types.StateDegraded
+		return types.StateDegraded, notReadyReason
 	}
 
-	return types.StateUnavailable
+	return types.StateUnavailable, notReadyReason
 }
 
-func makeDaemonSetResourceState(r *appsv1.DaemonSet, state types.State) types.ResourceState {
+func makeDaemonSetResourceState(r *appsv1.DaemonSet, state types.State, reason types.StateReason) types.ResourceState {
 	return types.ResourceState{
 		Kind:      DaemonSetResourceKind,
 		Name:      r.Name,
 		Namespace: r.Namespace,
 		State:     state,
+		Reason:    reason.Reason,
+		Message:   reason.Message,
 	}
 }
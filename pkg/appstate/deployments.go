@@ -2,6 +2,7 @@ package appstate
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/replicatedhq/kots/pkg/appstate/types"
@@ -65,7 +66,8 @@ func (h *deploymentEventHandler) ObjectCreated(obj interface{}) {
 	if _, ok := h.getInformer(r); !ok {
 		return
 	}
-	h.resourceStateCh <- makeDeploymentResourceState(r, CalculateDeploymentState(r))
+	state, reason := CalculateDeploymentState(r)
+	h.resourceStateCh <- makeDeploymentResourceState(r, state, reason)
 }
 
 func (h *deploymentEventHandler) ObjectUpdated(obj interface{}) {
@@ -73,7 +75,8 @@ func (h *deploymentEventHandler) ObjectUpdated(obj interface{}) {
 	if _, ok := h.getInformer(r); !ok {
 		return
 	}
-	h.resourceStateCh <- makeDeploymentResourceState(r, CalculateDeploymentState(r))
+	state, reason := CalculateDeploymentState(r)
+	h.resourceStateCh <- makeDeploymentResourceState(r, state, reason)
 }
 
 func (h *deploymentEventHandler) ObjectDeleted(obj interface{}) {
@@ -81,7 +84,7 @@ func (h *deploymentEventHandler) ObjectDeleted(obj interface{}) {
 	if _, ok := h.getInformer(r); !ok {
 		return
 	}
-	h.resourceStateCh <- makeDeploymentResourceState(r, types.StateMissing)
+	h.resourceStateCh <- makeDeploymentResourceState(r, types.StateMissing, deletedReason)
 }
 
 func (h *deploymentEventHandler) cast(obj interface{}) *appsv1.Deployment {
@@ -100,18 +103,23 @@ func (h *deploymentEventHandler) getInformer(r *appsv1.Deployment) (types.Status
 	return types.StatusInformer{}, false
 }
 
-func makeDeploymentResourceState(r *appsv1.Deployment, state types.State) types.ResourceState {
+func makeDeploymentResourceState(r *appsv1.Deployment, state types.State, reason types.StateReason) types.ResourceState {
 	return types.ResourceState{
 		Kind:      DeploymentResourceKind,
 		Name:      r.Name,
 		Namespace: r.Namespace,
 		State:     state,
+		Reason:    reason.Reason,
+		Message:   reason.Message,
 	}
 }
 
-func CalculateDeploymentState(r *appsv1.Deployment) types.State {
+func CalculateDeploymentState(r *appsv1.Deployment) (types.State, types.StateReason) {
 	if r.Status.ObservedGeneration != r.ObjectMeta.Generation {
-		return types.StateUpdating
+		return types.StateUpdating, types.StateReason{
+			Reason:  "RolloutPending",
+			Message: "the latest spec has not been observed by the deployment controller",
+		}
 	}
 	var desiredReplicas int32
 	if r.Spec.Replicas == nil {
@@ -121,12 +129,15 @@ func CalculateDeploymentState(r *appsv1.Deployment) types.State {
 	}
 	if r.Status.ReadyReplicas >= desiredReplicas {
 		if r.Status.UnavailableReplicas > 0 {
-			return types.StateUpdating
+			return types.StateUpdating, types.StateReason{
+				Reason:  "RolloutInProgress",
+				Message: fmt.Sprintf("%d replicas unavailable", r.Status.UnavailableReplicas),
+			}
 		}
-		return types.StateReady
+		return types.StateReady, types.StateReason{}
 	}
 	if r.Status.ReadyReplicas > 0 {
-		return types.StateDegraded
+		return types.StateDegraded, replicasReason("ReplicasUnavailable", r.Status.ReadyReplicas, desiredReplicas)
 	}
-	return types.StateUnavailable
+	return types.StateUnavailable, replicasReason("ReplicasUnavailable", r.Status.ReadyReplicas, desiredReplicas)
 }
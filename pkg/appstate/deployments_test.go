@@ -0,0 +1,62 @@
+package appstate
+
+import (
+	"testing"
+
+	"github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestCalculateDeploymentState(t *testing.T) {
+	tests := []struct {
+		name       string
+		replicas   int32
+		ready      int32
+		want       types.State
+		wantReason types.StateReason
+	}{
+		{
+			name:     "all replicas ready",
+			replicas: 3,
+			ready:    3,
+			want:     types.StateReady,
+		},
+		{
+			name:     "some replicas ready",
+			replicas: 3,
+			ready:    2,
+			want:     types.StateDegraded,
+			wantReason: types.StateReason{
+				Reason:  "ReplicasUnavailable",
+				Message: "2/3 replicas available",
+			},
+		},
+		{
+			name:     "no replicas ready",
+			replicas: 3,
+			ready:    0,
+			want:     types.StateUnavailable,
+			wantReason: types.StateReason{
+				Reason:  "ReplicasUnavailable",
+				Message: "0/3 replicas available",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &appsv1.Deployment{
+				Spec: appsv1.DeploymentSpec{
+					Replicas: pointer.Int32(tt.replicas),
+				},
+				Status: appsv1.DeploymentStatus{
+					ReadyReplicas: tt.ready,
+				},
+			}
+			got, reason := CalculateDeploymentState(r)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
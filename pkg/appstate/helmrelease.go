@@ -0,0 +1,196 @@
+package appstate
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/replicatedhq/kots/pkg/k8sutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/metadata"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	HelmReleaseResourceKind = "helmrelease"
+
+	// helm stores each revision of a release in a secret with these labels
+	helmReleaseOwnerLabel   = "owner"
+	helmReleaseOwner        = "helm"
+	helmReleaseNameLabel    = "name"
+	helmReleaseStatusLabel  = "status"
+	helmReleaseVersionLabel = "version"
+	helmReleaseSecretPrefix = "sh.helm.release.v1."
+)
+
+type helmReleaseEventHandler struct {
+	informers       []types.StatusInformer
+	resourceStateCh chan<- types.ResourceState
+	// secrets is the informer's cache of the metadata of release secrets
+	secrets cache.Store
+}
+
+func init() {
+	registerResourceKindNames(HelmReleaseResourceKind, "helmreleases", "release")
+}
+
+func runHelmReleaseController(
+	ctx context.Context, clientset kubernetes.Interface, targetNamespace string,
+	informers []types.StatusInformer, resourceStateCh chan<- types.ResourceState,
+) {
+	metadataClient, err := k8sutil.GetMetadataClient()
+	if err != nil {
+		log.Printf("failed to get metadata client for helm release informer: %s", err)
+		return
+	}
+
+	runHelmReleaseInformer(ctx, metadataClient, targetNamespace, informers, resourceStateCh)
+}
+
+// runHelmReleaseInformer watches only the metadata of the release secrets, since the state of a release is
+// in the labels and the secrets themselves hold the whole encoded release
+func runHelmReleaseInformer(
+	ctx context.Context, metadataClient metadata.Interface, targetNamespace string,
+	informers []types.StatusInformer, resourceStateCh chan<- types.ResourceState,
+) {
+	selector := labels.SelectorFromSet(labels.Set{helmReleaseOwnerLabel: helmReleaseOwner}).String()
+	informer := metadatainformer.NewFilteredMetadataInformer(
+		metadataClient,
+		corev1.SchemeGroupVersion.WithResource("secrets"),
+		targetNamespace,
+		time.Minute,
+		nil,
+		func(options *metav1.ListOptions) {
+			options.LabelSelector = selector
+		},
+	).Informer()
+
+	eventHandler := &helmReleaseEventHandler{
+		informers:       informers,
+		resourceStateCh: resourceStateCh,
+		secrets:         informer.GetStore(),
+	}
+
+	runInformer(ctx, informer, eventHandler)
+}
+
+func (h *helmReleaseEventHandler) ObjectCreated(obj interface{}) {
+	h.handleEvent(obj)
+}
+
+func (h *helmReleaseEventHandler) ObjectUpdated(obj interface{}) {
+	h.handleEvent(obj)
+}
+
+// ObjectDeleted recalculates the state of the release, since a release is only missing once all of
+// its revisions are deleted
+func (h *helmReleaseEventHandler) ObjectDeleted(obj interface{}) {
+	h.handleEvent(obj)
+}
+
+func (h *helmReleaseEventHandler) handleEvent(obj interface{}) {
+	r := h.cast(obj)
+	informer, ok := h.getInformer(r)
+	if !ok {
+		return
+	}
+	state, reason := CalculateHelmReleaseState(h.secrets, informer.Namespace, informer.Name)
+	h.resourceStateCh <- makeHelmReleaseResourceState(informer, state, reason)
+}
+
+func (h *helmReleaseEventHandler) cast(obj interface{}) *metav1.PartialObjectMetadata {
+	r, _ := obj.(*metav1.PartialObjectMetadata)
+	return r
+}
+
+func (h *helmReleaseEventHandler) getInformer(r *metav1.PartialObjectMetadata) (types.StatusInformer, bool) {
+	if isHelmReleaseSecret(r) {
+		for _, informer := range h.informers {
+			if r.Namespace == informer.Namespace && r.Labels[helmReleaseNameLabel] == informer.Name {
+				return informer, true
+			}
+		}
+	}
+	return types.StatusInformer{}, false
+}
+
+func makeHelmReleaseResourceState(informer types.StatusInformer, state types.State, reason types.StateReason) types.ResourceState {
+	return types.ResourceState{
+		Kind:      HelmReleaseResourceKind,
+		Name:      informer.Name,
+		Namespace: informer.Namespace,
+		State:     state,
+		Reason:    reason.Reason,
+		Message:   reason.Message,
+	}
+}
+
+// isHelmReleaseSecret returns true if the secret is a revision of a helm release. Only the metadata of the
+// secret is available, so its name is checked instead of its type.
+func isHelmReleaseSecret(secret *metav1.PartialObjectMetadata) bool {
+	return secret != nil && secret.Labels[helmReleaseOwnerLabel] == helmReleaseOwner && strings.HasPrefix(secret.Name, helmReleaseSecretPrefix)
+}
+
+// CalculateHelmReleaseState returns the state of the latest revision of the helm release from the
+// metadata of the release secrets in the store
+func CalculateHelmReleaseState(secrets cache.Store, namespace string, releaseName string) (types.State, types.StateReason) {
+	latestVersion := -1
+	latestStatus := ""
+	for _, obj := range secrets.List() {
+		secret, ok := obj.(*metav1.PartialObjectMetadata)
+		if !ok || !isHelmReleaseSecret(secret) {
+			continue
+		}
+		if secret.Namespace != namespace || secret.Labels[helmReleaseNameLabel] != releaseName {
+			continue
+		}
+		version, err := strconv.Atoi(secret.Labels[helmReleaseVersionLabel])
+		if err != nil {
+			log.Printf("failed to parse version of helm release secret %s: %s", secret.Name, err)
+			continue
+		}
+		if version > latestVersion {
+			latestVersion = version
+			latestStatus = secret.Labels[helmReleaseStatusLabel]
+		}
+	}
+
+	if latestVersion == -1 {
+		return types.StateMissing, types.StateReason{
+			Reason:  "ReleaseNotFound",
+			Message: "release has not been installed",
+		}
+	}
+
+	state := getHelmReleaseStatusState(latestStatus)
+	if state == types.StateReady {
+		return state, types.StateReason{}
+	}
+	return state, types.StateReason{
+		Reason:  "ReleaseNotDeployed",
+		Message: fmt.Sprintf("revision %d is %s", latestVersion, latestStatus),
+	}
+}
+
+// getHelmReleaseStatusState maps the status of a helm release to a resource state
+func getHelmReleaseStatusState(status string) types.State {
+	switch status {
+	case "deployed":
+		return types.StateReady
+	case "pending-install", "pending-upgrade", "pending-rollback", "uninstalling":
+		return types.StateUpdating
+	case "uninstalled", "superseded":
+		return types.StateMissing
+	default:
+		// failed and unknown releases
+		return types.StateUnavailable
+	}
+}
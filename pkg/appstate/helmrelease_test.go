@@ -0,0 +1,103 @@
+package appstate
+
+import (
+	"testing"
+
+	"github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func makeHelmReleaseSecret(releaseName string, version string, status string) *metav1.PartialObjectMetadata {
+	return &metav1.PartialObjectMetadata{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sh.helm.release.v1." + releaseName + ".v" + version,
+			Namespace: "app",
+			Labels: map[string]string{
+				"owner":   "helm",
+				"name":    releaseName,
+				"version": version,
+				"status":  status,
+			},
+		},
+	}
+}
+
+func TestCalculateHelmReleaseState(t *testing.T) {
+	tests := []struct {
+		name        string
+		secrets     []*metav1.PartialObjectMetadata
+		want        types.State
+		wantMessage string
+	}{
+		{
+			name:        "no revisions",
+			want:        types.StateMissing,
+			wantMessage: "release has not been installed",
+		},
+		{
+			name: "deployed",
+			secrets: []*metav1.PartialObjectMetadata{
+				makeHelmReleaseSecret("my-chart", "1", "superseded"),
+				makeHelmReleaseSecret("my-chart", "2", "deployed"),
+			},
+			want: types.StateReady,
+		},
+		{
+			name: "pending upgrade",
+			secrets: []*metav1.PartialObjectMetadata{
+				makeHelmReleaseSecret("my-chart", "9", "deployed"),
+				makeHelmReleaseSecret("my-chart", "10", "pending-upgrade"),
+			},
+			want:        types.StateUpdating,
+			wantMessage: "revision 10 is pending-upgrade",
+		},
+		{
+			name: "failed upgrade",
+			secrets: []*metav1.PartialObjectMetadata{
+				makeHelmReleaseSecret("my-chart", "1", "deployed"),
+				makeHelmReleaseSecret("my-chart", "2", "failed"),
+			},
+			want:        types.StateUnavailable,
+			wantMessage: "revision 2 is failed",
+		},
+		{
+			name: "other releases are ignored",
+			secrets: []*metav1.PartialObjectMetadata{
+				makeHelmReleaseSecret("my-chart", "1", "deployed"),
+				makeHelmReleaseSecret("other-chart", "2", "failed"),
+			},
+			want: types.StateReady,
+		},
+		{
+			name: "other secrets are ignored",
+			secrets: []*metav1.PartialObjectMetadata{
+				makeHelmReleaseSecret("my-chart", "1", "deployed"),
+				func() *metav1.PartialObjectMetadata {
+					s := makeHelmReleaseSecret("my-chart", "2", "failed")
+					s.Name = "my-chart-credentials"
+					return s
+				}(),
+			},
+			want: types.StateReady,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+			for _, secret := range tt.secrets {
+				require.NoError(t, store.Add(secret))
+			}
+			got, reason := CalculateHelmReleaseState(store, "app", "my-chart")
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantMessage, reason.Message)
+		})
+	}
+}
+
+func TestIsHelmReleaseKind(t *testing.T) {
+	require.True(t, IsHelmReleaseKind("helmrelease"))
+	require.True(t, IsHelmReleaseKind("HelmReleases"))
+	require.False(t, IsHelmReleaseKind("deployment"))
+}
@@ -0,0 +1,72 @@
+package history
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/store"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// purgeHistoryCronSpec - daily cron spec for the app status history retention job
+	purgeHistoryCronSpec = "45 0 * * *"
+
+	// RetentionDaysEnv - env var that sets how many days of app status history are kept. 0 keeps history forever.
+	RetentionDaysEnv = "APP_STATUS_HISTORY_RETENTION_DAYS"
+
+	// DefaultRetentionDays - number of days of app status history kept when the env var is not set
+	DefaultRetentionDays = 90
+)
+
+// GetRetentionDays - returns the configured app status history retention in days
+func GetRetentionDays() (int, error) {
+	val := os.Getenv(RetentionDaysEnv)
+	if val == "" {
+		return DefaultRetentionDays, nil
+	}
+
+	days, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse %s", RetentionDaysEnv)
+	}
+	if days < 0 {
+		return 0, errors.Errorf("%s cannot be negative", RetentionDaysEnv)
+	}
+
+	return days, nil
+}
+
+// StartRetentionCronJob - start the cron job which deletes app status history older than the retention period
+func StartRetentionCronJob() error {
+	retentionDays, err := GetRetentionDays()
+	if err != nil {
+		return errors.Wrap(err, "failed to get app status history retention")
+	}
+	if retentionDays == 0 {
+		logger.Debug("app status history retention is disabled")
+		return nil
+	}
+
+	logger.Debug("starting app status history retention cron job")
+
+	cronJob := cron.New(cron.WithChain(
+		cron.Recover(cron.DefaultLogger),
+	))
+
+	_, err = cronJob.AddFunc(purgeHistoryCronSpec, func() {
+		logger.Debug("running app status history retention job")
+		before := time.Now().AddDate(0, 0, -retentionDays)
+		if err := store.GetStore().DeleteAppStatusHistoryBefore(before); err != nil {
+			logger.Error(errors.Wrap(err, "failed to delete expired app status history"))
+		}
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to add cron job")
+	}
+	cronJob.Start()
+	return nil
+}
@@ -0,0 +1,139 @@
+package appstate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/replicatedhq/kots/pkg/appstate/types"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	HorizontalPodAutoscalerResourceKind = "horizontalpodautoscaler"
+)
+
+type horizontalPodAutoscalerEventHandler struct {
+	informers       []types.StatusInformer
+	resourceStateCh chan<- types.ResourceState
+}
+
+func init() {
+	registerResourceKindNames(HorizontalPodAutoscalerResourceKind, "horizontalpodautoscalers", "hpa")
+}
+
+func runHorizontalPodAutoscalerController(
+	ctx context.Context, clientset kubernetes.Interface, targetNamespace string,
+	informers []types.StatusInformer, resourceStateCh chan<- types.ResourceState,
+) {
+	listwatch := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return clientset.AutoscalingV2().HorizontalPodAutoscalers(targetNamespace).List(context.TODO(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return clientset.AutoscalingV2().HorizontalPodAutoscalers(targetNamespace).Watch(context.TODO(), options)
+		},
+	}
+	informer := cache.NewSharedInformer(
+		listwatch,
+		&autoscalingv2.HorizontalPodAutoscaler{},
+		time.Minute,
+	)
+
+	eventHandler := &horizontalPodAutoscalerEventHandler{
+		informers:       informers,
+		resourceStateCh: resourceStateCh,
+	}
+
+	runInformer(ctx, informer, eventHandler)
+	return
+}
+
+func (h *horizontalPodAutoscalerEventHandler) ObjectCreated(obj interface{}) {
+	r := h.cast(obj)
+	if _, ok := h.getInformer(r); !ok {
+		return
+	}
+	state, reason := CalculateHorizontalPodAutoscalerState(r)
+	h.resourceStateCh <- makeHorizontalPodAutoscalerResourceState(r, state, reason)
+}
+
+func (h *horizontalPodAutoscalerEventHandler) ObjectUpdated(obj interface{}) {
+	r := h.cast(obj)
+	if _, ok := h.getInformer(r); !ok {
+		return
+	}
+	state, reason := CalculateHorizontalPodAutoscalerState(r)
+	h.resourceStateCh <- makeHorizontalPodAutoscalerResourceState(r, state, reason)
+}
+
+func (h *horizontalPodAutoscalerEventHandler) ObjectDeleted(obj interface{}) {
+	r := h.cast(obj)
+	if _, ok := h.getInformer(r); !ok {
+		return
+	}
+	h.resourceStateCh <- makeHorizontalPodAutoscalerResourceState(r, types.StateMissing, deletedReason)
+}
+
+func (h *horizontalPodAutoscalerEventHandler) cast(obj interface{}) *autoscalingv2.HorizontalPodAutoscaler {
+	r, _ := obj.(*autoscalingv2.HorizontalPodAutoscaler)
+	return r
+}
+
+func (h *horizontalPodAutoscalerEventHandler) getInformer(r *autoscalingv2.HorizontalPodAutoscaler) (types.StatusInformer, bool) {
+	if r != nil {
+		for _, informer := range h.informers {
+			if r.Namespace == informer.Namespace && r.Name == informer.Name {
+				return informer, true
+			}
+		}
+	}
+	return types.StatusInformer{}, false
+}
+
+func makeHorizontalPodAutoscalerResourceState(r *autoscalingv2.HorizontalPodAutoscaler, state types.State, reason types.StateReason) types.ResourceState {
+	return types.ResourceState{
+		Kind:      HorizontalPodAutoscalerResourceKind,
+		Name:      r.Name,
+		Namespace: r.Namespace,
+		State:     state,
+		Reason:    reason.Reason,
+		Message:   reason.Message,
+	}
+}
+
+// CalculateHorizontalPodAutoscalerState returns degraded when the autoscaler is at its maximum replicas,
+// since it cannot scale up further, and unavailable when it is unable to scale
+func CalculateHorizontalPodAutoscalerState(r *autoscalingv2.HorizontalPodAutoscaler) (types.State, types.StateReason) {
+	if r == nil {
+		return types.StateMissing, types.StateReason{}
+	}
+
+	for _, condition := range r.Status.Conditions {
+		if condition.Type == autoscalingv2.AbleToScale && condition.Status == corev1.ConditionFalse {
+			reason := types.StateReason{
+				Reason:  "UnableToScale",
+				Message: "unable to scale",
+			}
+			if condition.Message != "" {
+				reason.Message = fmt.Sprintf("unable to scale: %s", condition.Message)
+			}
+			return types.StateUnavailable, reason
+		}
+	}
+
+	if r.Status.CurrentReplicas >= r.Spec.MaxReplicas {
+		return types.StateDegraded, types.StateReason{
+			Reason:  "AtMaxReplicas",
+			Message: fmt.Sprintf("at maximum of %d replicas", r.Spec.MaxReplicas),
+		}
+	}
+
+	return types.StateReady, types.StateReason{}
+}
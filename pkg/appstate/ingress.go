@@ -2,6 +2,7 @@ package appstate
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/replicatedhq/kots/pkg/appstate/types"
@@ -74,7 +75,8 @@ func (h *ingressEventHandler) ObjectCreated(obj interface{}) {
 	if _, ok := h.getInformer(r); !ok {
 		return
 	}
-	h.resourceStateCh <- makeIngressResourceState(r, CalculateIngressState(h.clientset, r))
+	state, reason := CalculateIngressState(h.clientset, r)
+	h.resourceStateCh <- makeIngressResourceState(r, state, reason)
 }
 
 func (h *ingressEventHandler) ObjectUpdated(obj interface{}) {
@@ -82,7 +84,8 @@ func (h *ingressEventHandler) ObjectUpdated(obj interface{}) {
 	if _, ok := h.getInformer(r); !ok {
 		return
 	}
-	h.resourceStateCh <- makeIngressResourceState(r, CalculateIngressState(h.clientset, r))
+	state, reason := CalculateIngressState(h.clientset, r)
+	h.resourceStateCh <- makeIngressResourceState(r, state, reason)
 }
 
 func (h *ingressEventHandler) ObjectDeleted(obj interface{}) {
@@ -90,7 +93,7 @@ func (h *ingressEventHandler) ObjectDeleted(obj interface{}) {
 	if _, ok := h.getInformer(r); !ok {
 		return
 	}
-	h.resourceStateCh <- makeIngressResourceState(r, types.StateMissing)
+	h.resourceStateCh <- makeIngressResourceState(r, types.StateMissing, deletedReason)
 }
 
 func (h *ingressEventHandler) cast(obj interface{}) *networkingv1.Ingress {
@@ -109,16 +112,18 @@ func (h *ingressEventHandler) getInformer(r *networkingv1.Ingress) (types.Status
 	return types.StatusInformer{}, false
 }
 
-func makeIngressResourceState(r *networkingv1.Ingress, state types.State) types.ResourceState {
+func makeIngressResourceState(r *networkingv1.Ingress, state types.State, reason types.StateReason) types.ResourceState {
 	return types.ResourceState{
 		Kind:      IngressResourceKind,
 		Name:      r.Name,
 		Namespace: r.Namespace,
 		State:     state,
+		Reason:    reason.Reason,
+		Message:   reason.Message,
 	}
 }
 
-func CalculateIngressState(clientset kubernetes.Interface, r *networkingv1.Ingress) types.State {
+func CalculateIngressState(clientset kubernetes.Interface, r *networkingv1.Ingress) (types.State, types.StateReason) {
 	ctx := context.TODO()
 	ns := r.Namespace
 	backend := r.Spec.DefaultBackend
@@ -142,15 +147,25 @@ func CalculateIngressState(clientset kubernetes.Interface, r *networkingv1.Ingre
 	}
 
 	services := []*v1.Service{} // includes nils which are mapped to unavailable
+	serviceNames := []string{}
 	if backend != nil {
 		service, _ := clientset.CoreV1().Services(ns).Get(ctx, backend.Service.Name, metav1.GetOptions{})
 		services = append(services, service)
+		serviceNames = append(serviceNames, backend.Service.Name)
 	}
 
 	for _, rules := range r.Spec.Rules {
 		for _, path := range rules.HTTP.Paths {
 			service, _ := clientset.CoreV1().Services(r.Namespace).Get(ctx, path.Backend.Service.Name, metav1.GetOptions{})
 			services = append(services, service)
+			serviceNames = append(serviceNames, path.Backend.Service.Name)
+		}
+	}
+
+	if len(services) == 0 {
+		return types.StateMissing, types.StateReason{
+			Reason:  "NoBackends",
+			Message: "ingress has no backend services",
 		}
 	}
 
@@ -162,30 +177,39 @@ func CalculateIngressState(clientset kubernetes.Interface, r *networkingv1.Ingre
 		}
 	}
 
-	var states []types.State
-	for _, service := range services {
+	var minState types.State
+	var minReason types.StateReason
+	for i, service := range services {
 		if service == nil {
-			states = append(states, types.StateUnavailable)
+			minState, minReason = minStateAndReason(minState, minReason, types.StateUnavailable, types.StateReason{
+				Reason:  "BackendServiceNotFound",
+				Message: fmt.Sprintf("backend service %s not found", serviceNames[i]),
+			})
 		} else {
-			states = append(states, serviceGetStateFromEndpoints(clientset, service))
+			state, reason := serviceGetStateFromEndpoints(clientset, service)
+			minState, minReason = minStateAndReason(minState, minReason, state, reason)
 		}
 	}
 
 	// An ingress will have an IP associated with it if it's type is LoadBalancer.
 	if hasLoadBalancer {
 		// https://github.com/kubernetes/kubernetes/blob/badcd4af3f592376ce891b7c1b7a43ed6a18a348/pkg/printers/internalversion/printers.go#L1067
-		states = append(states, ingressGetStateFromExternalIP(r))
+		state, reason := ingressGetStateFromExternalIP(r)
+		minState, minReason = minStateAndReason(minState, minReason, state, reason)
 	}
 
-	return types.MinState(states...)
+	return minState, minReason
 }
 
-func ingressGetStateFromExternalIP(ing *networkingv1.Ingress) types.State {
+func ingressGetStateFromExternalIP(ing *networkingv1.Ingress) (types.State, types.StateReason) {
 	lbIps := ingressLoadBalancerStatusIPs(ing.Status.LoadBalancer)
 	if len(lbIps) > 0 {
-		return types.StateReady
+		return types.StateReady, types.StateReason{}
+	}
+	return types.StateUnavailable, types.StateReason{
+		Reason:  "LoadBalancerPending",
+		Message: "ingress has no load balancer IP",
 	}
-	return types.StateUnavailable
 }
 
 func ingressLoadBalancerStatusIPs(s networkingv1.IngressLoadBalancerStatus) sets.String {
@@ -259,7 +259,7 @@ func TestCalculateIngressState(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := CalculateIngressState(tt.args.clientset, tt.args.r); !reflect.DeepEqual(got, tt.want) {
+			if got, _ := CalculateIngressState(tt.args.clientset, tt.args.r); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("CalculateIngressState() = %v, want %v", got, tt.want)
 			}
 		})
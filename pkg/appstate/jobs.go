@@ -0,0 +1,141 @@
+package appstate
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/replicatedhq/kots/pkg/appstate/types"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	JobResourceKind = "job"
+)
+
+type jobEventHandler struct {
+	informers       []types.StatusInformer
+	resourceStateCh chan<- types.ResourceState
+}
+
+func init() {
+	registerResourceKindNames(JobResourceKind, "jobs")
+}
+
+func runJobController(
+	ctx context.Context, clientset kubernetes.Interface, targetNamespace string,
+	informers []types.StatusInformer, resourceStateCh chan<- types.ResourceState,
+) {
+	listwatch := &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return clientset.BatchV1().Jobs(targetNamespace).List(context.TODO(), options)
+		},
+		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+			return clientset.BatchV1().Jobs(targetNamespace).Watch(context.TODO(), options)
+		},
+	}
+	informer := cache.NewSharedInformer(
+		listwatch,
+		&batchv1.Job{},
+		time.Minute,
+	)
+
+	eventHandler := &jobEventHandler{
+		informers:       informers,
+		resourceStateCh: resourceStateCh,
+	}
+
+	runInformer(ctx, informer, eventHandler)
+	return
+}
+
+func (h *jobEventHandler) ObjectCreated(obj interface{}) {
+	r := h.cast(obj)
+	if _, ok := h.getInformer(r); !ok {
+		return
+	}
+	state, reason := CalculateJobState(r)
+	h.resourceStateCh <- makeJobResourceState(r, state, reason)
+}
+
+func (h *jobEventHandler) ObjectUpdated(obj interface{}) {
+	r := h.cast(obj)
+	if _, ok := h.getInformer(r); !ok {
+		return
+	}
+	state, reason := CalculateJobState(r)
+	h.resourceStateCh <- makeJobResourceState(r, state, reason)
+}
+
+func (h *jobEventHandler) ObjectDeleted(obj interface{}) {
+	r := h.cast(obj)
+	if _, ok := h.getInformer(r); !ok {
+		return
+	}
+	h.resourceStateCh <- makeJobResourceState(r, types.StateMissing, deletedReason)
+}
+
+func (h *jobEventHandler) cast(obj interface{}) *batchv1.Job {
+	r, _ := obj.(*batchv1.Job)
+	return r
+}
+
+func (h *jobEventHandler) getInformer(r *batchv1.Job) (types.StatusInformer, bool) {
+	if r != nil {
+		for _, informer := range h.informers {
+			if r.Namespace == informer.Namespace && r.Name == informer.Name {
+				return informer, true
+			}
+		}
+	}
+	return types.StatusInformer{}, false
+}
+
+func makeJobResourceState(r *batchv1.Job, state types.State, reason types.StateReason) types.ResourceState {
+	return types.ResourceState{
+		Kind:      JobResourceKind,
+		Name:      r.Name,
+		Namespace: r.Namespace,
+		State:     state,
+		Reason:    reason.Reason,
+		Message:   reason.Message,
+	}
+}
+
+// CalculateJobState returns ready once the job has succeeded, unavailable if it failed,
+// and updating while it is running
+func CalculateJobState(r *batchv1.Job) (types.State, types.StateReason) {
+	if r == nil {
+		return types.StateMissing, types.StateReason{}
+	}
+
+	for _, condition := range r.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return types.StateReady, types.StateReason{}
+		case batchv1.JobFailed:
+			reason := types.StateReason{
+				Reason:  "JobFailed",
+				Message: "job failed",
+			}
+			if condition.Message != "" {
+				reason.Message = fmt.Sprintf("job failed: %s", condition.Message)
+			}
+			return types.StateUnavailable, reason
+		}
+	}
+
+	return types.StateUpdating, types.StateReason{
+		Reason:  "JobRunning",
+		Message: fmt.Sprintf("%d pods active, %d succeeded, %d failed", r.Status.Active, r.Status.Succeeded, r.Status.Failed),
+	}
+}
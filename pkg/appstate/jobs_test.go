@@ -0,0 +1,136 @@
+package appstate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/stretchr/testify/require"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func makeJob(name string, conditionType batchv1.JobConditionType, created time.Time, owner string) *batchv1.Job {
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         "app",
+			CreationTimestamp: metav1.NewTime(created),
+		},
+	}
+	if conditionType != "" {
+		job.Status.Conditions = []batchv1.JobCondition{
+			{Type: conditionType, Status: corev1.ConditionTrue},
+		}
+	}
+	if owner != "" {
+		job.OwnerReferences = []metav1.OwnerReference{
+			{Kind: CronJobOwnerKind, Name: owner},
+		}
+	}
+	return job
+}
+
+func TestCalculateJobState(t *testing.T) {
+	now := time.Now()
+
+	state, reason := CalculateJobState(makeJob("migrate", batchv1.JobComplete, now, ""))
+	require.Equal(t, types.StateReady, state)
+	require.Equal(t, types.StateReason{}, reason)
+	state, reason = CalculateJobState(makeJob("migrate", batchv1.JobFailed, now, ""))
+	require.Equal(t, types.StateUnavailable, state)
+	require.Equal(t, types.StateReason{Reason: "JobFailed", Message: "job failed"}, reason)
+	state, reason = CalculateJobState(makeJob("migrate", "", now, ""))
+	require.Equal(t, types.StateUpdating, state)
+	require.Equal(t, "JobRunning", reason.Reason)
+	state, _ = CalculateJobState(nil)
+	require.Equal(t, types.StateMissing, state)
+}
+
+func TestCalculateCronJobState(t *testing.T) {
+	now := time.Now()
+	cronJob := &batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "app"},
+	}
+
+	tests := []struct {
+		name       string
+		jobs       []*batchv1.Job
+		want       types.State
+		wantReason types.StateReason
+	}{
+		{
+			name: "no jobs yet",
+			want: types.StateReady,
+		},
+		{
+			name: "last job succeeded",
+			jobs: []*batchv1.Job{
+				makeJob("backup-1", batchv1.JobFailed, now.Add(-2*time.Hour), "backup"),
+				makeJob("backup-2", batchv1.JobComplete, now.Add(-time.Hour), "backup"),
+			},
+			want: types.StateReady,
+		},
+		{
+			name: "last job failed while the next one is running",
+			jobs: []*batchv1.Job{
+				makeJob("backup-1", batchv1.JobComplete, now.Add(-2*time.Hour), "backup"),
+				makeJob("backup-2", batchv1.JobFailed, now.Add(-time.Hour), "backup"),
+				makeJob("backup-3", "", now, "backup"),
+			},
+			want: types.StateDegraded,
+			wantReason: types.StateReason{
+				Reason:  "LastJobFailed",
+				Message: "the last job backup-2 failed",
+			},
+		},
+		{
+			name: "jobs of other cronjobs are ignored",
+			jobs: []*batchv1.Job{
+				makeJob("backup-1", batchv1.JobComplete, now.Add(-time.Hour), "backup"),
+				makeJob("report-1", batchv1.JobFailed, now, "report"),
+			},
+			want: types.StateReady,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jobs := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cronJobOwnerIndex: indexJobByCronJobOwner})
+			for _, job := range tt.jobs {
+				require.NoError(t, jobs.Add(job))
+			}
+			got, reason := CalculateCronJobState(jobs, cronJob)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
+
+func TestCalculateHorizontalPodAutoscalerState(t *testing.T) {
+	makeHPA := func(current int32, ableToScale corev1.ConditionStatus) *autoscalingv2.HorizontalPodAutoscaler {
+		return &autoscalingv2.HorizontalPodAutoscaler{
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+				MaxReplicas: 5,
+			},
+			Status: autoscalingv2.HorizontalPodAutoscalerStatus{
+				CurrentReplicas: current,
+				Conditions: []autoscalingv2.HorizontalPodAutoscalerCondition{
+					{Type: autoscalingv2.AbleToScale, Status: ableToScale},
+				},
+			},
+		}
+	}
+
+	state, reason := CalculateHorizontalPodAutoscalerState(makeHPA(3, corev1.ConditionTrue))
+	require.Equal(t, types.StateReady, state)
+	require.Equal(t, types.StateReason{}, reason)
+	state, reason = CalculateHorizontalPodAutoscalerState(makeHPA(5, corev1.ConditionTrue))
+	require.Equal(t, types.StateDegraded, state)
+	require.Equal(t, types.StateReason{Reason: "AtMaxReplicas", Message: "at maximum of 5 replicas"}, reason)
+	state, reason = CalculateHorizontalPodAutoscalerState(makeHPA(3, corev1.ConditionFalse))
+	require.Equal(t, types.StateUnavailable, state)
+	require.Equal(t, "UnableToScale", reason.Reason)
+}
@@ -2,6 +2,7 @@ package appstate
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/replicatedhq/kots/pkg/appstate/types"
@@ -65,7 +66,8 @@ func (h *persistentVolumeClaimEventHandler) ObjectCreated(obj interface{}) {
 	if _, ok := h.getInformer(r); !ok {
 		return
 	}
-	h.resourceStateCh <- makePersistentVolumeClaimResourceState(r, CalculatePersistentVolumeClaimState(r))
+	state, reason := CalculatePersistentVolumeClaimState(r)
+	h.resourceStateCh <- makePersistentVolumeClaimResourceState(r, state, reason)
 }
 
 func (h *persistentVolumeClaimEventHandler) ObjectUpdated(obj interface{}) {
@@ -73,7 +75,8 @@ func (h *persistentVolumeClaimEventHandler) ObjectUpdated(obj interface{}) {
 	if _, ok := h.getInformer(r); !ok {
 		return
 	}
-	h.resourceStateCh <- makePersistentVolumeClaimResourceState(r, CalculatePersistentVolumeClaimState(r))
+	state, reason := CalculatePersistentVolumeClaimState(r)
+	h.resourceStateCh <- makePersistentVolumeClaimResourceState(r, state, reason)
 }
 
 func (h *persistentVolumeClaimEventHandler) ObjectDeleted(obj interface{}) {
@@ -81,7 +84,7 @@ func (h *persistentVolumeClaimEventHandler) ObjectDeleted(obj interface{}) {
 	if _, ok := h.getInformer(r); !ok {
 		return
 	}
-	h.resourceStateCh <- makePersistentVolumeClaimResourceState(r, types.StateMissing)
+	h.resourceStateCh <- makePersistentVolumeClaimResourceState(r, types.StateMissing, deletedReason)
 }
 
 func (h *persistentVolumeClaimEventHandler) cast(obj interface{}) *corev1.PersistentVolumeClaim {
@@ -100,24 +103,44 @@ func (h *persistentVolumeClaimEventHandler) getInformer(r *corev1.PersistentVolu
 	return types.StatusInformer{}, false
 }
 
-func makePersistentVolumeClaimResourceState(r *corev1.PersistentVolumeClaim, state types.State) types.ResourceState {
+func makePersistentVolumeClaimResourceState(r *corev1.PersistentVolumeClaim, state types.State, reason types.StateReason) types.ResourceState {
 	return types.ResourceState{
 		Kind:      PersistentVolumeClaimResourceKind,
 		Name:      r.Name,
 		Namespace: r.Namespace,
 		State:     state,
+		Reason:    reason.Reason,
+		Message:   reason.Message,
 	}
 }
 
-func CalculatePersistentVolumeClaimState(r *corev1.PersistentVolumeClaim) types.State {
+func CalculatePersistentVolumeClaimState(r *corev1.PersistentVolumeClaim) (types.State, types.StateReason) {
 	// https://github.com/kubernetes/kubernetes/blob/badcd4af3f592376ce891b7c1b7a43ed6a18a348/pkg/printers/internalversion/printers.go#L1403
 	switch r.Status.Phase {
-	case corev1.ClaimPending, corev1.ClaimLost:
-		return types.StateUnavailable
+	case corev1.ClaimPending:
+		// the default storage class is set when the claim is created, so a claim without one will never be provisioned
+		if r.Spec.StorageClassName == nil || *r.Spec.StorageClassName == "" {
+			return types.StateUnavailable, types.StateReason{
+				Reason:  "ClaimPending",
+				Message: "PVC pending: no storage class",
+			}
+		}
+		return types.StateUnavailable, types.StateReason{
+			Reason:  "ClaimPending",
+			Message: fmt.Sprintf("PVC pending: waiting for a volume from storage class %s", *r.Spec.StorageClassName),
+		}
+	case corev1.ClaimLost:
+		return types.StateUnavailable, types.StateReason{
+			Reason:  "ClaimLost",
+			Message: fmt.Sprintf("PVC lost: volume %s no longer exists", r.Spec.VolumeName),
+		}
 	case corev1.ClaimBound:
-		return types.StateReady
+		return types.StateReady, types.StateReason{}
 	default:
 		// I'm not sure what state to return here
-		return types.StateUnavailable
+		return types.StateUnavailable, types.StateReason{
+			Reason:  "UnknownPhase",
+			Message: fmt.Sprintf("PVC is in unknown phase %q", r.Status.Phase),
+		}
 	}
 }
@@ -0,0 +1,55 @@
+package appstate
+
+import (
+	"testing"
+
+	"github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestCalculatePersistentVolumeClaimState(t *testing.T) {
+	tests := []struct {
+		name         string
+		storageClass *string
+		phase        corev1.PersistentVolumeClaimPhase
+		want         types.State
+		wantMessage  string
+	}{
+		{
+			name:         "bound",
+			storageClass: pointer.String("standard"),
+			phase:        corev1.ClaimBound,
+			want:         types.StateReady,
+		},
+		{
+			name:        "pending without a storage class",
+			phase:       corev1.ClaimPending,
+			want:        types.StateUnavailable,
+			wantMessage: "PVC pending: no storage class",
+		},
+		{
+			name:         "pending with a storage class",
+			storageClass: pointer.String("standard"),
+			phase:        corev1.ClaimPending,
+			want:         types.StateUnavailable,
+			wantMessage:  "PVC pending: waiting for a volume from storage class standard",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &corev1.PersistentVolumeClaim{
+				Spec: corev1.PersistentVolumeClaimSpec{
+					StorageClassName: tt.storageClass,
+				},
+				Status: corev1.PersistentVolumeClaimStatus{
+					Phase: tt.phase,
+				},
+			}
+			got, reason := CalculatePersistentVolumeClaimState(r)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantMessage, reason.Message)
+		})
+	}
+}
@@ -20,3 +20,8 @@ func getResourceKindCommonName(a string) string {
 	}
 	return a
 }
+
+// IsHelmReleaseKind returns true if the kind of a status informer refers to a helm release
+func IsHelmReleaseKind(kind string) bool {
+	return getResourceKindCommonName(kind) == HelmReleaseResourceKind
+}
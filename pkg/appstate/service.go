@@ -2,6 +2,7 @@ package appstate
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/replicatedhq/kots/pkg/appstate/types"
@@ -71,7 +72,8 @@ func (h *serviceEventHandler) ObjectCreated(obj interface{}) {
 	if _, ok := h.getInformer(r); !ok {
 		return
 	}
-	h.resourceStateCh <- makeServiceResourceState(r, CalculateServiceState(h.clientset, r))
+	state, reason := CalculateServiceState(h.clientset, r)
+	h.resourceStateCh <- makeServiceResourceState(r, state, reason)
 }
 
 func (h *serviceEventHandler) ObjectUpdated(obj interface{}) {
@@ -79,7 +81,8 @@ func (h *serviceEventHandler) ObjectUpdated(obj interface{}) {
 	if _, ok := h.getInformer(r); !ok {
 		return
 	}
-	h.resourceStateCh <- makeServiceResourceState(r, CalculateServiceState(h.clientset, r))
+	state, reason := CalculateServiceState(h.clientset, r)
+	h.resourceStateCh <- makeServiceResourceState(r, state, reason)
 }
 
 func (h *serviceEventHandler) ObjectDeleted(obj interface{}) {
@@ -87,7 +90,7 @@ func (h *serviceEventHandler) ObjectDeleted(obj interface{}) {
 	if _, ok := h.getInformer(r); !ok {
 		return
 	}
-	h.resourceStateCh <- makeServiceResourceState(r, types.StateMissing)
+	h.resourceStateCh <- makeServiceResourceState(r, types.StateMissing, deletedReason)
 }
 
 func (h *serviceEventHandler) cast(obj interface{}) *corev1.Service {
@@ -106,61 +109,76 @@ func (h *serviceEventHandler) getInformer(r *corev1.Service) (types.StatusInform
 	return types.StatusInformer{}, false
 }
 
-func makeServiceResourceState(r *corev1.Service, state types.State) types.ResourceState {
+func makeServiceResourceState(r *corev1.Service, state types.State, reason types.StateReason) types.ResourceState {
 	return types.ResourceState{
 		Kind:      ServiceResourceKind,
 		Name:      r.Name,
 		Namespace: r.Namespace,
 		State:     state,
+		Reason:    reason.Reason,
+		Message:   reason.Message,
 	}
 }
 
-func CalculateServiceState(clientset kubernetes.Interface, r *corev1.Service) types.State {
-	var states []types.State
+func CalculateServiceState(clientset kubernetes.Interface, r *corev1.Service) (types.State, types.StateReason) {
 	// https://github.com/kubernetes/kubectl/blob/6b77b0790ab40d2a692ad80e9e4c962e784bb9b8/pkg/describe/versioned/describe.go#L4617
-	states = append(states, serviceGetStateFromEndpoints(clientset, r))
+	state, reason := serviceGetStateFromEndpoints(clientset, r)
 	// https://github.com/kubernetes/kubernetes/blob/badcd4af3f592376ce891b7c1b7a43ed6a18a348/pkg/printers/internalversion/printers.go#L1003
-	states = append(states, serviceGetStateFromExternalIP(r))
-	return types.MinState(states...)
+	externalIPState, externalIPReason := serviceGetStateFromExternalIP(r)
+	return minStateAndReason(state, reason, externalIPState, externalIPReason)
 }
 
-func serviceGetStateFromEndpoints(clientset kubernetes.Interface, svc *corev1.Service) (minState types.State) {
+func serviceGetStateFromEndpoints(clientset kubernetes.Interface, svc *corev1.Service) (minState types.State, minReason types.StateReason) {
 	endpoints, _ := clientset.CoreV1().Endpoints(svc.Namespace).Get(context.TODO(), svc.Name, metav1.GetOptions{})
 	if endpoints == nil {
 		// I'm unsure of the state for this case
-		return types.StateUnavailable
+		return types.StateUnavailable, types.StateReason{
+			Reason:  "EndpointsNotFound",
+			Message: fmt.Sprintf("service %s has no endpoints", svc.Name),
+		}
 	}
 	for i := range svc.Spec.Ports {
 		sp := &svc.Spec.Ports[i]
-		minState = types.MinState(minState, servicePortGetStateFromEndpoints(endpoints, sets.NewString(sp.Name)))
+		state, reason := servicePortGetStateFromEndpoints(endpoints, sp)
+		minState, minReason = minStateAndReason(minState, minReason, state, reason)
 	}
 	return
 }
 
-func servicePortGetStateFromEndpoints(endpoints *corev1.Endpoints, ports sets.String) (minState types.State) {
+func servicePortGetStateFromEndpoints(endpoints *corev1.Endpoints, sp *corev1.ServicePort) (minState types.State, minReason types.StateReason) {
 	if len(endpoints.Subsets) == 0 {
 		// I'm unsure of the state for this case
-		return types.StateUnavailable
+		return types.StateUnavailable, types.StateReason{
+			Reason:  "EndpointsNotReady",
+			Message: fmt.Sprintf("endpoint port %d has no addresses", sp.Port),
+		}
+	}
+	notReadyReason := types.StateReason{
+		Reason:  "EndpointsNotReady",
+		Message: fmt.Sprintf("endpoint port %d has addresses that are not ready", sp.Port),
 	}
 	for i := range endpoints.Subsets {
 		ss := &endpoints.Subsets[i]
 		if len(ss.Ports) == 0 {
 			// It's possible to have headless services with no ports.
 			if len(ss.NotReadyAddresses) > 0 {
-				minState = types.MinState(minState, types.StateDegraded)
+				minState, minReason = minStateAndReason(minState, minReason, types.StateDegraded, notReadyReason)
 			}
 			// What else can we infer here?
 		} else {
 			// "Normal" services with ports defined.
 			for i := range ss.Ports {
 				port := &ss.Ports[i]
-				if ports.Has(port.Name) {
+				if port.Name == sp.Name {
 					if len(ss.Addresses) == 0 {
-						minState = types.MinState(minState, types.StateUnavailable)
+						minState, minReason = minStateAndReason(minState, minReason, types.StateUnavailable, types.StateReason{
+							Reason:  "EndpointsNotReady",
+							Message: fmt.Sprintf("endpoint port %d has no ready addresses", sp.Port),
+						})
 					} else if len(ss.NotReadyAddresses) > 0 {
-						minState = types.MinState(minState, types.StateDegraded)
+						minState, minReason = minStateAndReason(minState, minReason, types.StateDegraded, notReadyReason)
 					} else {
-						minState = types.MinState(minState, types.StateReady)
+						minState, minReason = minStateAndReason(minState, minReason, types.StateReady, types.StateReason{})
 					}
 				}
 			}
@@ -169,18 +187,21 @@ func servicePortGetStateFromEndpoints(endpoints *corev1.Endpoints, ports sets.St
 	return
 }
 
-func serviceGetStateFromExternalIP(svc *corev1.Service) types.State {
+func serviceGetStateFromExternalIP(svc *corev1.Service) (types.State, types.StateReason) {
 	if svc.Spec.Type != corev1.ServiceTypeLoadBalancer {
-		return types.StateReady
+		return types.StateReady, types.StateReason{}
 	}
 	if len(svc.Spec.ExternalIPs) > 0 {
-		return types.StateReady
+		return types.StateReady, types.StateReason{}
 	}
 	lbIps := loadBalancerStatusIPs(svc.Status.LoadBalancer)
 	if len(lbIps) > 0 {
-		return types.StateReady
+		return types.StateReady, types.StateReason{}
+	}
+	return types.StateUnavailable, types.StateReason{
+		Reason:  "LoadBalancerPending",
+		Message: "service has no load balancer IP",
 	}
-	return types.StateUnavailable
 }
 
 func loadBalancerStatusIPs(s corev1.LoadBalancerStatus) sets.String {
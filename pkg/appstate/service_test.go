@@ -0,0 +1,70 @@
+package appstate
+
+import (
+	"testing"
+
+	"github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCalculateServiceState(t *testing.T) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: 80},
+				{Name: "https", Port: 443},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		subsets    []corev1.EndpointSubset
+		want       types.State
+		wantReason types.StateReason
+	}{
+		{
+			name: "all ports ready",
+			subsets: []corev1.EndpointSubset{
+				{
+					Ports:     []corev1.EndpointPort{{Name: "http", Port: 8080}, {Name: "https", Port: 8443}},
+					Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}},
+				},
+			},
+			want: types.StateReady,
+		},
+		{
+			name: "one port has no ready addresses",
+			subsets: []corev1.EndpointSubset{
+				{
+					Ports:     []corev1.EndpointPort{{Name: "http", Port: 8080}},
+					Addresses: []corev1.EndpointAddress{{IP: "10.0.0.1"}},
+				},
+				{
+					Ports:             []corev1.EndpointPort{{Name: "https", Port: 8443}},
+					NotReadyAddresses: []corev1.EndpointAddress{{IP: "10.0.0.2"}},
+				},
+			},
+			want: types.StateUnavailable,
+			wantReason: types.StateReason{
+				Reason:  "EndpointsNotReady",
+				Message: "endpoint port 443 has no ready addresses",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientset := fake.NewSimpleClientset(&corev1.Endpoints{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "app"},
+				Subsets:    tt.subsets,
+			})
+			got, reason := CalculateServiceState(clientset, service)
+			require.Equal(t, tt.want, got)
+			require.Equal(t, tt.wantReason, reason)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package appstate
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
 
@@ -66,7 +67,8 @@ func (h *statefulSetEventHandler) ObjectCreated(obj interface{}) {
 	if _, ok := h.getInformer(r); !ok {
 		return
 	}
-	h.resourceStateCh <- makeStatefulSetResourceState(r, CalculateStatefulSetState(h.clientset, h.targetNamespace, r))
+	state, reason := CalculateStatefulSetState(h.clientset, h.targetNamespace, r)
+	h.resourceStateCh <- makeStatefulSetResourceState(r, state, reason)
 }
 
 func (h *statefulSetEventHandler) ObjectUpdated(obj interface{}) {
@@ -74,7 +76,8 @@ func (h *statefulSetEventHandler) ObjectUpdated(obj interface{}) {
 	if _, ok := h.getInformer(r); !ok {
 		return
 	}
-	h.resourceStateCh <- makeStatefulSetResourceState(r, CalculateStatefulSetState(h.clientset, h.targetNamespace, r))
+	state, reason := CalculateStatefulSetState(h.clientset, h.targetNamespace, r)
+	h.resourceStateCh <- makeStatefulSetResourceState(r, state, reason)
 }
 
 func (h *statefulSetEventHandler) ObjectDeleted(obj interface{}) {
@@ -82,7 +85,7 @@ func (h *statefulSetEventHandler) ObjectDeleted(obj interface{}) {
 	if _, ok := h.getInformer(r); !ok {
 		return
 	}
-	h.resourceStateCh <- makeStatefulSetResourceState(r, types.StateMissing)
+	h.resourceStateCh <- makeStatefulSetResourceState(r, types.StateMissing, deletedReason)
 }
 
 func (h *statefulSetEventHandler) cast(obj interface{}) *appsv1.StatefulSet {
@@ -101,22 +104,27 @@ func (h *statefulSetEventHandler) getInformer(r *appsv1.StatefulSet) (types.Stat
 	return types.StatusInformer{}, false
 }
 
-func makeStatefulSetResourceState(r *appsv1.StatefulSet, state types.State) types.ResourceState {
+func makeStatefulSetResourceState(r *appsv1.StatefulSet, state types.State, reason types.StateReason) types.ResourceState {
 	return types.ResourceState{
 		Kind:      StatefulSetResourceKind,
 		Name:      r.Name,
 		Namespace: r.Namespace,
 		State:     state,
+		Reason:    reason.Reason,
+		Message:   reason.Message,
 	}
 }
 
-func CalculateStatefulSetState(clientset kubernetes.Interface, targetNamespace string, r *appsv1.StatefulSet) types.State {
+func CalculateStatefulSetState(clientset kubernetes.Interface, targetNamespace string, r *appsv1.StatefulSet) (types.State, types.StateReason) {
 	if r == nil {
-		return types.StateMissing
+		return types.StateMissing, types.StateReason{}
 	}
 
 	if r.Status.ObservedGeneration != r.ObjectMeta.Generation {
-		return types.StateUpdating
+		return types.StateUpdating, types.StateReason{
+			Reason:  "RolloutPending",
+			Message: "the latest spec has not been observed by the statefulset controller",
+		}
 	}
 
 	listOptions := metav1.ListOptions{LabelSelector: labels.SelectorFromSet(r.Spec.Selector.MatchLabels).String()}
@@ -124,7 +132,10 @@ func CalculateStatefulSetState(clientset kubernetes.Interface, targetNamespace s
 	pods, err := clientset.CoreV1().Pods(targetNamespace).List(context.TODO(), listOptions)
 	if err != nil {
 		log.Printf("failed to get statefulset pod list: %s", err)
-		return types.StateUnavailable
+		return types.StateUnavailable, types.StateReason{
+			Reason:  "PodListFailed",
+			Message: fmt.Sprintf("failed to list pods: %s", err),
+		}
 	}
 
 	// If the pod version labels are not all the same, then the statefulset is updating.
@@ -146,13 +157,19 @@ func CalculateStatefulSetState(clientset kubernetes.Interface, targetNamespace s
 		version, exists := pod.Labels[StatefulSetPodVersionLabel]
 		if !exists {
 			log.Printf("failed to find %s label for pod %s", StatefulSetPodVersionLabel, pod.Name)
-			return types.StateUnavailable
+			return types.StateUnavailable, types.StateReason{
+				Reason:  "PodRevisionUnknown",
+				Message: fmt.Sprintf("pod %s has no %s label", pod.Name, StatefulSetPodVersionLabel),
+			}
 		}
 
 		if len(currentVersion) == 0 {
 			currentVersion = version
 		} else if version != currentVersion {
-			return types.StateUpdating
+			return types.StateUpdating, types.StateReason{
+				Reason:  "RolloutInProgress",
+				Message: "pods are running more than one revision",
+			}
 		}
 	}
 
@@ -164,12 +181,12 @@ func CalculateStatefulSetState(clientset kubernetes.Interface, targetNamespace s
 	}
 
 	if r.Status.ReadyReplicas >= desiredReplicas {
-		return types.StateReady
+		return types.StateReady, types.StateReason{}
 	}
 
 	if r.Status.ReadyReplicas > 0 {
-		return types.StateDegraded
+		return types.StateDegraded, replicasReason("ReplicasUnavailable", r.Status.ReadyReplicas, desiredReplicas)
 	}
 
-	return types.StateUnavailable
+	return types.StateUnavailable, replicasReason("ReplicasUnavailable", r.Status.ReadyReplicas, desiredReplicas)
 }
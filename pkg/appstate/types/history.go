@@ -0,0 +1,192 @@
+package types
+
+import (
+	"sort"
+	"time"
+)
+
+// StateTransition is a change in the state of an app or one of its resources. Transitions of the app's
+// aggregate state have an empty Kind, Name and Namespace.
+type StateTransition struct {
+	Kind      string    `json:"kind,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	Namespace string    `json:"namespace,omitempty"`
+	State     State     `json:"state"`
+	Reason    string    `json:"reason,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	Sequence  int64     `json:"sequence"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AppStatusHistory is the timeline of state transitions of an app and its resources during a time range
+type AppStatusHistory struct {
+	AppID string    `json:"appId"`
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	// Transitions includes the last transition before Start of the app and of each resource, which is
+	// the state they were in at Start
+	Transitions  []StateTransition      `json:"transitions"`
+	Availability Availability           `json:"availability"`
+	Resources    []ResourceAvailability `json:"resources"`
+}
+
+// Availability is how long something spent in each state during a time range. Time before its first
+// recorded transition is not counted.
+type Availability struct {
+	StateSeconds map[State]int64 `json:"stateSeconds"`
+	// Percentage is the percentage of the counted time that was spent ready
+	Percentage float64 `json:"percentage"`
+}
+
+type ResourceAvailability struct {
+	Kind         string       `json:"kind"`
+	Name         string       `json:"name"`
+	Namespace    string       `json:"namespace"`
+	Availability Availability `json:"availability"`
+}
+
+// GetStateTransitions returns the transitions from the previous resource states to the next ones, along with
+// a transition of the app's aggregate state if it changed. A new reason or message for the same state is not
+// a transition. Resources that are no longer monitored transition to the missing state, so that their history
+// does not stay in their last state.
+func GetStateTransitions(prev ResourceStates, next ResourceStates, sequence int64, createdAt time.Time) []StateTransition {
+	transitions := []StateTransition{}
+
+	prevAppState := GetState(prev)
+	nextAppState := GetState(next)
+	if prevAppState != nextAppState {
+		transitions = append(transitions, StateTransition{
+			State:     nextAppState,
+			Sequence:  sequence,
+			CreatedAt: createdAt,
+		})
+	}
+
+	for _, n := range next {
+		found := false
+		for _, p := range prev {
+			if p.Kind == n.Kind && p.Namespace == n.Namespace && p.Name == n.Name {
+				found = p.State == n.State
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		transitions = append(transitions, StateTransition{
+			Kind:      n.Kind,
+			Name:      n.Name,
+			Namespace: n.Namespace,
+			State:     n.State,
+			Reason:    n.Reason,
+			Message:   n.Message,
+			Sequence:  sequence,
+			CreatedAt: createdAt,
+		})
+	}
+
+	for _, p := range prev {
+		if p.State == StateMissing {
+			continue
+		}
+		found := false
+		for _, n := range next {
+			if p.Kind == n.Kind && p.Namespace == n.Namespace && p.Name == n.Name {
+				found = true
+				break
+			}
+		}
+		if found {
+			continue
+		}
+		transitions = append(transitions, StateTransition{
+			Kind:      p.Kind,
+			Name:      p.Name,
+			Namespace: p.Namespace,
+			State:     StateMissing,
+			Reason:    "NotMonitored",
+			Message:   "the resource is no longer monitored",
+			Sequence:  sequence,
+			CreatedAt: createdAt,
+		})
+	}
+
+	return transitions
+}
+
+// CalculateAppStatusHistory returns the history of an app during a time range. The transitions must be in
+// chronological order, and should include the last transition before start of the app and of each resource.
+func CalculateAppStatusHistory(appID string, transitions []StateTransition, start time.Time, end time.Time) AppStatusHistory {
+	history := AppStatusHistory{
+		AppID:       appID,
+		Start:       start,
+		End:         end,
+		Transitions: transitions,
+		Resources:   []ResourceAvailability{},
+	}
+
+	type resourceKey struct {
+		kind      string
+		namespace string
+		name      string
+	}
+	byResource := map[resourceKey][]StateTransition{}
+	for _, t := range transitions {
+		key := resourceKey{kind: t.Kind, namespace: t.Namespace, name: t.Name}
+		byResource[key] = append(byResource[key], t)
+	}
+
+	history.Availability = calculateAvailability(byResource[resourceKey{}], start, end)
+	delete(byResource, resourceKey{})
+
+	for key, resourceTransitions := range byResource {
+		history.Resources = append(history.Resources, ResourceAvailability{
+			Kind:         key.kind,
+			Name:         key.name,
+			Namespace:    key.namespace,
+			Availability: calculateAvailability(resourceTransitions, start, end),
+		})
+	}
+	sort.Slice(history.Resources, func(i, j int) bool {
+		a, b := history.Resources[i], history.Resources[j]
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return history
+}
+
+func calculateAvailability(transitions []StateTransition, start time.Time, end time.Time) Availability {
+	availability := Availability{
+		StateSeconds: map[State]int64{},
+	}
+
+	var total int64
+	for i, t := range transitions {
+		from := t.CreatedAt
+		if from.Before(start) {
+			from = start
+		}
+		to := end
+		if i+1 < len(transitions) && transitions[i+1].CreatedAt.Before(end) {
+			to = transitions[i+1].CreatedAt
+		}
+		if !to.After(from) {
+			continue
+		}
+		seconds := int64(to.Sub(from).Seconds())
+		availability.StateSeconds[t.State] += seconds
+		total += seconds
+	}
+
+	if total > 0 {
+		availability.Percentage = float64(availability.StateSeconds[StateReady]) * 100 / float64(total)
+	}
+
+	return availability
+}
@@ -0,0 +1,101 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStateTransitions(t *testing.T) {
+	now := time.Now()
+
+	prev := ResourceStates{
+		{Kind: "deployment", Name: "web", Namespace: "app", State: StateReady},
+		{Kind: "service", Name: "web", Namespace: "app", State: StateReady},
+	}
+
+	// only a new message is not a transition
+	next := ResourceStates{
+		{Kind: "deployment", Name: "web", Namespace: "app", State: StateReady, Message: "ignored"},
+		{Kind: "service", Name: "web", Namespace: "app", State: StateReady},
+	}
+	require.Empty(t, GetStateTransitions(prev, next, 1, now))
+
+	next = ResourceStates{
+		{Kind: "deployment", Name: "web", Namespace: "app", State: StateDegraded, Reason: "ReplicasUnavailable", Message: "2/3 replicas available"},
+		{Kind: "service", Name: "web", Namespace: "app", State: StateReady},
+	}
+	require.Equal(t, []StateTransition{
+		{State: StateDegraded, Sequence: 1, CreatedAt: now},
+		{Kind: "deployment", Name: "web", Namespace: "app", State: StateDegraded, Reason: "ReplicasUnavailable", Message: "2/3 replicas available", Sequence: 1, CreatedAt: now},
+	}, GetStateTransitions(prev, next, 1, now))
+
+	// the first status of an app is a transition of the app and of every resource
+	require.Equal(t, []StateTransition{
+		{State: StateReady, Sequence: 0, CreatedAt: now},
+		{Kind: "deployment", Name: "web", Namespace: "app", State: StateReady, Sequence: 0, CreatedAt: now},
+		{Kind: "service", Name: "web", Namespace: "app", State: StateReady, Sequence: 0, CreatedAt: now},
+	}, GetStateTransitions(nil, prev, 0, now))
+
+	// a resource that is no longer monitored becomes missing
+	next = ResourceStates{
+		{Kind: "deployment", Name: "web", Namespace: "app", State: StateReady},
+	}
+	require.Equal(t, []StateTransition{
+		{Kind: "service", Name: "web", Namespace: "app", State: StateMissing, Reason: "NotMonitored", Message: "the resource is no longer monitored", Sequence: 2, CreatedAt: now},
+	}, GetStateTransitions(prev, next, 2, now))
+}
+
+func TestCalculateAppStatusHistory(t *testing.T) {
+	start := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	end := start.Add(10 * time.Hour)
+
+	transitions := []StateTransition{
+		// before the range, so the app and deployment are ready at the start
+		{State: StateReady, CreatedAt: start.Add(-24 * time.Hour)},
+		{Kind: "deployment", Name: "web", Namespace: "app", State: StateReady, CreatedAt: start.Add(-24 * time.Hour)},
+		{State: StateDegraded, CreatedAt: start.Add(6 * time.Hour)},
+		{Kind: "deployment", Name: "web", Namespace: "app", State: StateDegraded, CreatedAt: start.Add(6 * time.Hour)},
+		{State: StateReady, CreatedAt: start.Add(8 * time.Hour)},
+		{Kind: "deployment", Name: "web", Namespace: "app", State: StateReady, CreatedAt: start.Add(8 * time.Hour)},
+		// first seen during the range
+		{Kind: "service", Name: "web", Namespace: "app", State: StateReady, CreatedAt: start.Add(5 * time.Hour)},
+	}
+
+	history := CalculateAppStatusHistory("app-id", transitions, start, end)
+
+	require.Equal(t, Availability{
+		StateSeconds: map[State]int64{
+			StateReady:    8 * 3600,
+			StateDegraded: 2 * 3600,
+		},
+		Percentage: 80,
+	}, history.Availability)
+
+	require.Equal(t, []ResourceAvailability{
+		{
+			Kind:      "deployment",
+			Name:      "web",
+			Namespace: "app",
+			Availability: Availability{
+				StateSeconds: map[State]int64{
+					StateReady:    8 * 3600,
+					StateDegraded: 2 * 3600,
+				},
+				Percentage: 80,
+			},
+		},
+		{
+			Kind:      "service",
+			Name:      "web",
+			Namespace: "app",
+			Availability: Availability{
+				StateSeconds: map[State]int64{
+					StateReady: 5 * 3600,
+				},
+				Percentage: 100,
+			},
+		},
+	}, history.Resources)
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"errors"
 	"regexp"
+	"strings"
 	"time"
 )
 
@@ -22,10 +23,37 @@ type StatusInformer struct {
 	Kind      string
 	Name      string
 	Namespace string
+	// JSONPath and Value are the readiness condition of a custom resource. The resource is ready
+	// when the value at the JSONPath matches Value.
+	JSONPath string
+	Value    string
 }
 
+// Parse parses a status informer string of the form [namespace/]kind/name[?jsonpath=value].
+// Custom resources use the fully qualified resource as the kind, such as certificates.v1.cert-manager.io,
+// and can declare a readiness condition, such as ?.status.conditions[?(@.type=="Ready")].status=True.
 func (s StatusInformerString) Parse() (i StatusInformer, err error) {
-	matches := StatusInformerRegexp.FindStringSubmatch(string(s))
+	str := string(s)
+	if idx := strings.Index(str, "?"); idx != -1 {
+		condition := str[idx+1:]
+		str = str[:idx]
+
+		// the jsonpath can contain "=" in filter expressions and the value can contain "=" too, so split
+		// on the first "=" after the last closing bracket of the jsonpath
+		start := strings.LastIndex(condition, "]") + 1
+		eq := strings.Index(condition[start:], "=")
+		if eq != -1 {
+			eq += start
+		}
+		if eq <= 0 {
+			err = errors.New("status informer readiness condition must be of the form jsonpath=value")
+			return
+		}
+		i.JSONPath = condition[:eq]
+		i.Value = condition[eq+1:]
+	}
+
+	matches := StatusInformerRegexp.FindStringSubmatch(str)
 	if len(matches) != 4 {
 		err = errors.New("status informer format string incorrect")
 		return
@@ -42,6 +70,37 @@ type AppStatus struct {
 	UpdatedAt      time.Time      `json:"updatedAt" hash:"ignore"`
 	State          State          `json:"state"`
 	Sequence       int64          `json:"sequence"`
+	Drift          *DriftStatus   `json:"drift,omitempty" hash:"ignore"`
+}
+
+// DriftStatus is the result of the last comparison of the live resources of an app against
+// the manifests of the deployed version
+type DriftStatus struct {
+	Sequence  int64             `json:"sequence"`
+	CheckedAt time.Time         `json:"checkedAt"`
+	Resources []DriftedResource `json:"resources"`
+	// HealedAt is when the deployed version was re-applied to correct the drift
+	HealedAt *time.Time `json:"healedAt,omitempty"`
+	Error    string     `json:"error,omitempty"`
+}
+
+type DriftedResource struct {
+	APIVersion  string `json:"apiVersion"`
+	Kind        string `json:"kind"`
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace,omitempty"`
+	HelmRelease string `json:"helmRelease,omitempty"`
+	// Missing is true if the resource was deleted from the cluster
+	Missing bool           `json:"missing,omitempty"`
+	Fields  []DriftedField `json:"fields,omitempty"`
+}
+
+// DriftedField is a field whose live value differs from the deployed manifest.
+// Values are json encoded, and are empty if the field is not set.
+type DriftedField struct {
+	Path     string `json:"path"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
 }
 
 type ResourceStates []ResourceState
@@ -51,6 +110,16 @@ type ResourceState struct {
 	Name      string `json:"name"`
 	Namespace string `json:"namespace"`
 	State     State  `json:"state"`
+	// Reason and Message explain why the resource is not ready
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// StateReason explains the state of a resource. Reason is a short CamelCase identifier such as
+// ReplicasUnavailable, and Message is a human readable description such as "2/3 replicas available".
+type StateReason struct {
+	Reason  string
+	Message string
 }
 
 type State string
@@ -29,6 +29,41 @@ func TestStatusInformerString_Parse(t *testing.T) {
 				Name:      "sentry-web",
 			},
 		},
+		{
+			name: "custom resource with readiness condition",
+			str:  `cert-manager/certificates.v1.cert-manager.io/my-cert?.status.conditions[?(@.type=="Ready")].status=True`,
+			want: StatusInformer{
+				Namespace: "cert-manager",
+				Kind:      "certificates.v1.cert-manager.io",
+				Name:      "my-cert",
+				JSONPath:  `.status.conditions[?(@.type=="Ready")].status`,
+				Value:     "True",
+			},
+		},
+		{
+			name: "readiness condition value containing =",
+			str:  `my-app/certificates.v1.cert-manager.io/my-cert?.metadata.annotations.checksum=sha256=abc`,
+			want: StatusInformer{
+				Namespace: "my-app",
+				Kind:      "certificates.v1.cert-manager.io",
+				Name:      "my-cert",
+				JSONPath:  ".metadata.annotations.checksum",
+				Value:     "sha256=abc",
+			},
+		},
+		{
+			name: "custom resource without readiness condition",
+			str:  "postgresqls.v1.acid.zalan.do/my-db",
+			want: StatusInformer{
+				Kind: "postgresqls.v1.acid.zalan.do",
+				Name: "my-db",
+			},
+		},
+		{
+			name:    "readiness condition without value",
+			str:     "postgresqls.v1.acid.zalan.do/my-db?.status.PostgresClusterStatus",
+			wantErr: true,
+		},
 		{
 			name:    "no match",
 			str:     "sentry-web",
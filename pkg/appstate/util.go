@@ -1,6 +1,7 @@
 package appstate
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/replicatedhq/kots/pkg/appstate/types"
@@ -45,7 +46,7 @@ func resourceStatesApplyNew(resourceStates types.ResourceStates, informers []typ
 		if resourceState.Kind == r.Kind &&
 			resourceState.Namespace == r.Namespace &&
 			resourceState.Name == r.Name &&
-			resourceState.State != r.State {
+			resourceState != r {
 			didChange = true
 			next = append(next, resourceState)
 		} else {
@@ -55,3 +56,25 @@ func resourceStatesApplyNew(resourceStates types.ResourceStates, informers []typ
 	sort.Sort(next)
 	return
 }
+
+// minStateAndReason returns the lower of the two states along with the reason for it. The current reason
+// is kept if the states are equal.
+func minStateAndReason(curr types.State, currReason types.StateReason, next types.State, nextReason types.StateReason) (types.State, types.StateReason) {
+	if min := types.MinState(curr, next); min != curr {
+		return min, nextReason
+	}
+	return curr, currReason
+}
+
+func replicasReason(reason string, ready int32, desired int32) types.StateReason {
+	return types.StateReason{
+		Reason:  reason,
+		Message: fmt.Sprintf("%d/%d replicas available", ready, desired),
+	}
+}
+
+// deletedReason is the reason of the missing state of resources whose informer saw them deleted
+var deletedReason = types.StateReason{
+	Reason:  "NotFound",
+	Message: "the resource was deleted",
+}
@@ -0,0 +1,31 @@
+package appstate
+
+import (
+	"testing"
+
+	"github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_resourceStatesApplyNew(t *testing.T) {
+	degraded := types.ResourceState{
+		Kind:      "deployment",
+		Name:      "web",
+		Namespace: "app",
+		State:     types.StateDegraded,
+		Reason:    "ReplicasUnavailable",
+		Message:   "2/3 replicas available",
+	}
+	resourceStates := types.ResourceStates{degraded}
+
+	next, didChange := resourceStatesApplyNew(resourceStates, nil, degraded)
+	require.False(t, didChange)
+	require.Equal(t, resourceStates, next)
+
+	// a new message is a change even if the state is the same
+	moreDegraded := degraded
+	moreDegraded.Message = "1/3 replicas available"
+	next, didChange = resourceStatesApplyNew(resourceStates, nil, moreDegraded)
+	require.True(t, didChange)
+	require.Equal(t, types.ResourceStates{moreDegraded}, next)
+}
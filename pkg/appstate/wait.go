@@ -21,7 +21,7 @@ import (
 )
 
 var (
-	WaitForResourceFns = map[string]func(clientset kubernetes.Interface, namespace, name string) error{
+	WaitForResourceFns = map[string]func(ctx context.Context, clientset kubernetes.Interface, namespace, name string) error{
 		DaemonSetResourceKind:             WaitForDaemonSetToBeReady,
 		DeploymentResourceKind:            WaitForDeploymentToBeReady,
 		IngressResourceKind:               WaitForIngressToBeReady,
@@ -33,7 +33,7 @@ var (
 	WaitForResourceInterval = time.Second * 2
 )
 
-func WaitForResourceToBeReady(namespace, name string, gvk *schema.GroupVersionKind) error {
+func WaitForResourceToBeReady(ctx context.Context, namespace, name string, gvk *schema.GroupVersionKind) error {
 	clientset, err := k8sutil.GetClientset()
 	if err != nil {
 		return errors.Wrap(err, "failed to get clientset")
@@ -45,7 +45,7 @@ func WaitForResourceToBeReady(namespace, name string, gvk *schema.GroupVersionKi
 	}
 
 	if fn, ok := WaitForResourceFns[kind]; ok {
-		return fn(clientset, namespace, name)
+		return fn(ctx, clientset, namespace, name)
 	}
 
 	dr, err := k8sutil.GetDynamicResourceInterface(gvk, namespace)
@@ -53,120 +53,132 @@ func WaitForResourceToBeReady(namespace, name string, gvk *schema.GroupVersionKi
 		return errors.Wrap(err, "failed to get dynamic resource interface")
 	}
 
-	return WaitForGenericResourceToBeReady(dr, name)
+	return WaitForGenericResourceToBeReady(ctx, dr, name)
 }
 
-func WaitForDaemonSetToBeReady(clientset kubernetes.Interface, namespace, name string) error {
+func WaitForDaemonSetToBeReady(ctx context.Context, clientset kubernetes.Interface, namespace, name string) error {
 	for {
-		r, err := clientset.AppsV1().DaemonSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		r, err := clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil && !kuberneteserrors.IsNotFound(err) {
 			return errors.Wrap(err, "failed to get existing daemonset")
 		}
 
 		if err == nil {
-			state := CalculateDaemonSetState(clientset, namespace, r)
+			state, _ := CalculateDaemonSetState(clientset, namespace, r)
 			if state == types.StateReady {
 				return nil
 			}
 		}
 
-		time.Sleep(WaitForResourceInterval)
+		if err := sleepOrDone(ctx, WaitForResourceInterval); err != nil {
+			return err
+		}
 	}
 }
 
-func WaitForDeploymentToBeReady(clientset kubernetes.Interface, namespace, name string) error {
+func WaitForDeploymentToBeReady(ctx context.Context, clientset kubernetes.Interface, namespace, name string) error {
 	for {
-		r, err := clientset.AppsV1().Deployments(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		r, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil && !kuberneteserrors.IsNotFound(err) {
 			return errors.Wrap(err, "failed to get existing deployment")
 		}
 
 		if err == nil {
-			state := CalculateDeploymentState(r)
+			state, _ := CalculateDeploymentState(r)
 			if state == types.StateReady {
 				return nil
 			}
 		}
 
-		time.Sleep(WaitForResourceInterval)
+		if err := sleepOrDone(ctx, WaitForResourceInterval); err != nil {
+			return err
+		}
 	}
 }
 
-func WaitForIngressToBeReady(clientset kubernetes.Interface, namespace, name string) error {
+func WaitForIngressToBeReady(ctx context.Context, clientset kubernetes.Interface, namespace, name string) error {
 	for {
-		r, err := clientset.NetworkingV1().Ingresses(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		r, err := clientset.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil && !kuberneteserrors.IsNotFound(err) {
 			return errors.Wrap(err, "failed to get existing ingress")
 		}
 
 		if err == nil {
-			state := CalculateIngressState(clientset, r)
+			state, _ := CalculateIngressState(clientset, r)
 			if state == types.StateReady {
 				return nil
 			}
 		}
 
-		time.Sleep(WaitForResourceInterval)
+		if err := sleepOrDone(ctx, WaitForResourceInterval); err != nil {
+			return err
+		}
 	}
 }
 
-func WaitForPersistentVolumeClaimToBeReady(clientset kubernetes.Interface, namespace, name string) error {
+func WaitForPersistentVolumeClaimToBeReady(ctx context.Context, clientset kubernetes.Interface, namespace, name string) error {
 	for {
-		r, err := clientset.CoreV1().PersistentVolumeClaims(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		r, err := clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil && !kuberneteserrors.IsNotFound(err) {
 			return errors.Wrap(err, "failed to get existing persistentvolumeclaim")
 		}
 
 		if err == nil {
-			state := CalculatePersistentVolumeClaimState(r)
+			state, _ := CalculatePersistentVolumeClaimState(r)
 			if state == types.StateReady {
 				return nil
 			}
 		}
 
-		time.Sleep(WaitForResourceInterval)
+		if err := sleepOrDone(ctx, WaitForResourceInterval); err != nil {
+			return err
+		}
 	}
 }
 
-func WaitForServiceToBeReady(clientset kubernetes.Interface, namespace, name string) error {
+func WaitForServiceToBeReady(ctx context.Context, clientset kubernetes.Interface, namespace, name string) error {
 	for {
-		r, err := clientset.CoreV1().Services(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		r, err := clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil && !kuberneteserrors.IsNotFound(err) {
 			return errors.Wrap(err, "failed to get existing service")
 		}
 
 		if err == nil {
-			state := CalculateServiceState(clientset, r)
+			state, _ := CalculateServiceState(clientset, r)
 			if state == types.StateReady {
 				return nil
 			}
 		}
 
-		time.Sleep(WaitForResourceInterval)
+		if err := sleepOrDone(ctx, WaitForResourceInterval); err != nil {
+			return err
+		}
 	}
 }
 
-func WaitForStatefulSetToBeReady(clientset kubernetes.Interface, namespace, name string) error {
+func WaitForStatefulSetToBeReady(ctx context.Context, clientset kubernetes.Interface, namespace, name string) error {
 	for {
-		r, err := clientset.AppsV1().StatefulSets(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+		r, err := clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil && !kuberneteserrors.IsNotFound(err) {
 			return errors.Wrap(err, "failed to get existing statefulset")
 		}
 
 		if err == nil {
-			state := CalculateStatefulSetState(clientset, namespace, r)
+			state, _ := CalculateStatefulSetState(clientset, namespace, r)
 			if state == types.StateReady {
 				return nil
 			}
 		}
 
-		time.Sleep(WaitForResourceInterval)
+		if err := sleepOrDone(ctx, WaitForResourceInterval); err != nil {
+			return err
+		}
 	}
 }
 
-func WaitForGenericResourceToBeReady(dr dynamic.ResourceInterface, name string) (err error) {
+func WaitForGenericResourceToBeReady(ctx context.Context, dr dynamic.ResourceInterface, name string) (err error) {
 	for {
-		_, err := dr.Get(context.TODO(), name, metav1.GetOptions{})
+		_, err := dr.Get(ctx, name, metav1.GetOptions{})
 		if err != nil && !kuberneteserrors.IsNotFound(err) {
 			return errors.Wrap(err, "failed to get existing resource")
 		}
@@ -175,18 +187,20 @@ func WaitForGenericResourceToBeReady(dr dynamic.ResourceInterface, name string)
 			return nil
 		}
 
-		time.Sleep(WaitForResourceInterval)
+		if err := sleepOrDone(ctx, WaitForResourceInterval); err != nil {
+			return err
+		}
 	}
 }
 
-func WaitForProperty(namespace, name string, gvk *schema.GroupVersionKind, path, desiredValue string) error {
+func WaitForProperty(ctx context.Context, namespace, name string, gvk *schema.GroupVersionKind, path, desiredValue string) error {
 	dr, err := k8sutil.GetDynamicResourceInterface(gvk, namespace)
 	if err != nil {
 		return errors.Wrap(err, "failed to get dynamic resource interface")
 	}
 
 	for {
-		r, err := dr.Get(context.TODO(), name, metav1.GetOptions{})
+		r, err := dr.Get(ctx, name, metav1.GetOptions{})
 		if err != nil && !kuberneteserrors.IsNotFound(err) {
 			return errors.Wrap(err, "failed to get existing resource")
 		}
@@ -199,7 +213,9 @@ func WaitForProperty(namespace, name string, gvk *schema.GroupVersionKind, path,
 			}
 		}
 
-		time.Sleep(WaitForResourceInterval)
+		if err := sleepOrDone(ctx, WaitForResourceInterval); err != nil {
+			return err
+		}
 	}
 }
 
@@ -226,3 +242,13 @@ func resourcePropertyMatchesValue(r *unstructured.Unstructured, path, desiredVal
 
 	return false, nil
 }
+
+// sleepOrDone waits for the given duration, or returns the context error if it is done first
+func sleepOrDone(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
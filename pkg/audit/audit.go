@@ -0,0 +1,80 @@
+package audit
+
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/store"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// purgeAuditLogCronSpec - daily cron spec for the audit log retention job
+	purgeAuditLogCronSpec = "30 0 * * *"
+
+	// RetentionDaysEnv - env var that sets how many days of audit events are kept. 0 keeps events forever.
+	RetentionDaysEnv = "AUDIT_LOG_RETENTION_DAYS"
+
+	// DefaultRetentionDays - number of days of audit events kept when the env var is not set
+	DefaultRetentionDays = 365
+)
+
+// GetRetentionDays - returns the configured audit log retention in days
+func GetRetentionDays() (int, error) {
+	val := os.Getenv(RetentionDaysEnv)
+	if val == "" {
+		return DefaultRetentionDays, nil
+	}
+
+	days, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse %s", RetentionDaysEnv)
+	}
+	if days < 0 {
+		return 0, errors.Errorf("%s cannot be negative", RetentionDaysEnv)
+	}
+
+	return days, nil
+}
+
+// StartRetentionCronJob - start the cron job which deletes audit events older than the retention period
+func StartRetentionCronJob() error {
+	retentionDays, err := GetRetentionDays()
+	if err != nil {
+		return errors.Wrap(err, "failed to get audit log retention")
+	}
+	if retentionDays == 0 {
+		logger.Debug("audit log retention is disabled")
+		return nil
+	}
+
+	logger.Debug("starting audit log retention cron job")
+
+	cronJob := cron.New(cron.WithChain(
+		cron.Recover(cron.DefaultLogger),
+	))
+
+	_, err = cronJob.AddFunc(purgeAuditLogCronSpec, func() {
+		logger.Debug("running audit log retention job")
+		if err := purgeExpiredEvents(retentionDays); err != nil {
+			logger.Error(errors.Wrap(err, "failed to purge expired audit events"))
+		}
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to add cron job")
+	}
+	cronJob.Start()
+	return nil
+}
+
+// purgeExpiredEvents - delete all audit events older than the retention period
+func purgeExpiredEvents(retentionDays int) error {
+	before := time.Now().AddDate(0, 0, -retentionDays)
+	if err := store.GetStore().DeleteAuditEventsBefore(before); err != nil {
+		return errors.Wrap(err, "failed to delete audit events")
+	}
+	return nil
+}
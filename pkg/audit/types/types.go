@@ -0,0 +1,45 @@
+package types
+
+import "time"
+
+type Outcome string
+
+const (
+	// OutcomeSuccess means the request was allowed and the handler responded with a non-error status
+	OutcomeSuccess Outcome = "success"
+	// OutcomeFailure means the request was allowed but the handler responded with an error status
+	OutcomeFailure Outcome = "failure"
+	// OutcomeDenied means the request was rejected by rbac before reaching the handler
+	OutcomeDenied Outcome = "denied"
+)
+
+// Event is a single audited admin console request
+type Event struct {
+	ID         string    `json:"id"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UserID     string    `json:"userId,omitempty"`
+	SessionID  string    `json:"sessionId,omitempty"`
+	APITokenID string    `json:"apiTokenId,omitempty"`
+	Roles      []string  `json:"roles,omitempty"`
+	Action     string    `json:"action"`
+	Resource   string    `json:"resource,omitempty"`
+	RouteName  string    `json:"routeName,omitempty"`
+	Method     string    `json:"method,omitempty"`
+	Path       string    `json:"path,omitempty"`
+	AppSlug    string    `json:"appSlug,omitempty"`
+	Sequence   *int64    `json:"sequence,omitempty"`
+	StatusCode int       `json:"statusCode,omitempty"`
+	Outcome    Outcome   `json:"outcome"`
+}
+
+type ListOptions struct {
+	Since       *time.Time
+	AppSlug     string
+	CurrentPage int
+	PageSize    int
+}
+
+type EventList struct {
+	Events     []*Event `json:"events"`
+	TotalCount int      `json:"totalCount"`
+}
@@ -0,0 +1,298 @@
+package gitops
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/apparchive"
+	"github.com/replicatedhq/kots/pkg/binaries"
+	"github.com/replicatedhq/kots/pkg/util"
+	"go.yaml.in/yaml/v3"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// FormatSingle writes the rendered app as a single <appSlug>.yaml file. This is the default.
+	FormatSingle = "single"
+	// FormatFiles writes one file per rendered resource to <appSlug>/<kind>/[<namespace>/]<name>.yaml
+	FormatFiles = "files"
+	// FormatKustomize writes the kustomize base and the overlays for the downstream to <appSlug>/
+	FormatKustomize = "kustomize"
+	// FormatHelm writes the v1beta2 helm charts and their values to <appSlug>/helm/, and the rest of the
+	// rendered app to <appSlug>/manifests.yaml
+	FormatHelm = "helm"
+)
+
+// IsSupportedFormat returns true if commits can be created for the format. An empty format is the single format.
+func IsSupportedFormat(format string) bool {
+	switch format {
+	case "", FormatSingle, FormatFiles, FormatKustomize, FormatHelm:
+		return true
+	}
+	return false
+}
+
+var kindRegex = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+
+// ownedFilesManifestPath is the path relative to the gitops path of the file that lists the files kots wrote
+// for the app in the last commit. Only the files in it are removed before the files for a new version are
+// written, so that removed resources are deleted from the repo without touching files kots does not own.
+func ownedFilesManifestPath(appSlug string) string {
+	return fmt.Sprintf(".%s.kots-files", appSlug)
+}
+
+// readOwnedFiles returns the files kots wrote for the app in the last commit. Repos committed to before the
+// manifest existed only have the single format output.
+func readOwnedFiles(dirPath string, appSlug string) ([]string, error) {
+	content, err := os.ReadFile(filepath.Join(dirPath, ownedFilesManifestPath(appSlug)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []string{fmt.Sprintf("%s.yaml", appSlug)}, nil
+		}
+		return nil, errors.Wrap(err, "failed to read owned files manifest")
+	}
+
+	ownedFiles := []string{}
+	for _, line := range strings.Split(string(content), "\n") {
+		relPath := strings.TrimSpace(line)
+		if relPath == "" || !filepath.IsLocal(relPath) {
+			continue
+		}
+		ownedFiles = append(ownedFiles, relPath)
+	}
+
+	return ownedFiles, nil
+}
+
+// removeOwnedFiles removes the files and the directories that are left empty, up to the gitops path
+func removeOwnedFiles(dirPath string, ownedFiles []string) error {
+	for _, relPath := range ownedFiles {
+		if err := os.Remove(filepath.Join(dirPath, relPath)); err != nil && !os.IsNotExist(err) {
+			return errors.Wrapf(err, "failed to remove %s", relPath)
+		}
+
+		for dir := filepath.Dir(relPath); dir != "."; dir = filepath.Dir(dir) {
+			entries, err := os.ReadDir(filepath.Join(dirPath, dir))
+			if err != nil || len(entries) > 0 {
+				break
+			}
+			if err := os.Remove(filepath.Join(dirPath, dir)); err != nil {
+				return errors.Wrapf(err, "failed to remove %s", dir)
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeOwnedFiles writes the manifest of the files kots wrote for the app
+func writeOwnedFiles(dirPath string, appSlug string, files map[string][]byte) error {
+	ownedFiles := []string{}
+	for relPath := range files {
+		ownedFiles = append(ownedFiles, relPath)
+	}
+	sort.Strings(ownedFiles)
+
+	content := strings.Join(ownedFiles, "\n") + "\n"
+	if err := os.MkdirAll(dirPath, 0755); err != nil {
+		return errors.Wrap(err, "failed to create gitops path")
+	}
+	if err := os.WriteFile(filepath.Join(dirPath, ownedFilesManifestPath(appSlug)), []byte(content), 0644); err != nil {
+		return errors.Wrap(err, "failed to write owned files manifest")
+	}
+
+	return nil
+}
+
+// getGitOpsFiles returns the files to commit for the app version, keyed by path relative to the gitops path
+func getGitOpsFiles(format string, appSlug string, archiveDir string, downstreamName string) (map[string][]byte, error) {
+	switch format {
+	case "", FormatSingle:
+		out, _, err := apparchive.GetRenderedApp(archiveDir, downstreamName, binaries.GetKustomizeBinPath())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get rendered app")
+		}
+		return map[string][]byte{
+			fmt.Sprintf("%s.yaml", appSlug): out,
+		}, nil
+
+	case FormatFiles:
+		out, _, err := apparchive.GetRenderedApp(archiveDir, downstreamName, binaries.GetKustomizeBinPath())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get rendered app")
+		}
+		files, err := splitResourcesToFiles(appSlug, out)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to split rendered app into files")
+		}
+		return files, nil
+
+	case FormatKustomize:
+		files, err := getKustomizeFiles(appSlug, archiveDir, downstreamName)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get kustomize files")
+		}
+		return files, nil
+
+	case FormatHelm:
+		files, err := getHelmFiles(appSlug, archiveDir)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get helm files")
+		}
+		out, _, err := apparchive.GetRenderedApp(archiveDir, downstreamName, binaries.GetKustomizeBinPath())
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get rendered app")
+		}
+		if len(bytes.TrimSpace(out)) > 0 {
+			files[filepath.Join(appSlug, "manifests.yaml")] = out
+		}
+		return files, nil
+	}
+
+	return nil, errors.Errorf("unsupported gitops format: %s", format)
+}
+
+type resourceHeader struct {
+	Kind     string `yaml:"kind"`
+	Metadata struct {
+		Name      string `yaml:"name"`
+		Namespace string `yaml:"namespace"`
+	} `yaml:"metadata"`
+}
+
+// splitResourcesToFiles returns a file per resource in the rendered app. Resources without a namespace are
+// written to the kind directory, and resources with the same kind, namespace and name get a numeric suffix
+// in the order they are rendered. The kind, namespace and name are used as path segments, so resources where
+// they could escape their directory are rejected, and characters that are not safe in file names are escaped.
+func splitResourcesToFiles(appSlug string, renderedApp []byte) (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	for _, doc := range util.ConvertToSingleDocs(renderedApp) {
+		doc = bytes.TrimPrefix(doc, []byte("---\n"))
+
+		header := resourceHeader{}
+		if err := yaml.Unmarshal(doc, &header); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal resource")
+		}
+		if header.Kind == "" || header.Metadata.Name == "" {
+			continue
+		}
+		if err := validateResourceHeader(header); err != nil {
+			return nil, errors.Wrapf(err, "invalid resource %s %s", header.Kind, header.Metadata.Name)
+		}
+
+		dir := filepath.Join(appSlug, strings.ToLower(header.Kind))
+		if header.Metadata.Namespace != "" {
+			dir = filepath.Join(dir, header.Metadata.Namespace)
+		}
+
+		name := escapeFileName(header.Metadata.Name)
+		filePath := filepath.Join(dir, fmt.Sprintf("%s.yaml", name))
+		for suffix := 1; ; suffix++ {
+			if _, exists := files[filePath]; !exists {
+				break
+			}
+			filePath = filepath.Join(dir, fmt.Sprintf("%s-%d.yaml", name, suffix))
+		}
+
+		files[filePath] = doc
+	}
+
+	return files, nil
+}
+
+func validateResourceHeader(header resourceHeader) error {
+	if !kindRegex.MatchString(header.Kind) {
+		return errors.New("kind must be alphanumeric")
+	}
+	// names of some kinds, e.g. ClusterRoles, are not DNS-1123 subdomains, so only names that are not a single
+	// path segment are rejected
+	name := header.Metadata.Name
+	if name == "." || name == ".." || strings.ContainsAny(name, "/\\") {
+		return errors.New("name must not be \".\" or \"..\" or contain path separators")
+	}
+	if header.Metadata.Namespace != "" {
+		if errs := validation.IsDNS1123Label(header.Metadata.Namespace); len(errs) > 0 {
+			return errors.Errorf("namespace is not a valid DNS-1123 label: %s", strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// escapeFileName percent-encodes the characters of a resource name that are not safe in file names on every
+// platform, e.g. the ":" in "system:aggregate-to-view"
+func escapeFileName(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9') || c == '-' || c == '_' || c == '.' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// getKustomizeFiles returns the base and the midstream and downstream overlays as they are in the archive, so that
+// the downstream overlay can be built with kustomize from the repo
+func getKustomizeFiles(appSlug string, archiveDir string, downstreamName string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	dirs := []string{
+		"base",
+		filepath.Join("overlays", "midstream"),
+		filepath.Join("overlays", "downstreams", downstreamName),
+	}
+	for _, dir := range dirs {
+		if _, err := os.Stat(filepath.Join(archiveDir, dir)); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to stat %s", dir)
+		}
+
+		dirFiles, err := util.GetFilesMap(filepath.Join(archiveDir, dir))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get files in %s", dir)
+		}
+		for relPath, content := range dirFiles {
+			files[filepath.Join(appSlug, dir, relPath)] = content
+		}
+	}
+
+	if len(files) == 0 {
+		return nil, errors.New("no kustomize base or overlays found in app version archive")
+	}
+
+	return files, nil
+}
+
+// getHelmFiles returns the v1beta2 helm chart archives and values files of the app version
+func getHelmFiles(appSlug string, archiveDir string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+
+	helmDir := filepath.Join(archiveDir, "helm")
+	if _, err := os.Stat(helmDir); err != nil {
+		if os.IsNotExist(err) {
+			return files, nil
+		}
+		return nil, errors.Wrap(err, "failed to stat helm dir")
+	}
+
+	helmFiles, err := util.GetFilesMap(helmDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get helm files")
+	}
+	for relPath, content := range helmFiles {
+		files[filepath.Join(appSlug, "helm", relPath)] = content
+	}
+
+	return files, nil
+}
@@ -0,0 +1,269 @@
+package gitops
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_splitResourcesToFiles(t *testing.T) {
+	renderedApp := []byte(`apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: app
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: reader
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: app
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: system:app-reader
+---
+# only a comment
+`)
+
+	files, err := splitResourcesToFiles("my-app", renderedApp)
+	require.NoError(t, err)
+
+	want := map[string][]byte{
+		"my-app/deployment/app/web.yaml":              []byte("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n  namespace: app"),
+		"my-app/service/web.yaml":                     []byte("apiVersion: v1\nkind: Service\nmetadata:\n  name: web"),
+		"my-app/clusterrole/reader.yaml":              []byte("apiVersion: rbac.authorization.k8s.io/v1\nkind: ClusterRole\nmetadata:\n  name: reader"),
+		"my-app/deployment/app/web-1.yaml":            []byte("apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n  namespace: app"),
+		"my-app/clusterrole/system%3Aapp-reader.yaml": []byte("apiVersion: rbac.authorization.k8s.io/v1\nkind: ClusterRole\nmetadata:\n  name: system:app-reader"),
+	}
+	assert.Equal(t, want, files)
+}
+
+func Test_splitResourcesToFilesRejectsInvalidPaths(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+	}{
+		{
+			name:     "name with path separator",
+			resource: "kind: ConfigMap\nmetadata:\n  name: ../../etc/passwd\n",
+		},
+		{
+			name:     "name with backslash",
+			resource: "kind: ConfigMap\nmetadata:\n  name: ..\\\\outside\n",
+		},
+		{
+			name:     "name is dot dot",
+			resource: "kind: ConfigMap\nmetadata:\n  name: ..\n",
+		},
+		{
+			name:     "namespace with path separator",
+			resource: "kind: ConfigMap\nmetadata:\n  name: web\n  namespace: ../outside\n",
+		},
+		{
+			name:     "namespace with dot dot",
+			resource: "kind: ConfigMap\nmetadata:\n  name: web\n  namespace: ..\n",
+		},
+		{
+			name:     "kind with path separator",
+			resource: "kind: ../ConfigMap\nmetadata:\n  name: web\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := splitResourcesToFiles("my-app", []byte(test.resource))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_getGitOpsFiles(t *testing.T) {
+	archiveDir := t.TempDir()
+	writeArchiveFile(t, archiveDir, "rendered/this-cluster/deployment.yaml", "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n")
+	writeArchiveFile(t, archiveDir, "base/kustomization.yaml", "resources:\n- deployment.yaml\n")
+	writeArchiveFile(t, archiveDir, "base/deployment.yaml", "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n")
+	writeArchiveFile(t, archiveDir, "overlays/midstream/kustomization.yaml", "bases:\n- ../../base\n")
+	writeArchiveFile(t, archiveDir, "overlays/downstreams/this-cluster/kustomization.yaml", "bases:\n- ../../midstream\n")
+	writeArchiveFile(t, archiveDir, "overlays/downstreams/other-cluster/kustomization.yaml", "bases:\n- ../../midstream\n")
+	writeArchiveFile(t, archiveDir, "helm/my-chart/my-chart-1.0.0.tgz", "chart")
+	writeArchiveFile(t, archiveDir, "helm/my-chart/values.yaml", "replicas: 1\n")
+
+	tests := []struct {
+		format    string
+		wantPaths []string
+	}{
+		{
+			format:    "",
+			wantPaths: []string{"my-app.yaml"},
+		},
+		{
+			format:    FormatSingle,
+			wantPaths: []string{"my-app.yaml"},
+		},
+		{
+			format:    FormatFiles,
+			wantPaths: []string{"my-app/deployment/web.yaml"},
+		},
+		{
+			format: FormatKustomize,
+			wantPaths: []string{
+				"my-app/base/kustomization.yaml",
+				"my-app/base/deployment.yaml",
+				"my-app/overlays/midstream/kustomization.yaml",
+				"my-app/overlays/downstreams/this-cluster/kustomization.yaml",
+			},
+		},
+		{
+			format: FormatHelm,
+			wantPaths: []string{
+				"my-app/helm/my-chart/my-chart-1.0.0.tgz",
+				"my-app/helm/my-chart/values.yaml",
+				"my-app/manifests.yaml",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.format, func(t *testing.T) {
+			files, err := getGitOpsFiles(test.format, "my-app", archiveDir, "this-cluster")
+			require.NoError(t, err)
+
+			paths := []string{}
+			for p := range files {
+				paths = append(paths, p)
+			}
+			assert.ElementsMatch(t, test.wantPaths, paths)
+		})
+	}
+
+	_, err := getGitOpsFiles("unknown", "my-app", archiveDir, "this-cluster")
+	assert.Error(t, err)
+}
+
+func TestCreateGitOpsCommit_removesDeletedResources(t *testing.T) {
+	remoteDir := t.TempDir()
+	remote, err := git.PlainInit(remoteDir, true)
+	require.NoError(t, err)
+	err = remote.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("main")))
+	require.NoError(t, err)
+
+	gitOpsConfig := &GitOpsConfig{
+		Provider:       ProviderGeneric,
+		CustomCloneURL: remoteDir,
+		AuthType:       AuthTypeBasic,
+		Username:       "user",
+		Password:       "pass",
+		Branch:         "main",
+		Path:           "apps",
+		Format:         FormatFiles,
+	}
+
+	archiveDir := t.TempDir()
+	writeArchiveFile(t, archiveDir, "rendered/this-cluster/web.yaml", "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: web\n")
+	writeArchiveFile(t, archiveDir, "rendered/this-cluster/worker.yaml", "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: worker\n")
+
+	// files in the repo that kots did not write are never removed
+	commitUserFile(t, remoteDir, "apps/my-app/README.md")
+
+	_, err = CreateGitOpsCommit(gitOpsConfig, "my-app", "My App", 1, archiveDir, "this-cluster")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"apps/.my-app.kots-files",
+		"apps/my-app/README.md",
+		"apps/my-app/deployment/web.yaml",
+		"apps/my-app/deployment/worker.yaml",
+	}, listCommittedFiles(t, remoteDir))
+
+	// committing the same version again does not create a commit
+	commitURL, err := CreateGitOpsCommit(gitOpsConfig, "my-app", "My App", 1, archiveDir, "this-cluster")
+	require.NoError(t, err)
+	assert.Empty(t, commitURL)
+
+	require.NoError(t, os.Remove(filepath.Join(archiveDir, "rendered/this-cluster/worker.yaml")))
+	_, err = CreateGitOpsCommit(gitOpsConfig, "my-app", "My App", 2, archiveDir, "this-cluster")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"apps/.my-app.kots-files",
+		"apps/my-app/README.md",
+		"apps/my-app/deployment/web.yaml",
+	}, listCommittedFiles(t, remoteDir))
+
+	// switching formats removes the output of the previous format
+	gitOpsConfig.Format = FormatSingle
+	_, err = CreateGitOpsCommit(gitOpsConfig, "my-app", "My App", 2, archiveDir, "this-cluster")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"apps/.my-app.kots-files",
+		"apps/my-app.yaml",
+		"apps/my-app/README.md",
+	}, listCommittedFiles(t, remoteDir))
+}
+
+func writeArchiveFile(t *testing.T, archiveDir string, relPath string, content string) {
+	filePath := filepath.Join(archiveDir, relPath)
+	require.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+	require.NoError(t, os.WriteFile(filePath, []byte(content), 0644))
+}
+
+func commitUserFile(t *testing.T, repoDir string, relPath string) {
+	workDir := t.TempDir()
+	r, err := git.PlainInit(workDir, false)
+	require.NoError(t, err)
+	err = r.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.NewBranchReferenceName("main")))
+	require.NoError(t, err)
+	_, err = r.CreateRemote(&config.RemoteConfig{Name: git.DefaultRemoteName, URLs: []string{repoDir}})
+	require.NoError(t, err)
+
+	writeArchiveFile(t, workDir, relPath, "not written by kots\n")
+
+	workTree, err := r.Worktree()
+	require.NoError(t, err)
+	_, err = workTree.Add(relPath)
+	require.NoError(t, err)
+	_, err = workTree.Commit("add user file", &git.CommitOptions{
+		Author: &object.Signature{Name: "user", Email: "user@example.com", When: time.Now()},
+	})
+	require.NoError(t, err)
+	require.NoError(t, r.Push(&git.PushOptions{RemoteName: git.DefaultRemoteName}))
+}
+
+func listCommittedFiles(t *testing.T, repoDir string) []string {
+	r, err := git.PlainOpen(repoDir)
+	require.NoError(t, err)
+
+	ref, err := r.Reference("refs/heads/main", true)
+	require.NoError(t, err)
+
+	commit, err := r.CommitObject(ref.Hash())
+	require.NoError(t, err)
+
+	tree, err := commit.Tree()
+	require.NoError(t, err)
+
+	files := []string{}
+	err = tree.Files().ForEach(func(f *object.File) error {
+		files = append(files, f.Name)
+		return nil
+	})
+	require.NoError(t, err)
+
+	return files
+}
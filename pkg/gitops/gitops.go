@@ -17,12 +17,11 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/plumbing/transport"
+	go_git_http "github.com/go-git/go-git/v5/plumbing/transport/http"
 	go_git_ssh "github.com/go-git/go-git/v5/plumbing/transport/ssh"
 	"github.com/mikesmitty/edkey"
 	"github.com/pkg/errors"
 	apptypes "github.com/replicatedhq/kots/pkg/app/types"
-	"github.com/replicatedhq/kots/pkg/apparchive"
-	"github.com/replicatedhq/kots/pkg/binaries"
 	"github.com/replicatedhq/kots/pkg/crypto"
 	"github.com/replicatedhq/kots/pkg/k8sutil"
 	"github.com/replicatedhq/kots/pkg/kotsadm/types"
@@ -34,28 +33,74 @@ import (
 	"k8s.io/client-go/kubernetes"
 )
 
+const (
+	// ProviderGeneric is a git host that is not known to kots. The clone url and the commit url template are
+	// supplied by the user.
+	ProviderGeneric = "generic"
+
+	// AuthTypeSSH authenticates with the ed25519 deploy key generated by kots. This is the default.
+	AuthTypeSSH = "ssh"
+	// AuthTypeToken authenticates over https with a personal access token
+	AuthTypeToken = "token"
+	// AuthTypeBasic authenticates over https with a username and password
+	AuthTypeBasic = "basic"
+
+	// commitURLTemplateHashPlaceholder is replaced with the commit hash in the commit url template
+	commitURLTemplateHashPlaceholder = "{hash}"
+	// defaultTokenUsername is sent with access tokens when no username is configured. Most git hosts ignore
+	// the username when the password is a token, but it cannot be empty.
+	defaultTokenUsername = "x-access-token"
+)
+
 type GitOpsConfig struct {
-	Provider    string `json:"provider"`
-	RepoURI     string `json:"repoUri"`
-	Hostname    string `json:"hostname"`
-	HTTPPort    string `json:"httpPort"`
-	SSHPort     string `json:"sshPort"`
-	Path        string `json:"path"`
-	Branch      string `json:"branch"`
-	Format      string `json:"format"`
-	Action      string `json:"action"`
-	PublicKey   string `json:"publicKey"`
-	PrivateKey  string `json:"-"`
-	IsConnected bool   `json:"isConnected"`
+	Provider          string `json:"provider"`
+	RepoURI           string `json:"repoUri"`
+	Hostname          string `json:"hostname"`
+	HTTPPort          string `json:"httpPort"`
+	SSHPort           string `json:"sshPort"`
+	Path              string `json:"path"`
+	Branch            string `json:"branch"`
+	Format            string `json:"format"`
+	Action            string `json:"action"`
+	PublicKey         string `json:"publicKey"`
+	PrivateKey        string `json:"-"`
+	AuthType          string `json:"authType"`
+	Username          string `json:"username"`
+	Password          string `json:"-"`
+	CustomCloneURL    string `json:"cloneUrl"`
+	CommitURLTemplate string `json:"commitUrlTemplate"`
+	IsConnected       bool   `json:"isConnected"`
 }
 
 type GlobalGitOpsConfig struct {
-	Enabled  bool   `json:"enabled"`
-	Hostname string `json:"hostname"`
-	HTTPPort string `json:"httpPort"`
-	SSHPort  string `json:"sshPort"`
-	Provider string `json:"provider"`
-	URI      string `json:"uri"`
+	Enabled           bool   `json:"enabled"`
+	Hostname          string `json:"hostname"`
+	HTTPPort          string `json:"httpPort"`
+	SSHPort           string `json:"sshPort"`
+	Provider          string `json:"provider"`
+	URI               string `json:"uri"`
+	AuthType          string `json:"authType"`
+	Username          string `json:"username"`
+	CloneURL          string `json:"cloneUrl"`
+	CommitURLTemplate string `json:"commitUrlTemplate"`
+}
+
+type CreateGitOpsOptions struct {
+	Provider string
+	RepoURI  string
+	Hostname string
+	HTTPPort string
+	SSHPort  string
+	// AuthType is one of ssh, token or basic. Defaults to ssh.
+	AuthType string
+	// Username is required for basic auth and optional for token auth
+	Username string
+	// Password is the password for basic auth or the access token for token auth
+	Password string
+	// CloneURL is required for the generic provider
+	CloneURL string
+	// CommitURLTemplate is used to link to commits, with {hash} replaced by the commit hash
+	CommitURLTemplate string
 }
 
 type KeyPair struct {
@@ -63,7 +108,16 @@ type KeyPair struct {
 	PublicKeySSH  string
 }
 
+// IsHTTPS returns true if the repo is accessed over https with a token or a username and password
+func (g *GitOpsConfig) IsHTTPS() bool {
+	return g.AuthType == AuthTypeToken || g.AuthType == AuthTypeBasic
+}
+
 func (g *GitOpsConfig) CommitURL(hash string) string {
+	if g.CommitURLTemplate != "" {
+		return strings.ReplaceAll(g.CommitURLTemplate, commitURLTemplateHashPlaceholder, hash)
+	}
+
 	switch g.Provider {
 	case "github", "github_enterprise":
 		return fmt.Sprintf("%s/commit/%s", g.RepoURI, hash)
@@ -74,12 +128,22 @@ func (g *GitOpsConfig) CommitURL(hash string) string {
 	case "bitbucket", "bitbucket_server":
 		return fmt.Sprintf("%s/commits/%s", g.RepoURI, hash)
 
+	case ProviderGeneric:
+		return ""
+
 	default:
 		return fmt.Sprintf("%s/commit/%s", g.RepoURI, hash)
 	}
 }
 
 func (g *GitOpsConfig) CloneURL() (string, error) {
+	if g.Provider == ProviderGeneric {
+		if g.CustomCloneURL == "" {
+			return "", errors.New("clone url is required for the generic provider")
+		}
+		return g.CustomCloneURL, nil
+	}
+
 	// copied this logic from node js api
 	uriParts := strings.Split(g.RepoURI, "/")
 
@@ -98,6 +162,10 @@ func (g *GitOpsConfig) CloneURL() (string, error) {
 		repo = uriParts[6]
 	}
 
+	if g.IsHTTPS() {
+		return g.httpsCloneURL(owner, repo)
+	}
+
 	switch g.Provider {
 	case "github":
 		return fmt.Sprintf("git@github.com:%s/%s.git", owner, repo), nil
@@ -114,6 +182,28 @@ func (g *GitOpsConfig) CloneURL() (string, error) {
 	return "", errors.Errorf("unsupported provider type: %s", g.Provider)
 }
 
+func (g *GitOpsConfig) httpsCloneURL(owner string, repo string) (string, error) {
+	host := g.Hostname
+	if g.HTTPPort != "" {
+		host = fmt.Sprintf("%s:%s", g.Hostname, g.HTTPPort)
+	}
+
+	switch g.Provider {
+	case "github":
+		return fmt.Sprintf("https://github.com/%s/%s.git", owner, repo), nil
+	case "gitlab":
+		return fmt.Sprintf("https://gitlab.com/%s/%s.git", owner, repo), nil
+	case "bitbucket":
+		return fmt.Sprintf("https://bitbucket.org/%s/%s.git", owner, repo), nil
+	case "bitbucket_server":
+		return fmt.Sprintf("https://%s/scm/%s/%s.git", host, owner, repo), nil
+	case "github_enterprise", "gitlab_enterprise":
+		return fmt.Sprintf("https://%s/%s/%s.git", host, owner, repo), nil
+	}
+
+	return "", errors.Errorf("unsupported provider type: %s", g.Provider)
+}
+
 // GetDownstreamGitOps will return the gitops config for a downstream,
 // This implementation copies how it works in typescript.
 func GetDownstreamGitOps(appID string, clusterID string) (*GitOpsConfig, error) {
@@ -167,31 +257,24 @@ func GetDownstreamGitOpsConfig(clientset kubernetes.Interface, appID string, clu
 				if err != nil {
 					return nil, errors.Wrap(err, "failed to parse index")
 				}
-				provider, publicKey, privateKey, repoURI, hostname, httpPort, sshPort := gitOpsConfigFromSecretData(idx, secret.Data)
+				gitOpsConfig := gitOpsConfigFromSecretData(idx, secret.Data)
 
-				decodedPrivateKey, err := base64.StdEncoding.DecodeString(privateKey)
+				decryptedPrivateKey, err := decryptSecretValue(gitOpsConfig.PrivateKey)
 				if err != nil {
-					return nil, errors.Wrap(err, "failed to decode")
+					return nil, errors.Wrap(err, "failed to decrypt private key")
 				}
+				gitOpsConfig.PrivateKey = decryptedPrivateKey
 
-				decryptedPrivateKey, err := crypto.Decrypt([]byte(decodedPrivateKey))
+				decryptedPassword, err := decryptSecretValue(gitOpsConfig.Password)
 				if err != nil {
-					return nil, errors.Wrap(err, "failed to decrypt")
+					return nil, errors.Wrap(err, "failed to decrypt password")
 				}
+				gitOpsConfig.Password = decryptedPassword
 
-				gitOpsConfig := GitOpsConfig{
-					Provider:   provider,
-					PublicKey:  publicKey,
-					PrivateKey: string(decryptedPrivateKey),
-					RepoURI:    repoURI,
-					Hostname:   hostname,
-					HTTPPort:   httpPort,
-					SSHPort:    sshPort,
-					Branch:     configMapData["branch"],
-					Path:       configMapData["path"],
-					Format:     configMapData["format"],
-					Action:     configMapData["action"],
-				}
+				gitOpsConfig.Branch = configMapData["branch"]
+				gitOpsConfig.Path = configMapData["path"]
+				gitOpsConfig.Format = configMapData["format"]
+				gitOpsConfig.Action = configMapData["action"]
 
 				if lastError, ok := configMapData["lastError"]; ok && lastError == "" {
 					gitOpsConfig.IsConnected = true
@@ -452,7 +535,7 @@ func SetGitOpsError(appID string, clusterID string, errMsg string) error {
 // TestGitOpsConnection will attempt a clone of the target gitops repo.
 // It returns the default branch name from the clone.
 func TestGitOpsConnection(gitOpsConfig *GitOpsConfig) (string, error) {
-	auth, err := getAuth(gitOpsConfig.PrivateKey)
+	auth, err := getAuth(gitOpsConfig)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to get auth")
 	}
@@ -485,17 +568,85 @@ func TestGitOpsConnection(gitOpsConfig *GitOpsConfig) (string, error) {
 	return ref.Name().Short(), nil
 }
 
-func CreateGitOps(provider string, repoURI string, hostname string, httpPort string, sshPort string) error {
+func CreateGitOps(opts CreateGitOpsOptions) error {
 	clientset, err := k8sutil.GetClientset()
 	if err != nil {
 		return errors.Wrap(err, "failed to get k8s client set")
 	}
 
-	err = createGitOps(clientset, provider, repoURI, hostname, httpPort, sshPort)
+	err = createGitOps(clientset, opts)
 	return errors.Wrap(err, "failed to create gitops")
 }
 
-func createGitOps(clientset kubernetes.Interface, provider string, repoURI string, hostname string, httpPort string, sshPort string) error {
+// mergeStoredGitOpsOptions fills in the options that were not sent from the provider config already stored in the
+// secret. Clients that do not send an auth type keep the stored auth, and the stored password or token is kept
+// unless a new one is sent. It returns the encrypted password to store.
+func mergeStoredGitOpsOptions(opts *CreateGitOpsOptions, stored *GitOpsConfig) string {
+	if stored == nil {
+		return ""
+	}
+
+	if opts.AuthType == "" {
+		opts.AuthType = stored.AuthType
+		opts.Username = stored.Username
+		if opts.CloneURL == "" {
+			opts.CloneURL = stored.CustomCloneURL
+		}
+		if opts.CommitURLTemplate == "" {
+			opts.CommitURLTemplate = stored.CommitURLTemplate
+		}
+	}
+
+	if opts.Password == "" && opts.AuthType != AuthTypeSSH {
+		return stored.Password
+	}
+
+	return ""
+}
+
+func validateCreateGitOpsOptions(opts *CreateGitOpsOptions, hasStoredPassword bool) error {
+	if opts.AuthType == "" {
+		opts.AuthType = AuthTypeSSH
+	}
+
+	hasPassword := opts.Password != "" || hasStoredPassword
+
+	switch opts.AuthType {
+	case AuthTypeSSH:
+	case AuthTypeToken:
+		if !hasPassword {
+			return errors.New("access token is required for token auth")
+		}
+	case AuthTypeBasic:
+		if opts.Username == "" || !hasPassword {
+			return errors.New("username and password are required for basic auth")
+		}
+	default:
+		return errors.Errorf("unsupported auth type: %s", opts.AuthType)
+	}
+
+	if opts.Provider == ProviderGeneric {
+		if opts.CloneURL == "" {
+			return errors.New("clone url is required for the generic provider")
+		}
+		isHTTPSURL := strings.HasPrefix(opts.CloneURL, "https://") || strings.HasPrefix(opts.CloneURL, "http://")
+		if opts.AuthType == AuthTypeSSH && isHTTPSURL {
+			return errors.New("clone url must be an ssh url for ssh auth")
+		}
+		if opts.AuthType != AuthTypeSSH && !isHTTPSURL {
+			return errors.New("clone url must be an https url for token or basic auth")
+		}
+	}
+
+	return nil
+}
+
+func createGitOps(clientset kubernetes.Interface, opts CreateGitOpsOptions) error {
+	// the repo uri identifies the provider in the secret
+	if opts.Provider == ProviderGeneric && opts.RepoURI == "" {
+		opts.RepoURI = opts.CloneURL
+	}
+
 	secret, err := clientset.CoreV1().Secrets(util.PodNamespace).Get(context.TODO(), "kotsadm-gitops", metav1.GetOptions{})
 	if err != nil && !kuberneteserrors.IsNotFound(err) {
 		return errors.Wrap(err, "failed to get secret")
@@ -530,7 +681,7 @@ func createGitOps(clientset kubernetes.Interface, provider string, repoURI strin
 			return errors.Wrap(err, "failed to parse repo index")
 		}
 
-		if string(val) == repoURI {
+		if string(val) == opts.RepoURI {
 			repoIdx = idx
 			repoExists = true
 		}
@@ -544,47 +695,52 @@ func createGitOps(clientset kubernetes.Interface, provider string, repoURI strin
 		repoIdx = maxIdx + 1
 	}
 
-	secretData[fmt.Sprintf("provider.%d.type", repoIdx)] = []byte(provider)
-	secretData[fmt.Sprintf("provider.%d.repoUri", repoIdx)] = []byte(repoURI)
+	var stored *GitOpsConfig
+	if repoExists {
+		storedConfig := gitOpsConfigFromSecretData(repoIdx, secretData)
+		stored = &storedConfig
+	}
+	storedPassword := mergeStoredGitOpsOptions(&opts, stored)
 
-	if !repoExists {
+	if err := validateCreateGitOpsOptions(&opts, storedPassword != ""); err != nil {
+		return errors.Wrap(err, "invalid gitops options")
+	}
+
+	secretData[fmt.Sprintf("provider.%d.type", repoIdx)] = []byte(opts.Provider)
+	secretData[fmt.Sprintf("provider.%d.repoUri", repoIdx)] = []byte(opts.RepoURI)
+	secretData[fmt.Sprintf("provider.%d.authType", repoIdx)] = []byte(opts.AuthType)
+
+	// the deploy key is kept when switching to https auth so that switching back does not require adding a new key to the repo
+	if _, ok := secretData[fmt.Sprintf("provider.%d.privateKey", repoIdx)]; !ok && opts.AuthType == AuthTypeSSH {
 		keyPair, err := generatePrivateKey_ed25519()
 		if err != nil {
 			return errors.Wrap(err, "failed to generate ed25519 key pair")
 		}
 
-		encryptedPrivateKey := crypto.Encrypt([]byte(keyPair.PrivateKeyPEM))
-		encodedPrivateKey := base64.StdEncoding.EncodeToString(encryptedPrivateKey) // encoding here shouldn't be needed. moved logic from TS where ffi EncryptString function base64 encodes the value as well
-
-		secretData[fmt.Sprintf("provider.%d.privateKey", repoIdx)] = []byte(encodedPrivateKey)
+		secretData[fmt.Sprintf("provider.%d.privateKey", repoIdx)] = []byte(encryptSecretValue(keyPair.PrivateKeyPEM))
 		secretData[fmt.Sprintf("provider.%d.publicKey", repoIdx)] = []byte(keyPair.PublicKeySSH)
 	}
 
-	hostnameKey := fmt.Sprintf("provider.%d.hostname", repoIdx)
-	_, ok := secretData[hostnameKey]
-	if ok {
-		delete(secretData, hostnameKey)
-	}
-	if hostname != "" {
-		secretData[hostnameKey] = []byte(hostname)
+	password := storedPassword
+	if opts.Password != "" {
+		password = encryptSecretValue(opts.Password)
 	}
 
-	httpPortKey := fmt.Sprintf("provider.%d.httpPort", repoIdx)
-	_, ok = secretData[httpPortKey]
-	if ok {
-		delete(secretData, httpPortKey)
-	}
-	if httpPort != "" {
-		secretData[httpPortKey] = []byte(httpPort)
-	}
-
-	sshPortKey := fmt.Sprintf("provider.%d.sshPort", repoIdx)
-	_, ok = secretData[sshPortKey]
-	if ok {
-		delete(secretData, sshPortKey)
+	optionalValues := map[string]string{
+		"hostname":          opts.Hostname,
+		"httpPort":          opts.HTTPPort,
+		"sshPort":           opts.SSHPort,
+		"username":          opts.Username,
+		"password":          password,
+		"cloneUrl":          opts.CloneURL,
+		"commitUrlTemplate": opts.CommitURLTemplate,
 	}
-	if sshPort != "" {
-		secretData[sshPortKey] = []byte(sshPort)
+	for field, value := range optionalValues {
+		key := fmt.Sprintf("provider.%d.%s", repoIdx, field)
+		delete(secretData, key)
+		if value != "" {
+			secretData[key] = []byte(value)
+		}
 	}
 
 	if secretExists {
@@ -643,68 +799,91 @@ func GetGitOps() (GlobalGitOpsConfig, error) {
 		return GlobalGitOpsConfig{}, errors.Wrap(err, "get kotsadm-gitops secret")
 	}
 
+	providerConfig := gitOpsConfigFromSecretData(0, secret.Data)
 	parsedConfig := GlobalGitOpsConfig{
-		Enabled:  true,
-		Provider: string(secret.Data["provider.0.type"]),
-		URI:      string(secret.Data["provider.0.repoUri"]),
-		Hostname: string(secret.Data["provider.0.hostname"]),
-		HTTPPort: string(secret.Data["provider.0.httpPort"]),
-		SSHPort:  string(secret.Data["provider.0.sshPort"]),
+		Enabled:           true,
+		Provider:          providerConfig.Provider,
+		URI:               providerConfig.RepoURI,
+		Hostname:          providerConfig.Hostname,
+		HTTPPort:          providerConfig.HTTPPort,
+		SSHPort:           providerConfig.SSHPort,
+		AuthType:          providerConfig.AuthType,
+		Username:          providerConfig.Username,
+		CloneURL:          providerConfig.CustomCloneURL,
+		CommitURLTemplate: providerConfig.CommitURLTemplate,
 	}
 
 	return parsedConfig, nil
 }
 
-func gitOpsConfigFromSecretData(idx int64, secretData map[string][]byte) (string, string, string, string, string, string, string) {
-	provider := ""
-	publicKey := ""
-	privateKey := ""
-	repoURI := ""
-	hostname := ""
-	httpPort := ""
-	sshPort := ""
-
-	providerDecoded, ok := secretData[fmt.Sprintf("provider.%d.type", idx)]
-	if ok {
-		provider = string(providerDecoded)
+// gitOpsConfigFromSecretData returns the provider config stored at the given index of the gitops secret.
+// The private key and password are returned as stored, encrypted and base64 encoded.
+func gitOpsConfigFromSecretData(idx int64, secretData map[string][]byte) GitOpsConfig {
+	value := func(field string) string {
+		return string(secretData[fmt.Sprintf("provider.%d.%s", idx, field)])
 	}
 
-	publicKeyDecoded, ok := secretData[fmt.Sprintf("provider.%d.publicKey", idx)]
-	if ok {
-		publicKey = string(publicKeyDecoded)
+	authType := value("authType")
+	if authType == "" {
+		authType = AuthTypeSSH
 	}
 
-	privateKeyDecoded, ok := secretData[fmt.Sprintf("provider.%d.privateKey", idx)]
-	if ok {
-		privateKey = string(privateKeyDecoded)
+	return GitOpsConfig{
+		Provider:          value("type"),
+		PublicKey:         value("publicKey"),
+		PrivateKey:        value("privateKey"),
+		RepoURI:           value("repoUri"),
+		Hostname:          value("hostname"),
+		HTTPPort:          value("httpPort"),
+		SSHPort:           value("sshPort"),
+		AuthType:          authType,
+		Username:          value("username"),
+		Password:          value("password"),
+		CustomCloneURL:    value("cloneUrl"),
+		CommitURLTemplate: value("commitUrlTemplate"),
 	}
+}
 
-	repoURIDecoded, ok := secretData[fmt.Sprintf("provider.%d.repoUri", idx)]
-	if ok {
-		repoURI = string(repoURIDecoded)
-	}
+// encryptSecretValue encrypts a value to be stored in the gitops secret
+func encryptSecretValue(value string) string {
+	encrypted := crypto.Encrypt([]byte(value))
+	return base64.StdEncoding.EncodeToString(encrypted) // encoding here shouldn't be needed. moved logic from TS where ffi EncryptString function base64 encodes the value as well
+}
 
-	hostnameDecoded, ok := secretData[fmt.Sprintf("provider.%d.hostname", idx)]
-	if ok {
-		hostname = string(hostnameDecoded)
+// decryptSecretValue decrypts a value stored in the gitops secret, an empty value is returned as is
+func decryptSecretValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
 	}
 
-	httpPortDecoded, ok := secretData[fmt.Sprintf("provider.%d.httpPort", idx)]
-	if ok {
-		httpPort = string(httpPortDecoded)
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode")
 	}
 
-	sshPortDecoded, ok := secretData[fmt.Sprintf("provider.%d.sshPort", idx)]
-	if ok {
-		sshPort = string(sshPortDecoded)
+	decrypted, err := crypto.Decrypt(decoded)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decrypt")
 	}
 
-	return provider, publicKey, privateKey, repoURI, hostname, httpPort, sshPort
+	return string(decrypted), nil
 }
 
-func getAuth(privateKey string) (transport.AuthMethod, error) {
+func getAuth(gitOpsConfig *GitOpsConfig) (transport.AuthMethod, error) {
+	switch gitOpsConfig.AuthType {
+	case AuthTypeToken:
+		username := gitOpsConfig.Username
+		if username == "" {
+			username = defaultTokenUsername
+		}
+		return &go_git_http.BasicAuth{Username: username, Password: gitOpsConfig.Password}, nil
+
+	case AuthTypeBasic:
+		return &go_git_http.BasicAuth{Username: gitOpsConfig.Username, Password: gitOpsConfig.Password}, nil
+	}
+
 	var auth transport.AuthMethod
-	signer, err := ssh.ParsePrivateKey([]byte(privateKey))
+	signer, err := ssh.ParsePrivateKey([]byte(gitOpsConfig.PrivateKey))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse deploy key")
 	}
@@ -729,13 +908,13 @@ func CreateGitOpsDownstreamCommit(a *apptypes.App, clusterID string, newSequence
 }
 
 func CreateGitOpsCommit(gitOpsConfig *GitOpsConfig, appSlug string, appName string, newSequence int, archiveDir string, downstreamName string) (string, error) {
-	out, _, err := apparchive.GetRenderedApp(archiveDir, downstreamName, binaries.GetKustomizeBinPath())
+	files, err := getGitOpsFiles(gitOpsConfig.Format, appSlug, archiveDir, downstreamName)
 	if err != nil {
-		return "", errors.Wrap(err, "failed to get rendered app")
+		return "", errors.Wrap(err, "failed to get gitops files")
 	}
 
-	// using the deploy key, create the commit in a new branch
-	auth, err := getAuth(gitOpsConfig.PrivateKey)
+	// using the deploy key or the https credentials, create the commit in a new branch
+	auth, err := getAuth(gitOpsConfig)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to get auth")
 	}
@@ -763,37 +942,39 @@ func CreateGitOpsCommit(gitOpsConfig *GitOpsConfig, appSlug string, appName stri
 	}
 
 	dirPath := filepath.Join(workDir, gitOpsConfig.Path)
-	_, err = os.Stat(dirPath)
-	if os.IsNotExist(err) {
-		// create subdirectory if not exist
-		err := os.MkdirAll(dirPath, 0755)
-		if err != nil {
-			return "", errors.Wrap(err, "failed to mkdir")
-		}
-	} // ignore error here and let the stat of the file below handle any errors
 
-	filePath := filepath.Join(dirPath, fmt.Sprintf("%s.yaml", appSlug))
-	_, err = os.Stat(filePath)
-	if err == nil { // if the file has not changed, end now
-		currentRevision, err := os.ReadFile(filePath)
-		if err != nil {
-			return "", errors.Wrap(err, "failed to read current app yaml")
+	// remove the files kots wrote for the previous version, including output of other formats, so that removed resources are deleted
+	ownedFiles, err := readOwnedFiles(dirPath, appSlug)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read owned files")
+	}
+	if err := removeOwnedFiles(dirPath, ownedFiles); err != nil {
+		return "", errors.Wrap(err, "failed to remove owned files")
+	}
+
+	for relPath, content := range files {
+		filePath := filepath.Join(dirPath, relPath)
+		if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+			return "", errors.Wrapf(err, "failed to create dir for %s", relPath)
 		}
-		if string(currentRevision) == string(out) {
-			return "", nil
+		if err := os.WriteFile(filePath, content, 0644); err != nil {
+			return "", errors.Wrapf(err, "failed to write %s", relPath)
 		}
-	} else if !os.IsNotExist(err) {
-		return "", errors.Wrap(err, "failed to stat current app yaml")
+	}
+	if err := writeOwnedFiles(dirPath, appSlug, files); err != nil {
+		return "", errors.Wrap(err, "failed to write owned files")
 	}
 
-	err = ioutil.WriteFile(filePath, out, 0644)
-	if err != nil {
-		return "", errors.Wrap(err, "failed to write updated app yaml")
+	if err := workTree.AddWithOptions(&git.AddOptions{All: true}); err != nil {
+		return "", errors.Wrap(err, "failed to add to worktree")
 	}
 
-	_, err = workTree.Add(strings.TrimPrefix(filepath.Join(gitOpsConfig.Path, fmt.Sprintf("%s.yaml", appSlug)), "/"))
+	status, err := workTree.Status()
 	if err != nil {
-		return "", errors.Wrap(err, "failed to add to worktree")
+		return "", errors.Wrap(err, "failed to get worktree status")
+	}
+	if status.IsClean() { // if the files have not changed, end now
+		return "", nil
 	}
 
 	// commit it
@@ -24,6 +24,10 @@ func Test_createGitOps(t *testing.T) {
 		hostname    string
 		httpPort    string
 		sshPort     string
+		authType    string
+		username    string
+		password    string
+		cloneURL    string
 		configIndex int64
 		action      string
 		branch      string
@@ -73,22 +77,63 @@ func Test_createGitOps(t *testing.T) {
 			path:        "/test/path/2",
 			wantKeyType: "ssh-ed25519",
 		},
+		{
+			name:        "github enterprise provider with token auth",
+			provider:    "github_enterprise",
+			repoURI:     "https://1.2.3.7/test_org/test_repo",
+			hostname:    "1.2.3.7",
+			authType:    AuthTypeToken,
+			password:    "test-token",
+			configIndex: 2,
+			action:      "commit",
+			branch:      "test3-branch",
+			format:      "single",
+			path:        "/test/path/3",
+		},
+		{
+			name:        "generic provider with basic auth",
+			provider:    ProviderGeneric,
+			cloneURL:    "https://git.example.com/test_org/test_repo.git",
+			authType:    AuthTypeBasic,
+			username:    "test-user",
+			password:    "test-password",
+			configIndex: 3,
+			action:      "commit",
+			branch:      "test4-branch",
+			format:      "single",
+			path:        "/test/path/4",
+		},
 	}
 
 	clientset := fake.NewSimpleClientset()
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			err := createGitOps(clientset, test.provider, test.repoURI, test.hostname, test.httpPort, test.sshPort)
+			err := createGitOps(clientset, CreateGitOpsOptions{
+				Provider: test.provider,
+				RepoURI:  test.repoURI,
+				Hostname: test.hostname,
+				HTTPPort: test.httpPort,
+				SSHPort:  test.sshPort,
+				AuthType: test.authType,
+				Username: test.username,
+				Password: test.password,
+				CloneURL: test.cloneURL,
+			})
 			assert.NoError(t, err)
 
-			err = updateDownstreamGitOps(clientset, test.appID, test.clusterID, test.repoURI, test.branch, test.path, test.format, test.action)
+			repoURI := test.repoURI
+			if repoURI == "" {
+				repoURI = test.cloneURL
+			}
+
+			err = updateDownstreamGitOps(clientset, test.appID, test.clusterID, repoURI, test.branch, test.path, test.format, test.action)
 			assert.NoError(t, err)
 
 			config, err := GetDownstreamGitOpsConfig(clientset, test.appID, test.clusterID)
 			assert.NoError(t, err)
 
 			assert.Equal(t, test.provider, config.Provider)
-			assert.Equal(t, test.repoURI, config.RepoURI)
+			assert.Equal(t, repoURI, config.RepoURI)
 			assert.Equal(t, test.hostname, config.Hostname)
 			assert.Equal(t, test.httpPort, config.HTTPPort)
 			assert.Equal(t, test.sshPort, config.SSHPort)
@@ -96,6 +141,14 @@ func Test_createGitOps(t *testing.T) {
 			assert.Equal(t, test.branch, config.Branch)
 			assert.Equal(t, test.format, config.Format)
 			assert.Equal(t, test.path, config.Path)
+			assert.Equal(t, test.username, config.Username)
+			assert.Equal(t, test.password, config.Password)
+			assert.Equal(t, test.cloneURL, config.CustomCloneURL)
+
+			if test.wantKeyType == "" {
+				assert.Empty(t, config.PublicKey)
+				return
+			}
 
 			publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(config.PublicKey))
 			assert.NoError(t, err)
@@ -105,6 +158,147 @@ func Test_createGitOps(t *testing.T) {
 	}
 }
 
+func Test_createGitOpsInvalidOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts CreateGitOpsOptions
+	}{
+		{
+			name: "token auth without token",
+			opts: CreateGitOpsOptions{Provider: "github", RepoURI: "https://github.com/org/repo", AuthType: AuthTypeToken},
+		},
+		{
+			name: "basic auth without username",
+			opts: CreateGitOpsOptions{Provider: "github", RepoURI: "https://github.com/org/repo", AuthType: AuthTypeBasic, Password: "pass"},
+		},
+		{
+			name: "unknown auth type",
+			opts: CreateGitOpsOptions{Provider: "github", RepoURI: "https://github.com/org/repo", AuthType: "oauth"},
+		},
+		{
+			name: "generic provider without clone url",
+			opts: CreateGitOpsOptions{Provider: ProviderGeneric},
+		},
+		{
+			name: "generic provider with https clone url and ssh auth",
+			opts: CreateGitOpsOptions{Provider: ProviderGeneric, CloneURL: "https://git.example.com/org/repo.git"},
+		},
+		{
+			name: "generic provider with ssh clone url and token auth",
+			opts: CreateGitOpsOptions{Provider: ProviderGeneric, CloneURL: "git@git.example.com:org/repo.git", AuthType: AuthTypeToken, Password: "token"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := createGitOps(fake.NewSimpleClientset(), test.opts)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func Test_createGitOpsKeepsStoredAuth(t *testing.T) {
+	clientset := fake.NewSimpleClientset()
+	repoURI := "https://gitea.example.com/org/repo"
+
+	err := createGitOps(clientset, CreateGitOpsOptions{
+		Provider:          "github_enterprise",
+		RepoURI:           repoURI,
+		Hostname:          "gitea.example.com",
+		AuthType:          AuthTypeToken,
+		Username:          "bot",
+		Password:          "secret-token",
+		CommitURLTemplate: "https://gitea.example.com/org/repo/commit/{hash}",
+	})
+	assert.NoError(t, err)
+
+	// clients that do not send the auth fields, like older versions of the admin console, keep the stored auth
+	err = createGitOps(clientset, CreateGitOpsOptions{
+		Provider: "github_enterprise",
+		RepoURI:  repoURI,
+		Hostname: "gitea.example.com",
+	})
+	assert.NoError(t, err)
+
+	err = updateDownstreamGitOps(clientset, "app", "cluster", repoURI, "main", "", FormatSingle, "commit")
+	assert.NoError(t, err)
+
+	config, err := GetDownstreamGitOpsConfig(clientset, "app", "cluster")
+	assert.NoError(t, err)
+	assert.Equal(t, AuthTypeToken, config.AuthType)
+	assert.Equal(t, "bot", config.Username)
+	assert.Equal(t, "secret-token", config.Password)
+	assert.Equal(t, "https://gitea.example.com/org/repo/commit/{hash}", config.CommitURLTemplate)
+
+	// the token is kept when changing other settings without sending it again
+	err = createGitOps(clientset, CreateGitOpsOptions{
+		Provider: "github_enterprise",
+		RepoURI:  repoURI,
+		Hostname: "gitea.example.com",
+		HTTPPort: "8443",
+		AuthType: AuthTypeToken,
+	})
+	assert.NoError(t, err)
+
+	config, err = GetDownstreamGitOpsConfig(clientset, "app", "cluster")
+	assert.NoError(t, err)
+	assert.Equal(t, AuthTypeToken, config.AuthType)
+	assert.Equal(t, "secret-token", config.Password)
+	assert.Equal(t, "8443", config.HTTPPort)
+	assert.Empty(t, config.Username)
+}
+
+func TestGitOpsConfig_CloneURL(t *testing.T) {
+	tests := []struct {
+		name   string
+		config GitOpsConfig
+		want   string
+	}{
+		{
+			name:   "github ssh",
+			config: GitOpsConfig{Provider: "github", RepoURI: "https://github.com/org/repo", AuthType: AuthTypeSSH},
+			want:   "git@github.com:org/repo.git",
+		},
+		{
+			name:   "github token",
+			config: GitOpsConfig{Provider: "github", RepoURI: "https://github.com/org/repo", AuthType: AuthTypeToken},
+			want:   "https://github.com/org/repo.git",
+		},
+		{
+			name:   "gitlab enterprise basic with http port",
+			config: GitOpsConfig{Provider: "gitlab_enterprise", RepoURI: "https://gitlab.example.com/org/repo", Hostname: "gitlab.example.com", HTTPPort: "8443", AuthType: AuthTypeBasic},
+			want:   "https://gitlab.example.com:8443/org/repo.git",
+		},
+		{
+			name:   "bitbucket server token",
+			config: GitOpsConfig{Provider: "bitbucket_server", RepoURI: "https://bitbucket.example.com/projects/PROJ/repos/repo", Hostname: "bitbucket.example.com", AuthType: AuthTypeToken},
+			want:   "https://bitbucket.example.com/scm/PROJ/repo.git",
+		},
+		{
+			name:   "generic",
+			config: GitOpsConfig{Provider: ProviderGeneric, CustomCloneURL: "https://dev.azure.com/org/project/_git/repo", AuthType: AuthTypeToken},
+			want:   "https://dev.azure.com/org/project/_git/repo",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.config.CloneURL()
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestGitOpsConfig_CommitURL(t *testing.T) {
+	config := GitOpsConfig{Provider: "github", RepoURI: "https://github.com/org/repo"}
+	assert.Equal(t, "https://github.com/org/repo/commit/abc123", config.CommitURL("abc123"))
+
+	config = GitOpsConfig{Provider: ProviderGeneric, CommitURLTemplate: "https://gitea.example.com/org/repo/commit/{hash}"}
+	assert.Equal(t, "https://gitea.example.com/org/repo/commit/abc123", config.CommitURL("abc123"))
+
+	config = GitOpsConfig{Provider: ProviderGeneric}
+	assert.Equal(t, "", config.CommitURL("abc123"))
+}
+
 func mockGitOpsConfigMapNotFoundClient() kubernetes.Interface {
 	mockClient := fake.Clientset{}
 	mockClient.AddReactor("get", "configmaps", func(action core.Action) (bool, runtime.Object, error) {
@@ -0,0 +1,286 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
+	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/replicatedhq/kots/pkg/handlers/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/operator"
+	operatorclient "github.com/replicatedhq/kots/pkg/operator/client"
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+	"github.com/replicatedhq/kots/pkg/store"
+)
+
+type ListAgentClustersResponse struct {
+	Clusters []*downstreamtypes.AgentCluster `json:"clusters"`
+}
+
+type CreateAgentClusterRequest struct {
+	Title string `json:"title"`
+}
+
+type CreateAgentClusterResponse struct {
+	ClusterID string `json:"clusterId"`
+	Token     string `json:"token"`
+}
+
+type AssignAppToClusterRequest struct {
+	ClusterID string `json:"clusterId"`
+}
+
+// ListAgentClusters returns the remote clusters that apps can be deployed to by an agent
+func (h *Handler) ListAgentClusters(w http.ResponseWriter, r *http.Request) {
+	clusters, err := store.GetStore().ListAgentClusters()
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to list agent clusters"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, ListAgentClustersResponse{
+		Clusters: clusters,
+	})
+}
+
+// CreateAgentCluster creates a remote cluster and returns the deploy token for its agent.
+// The token is only returned once.
+func (h *Handler) CreateAgentCluster(w http.ResponseWriter, r *http.Request) {
+	request := CreateAgentClusterRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.Error(errors.Wrap(err, "failed to decode request body"))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if strings.TrimSpace(request.Title) == "" {
+		JSON(w, http.StatusBadRequest, types.NewErrorResponse(errors.New("cluster title is required")))
+		return
+	}
+
+	clusterID, token, err := store.GetStore().CreateAgentCluster(request.Title)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to create agent cluster"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, CreateAgentClusterResponse{
+		ClusterID: clusterID,
+		Token:     token,
+	})
+}
+
+// AssignAppToCluster moves the app to a remote cluster, or back to the admin console's cluster.
+// The app's next deploy goes to the new cluster. Resources already deployed to the old cluster are not removed.
+func (h *Handler) AssignAppToCluster(w http.ResponseWriter, r *http.Request) {
+	appSlug := mux.Vars(r)["appSlug"]
+
+	request := AssignAppToClusterRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		logger.Error(errors.Wrap(err, "failed to decode request body"))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	a, err := store.GetStore().GetAppFromSlug(appSlug)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "failed to get app for slug %s", appSlug))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	isKnownCluster := request.ClusterID == operator.MustGetOperator().GetClusterID()
+	if !isKnownCluster {
+		clusters, err := store.GetStore().ListAgentClusters()
+		if err != nil {
+			logger.Error(errors.Wrap(err, "failed to list agent clusters"))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		for _, c := range clusters {
+			if c.ID == request.ClusterID {
+				isKnownCluster = true
+				break
+			}
+		}
+	}
+	if !isKnownCluster {
+		JSON(w, http.StatusNotFound, types.NewErrorResponse(errors.Errorf("cluster %s not found", request.ClusterID)))
+		return
+	}
+
+	if deploys := operator.MustGetOperator().ListDeploys(a.ID); len(deploys) > 0 {
+		JSON(w, http.StatusConflict, types.NewErrorResponse(errors.New("the app cannot be moved while it is being deployed")))
+		return
+	}
+
+	if err := store.GetStore().AssignAppToCluster(a.ID, request.ClusterID); err != nil {
+		logger.Error(errors.Wrapf(err, "failed to assign app %s to cluster %s", a.Slug, request.ClusterID))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, struct{}{})
+}
+
+// GetAgentDesiredState returns the app versions that the calling agent should deploy
+func (h *Handler) GetAgentDesiredState(w http.ResponseWriter, r *http.Request) {
+	clusterID, err := requireAgentClusterID(w, r)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to authenticate agent"))
+		return
+	}
+
+	includeInformers := r.URL.Query().Get("informers") == "true"
+
+	desiredState, err := operator.MustGetOperator().GetAgentDesiredState(clusterID, includeInformers)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "failed to get desired state for cluster %s", clusterID))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, desiredState)
+}
+
+// SetAgentDeployResults records the output of the calling agent applying an app version
+func (h *Handler) SetAgentDeployResults(w http.ResponseWriter, r *http.Request) {
+	clusterID, err := requireAgentClusterID(w, r)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to authenticate agent"))
+		return
+	}
+
+	results := operatortypes.AgentDeployResults{}
+	if err := json.NewDecoder(r.Body).Decode(&results); err != nil {
+		logger.Error(errors.Wrap(err, "failed to decode request body"))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := requireAppInAgentCluster(w, results.AppID, clusterID); err != nil {
+		logger.Error(err)
+		return
+	}
+
+	args := operatortypes.DeployAppArgs{
+		AppID:     results.AppID,
+		ClusterID: clusterID,
+		Sequence:  results.Sequence,
+	}
+	if err := (operatorclient.StoreReporter{}).SetDeployResults(args, results.IsError, results.Output); err != nil {
+		logger.Error(errors.Wrap(err, "failed to set deploy results"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, struct{}{})
+}
+
+// SetAgentDeployStatus records whether the calling agent deployed an app version
+func (h *Handler) SetAgentDeployStatus(w http.ResponseWriter, r *http.Request) {
+	clusterID, err := requireAgentClusterID(w, r)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to authenticate agent"))
+		return
+	}
+
+	status := operatortypes.AgentDeployStatus{}
+	if err := json.NewDecoder(r.Body).Decode(&status); err != nil {
+		logger.Error(errors.Wrap(err, "failed to decode request body"))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := requireAppInAgentCluster(w, status.AppID, clusterID); err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if err := operator.MustGetOperator().SetAgentDeployStatus(clusterID, status); err != nil {
+		logger.Error(errors.Wrap(err, "failed to set deploy status"))
+		JSON(w, http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	JSON(w, http.StatusOK, struct{}{})
+}
+
+// SetAgentAppStatus records the status of an app's resources in the calling agent's cluster
+func (h *Handler) SetAgentAppStatus(w http.ResponseWriter, r *http.Request) {
+	clusterID, err := requireAgentClusterID(w, r)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to authenticate agent"))
+		return
+	}
+
+	appStatus := appstatetypes.AppStatus{}
+	if err := json.NewDecoder(r.Body).Decode(&appStatus); err != nil {
+		logger.Error(errors.Wrap(err, "failed to decode request body"))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := requireAppInAgentCluster(w, appStatus.AppID, clusterID); err != nil {
+		logger.Error(err)
+		return
+	}
+
+	if err := (operatorclient.StoreReporter{}).SetAppStatus(appStatus); err != nil {
+		logger.Error(errors.Wrap(err, "failed to set app status"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, struct{}{})
+}
+
+// requireAgentClusterID returns the id of the remote cluster whose deploy token is in the request's
+// Authorization header, and records that the cluster's agent was seen.
+func requireAgentClusterID(w http.ResponseWriter, r *http.Request) (string, error) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return "", errors.New("authorization header empty")
+	}
+
+	clusterID, err := store.GetStore().GetAgentClusterIDFromToken(token)
+	if store.GetStore().IsNotFound(err) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return "", errors.New("invalid deploy token")
+	} else if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return "", errors.Wrap(err, "failed to get cluster from deploy token")
+	}
+
+	if err := store.GetStore().SetAgentClusterLastSeen(clusterID, time.Now()); err != nil {
+		logger.Error(errors.Wrapf(err, "failed to set last seen time of cluster %s", clusterID))
+	}
+
+	return clusterID, nil
+}
+
+// requireAppInAgentCluster ensures that agents only report on the apps assigned to their cluster
+func requireAppInAgentCluster(w http.ResponseWriter, appID string, clusterID string) error {
+	downstreams, err := store.GetStore().ListDownstreamsForApp(appID)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return errors.Wrapf(err, "failed to list downstreams for app %s", appID)
+	}
+
+	for _, d := range downstreams {
+		if d.ClusterID == clusterID {
+			return nil
+		}
+	}
+
+	w.WriteHeader(http.StatusForbidden)
+	return errors.Errorf("app %s is not assigned to cluster %s", appID, clusterID)
+}
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/apitoken"
+	apitokentypes "github.com/replicatedhq/kots/pkg/apitoken/types"
+	"github.com/replicatedhq/kots/pkg/handlers/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/session"
+	"github.com/replicatedhq/kots/pkg/store"
+	"github.com/replicatedhq/kots/pkg/user"
+)
+
+type ListAPITokensResponse struct {
+	Tokens []*apitokentypes.APIToken `json:"tokens"`
+}
+
+type CreateAPITokenRequest struct {
+	Name      string     `json:"name"`
+	Roles     []string   `json:"roles"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type CreateAPITokenResponse struct {
+	APIToken *apitokentypes.APIToken `json:"apiToken"`
+	// Token is the plaintext token. It is only returned when the token is created.
+	Token string `json:"token"`
+}
+
+func (h *Handler) ListAPITokens(w http.ResponseWriter, r *http.Request) {
+	tokens, err := store.GetStore().ListAPITokens()
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to list api tokens"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, ListAPITokensResponse{Tokens: tokens})
+}
+
+func (h *Handler) CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	createAPITokenRequest := CreateAPITokenRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&createAPITokenRequest); err != nil {
+		logger.Error(errors.Wrap(err, "failed to decode request body"))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if createAPITokenRequest.ExpiresAt != nil && createAPITokenRequest.ExpiresAt.Before(time.Now()) {
+		JSON(w, http.StatusBadRequest, types.NewErrorResponse(errors.New("expiresAt must be in the future")))
+		return
+	}
+
+	sess := session.ContextGetSession(r)
+	if sess == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	// a token cannot be granted roles that the session creating it does not have
+	for _, roleID := range createAPITokenRequest.Roles {
+		if !sessionHasRole(sess.Roles, roleID) {
+			JSON(w, http.StatusForbidden, types.NewErrorResponse(errors.Errorf("cannot grant role %q", roleID)))
+			return
+		}
+	}
+
+	createdBy := sess.UserID
+	if createdBy == "" {
+		createdBy = sess.ID
+	}
+
+	token, plaintext, err := apitoken.Create(createAPITokenRequest.Name, createAPITokenRequest.Roles, createdBy, createAPITokenRequest.ExpiresAt)
+	if err != nil {
+		switch errors.Cause(err) {
+		case apitoken.ErrNameRequired, user.ErrNoRoles, user.ErrUnknownRole:
+			JSON(w, http.StatusBadRequest, types.NewErrorResponse(err))
+			return
+		}
+		logger.Error(errors.Wrap(err, "failed to create api token"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusCreated, CreateAPITokenResponse{
+		APIToken: token,
+		Token:    plaintext,
+	})
+}
+
+func (h *Handler) RevokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	tokenID := mux.Vars(r)["tokenId"]
+
+	if err := store.GetStore().DeleteAPIToken(tokenID); err != nil {
+		if store.GetStore().IsNotFound(err) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		logger.Error(errors.Wrap(err, "failed to revoke api token"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func sessionHasRole(sessionRoles []string, roleID string) bool {
+	for _, sessionRole := range sessionRoles {
+		if sessionRole == roleID {
+			return true
+		}
+	}
+	return false
+}
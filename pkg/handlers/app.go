@@ -11,6 +11,7 @@ import (
 	"github.com/pkg/errors"
 	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
 	"github.com/replicatedhq/kots/pkg/api/handlers/types"
+	"github.com/replicatedhq/kots/pkg/app"
 	apptypes "github.com/replicatedhq/kots/pkg/app/types"
 	"github.com/replicatedhq/kots/pkg/embeddedcluster"
 	"github.com/replicatedhq/kots/pkg/gitops"
@@ -26,6 +27,7 @@ import (
 	storetypes "github.com/replicatedhq/kots/pkg/store/types"
 	"github.com/replicatedhq/kots/pkg/tasks"
 	"github.com/replicatedhq/kots/pkg/update"
+	"github.com/replicatedhq/kots/pkg/updatechecker"
 	"github.com/replicatedhq/kots/pkg/util"
 	"github.com/replicatedhq/kots/pkg/version"
 	kotsv1beta1 "github.com/replicatedhq/kotskinds/apis/kots/v1beta1"
@@ -51,10 +53,10 @@ func (h *Handler) GetPendingApp(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	defaultRoles := rbac.DefaultRoles() // TODO (ethan): this should be set in the handler
+	roles := rbac.Roles() // TODO (ethan): this should be set in the handler
 
 	if sess.HasRBAC { // handle pre-rbac sessions
-		allow, err := rbac.CheckAccess(r.Context(), defaultRoles, "read", fmt.Sprintf("app.%s", papp.Slug), sess.Roles)
+		allow, err := rbac.CheckAccess(r.Context(), roles, "read", fmt.Sprintf("app.%s", papp.Slug), sess.Roles)
 		if err != nil {
 			logger.Error(errors.Wrapf(err, "failed to check access for pending app %s", papp.Slug))
 			w.WriteHeader(http.StatusInternalServerError)
@@ -101,11 +103,11 @@ func (h *Handler) ListApps(w http.ResponseWriter, r *http.Request) {
 	}
 
 	responseApps := []types.ResponseApp{}
-	defaultRoles := rbac.DefaultRoles() // TODO (ethan): this should be set in the handler
+	roles := rbac.Roles() // TODO (ethan): this should be set in the handler
 
 	for _, a := range apps {
 		if sess.HasRBAC { // handle pre-rbac sessions
-			allow, err := rbac.CheckAccess(r.Context(), defaultRoles, "read", fmt.Sprintf("app.%s", a.Slug), sess.Roles)
+			allow, err := rbac.CheckAccess(r.Context(), roles, "read", fmt.Sprintf("app.%s", a.Slug), sess.Roles)
 			if err != nil {
 				logger.Error(errors.Wrapf(err, "failed to check access for app %s", a.Slug))
 				w.WriteHeader(http.StatusInternalServerError)
@@ -259,18 +261,22 @@ func responseAppFromApp(ctx context.Context, a *apptypes.App, kcb kubeclient.Kub
 	responseGitOps := types.ResponseGitOps{}
 	if downstreamGitOps != nil {
 		responseGitOps = types.ResponseGitOps{
-			Enabled:     true,
-			Provider:    downstreamGitOps.Provider,
-			Uri:         downstreamGitOps.RepoURI,
-			Hostname:    downstreamGitOps.Hostname,
-			HTTPPort:    downstreamGitOps.HTTPPort,
-			SSHPort:     downstreamGitOps.SSHPort,
-			Path:        downstreamGitOps.Path,
-			Branch:      downstreamGitOps.Branch,
-			Format:      downstreamGitOps.Format,
-			Action:      downstreamGitOps.Action,
-			DeployKey:   downstreamGitOps.PublicKey,
-			IsConnected: downstreamGitOps.IsConnected,
+			Enabled:           true,
+			Provider:          downstreamGitOps.Provider,
+			Uri:               downstreamGitOps.RepoURI,
+			Hostname:          downstreamGitOps.Hostname,
+			HTTPPort:          downstreamGitOps.HTTPPort,
+			SSHPort:           downstreamGitOps.SSHPort,
+			Path:              downstreamGitOps.Path,
+			Branch:            downstreamGitOps.Branch,
+			Format:            downstreamGitOps.Format,
+			Action:            downstreamGitOps.Action,
+			DeployKey:         downstreamGitOps.PublicKey,
+			AuthType:          downstreamGitOps.AuthType,
+			Username:          downstreamGitOps.Username,
+			CloneURL:          downstreamGitOps.CustomCloneURL,
+			CommitURLTemplate: downstreamGitOps.CommitURLTemplate,
+			IsConnected:       downstreamGitOps.IsConnected,
 		}
 	}
 
@@ -318,6 +324,7 @@ func responseAppFromApp(ctx context.Context, a *apptypes.App, kcb kubeclient.Kub
 		IsConfigurable:                    a.IsConfigurable,
 		UpdateCheckerSpec:                 a.UpdateCheckerSpec,
 		AutoDeploy:                        a.AutoDeploy,
+		ScheduledDeploy:                   a.ScheduledDeploy,
 		AppState:                          appState,
 		IsGitOpsSupported:                 isGitopsSupported,
 		IsIdentityServiceSupported:        license.IsIdentityServiceSupported(),
@@ -399,6 +406,20 @@ func (h *Handler) GetAppVersionHistory(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	soakDuration, err := app.ParseSoakDuration(foundApp.SoakDuration)
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse soak duration")
+		logger.Error(err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	for _, v := range history.VersionHistory {
+		// only versions that have never been deployed are waiting on the soak time
+		if v.DeployedAt == nil {
+			v.EligibleAt = updatechecker.GetEligibleAt(v, soakDuration)
+		}
+	}
+
 	response := GetAppVersionHistoryResponse{
 		DownstreamVersionHistory: *history,
 	}
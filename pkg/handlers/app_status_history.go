@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/replicatedhq/kots/pkg/handlers/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/store"
+)
+
+// defaultAppStatusHistoryRange is the time range of the app status history when no start is requested
+const defaultAppStatusHistoryRange = 7 * 24 * time.Hour
+
+type GetAppStatusHistoryResponse struct {
+	appstatetypes.AppStatusHistory `json:",inline"`
+}
+
+// GetAppStatusHistory returns the state transitions of the app and its resources between the start and end
+// query params, and how long they spent in each state. The range defaults to the last 7 days.
+func (h *Handler) GetAppStatusHistory(w http.ResponseWriter, r *http.Request) {
+	end := time.Now()
+	if val := r.URL.Query().Get("end"); val != "" {
+		t, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			JSON(w, http.StatusBadRequest, types.NewErrorResponse(errors.Wrap(err, "failed to parse end")))
+			return
+		}
+		end = t
+	}
+
+	start := end.Add(-defaultAppStatusHistoryRange)
+	if val := r.URL.Query().Get("start"); val != "" {
+		t, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			JSON(w, http.StatusBadRequest, types.NewErrorResponse(errors.Wrap(err, "failed to parse start")))
+			return
+		}
+		start = t
+	}
+
+	if !start.Before(end) {
+		JSON(w, http.StatusBadRequest, types.NewErrorResponse(errors.New("start must be before end")))
+		return
+	}
+
+	appSlug := mux.Vars(r)["appSlug"]
+	a, err := store.GetStore().GetAppFromSlug(appSlug)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "failed to get app for slug %s", appSlug))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	transitions, err := store.GetStore().ListAppStatusTransitions(a.ID, start, end)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "failed to list status transitions for app %s", a.Slug))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, GetAppStatusHistoryResponse{
+		AppStatusHistory: appstatetypes.CalculateAppStatusHistory(a.ID, transitions, start, end),
+	})
+}
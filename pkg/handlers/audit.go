@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+	audittypes "github.com/replicatedhq/kots/pkg/audit/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/store"
+)
+
+type GetAuditLogResponse struct {
+	audittypes.EventList `json:",inline"`
+}
+
+func (h *Handler) GetAuditLog(w http.ResponseWriter, r *http.Request) {
+	opts := audittypes.ListOptions{
+		AppSlug:     r.URL.Query().Get("appSlug"),
+		CurrentPage: 0,
+		PageSize:    50,
+	}
+
+	if val := r.URL.Query().Get("since"); val != "" {
+		since, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			logger.Error(errors.Wrap(err, "failed to parse since"))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		opts.Since = &since
+	}
+	if val := r.URL.Query().Get("pageSize"); val != "" {
+		ps, err := strconv.Atoi(val)
+		if err != nil || ps < 0 {
+			logger.Error(errors.Errorf("failed to parse page size %q", val))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		opts.PageSize = ps
+	}
+	if val := r.URL.Query().Get("currentPage"); val != "" {
+		cp, err := strconv.Atoi(val)
+		if err != nil || cp < 0 {
+			logger.Error(errors.Errorf("failed to parse current page %q", val))
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		opts.CurrentPage = cp
+	}
+
+	eventList, err := store.GetStore().ListAuditEvents(opts)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to list audit events"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, GetAuditLogResponse{EventList: *eventList})
+}
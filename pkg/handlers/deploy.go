@@ -121,6 +121,20 @@ func (h *Handler) DeployAppVersion(w http.ResponseWriter, r *http.Request) {
 		JSON(w, http.StatusInternalServerError, deployAppVersionResponse)
 		return
 	}
+	for _, v := range versions.AllVersions {
+		if v.Sequence != sequence || !v.IsBlocked {
+			continue
+		}
+		errMsg := fmt.Sprintf("not deploying version %s because it's blocked: %s", v.VersionLabel, v.BlockedReason)
+		if v.IsRequired {
+			errMsg += ". It is a required version and cannot be skipped, so later versions cannot be deployed until the block is removed"
+		}
+		logger.Error(errors.New(errMsg))
+		deployAppVersionResponse.Error = errMsg
+		JSON(w, http.StatusBadRequest, deployAppVersionResponse)
+		return
+	}
+
 	for _, v := range versions.PastVersions {
 		if int64(sequence) == v.Sequence {
 			// a past version is being deployed/rolled back to, disable automatic deployments so that it doesn't undo this action later
@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/handlers/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/operator"
+	"github.com/replicatedhq/kots/pkg/store"
+)
+
+// GetAppVersionDeployPlan returns the changes that deploying the sequence would make to the cluster
+func (h *Handler) GetAppVersionDeployPlan(w http.ResponseWriter, r *http.Request) {
+	appSlug := mux.Vars(r)["appSlug"]
+
+	sequence, err := strconv.ParseInt(mux.Vars(r)["sequence"], 10, 64)
+	if err != nil {
+		JSON(w, http.StatusBadRequest, types.NewErrorResponse(errors.Wrap(err, "failed to parse sequence number")))
+		return
+	}
+
+	a, err := store.GetStore().GetAppFromSlug(appSlug)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "failed to get app for slug %s", appSlug))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	plan, err := operator.MustGetOperator().PlanApp(a.ID, sequence)
+	if err != nil {
+		err = errors.Wrapf(err, "failed to plan deploy of sequence %d", sequence)
+		logger.Error(err)
+		JSON(w, http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	JSON(w, http.StatusOK, plan)
+}
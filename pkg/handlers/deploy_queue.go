@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/handlers/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/operator"
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+	"github.com/replicatedhq/kots/pkg/store"
+	"github.com/replicatedhq/kots/pkg/tasks"
+)
+
+type ListAppDeploysResponse struct {
+	Deploys []operatortypes.QueuedDeploy `json:"deploys"`
+}
+
+type CancelAppDeployResponse struct {
+	Deploy *operatortypes.QueuedDeploy `json:"deploy"`
+}
+
+type GetAppDeployStatusResponse struct {
+	CurrentMessage string `json:"currentMessage"`
+	Status         string `json:"status"`
+}
+
+// ListAppDeploys returns the running and queued deploys of the app
+func (h *Handler) ListAppDeploys(w http.ResponseWriter, r *http.Request) {
+	appSlug := mux.Vars(r)["appSlug"]
+
+	a, err := store.GetStore().GetAppFromSlug(appSlug)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "failed to get app for slug %s", appSlug))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, ListAppDeploysResponse{
+		Deploys: operator.MustGetOperator().ListDeploys(a.ID),
+	})
+}
+
+// CancelAppDeploy cancels a queued deploy, or requests cancellation of the running deploy
+func (h *Handler) CancelAppDeploy(w http.ResponseWriter, r *http.Request) {
+	appSlug := mux.Vars(r)["appSlug"]
+	deployID := mux.Vars(r)["deployId"]
+
+	a, err := store.GetStore().GetAppFromSlug(appSlug)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "failed to get app for slug %s", appSlug))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	cancelled, err := operator.MustGetOperator().CancelDeploy(a.ID, deployID)
+	if errors.Is(err, operator.ErrDeployNotFound) {
+		JSON(w, http.StatusNotFound, types.NewErrorResponse(errors.Errorf("deploy %s is not queued or running", deployID)))
+		return
+	} else if err != nil {
+		err = errors.Wrapf(err, "failed to cancel deploy %s", deployID)
+		logger.Error(err)
+		JSON(w, http.StatusInternalServerError, types.NewErrorResponse(err))
+		return
+	}
+
+	JSON(w, http.StatusOK, CancelAppDeployResponse{
+		Deploy: cancelled,
+	})
+}
+
+// GetAppDeployStatus returns the status of the app's deploy queue task
+func (h *Handler) GetAppDeployStatus(w http.ResponseWriter, r *http.Request) {
+	appSlug := mux.Vars(r)["appSlug"]
+
+	a, err := store.GetStore().GetAppFromSlug(appSlug)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "failed to get app for slug %s", appSlug))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	status, message, err := tasks.GetTaskStatus(operator.GetDeployTaskID(a.ID))
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get deploy task status"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, GetAppDeployStatusResponse{
+		CurrentMessage: message,
+		Status:         status,
+	})
+}
@@ -5,12 +5,14 @@ import (
 	"strconv"
 
 	"github.com/gorilla/mux"
+	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
 	"github.com/replicatedhq/kots/pkg/logger"
 	"github.com/replicatedhq/kots/pkg/store"
 )
 
 type GetDownstreamOutputResponse struct {
-	Logs DownstreamLogs `json:"logs"`
+	Logs    DownstreamLogs                 `json:"logs"`
+	Results *downstreamtypes.DeployResults `json:"results,omitempty"`
 }
 type DownstreamLogs struct {
 	DryrunStdout string `json:"dryrunStdout"`
@@ -56,7 +58,8 @@ func (h *Handler) GetDownstreamOutput(w http.ResponseWriter, r *http.Request) {
 		RenderError:  output.RenderError,
 	}
 	getDownstreamOutputResponse := GetDownstreamOutputResponse{
-		Logs: downstreamLogs,
+		Logs:    downstreamLogs,
+		Results: output.Results,
 	}
 
 	JSON(w, http.StatusOK, getDownstreamOutputResponse)
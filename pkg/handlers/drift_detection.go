@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/app"
+	apptypes "github.com/replicatedhq/kots/pkg/app/types"
+	"github.com/replicatedhq/kots/pkg/handlers/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/store"
+)
+
+type SetDriftDetectionRequest struct {
+	// DriftDetection configures how often drift is checked for and whether it is corrected. A nil value disables it.
+	DriftDetection *apptypes.DriftDetection `json:"driftDetection"`
+}
+
+type GetDriftDetectionResponse struct {
+	DriftDetection *apptypes.DriftDetection `json:"driftDetection"`
+}
+
+func (h *Handler) SetDriftDetection(w http.ResponseWriter, r *http.Request) {
+	setDriftDetectionRequest := SetDriftDetectionRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&setDriftDetectionRequest); err != nil {
+		logger.Error(errors.Wrap(err, "failed to decode request body"))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if _, err := app.ParseDriftDetection(setDriftDetectionRequest.DriftDetection); err != nil {
+		JSON(w, http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	foundApp, err := store.GetStore().GetAppFromSlug(mux.Vars(r)["appSlug"])
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get app from slug"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := store.GetStore().SetDriftDetection(foundApp.ID, setDriftDetectionRequest.DriftDetection); err != nil {
+		logger.Error(errors.Wrap(err, "failed to set drift detection"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetDriftDetection(w http.ResponseWriter, r *http.Request) {
+	foundApp, err := store.GetStore().GetAppFromSlug(mux.Vars(r)["appSlug"])
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get app from slug"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, GetDriftDetectionResponse{DriftDetection: foundApp.DriftDetection})
+}
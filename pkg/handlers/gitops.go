@@ -31,11 +31,16 @@ type CreateGitOpsRequest struct {
 	GitOpsInput CreateGitOpsInput `json:"gitOpsInput"`
 }
 type CreateGitOpsInput struct {
-	Provider string `json:"provider"`
-	URI      string `json:"uri"`
-	Hostname string `json:"hostname"`
-	HTTPPort string `json:"httpPort"`
-	SSHPort  string `json:"sshPort"`
+	Provider          string `json:"provider"`
+	URI               string `json:"uri"`
+	Hostname          string `json:"hostname"`
+	HTTPPort          string `json:"httpPort"`
+	SSHPort           string `json:"sshPort"`
+	AuthType          string `json:"authType"`
+	Username          string `json:"username"`
+	Password          string `json:"password"`
+	CloneURL          string `json:"cloneUrl"`
+	CommitURLTemplate string `json:"commitUrlTemplate"`
 }
 
 func (h *Handler) UpdateAppGitOps(w http.ResponseWriter, r *http.Request) {
@@ -57,6 +62,11 @@ func (h *Handler) UpdateAppGitOps(w http.ResponseWriter, r *http.Request) {
 	}
 
 	gitOpsInput := updateAppGitOpsRequest.GitOpsInput
+	if !gitops.IsSupportedFormat(gitOpsInput.Format) {
+		JSON(w, http.StatusBadRequest, types.NewErrorResponse(errors.Errorf("unsupported gitops format %q", gitOpsInput.Format)))
+		return
+	}
+
 	if err := gitops.UpdateDownstreamGitOps(a.ID, clusterID, gitOpsInput.URI, gitOpsInput.Branch, gitOpsInput.Path, gitOpsInput.Format, gitOpsInput.Action); err != nil {
 		logger.Error(err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -147,8 +157,8 @@ func (h *Handler) InitGitOpsConnection(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if downstreamGitOps.Format != "single" {
-		logger.Error(errors.New("unsupported gitops format"))
+	if !gitops.IsSupportedFormat(downstreamGitOps.Format) {
+		logger.Error(errors.Errorf("unsupported gitops format %q", downstreamGitOps.Format))
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
@@ -311,7 +321,19 @@ func (h *Handler) CreateGitOps(w http.ResponseWriter, r *http.Request) {
 	}
 
 	gitOpsInput := createGitOpsRequest.GitOpsInput
-	if err := gitops.CreateGitOps(gitOpsInput.Provider, gitOpsInput.URI, gitOpsInput.Hostname, gitOpsInput.HTTPPort, gitOpsInput.SSHPort); err != nil {
+	createGitOpsOptions := gitops.CreateGitOpsOptions{
+		Provider:          gitOpsInput.Provider,
+		RepoURI:           gitOpsInput.URI,
+		Hostname:          gitOpsInput.Hostname,
+		HTTPPort:          gitOpsInput.HTTPPort,
+		SSHPort:           gitOpsInput.SSHPort,
+		AuthType:          gitOpsInput.AuthType,
+		Username:          gitOpsInput.Username,
+		Password:          gitOpsInput.Password,
+		CloneURL:          gitOpsInput.CloneURL,
+		CommitURLTemplate: gitOpsInput.CommitURLTemplate,
+	}
+	if err := gitops.CreateGitOps(createGitOpsOptions); err != nil {
 		logger.Error(err)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
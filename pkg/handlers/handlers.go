@@ -113,6 +113,8 @@ func RegisterSessionAuthRoutes(r *mux.Router, kotsStore store.Store, handler KOT
 		HandlerFunc(middleware.EnforceAccess(policy.AppRead, handler.GetApp))
 	r.Name("GetAppStatus").Path("/api/v1/app/{appSlug}/status").Methods("GET").
 		HandlerFunc(middleware.EnforceAccess(policy.AppStatusRead, handler.GetAppStatus))
+	r.Name("GetAppStatusHistory").Path("/api/v1/app/{appSlug}/status/history").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.AppStatusRead, handler.GetAppStatusHistory))
 	r.Name("GetAppVersionHistory").Path("/api/v1/app/{appSlug}/versions").Methods("GET").
 		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamRead, handler.GetAppVersionHistory))
 	r.Name("GetLatestDeployableVersion").Path("/api/v1/app/{appSlug}/next-app-version").Methods("GET").
@@ -170,6 +172,14 @@ func RegisterSessionAuthRoutes(r *mux.Router, kotsStore store.Store, handler KOT
 		HandlerFunc(middleware.EnforceAccess(policy.AppRead, handler.GetAppVersionDownloadStatus)) // NOTE: appSlug is unused
 	r.Name("DeployAppVersion").Path("/api/v1/app/{appSlug}/sequence/{sequence}/deploy").Methods("POST").
 		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamWrite, handler.DeployAppVersion))
+	r.Name("GetAppVersionDeployPlan").Path("/api/v1/app/{appSlug}/sequence/{sequence}/plan").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamRead, handler.GetAppVersionDeployPlan))
+	r.Name("ListAppDeploys").Path("/api/v1/app/{appSlug}/deploys").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamRead, handler.ListAppDeploys))
+	r.Name("CancelAppDeploy").Path("/api/v1/app/{appSlug}/deploys/{deployId}/cancel").Methods("POST").
+		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamWrite, handler.CancelAppDeploy))
+	r.Name("GetAppDeployStatus").Path("/api/v1/app/{appSlug}/task/deploy").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamRead, handler.GetAppDeployStatus))
 	r.Name("RedeployAppVersion").Path("/api/v1/app/{appSlug}/sequence/{sequence}/redeploy").Methods("POST").
 		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamWrite, handler.RedeployAppVersion))
 	r.Name("GetAppRenderedContents").Path("/api/v1/app/{appSlug}/sequence/{sequence}/renderedcontents").Methods("GET").
@@ -225,6 +235,28 @@ func RegisterSessionAuthRoutes(r *mux.Router, kotsStore store.Store, handler KOT
 		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamWrite, handler.SetAutomaticUpdatesConfig))
 	r.Name("GetAutomaticUpdatesConfig").Path("/api/v1/app/{appSlug}/automaticupdates").Methods("GET").
 		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamWrite, handler.GetAutomaticUpdatesConfig))
+	r.Name("SetHealthGate").Path("/api/v1/app/{appSlug}/health-gate").Methods("PUT").
+		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamWrite, handler.SetHealthGate))
+	r.Name("GetHealthGate").Path("/api/v1/app/{appSlug}/health-gate").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamRead, handler.GetHealthGate))
+	r.Name("SetDriftDetection").Path("/api/v1/app/{appSlug}/drift-detection").Methods("PUT").
+		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamWrite, handler.SetDriftDetection))
+	r.Name("GetDriftDetection").Path("/api/v1/app/{appSlug}/drift-detection").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamRead, handler.GetDriftDetection))
+	r.Name("ListVersionBlocks").Path("/api/v1/app/{appSlug}/version-blocks").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamRead, handler.ListVersionBlocks))
+	r.Name("CreateVersionBlock").Path("/api/v1/app/{appSlug}/version-blocks").Methods("POST").
+		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamWrite, handler.CreateVersionBlock))
+	r.Name("DeleteVersionBlock").Path("/api/v1/app/{appSlug}/version-blocks/{blockId}").Methods("DELETE").
+		HandlerFunc(middleware.EnforceAccess(policy.AppDownstreamWrite, handler.DeleteVersionBlock))
+	r.Name("ListNotificationSinks").Path("/api/v1/app/{appSlug}/notifications/sinks").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.AppNotificationsRead, handler.ListNotificationSinks))
+	r.Name("CreateNotificationSink").Path("/api/v1/app/{appSlug}/notifications/sinks").Methods("POST").
+		HandlerFunc(middleware.EnforceAccess(policy.AppNotificationsWrite, handler.CreateNotificationSink))
+	r.Name("DeleteNotificationSink").Path("/api/v1/app/{appSlug}/notifications/sinks/{sinkId}").Methods("DELETE").
+		HandlerFunc(middleware.EnforceAccess(policy.AppNotificationsWrite, handler.DeleteNotificationSink))
+	r.Name("ListNotificationDeliveries").Path("/api/v1/app/{appSlug}/notifications/deliveries").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.AppNotificationsRead, handler.ListNotificationDeliveries))
 	r.Name("RemoveApp").Path("/api/v1/app/{appSlug}/remove").Methods("POST").
 		HandlerFunc(middleware.EnforceAccess(policy.AppUpdate, handler.RemoveApp))
 
@@ -312,6 +344,14 @@ func RegisterSessionAuthRoutes(r *mux.Router, kotsStore store.Store, handler KOT
 	r.Name("GetEmbeddedClusterRoles").Path("/api/v1/embedded-cluster/roles").Methods("GET").
 		HandlerFunc(middleware.EnforceAccess(policy.ClusterRead, handler.GetEmbeddedClusterRoles))
 
+	// Remote clusters
+	r.Name("ListAgentClusters").Path("/api/v1/agent/clusters").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.ClusterRead, handler.ListAgentClusters))
+	r.Name("CreateAgentCluster").Path("/api/v1/agent/clusters").Methods("POST").
+		HandlerFunc(middleware.EnforceAccess(policy.ClusterWrite, handler.CreateAgentCluster))
+	r.Name("AssignAppToCluster").Path("/api/v1/app/{appSlug}/cluster").Methods("PUT").
+		HandlerFunc(middleware.EnforceAccess(policy.ClusterWrite, handler.AssignAppToCluster))
+
 	// Prometheus
 	r.Name("SetPrometheusAddress").Path("/api/v1/prometheus").Methods("POST").
 		HandlerFunc(middleware.EnforceAccess(policy.PrometheussettingsWrite, handler.SetPrometheusAddress))
@@ -334,6 +374,52 @@ func RegisterSessionAuthRoutes(r *mux.Router, kotsStore store.Store, handler KOT
 	r.Name("ChangePassword").Path("/api/v1/password/change").Methods("PUT").
 		HandlerFunc(middleware.EnforceAccess(policy.PasswordChange, handler.ChangePassword))
 
+	// Users
+	r.Name("ListUsers").Path("/api/v1/users").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.UserRead, handler.ListUsers))
+	r.Name("CreateUser").Path("/api/v1/users").Methods("POST").
+		HandlerFunc(middleware.EnforceAccess(policy.UserWrite, handler.CreateUser))
+	r.Name("UpdateUser").Path("/api/v1/user/{userId}").Methods("PUT").
+		HandlerFunc(middleware.EnforceAccess(policy.UserWrite, handler.UpdateUser))
+	r.Name("DeleteUser").Path("/api/v1/user/{userId}").Methods("DELETE").
+		HandlerFunc(middleware.EnforceAccess(policy.UserWrite, handler.DeleteUser))
+	r.Name("ResetUserPassword").Path("/api/v1/user/{userId}/password").Methods("PUT").
+		HandlerFunc(middleware.EnforceAccess(policy.UserWrite, handler.ResetUserPassword))
+
+	// API tokens
+	r.Name("ListAPITokens").Path("/api/v1/tokens").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.TokenRead, handler.ListAPITokens))
+	r.Name("CreateAPIToken").Path("/api/v1/tokens").Methods("POST").
+		HandlerFunc(middleware.EnforceAccess(policy.TokenWrite, handler.CreateAPIToken))
+	r.Name("RevokeAPIToken").Path("/api/v1/tokens/{tokenId}").Methods("DELETE").
+		HandlerFunc(middleware.EnforceAccess(policy.TokenWrite, handler.RevokeAPIToken))
+
+	// RBAC
+	r.Name("ListRBACRoles").Path("/api/v1/rbac/roles").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.RBACRead, handler.ListRBACRoles))
+
+	// Audit log
+	r.Name("GetAuditLog").Path("/api/v1/audit").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.AuditRead, handler.GetAuditLog))
+
+	// Sessions
+	r.Name("ListSessions").Path("/api/v1/sessions").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.SessionRead, handler.ListSessions))
+	r.Name("RevokeAllSessions").Path("/api/v1/sessions").Methods("DELETE").
+		HandlerFunc(middleware.EnforceAccess(policy.SessionWrite, handler.RevokeAllSessions))
+	r.Name("RevokeSession").Path("/api/v1/sessions/{sessionId}").Methods("DELETE").
+		HandlerFunc(middleware.EnforceAccess(policy.SessionWrite, handler.RevokeSession))
+
+	// Two-factor authentication
+	r.Name("GetTwoFactorStatus").Path("/api/v1/2fa").Methods("GET").
+		HandlerFunc(middleware.EnforceAccess(policy.TwoFactorRead, handler.GetTwoFactorStatus))
+	r.Name("EnrollTwoFactor").Path("/api/v1/2fa/enroll").Methods("POST").
+		HandlerFunc(middleware.EnforceAccess(policy.TwoFactorWrite, handler.EnrollTwoFactor))
+	r.Name("ConfirmTwoFactor").Path("/api/v1/2fa/confirm").Methods("POST").
+		HandlerFunc(middleware.EnforceAccess(policy.TwoFactorWrite, handler.ConfirmTwoFactor))
+	r.Name("DisableTwoFactor").Path("/api/v1/2fa").Methods("DELETE").
+		HandlerFunc(middleware.EnforceAccess(policy.TwoFactorWrite, handler.DisableTwoFactor))
+
 	// Upgrade service
 	r.Name("StartUpgradeService").Path("/api/v1/app/{appSlug}/start-upgrade-service").Methods("POST").
 		HandlerFunc(middleware.EnforceAccess(policy.AppUpdate, handler.StartUpgradeService))
@@ -365,6 +451,12 @@ func RegisterTokenAuthRoutes(handler *Handler, debugRouter *mux.Router, loggingR
 	loggingRouter.Path("/api/v1/download").Methods("GET").HandlerFunc(handler.DownloadApp)
 	loggingRouter.Path("/api/v1/airgap/install").Methods("POST").HandlerFunc(handler.UploadInitialAirgapApp)
 	loggingRouter.Path("/api/v1/branding/install").Methods("POST").HandlerFunc(handler.UploadInitialBranding)
+
+	// These routes are called by the agents of remote clusters, and use the cluster's deploy token
+	loggingRouter.Path("/api/v1/agent/desired-state").Methods("GET").HandlerFunc(handler.GetAgentDesiredState)
+	loggingRouter.Path("/api/v1/agent/deploy/results").Methods("PUT").HandlerFunc(handler.SetAgentDeployResults)
+	loggingRouter.Path("/api/v1/agent/deploy/status").Methods("PUT").HandlerFunc(handler.SetAgentDeployStatus)
+	debugRouter.Path("/api/v1/agent/app-status").Methods("PUT").HandlerFunc(handler.SetAgentAppStatus)
 }
 
 func RegisterUnauthenticatedRoutes(handler *Handler, kotsStore store.Store, debugRouter *mux.Router, loggingRouter *mux.Router) {
@@ -372,8 +464,10 @@ func RegisterUnauthenticatedRoutes(handler *Handler, kotsStore store.Store, debu
 	// if the route does not need to be accessed from outside the cluster, it should be blocked in kurl-proxy
 
 	debugRouter.HandleFunc("/healthz", handler.Healthz)
+	debugRouter.Path("/metrics").Methods("GET").HandlerFunc(handler.GetPrometheusMetrics) // this route uses its own auth
 	loggingRouter.HandleFunc("/api/v1/login", handler.Login)
 	loggingRouter.HandleFunc("/api/v1/login/info", handler.GetLoginInfo)
+	loggingRouter.Path("/api/v1/login/2fa").Methods("POST").HandlerFunc(handler.LoginTwoFactor)
 	loggingRouter.HandleFunc("/api/v1/logout", handler.Logout) // this route uses its own auth
 	loggingRouter.Path("/api/v1/metadata").Methods("GET").HandlerFunc(GetMetadataHandler(GetMetaDataConfig, kotsStore))
 
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/app"
+	apptypes "github.com/replicatedhq/kots/pkg/app/types"
+	"github.com/replicatedhq/kots/pkg/handlers/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/store"
+)
+
+type SetHealthGateRequest struct {
+	// HealthGate enables automatic rollbacks when the app does not become healthy after a deploy. A nil value disables them.
+	HealthGate *apptypes.HealthGate `json:"healthGate"`
+}
+
+type GetHealthGateResponse struct {
+	HealthGate *apptypes.HealthGate `json:"healthGate"`
+}
+
+func (h *Handler) SetHealthGate(w http.ResponseWriter, r *http.Request) {
+	setHealthGateRequest := SetHealthGateRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&setHealthGateRequest); err != nil {
+		logger.Error(errors.Wrap(err, "failed to decode request body"))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if _, _, err := app.ParseHealthGate(setHealthGateRequest.HealthGate); err != nil {
+		JSON(w, http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	foundApp, err := store.GetStore().GetAppFromSlug(mux.Vars(r)["appSlug"])
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get app from slug"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := store.GetStore().SetHealthGate(foundApp.ID, setHealthGateRequest.HealthGate); err != nil {
+		logger.Error(errors.Wrap(err, "failed to set health gate"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) GetHealthGate(w http.ResponseWriter, r *http.Request) {
+	foundApp, err := store.GetStore().GetAppFromSlug(mux.Vars(r)["appSlug"])
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get app from slug"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, GetHealthGateResponse{HealthGate: foundApp.HealthGate})
+}
@@ -45,6 +45,7 @@ type KOTSHandler interface {
 	ListApps(w http.ResponseWriter, r *http.Request)
 	GetApp(w http.ResponseWriter, r *http.Request)
 	GetAppStatus(w http.ResponseWriter, r *http.Request)
+	GetAppStatusHistory(w http.ResponseWriter, r *http.Request)
 	GetAppVersionHistory(w http.ResponseWriter, r *http.Request)
 	GetLatestDeployableVersion(w http.ResponseWriter, r *http.Request)
 	GetUpdateDownloadStatus(w http.ResponseWriter, r *http.Request) // NOTE: appSlug is unused
@@ -77,6 +78,10 @@ type KOTSHandler interface {
 	UpstreamUpdate(w http.ResponseWriter, r *http.Request)
 	GetAppVersionDownloadStatus(w http.ResponseWriter, r *http.Request)
 	DeployAppVersion(w http.ResponseWriter, r *http.Request)
+	GetAppVersionDeployPlan(w http.ResponseWriter, r *http.Request)
+	ListAppDeploys(w http.ResponseWriter, r *http.Request)
+	CancelAppDeploy(w http.ResponseWriter, r *http.Request)
+	GetAppDeployStatus(w http.ResponseWriter, r *http.Request)
 	RedeployAppVersion(w http.ResponseWriter, r *http.Request)
 	GetAppRenderedContents(w http.ResponseWriter, r *http.Request)
 	GetAppContents(w http.ResponseWriter, r *http.Request)
@@ -105,6 +110,17 @@ type KOTSHandler interface {
 	AppUpdateCheck(w http.ResponseWriter, r *http.Request)
 	SetAutomaticUpdatesConfig(w http.ResponseWriter, r *http.Request)
 	GetAutomaticUpdatesConfig(w http.ResponseWriter, r *http.Request)
+	SetHealthGate(w http.ResponseWriter, r *http.Request)
+	GetHealthGate(w http.ResponseWriter, r *http.Request)
+	SetDriftDetection(w http.ResponseWriter, r *http.Request)
+	GetDriftDetection(w http.ResponseWriter, r *http.Request)
+	ListVersionBlocks(w http.ResponseWriter, r *http.Request)
+	CreateVersionBlock(w http.ResponseWriter, r *http.Request)
+	DeleteVersionBlock(w http.ResponseWriter, r *http.Request)
+	ListNotificationSinks(w http.ResponseWriter, r *http.Request)
+	CreateNotificationSink(w http.ResponseWriter, r *http.Request)
+	DeleteNotificationSink(w http.ResponseWriter, r *http.Request)
+	ListNotificationDeliveries(w http.ResponseWriter, r *http.Request)
 	RemoveApp(w http.ResponseWriter, r *http.Request)
 
 	// App snapshot routes
@@ -152,6 +168,11 @@ type KOTSHandler interface {
 	GetEmbeddedClusterNode(w http.ResponseWriter, r *http.Request)
 	GetEmbeddedClusterRoles(w http.ResponseWriter, r *http.Request)
 
+	// Remote clusters
+	ListAgentClusters(w http.ResponseWriter, r *http.Request)
+	CreateAgentCluster(w http.ResponseWriter, r *http.Request)
+	AssignAppToCluster(w http.ResponseWriter, r *http.Request)
+
 	// Prometheus
 	SetPrometheusAddress(w http.ResponseWriter, r *http.Request)
 
@@ -166,6 +187,35 @@ type KOTSHandler interface {
 	// Password change
 	ChangePassword(w http.ResponseWriter, r *http.Request)
 
+	// Users
+	ListUsers(w http.ResponseWriter, r *http.Request)
+	CreateUser(w http.ResponseWriter, r *http.Request)
+	UpdateUser(w http.ResponseWriter, r *http.Request)
+	DeleteUser(w http.ResponseWriter, r *http.Request)
+	ResetUserPassword(w http.ResponseWriter, r *http.Request)
+
+	// API tokens
+	ListAPITokens(w http.ResponseWriter, r *http.Request)
+	CreateAPIToken(w http.ResponseWriter, r *http.Request)
+	RevokeAPIToken(w http.ResponseWriter, r *http.Request)
+
+	// RBAC
+	ListRBACRoles(w http.ResponseWriter, r *http.Request)
+
+	// Audit log
+	GetAuditLog(w http.ResponseWriter, r *http.Request)
+
+	// Sessions
+	ListSessions(w http.ResponseWriter, r *http.Request)
+	RevokeSession(w http.ResponseWriter, r *http.Request)
+	RevokeAllSessions(w http.ResponseWriter, r *http.Request)
+
+	// Two-factor authentication
+	GetTwoFactorStatus(w http.ResponseWriter, r *http.Request)
+	EnrollTwoFactor(w http.ResponseWriter, r *http.Request)
+	ConfirmTwoFactor(w http.ResponseWriter, r *http.Request)
+	DisableTwoFactor(w http.ResponseWriter, r *http.Request)
+
 	// Upgrade service
 	StartUpgradeService(w http.ResponseWriter, r *http.Request)
 	GetUpgradeServiceStatus(w http.ResponseWriter, r *http.Request)
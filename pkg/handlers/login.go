@@ -20,7 +20,9 @@ import (
 	"github.com/replicatedhq/kots/pkg/k8sutil"
 	"github.com/replicatedhq/kots/pkg/logger"
 	"github.com/replicatedhq/kots/pkg/session"
+	sessiontypes "github.com/replicatedhq/kots/pkg/session/types"
 	"github.com/replicatedhq/kots/pkg/store"
+	"github.com/replicatedhq/kots/pkg/twofactor"
 	"github.com/replicatedhq/kots/pkg/user"
 	usertypes "github.com/replicatedhq/kots/pkg/user/types"
 	"github.com/replicatedhq/kots/pkg/util"
@@ -30,11 +32,21 @@ import (
 )
 
 type LoginRequest struct {
+	// Username is the email address of a local user account. When empty, the shared admin password is used.
+	Username string `json:"username,omitempty"`
 	Password string `json:"password"`
 }
 
 type LoginResponse struct {
-	Error string `json:"error,omitempty"`
+	// TwoFactorRequired is set when the password was accepted but a second factor is needed to complete the login
+	TwoFactorRequired bool   `json:"twoFactorRequired,omitempty"`
+	TwoFactorToken    string `json:"twoFactorToken,omitempty"`
+	Error             string `json:"error,omitempty"`
+}
+
+type LoginTwoFactorRequest struct {
+	TwoFactorToken string `json:"twoFactorToken"`
+	Code           string `json:"code"`
 }
 
 type LoginMethod string
@@ -43,7 +55,10 @@ const (
 	PasswordAuth    LoginMethod = "shared-password"
 	IdentityService LoginMethod = "identity-service"
 
-	SessionTimeout = time.Hour * 12
+	SessionTimeout = sessiontypes.SessionTimeout
+
+	// TwoFactorTimeout is how long a user has to enter a second factor after entering their password
+	TwoFactorTimeout = time.Minute * 5
 )
 
 func getRedirectOnErrorURL(redirectURL string, errorMsg string) string {
@@ -79,11 +94,24 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	foundUser, err := user.LogIn(loginRequest.Password)
+	var foundUser *usertypes.User
+	if loginRequest.Username != "" {
+		foundUser, err = user.LogInLocalUser(loginRequest.Username, loginRequest.Password)
+	} else {
+		foundUser, err = user.LogIn(loginRequest.Password)
+	}
 	if err == user.ErrInvalidPassword {
 		loginResponse.Error = "Invalid password. Please try again."
 		JSON(w, http.StatusUnauthorized, loginResponse)
 		return
+	} else if err == user.ErrTooManyAttempts && loginRequest.Username != "" {
+		resetPasswordCmd := fmt.Sprintf("kubectl kots user reset-password %s", loginRequest.Username)
+		if util.PodNamespace != "" {
+			resetPasswordCmd = fmt.Sprintf("%s -n %s", resetPasswordCmd, util.PodNamespace)
+		}
+		loginResponse.Error = fmt.Sprintf("This account has been locked.  Please ask an administrator to reset its password using the \"%s\" command.", resetPasswordCmd)
+		JSON(w, http.StatusUnauthorized, loginResponse)
+		return
 	} else if err == user.ErrTooManyAttempts {
 		resetPasswordCmd := "kubectl kots reset-password"
 		if util.IsEmbeddedCluster() {
@@ -112,38 +140,136 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// TODO: super user permissions
-	roles := session.GetSessionRolesFromRBAC(nil, identity.DefaultGroups)
-
-	issuedAt, expiresAt := time.Now(), time.Now().Add(SessionTimeout)
-	createdSession, err := store.GetStore().CreateSession(foundUser, issuedAt, expiresAt, roles)
+	twoFactorEnabled, err := twofactor.IsEnabled(foundUser.ID)
 	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to check two-factor authentication"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if twoFactorEnabled {
+		twoFactorToken, err := session.SignTwoFactorJWT(foundUser.ID, time.Now().Add(TwoFactorTimeout))
+		if err != nil {
+			logger.Error(errors.Wrap(err, "failed to sign two-factor token"))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		loginResponse.TwoFactorRequired = true
+		loginResponse.TwoFactorToken = twoFactorToken
+		JSON(w, http.StatusOK, loginResponse)
+		return
+	}
+
+	if err := setLoginSessionCookie(w, r, foundUser); err != nil {
 		logger.Error(err)
 		JSON(w, http.StatusInternalServerError, loginResponse)
 		return
 	}
 
-	signedJWT, err := session.SignJWT(createdSession)
+	JSON(w, http.StatusOK, loginResponse)
+}
+
+// LoginTwoFactor completes a password login for a user with two-factor authentication enabled
+func (h *Handler) LoginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	loginResponse := LoginResponse{}
+
+	loginTwoFactorRequest := LoginTwoFactorRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&loginTwoFactorRequest); err != nil {
+		logger.Error(err)
+		JSON(w, http.StatusBadRequest, loginResponse)
+		return
+	}
+
+	userID, err := session.ParseTwoFactorJWT(loginTwoFactorRequest.TwoFactorToken)
 	if err != nil {
+		logger.Debugf("invalid two-factor token: %v", err)
+		loginResponse.Error = "Your login has expired. Please log in again."
+		JSON(w, http.StatusUnauthorized, loginResponse)
+		return
+	}
+
+	if err := twofactor.Verify(userID, loginTwoFactorRequest.Code); err != nil {
+		if errors.Is(err, twofactor.ErrInvalidCode) {
+			// the two-factor token is still valid, the client can retry with another code
+			loginResponse.TwoFactorRequired = true
+			loginResponse.Error = "Invalid code. Please try again."
+			JSON(w, http.StatusUnauthorized, loginResponse)
+			return
+		} else if errors.Is(err, twofactor.ErrTooManyAttempts) {
+			resetCmd := "kubectl kots reset-2fa"
+			if util.PodNamespace != "" {
+				resetCmd = fmt.Sprintf("%s -n %s", resetCmd, util.PodNamespace)
+			}
+			loginResponse.Error = fmt.Sprintf("Two-factor authentication has been locked.  Please reset it using the \"%s\" command.", resetCmd)
+			JSON(w, http.StatusUnauthorized, loginResponse)
+			return
+		} else if errors.Is(err, twofactor.ErrNotEnabled) {
+			loginResponse.Error = "Your login has expired. Please log in again."
+			JSON(w, http.StatusUnauthorized, loginResponse)
+			return
+		}
+		logger.Error(errors.Wrap(err, "failed to verify two-factor code"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	foundUser := &usertypes.User{
+		ID: userID,
+	}
+	if userID != user.SharedPasswordUserID {
+		foundUser, err = store.GetStore().GetUser(userID)
+		if err != nil && !store.GetStore().IsNotFound(err) {
+			logger.Error(errors.Wrap(err, "failed to get user"))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		if err != nil || foundUser.IsDisabled {
+			loginResponse.Error = "Your login has expired. Please log in again."
+			JSON(w, http.StatusUnauthorized, loginResponse)
+			return
+		}
+	}
+
+	if err := setLoginSessionCookie(w, r, foundUser); err != nil {
 		logger.Error(err)
 		JSON(w, http.StatusInternalServerError, loginResponse)
 		return
 	}
 
+	JSON(w, http.StatusOK, loginResponse)
+}
+
+// setLoginSessionCookie creates a session for a user that logged in with a password and sets the session cookie
+func setLoginSessionCookie(w http.ResponseWriter, r *http.Request, foundUser *usertypes.User) error {
+	roles := foundUser.Roles
+	if foundUser.ID == user.SharedPasswordUserID {
+		// TODO: super user permissions
+		roles = session.GetSessionRolesFromRBAC(nil, identity.DefaultGroups)
+	}
+
+	issuedAt, expiresAt := time.Now(), time.Now().Add(SessionTimeout)
+	source := session.GetSessionSource(r, sessiontypes.AuthMethodPassword)
+	createdSession, err := store.GetStore().CreateSession(foundUser, issuedAt, expiresAt, roles, source)
+	if err != nil {
+		return errors.Wrap(err, "failed to create session")
+	}
+
+	signedJWT, err := session.SignJWT(createdSession)
+	if err != nil {
+		return errors.Wrap(err, "failed to sign jwt")
+	}
+
 	responseToken := fmt.Sprintf("Bearer %s", signedJWT)
 
 	expiration := time.Now().Add(SessionTimeout)
 	origin := r.Header.Get("Origin")
 	tokenCookie, err := session.GetSessionCookie(responseToken, expiration, origin)
 	if err != nil {
-		logger.Error(errors.Wrap(err, "failed to get session cookie"))
-		w.WriteHeader(http.StatusInternalServerError)
-		return
+		return errors.Wrap(err, "failed to get session cookie")
 	}
 
 	http.SetCookie(w, tokenCookie)
 
-	JSON(w, http.StatusOK, loginResponse)
+	return nil
 }
 
 type OIDCLoginResponse struct {
@@ -370,7 +496,8 @@ func (h *Handler) OIDCLoginCallback(w http.ResponseWriter, r *http.Request) {
 	}
 
 	issuedAt, expiresAt := time.Now(), time.Now().Add(SessionTimeout)
-	createdSession, err := store.GetStore().CreateSession(user, issuedAt, expiresAt, roles) // idToken.IssuedAt, idToken.Expiry
+	source := session.GetSessionSource(r, sessiontypes.AuthMethodOIDC)
+	createdSession, err := store.GetStore().CreateSession(user, issuedAt, expiresAt, roles, source) // idToken.IssuedAt, idToken.Expiry
 	if err != nil {
 		logger.Error(errors.Wrap(err, "failed to create session"))
 		w.WriteHeader(http.StatusInternalServerError)
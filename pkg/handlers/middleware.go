@@ -7,6 +7,7 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/kotsadmmetrics"
 	"github.com/replicatedhq/kots/pkg/logger"
 	"github.com/replicatedhq/kots/pkg/session"
 	"github.com/replicatedhq/kots/pkg/store"
@@ -26,6 +27,19 @@ func (lrw *loggingResponseWriter) WriteHeader(code int) {
 	lrw.ResponseWriter.WriteHeader(code)
 }
 
+// Flush passes flushes through to the underlying writer, since streaming handlers assert http.Flusher
+// on the writer they are given
+func (lrw *loggingResponseWriter) Flush() {
+	if f, ok := lrw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to flush proxied responses
+func (lrw *loggingResponseWriter) Unwrap() http.ResponseWriter {
+	return lrw.ResponseWriter
+}
+
 func CorsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if handleOptionsRequest(w, r) {
@@ -77,6 +91,27 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 	})
 }
 
+// MetricsMiddleware records the latency of every request that matched a route. Requests are labeled with the
+// route name, or the path template for unnamed routes.
+func MetricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		startTime := time.Now()
+
+		lrw := NewLoggingResponseWriter(w)
+		next.ServeHTTP(lrw, r)
+
+		route := "unknown"
+		if currentRoute := mux.CurrentRoute(r); currentRoute != nil {
+			if name := currentRoute.GetName(); name != "" {
+				route = name
+			} else if tpl, err := currentRoute.GetPathTemplate(); err == nil {
+				route = tpl
+			}
+		}
+		kotsadmmetrics.ObserveHTTPRequest(route, r.Method, lrw.StatusCode, time.Since(startTime))
+	})
+}
+
 func RequireValidSessionMiddleware(kotsStore store.Store) mux.MiddlewareFunc {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -70,6 +70,18 @@ func (mr *MockKOTSHandlerMockRecorder) AppUpdateCheck(w, r interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AppUpdateCheck", reflect.TypeOf((*MockKOTSHandler)(nil).AppUpdateCheck), w, r)
 }
 
+// AssignAppToCluster mocks base method.
+func (m *MockKOTSHandler) AssignAppToCluster(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AssignAppToCluster", w, r)
+}
+
+// AssignAppToCluster indicates an expected call of AssignAppToCluster.
+func (mr *MockKOTSHandlerMockRecorder) AssignAppToCluster(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignAppToCluster", reflect.TypeOf((*MockKOTSHandler)(nil).AssignAppToCluster), w, r)
+}
+
 // CanInstallAppVersion mocks base method.
 func (m *MockKOTSHandler) CanInstallAppVersion(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -82,6 +94,18 @@ func (mr *MockKOTSHandlerMockRecorder) CanInstallAppVersion(w, r interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CanInstallAppVersion", reflect.TypeOf((*MockKOTSHandler)(nil).CanInstallAppVersion), w, r)
 }
 
+// CancelAppDeploy mocks base method.
+func (m *MockKOTSHandler) CancelAppDeploy(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CancelAppDeploy", w, r)
+}
+
+// CancelAppDeploy indicates an expected call of CancelAppDeploy.
+func (mr *MockKOTSHandlerMockRecorder) CancelAppDeploy(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelAppDeploy", reflect.TypeOf((*MockKOTSHandler)(nil).CancelAppDeploy), w, r)
+}
+
 // CancelRestore mocks base method.
 func (m *MockKOTSHandler) CancelRestore(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -178,6 +202,42 @@ func (mr *MockKOTSHandlerMockRecorder) ConfirmEmbeddedClusterManagement(w, r int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmEmbeddedClusterManagement", reflect.TypeOf((*MockKOTSHandler)(nil).ConfirmEmbeddedClusterManagement), w, r)
 }
 
+// ConfirmTwoFactor mocks base method.
+func (m *MockKOTSHandler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ConfirmTwoFactor", w, r)
+}
+
+// ConfirmTwoFactor indicates an expected call of ConfirmTwoFactor.
+func (mr *MockKOTSHandlerMockRecorder) ConfirmTwoFactor(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTwoFactor", reflect.TypeOf((*MockKOTSHandler)(nil).ConfirmTwoFactor), w, r)
+}
+
+// CreateAPIToken mocks base method.
+func (m *MockKOTSHandler) CreateAPIToken(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateAPIToken", w, r)
+}
+
+// CreateAPIToken indicates an expected call of CreateAPIToken.
+func (mr *MockKOTSHandlerMockRecorder) CreateAPIToken(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIToken", reflect.TypeOf((*MockKOTSHandler)(nil).CreateAPIToken), w, r)
+}
+
+// CreateAgentCluster mocks base method.
+func (m *MockKOTSHandler) CreateAgentCluster(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateAgentCluster", w, r)
+}
+
+// CreateAgentCluster indicates an expected call of CreateAgentCluster.
+func (mr *MockKOTSHandlerMockRecorder) CreateAgentCluster(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAgentCluster", reflect.TypeOf((*MockKOTSHandler)(nil).CreateAgentCluster), w, r)
+}
+
 // CreateAppFromAirgap mocks base method.
 func (m *MockKOTSHandler) CreateAppFromAirgap(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -238,6 +298,42 @@ func (mr *MockKOTSHandlerMockRecorder) CreateInstanceBackup(w, r interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInstanceBackup", reflect.TypeOf((*MockKOTSHandler)(nil).CreateInstanceBackup), w, r)
 }
 
+// CreateNotificationSink mocks base method.
+func (m *MockKOTSHandler) CreateNotificationSink(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateNotificationSink", w, r)
+}
+
+// CreateNotificationSink indicates an expected call of CreateNotificationSink.
+func (mr *MockKOTSHandlerMockRecorder) CreateNotificationSink(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotificationSink", reflect.TypeOf((*MockKOTSHandler)(nil).CreateNotificationSink), w, r)
+}
+
+// CreateUser mocks base method.
+func (m *MockKOTSHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateUser", w, r)
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockKOTSHandlerMockRecorder) CreateUser(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockKOTSHandler)(nil).CreateUser), w, r)
+}
+
+// CreateVersionBlock mocks base method.
+func (m *MockKOTSHandler) CreateVersionBlock(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "CreateVersionBlock", w, r)
+}
+
+// CreateVersionBlock indicates an expected call of CreateVersionBlock.
+func (mr *MockKOTSHandlerMockRecorder) CreateVersionBlock(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVersionBlock", reflect.TypeOf((*MockKOTSHandler)(nil).CreateVersionBlock), w, r)
+}
+
 // CurrentAppConfig mocks base method.
 func (m *MockKOTSHandler) CurrentAppConfig(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -286,6 +382,18 @@ func (mr *MockKOTSHandlerMockRecorder) DeleteKurlNode(w, r interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteKurlNode", reflect.TypeOf((*MockKOTSHandler)(nil).DeleteKurlNode), w, r)
 }
 
+// DeleteNotificationSink mocks base method.
+func (m *MockKOTSHandler) DeleteNotificationSink(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteNotificationSink", w, r)
+}
+
+// DeleteNotificationSink indicates an expected call of DeleteNotificationSink.
+func (mr *MockKOTSHandlerMockRecorder) DeleteNotificationSink(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationSink", reflect.TypeOf((*MockKOTSHandler)(nil).DeleteNotificationSink), w, r)
+}
+
 // DeleteRedact mocks base method.
 func (m *MockKOTSHandler) DeleteRedact(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -310,6 +418,30 @@ func (mr *MockKOTSHandlerMockRecorder) DeleteSupportBundle(w, r interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSupportBundle", reflect.TypeOf((*MockKOTSHandler)(nil).DeleteSupportBundle), w, r)
 }
 
+// DeleteUser mocks base method.
+func (m *MockKOTSHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteUser", w, r)
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockKOTSHandlerMockRecorder) DeleteUser(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockKOTSHandler)(nil).DeleteUser), w, r)
+}
+
+// DeleteVersionBlock mocks base method.
+func (m *MockKOTSHandler) DeleteVersionBlock(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DeleteVersionBlock", w, r)
+}
+
+// DeleteVersionBlock indicates an expected call of DeleteVersionBlock.
+func (mr *MockKOTSHandlerMockRecorder) DeleteVersionBlock(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVersionBlock", reflect.TypeOf((*MockKOTSHandler)(nil).DeleteVersionBlock), w, r)
+}
+
 // DeployAppVersion mocks base method.
 func (m *MockKOTSHandler) DeployAppVersion(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -334,6 +466,18 @@ func (mr *MockKOTSHandlerMockRecorder) DisableAppGitOps(w, r interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableAppGitOps", reflect.TypeOf((*MockKOTSHandler)(nil).DisableAppGitOps), w, r)
 }
 
+// DisableTwoFactor mocks base method.
+func (m *MockKOTSHandler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "DisableTwoFactor", w, r)
+}
+
+// DisableTwoFactor indicates an expected call of DisableTwoFactor.
+func (mr *MockKOTSHandlerMockRecorder) DisableTwoFactor(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTwoFactor", reflect.TypeOf((*MockKOTSHandler)(nil).DisableTwoFactor), w, r)
+}
+
 // DockerHubSecretUpdated mocks base method.
 func (m *MockKOTSHandler) DockerHubSecretUpdated(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -418,6 +562,18 @@ func (mr *MockKOTSHandlerMockRecorder) DrainKurlNode(w, r interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DrainKurlNode", reflect.TypeOf((*MockKOTSHandler)(nil).DrainKurlNode), w, r)
 }
 
+// EnrollTwoFactor mocks base method.
+func (m *MockKOTSHandler) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "EnrollTwoFactor", w, r)
+}
+
+// EnrollTwoFactor indicates an expected call of EnrollTwoFactor.
+func (mr *MockKOTSHandlerMockRecorder) EnrollTwoFactor(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTwoFactor", reflect.TypeOf((*MockKOTSHandler)(nil).EnrollTwoFactor), w, r)
+}
+
 // ExchangePlatformLicense mocks base method.
 func (m *MockKOTSHandler) ExchangePlatformLicense(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -574,6 +730,18 @@ func (mr *MockKOTSHandlerMockRecorder) GetAppDashboard(w, r interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppDashboard", reflect.TypeOf((*MockKOTSHandler)(nil).GetAppDashboard), w, r)
 }
 
+// GetAppDeployStatus mocks base method.
+func (m *MockKOTSHandler) GetAppDeployStatus(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetAppDeployStatus", w, r)
+}
+
+// GetAppDeployStatus indicates an expected call of GetAppDeployStatus.
+func (mr *MockKOTSHandlerMockRecorder) GetAppDeployStatus(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppDeployStatus", reflect.TypeOf((*MockKOTSHandler)(nil).GetAppDeployStatus), w, r)
+}
+
 // GetAppIdentityServiceConfig mocks base method.
 func (m *MockKOTSHandler) GetAppIdentityServiceConfig(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -622,6 +790,30 @@ func (mr *MockKOTSHandlerMockRecorder) GetAppStatus(w, r interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppStatus", reflect.TypeOf((*MockKOTSHandler)(nil).GetAppStatus), w, r)
 }
 
+// GetAppStatusHistory mocks base method.
+func (m *MockKOTSHandler) GetAppStatusHistory(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetAppStatusHistory", w, r)
+}
+
+// GetAppStatusHistory indicates an expected call of GetAppStatusHistory.
+func (mr *MockKOTSHandlerMockRecorder) GetAppStatusHistory(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppStatusHistory", reflect.TypeOf((*MockKOTSHandler)(nil).GetAppStatusHistory), w, r)
+}
+
+// GetAppVersionDeployPlan mocks base method.
+func (m *MockKOTSHandler) GetAppVersionDeployPlan(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetAppVersionDeployPlan", w, r)
+}
+
+// GetAppVersionDeployPlan indicates an expected call of GetAppVersionDeployPlan.
+func (mr *MockKOTSHandlerMockRecorder) GetAppVersionDeployPlan(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppVersionDeployPlan", reflect.TypeOf((*MockKOTSHandler)(nil).GetAppVersionDeployPlan), w, r)
+}
+
 // GetAppVersionDownloadStatus mocks base method.
 func (m *MockKOTSHandler) GetAppVersionDownloadStatus(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -646,6 +838,18 @@ func (mr *MockKOTSHandlerMockRecorder) GetAppVersionHistory(w, r interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppVersionHistory", reflect.TypeOf((*MockKOTSHandler)(nil).GetAppVersionHistory), w, r)
 }
 
+// GetAuditLog mocks base method.
+func (m *MockKOTSHandler) GetAuditLog(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetAuditLog", w, r)
+}
+
+// GetAuditLog indicates an expected call of GetAuditLog.
+func (mr *MockKOTSHandlerMockRecorder) GetAuditLog(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLog", reflect.TypeOf((*MockKOTSHandler)(nil).GetAuditLog), w, r)
+}
+
 // GetAutomatedInstallStatus mocks base method.
 func (m *MockKOTSHandler) GetAutomatedInstallStatus(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -706,6 +910,18 @@ func (mr *MockKOTSHandlerMockRecorder) GetDownstreamOutput(w, r interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDownstreamOutput", reflect.TypeOf((*MockKOTSHandler)(nil).GetDownstreamOutput), w, r)
 }
 
+// GetDriftDetection mocks base method.
+func (m *MockKOTSHandler) GetDriftDetection(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetDriftDetection", w, r)
+}
+
+// GetDriftDetection indicates an expected call of GetDriftDetection.
+func (mr *MockKOTSHandlerMockRecorder) GetDriftDetection(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDriftDetection", reflect.TypeOf((*MockKOTSHandler)(nil).GetDriftDetection), w, r)
+}
+
 // GetEmbeddedClusterNode mocks base method.
 func (m *MockKOTSHandler) GetEmbeddedClusterNode(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -778,6 +994,18 @@ func (mr *MockKOTSHandlerMockRecorder) GetGlobalSnapshotSettings(w, r interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGlobalSnapshotSettings", reflect.TypeOf((*MockKOTSHandler)(nil).GetGlobalSnapshotSettings), w, r)
 }
 
+// GetHealthGate mocks base method.
+func (m *MockKOTSHandler) GetHealthGate(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetHealthGate", w, r)
+}
+
+// GetHealthGate indicates an expected call of GetHealthGate.
+func (mr *MockKOTSHandlerMockRecorder) GetHealthGate(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHealthGate", reflect.TypeOf((*MockKOTSHandler)(nil).GetHealthGate), w, r)
+}
+
 // GetIdentityServiceConfig mocks base method.
 func (m *MockKOTSHandler) GetIdentityServiceConfig(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -1054,6 +1282,18 @@ func (mr *MockKOTSHandlerMockRecorder) GetSupportBundleRedactions(w, r interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSupportBundleRedactions", reflect.TypeOf((*MockKOTSHandler)(nil).GetSupportBundleRedactions), w, r)
 }
 
+// GetTwoFactorStatus mocks base method.
+func (m *MockKOTSHandler) GetTwoFactorStatus(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "GetTwoFactorStatus", w, r)
+}
+
+// GetTwoFactorStatus indicates an expected call of GetTwoFactorStatus.
+func (mr *MockKOTSHandlerMockRecorder) GetTwoFactorStatus(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwoFactorStatus", reflect.TypeOf((*MockKOTSHandler)(nil).GetTwoFactorStatus), w, r)
+}
+
 // GetUpdateDownloadStatus mocks base method.
 func (m *MockKOTSHandler) GetUpdateDownloadStatus(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -1114,6 +1354,42 @@ func (mr *MockKOTSHandlerMockRecorder) InitGitOpsConnection(w, r interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitGitOpsConnection", reflect.TypeOf((*MockKOTSHandler)(nil).InitGitOpsConnection), w, r)
 }
 
+// ListAPITokens mocks base method.
+func (m *MockKOTSHandler) ListAPITokens(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListAPITokens", w, r)
+}
+
+// ListAPITokens indicates an expected call of ListAPITokens.
+func (mr *MockKOTSHandlerMockRecorder) ListAPITokens(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPITokens", reflect.TypeOf((*MockKOTSHandler)(nil).ListAPITokens), w, r)
+}
+
+// ListAgentClusters mocks base method.
+func (m *MockKOTSHandler) ListAgentClusters(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListAgentClusters", w, r)
+}
+
+// ListAgentClusters indicates an expected call of ListAgentClusters.
+func (mr *MockKOTSHandlerMockRecorder) ListAgentClusters(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAgentClusters", reflect.TypeOf((*MockKOTSHandler)(nil).ListAgentClusters), w, r)
+}
+
+// ListAppDeploys mocks base method.
+func (m *MockKOTSHandler) ListAppDeploys(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListAppDeploys", w, r)
+}
+
+// ListAppDeploys indicates an expected call of ListAppDeploys.
+func (mr *MockKOTSHandlerMockRecorder) ListAppDeploys(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAppDeploys", reflect.TypeOf((*MockKOTSHandler)(nil).ListAppDeploys), w, r)
+}
+
 // ListApps mocks base method.
 func (m *MockKOTSHandler) ListApps(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -1150,6 +1426,42 @@ func (mr *MockKOTSHandlerMockRecorder) ListInstanceBackups(w, r interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstanceBackups", reflect.TypeOf((*MockKOTSHandler)(nil).ListInstanceBackups), w, r)
 }
 
+// ListNotificationDeliveries mocks base method.
+func (m *MockKOTSHandler) ListNotificationDeliveries(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListNotificationDeliveries", w, r)
+}
+
+// ListNotificationDeliveries indicates an expected call of ListNotificationDeliveries.
+func (mr *MockKOTSHandlerMockRecorder) ListNotificationDeliveries(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationDeliveries", reflect.TypeOf((*MockKOTSHandler)(nil).ListNotificationDeliveries), w, r)
+}
+
+// ListNotificationSinks mocks base method.
+func (m *MockKOTSHandler) ListNotificationSinks(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListNotificationSinks", w, r)
+}
+
+// ListNotificationSinks indicates an expected call of ListNotificationSinks.
+func (mr *MockKOTSHandlerMockRecorder) ListNotificationSinks(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationSinks", reflect.TypeOf((*MockKOTSHandler)(nil).ListNotificationSinks), w, r)
+}
+
+// ListRBACRoles mocks base method.
+func (m *MockKOTSHandler) ListRBACRoles(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListRBACRoles", w, r)
+}
+
+// ListRBACRoles indicates an expected call of ListRBACRoles.
+func (mr *MockKOTSHandlerMockRecorder) ListRBACRoles(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRBACRoles", reflect.TypeOf((*MockKOTSHandler)(nil).ListRBACRoles), w, r)
+}
+
 // ListRedactors mocks base method.
 func (m *MockKOTSHandler) ListRedactors(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -1162,6 +1474,18 @@ func (mr *MockKOTSHandlerMockRecorder) ListRedactors(w, r interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRedactors", reflect.TypeOf((*MockKOTSHandler)(nil).ListRedactors), w, r)
 }
 
+// ListSessions mocks base method.
+func (m *MockKOTSHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListSessions", w, r)
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockKOTSHandlerMockRecorder) ListSessions(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockKOTSHandler)(nil).ListSessions), w, r)
+}
+
 // ListSupportBundles mocks base method.
 func (m *MockKOTSHandler) ListSupportBundles(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -1174,6 +1498,30 @@ func (mr *MockKOTSHandlerMockRecorder) ListSupportBundles(w, r interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSupportBundles", reflect.TypeOf((*MockKOTSHandler)(nil).ListSupportBundles), w, r)
 }
 
+// ListUsers mocks base method.
+func (m *MockKOTSHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListUsers", w, r)
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockKOTSHandlerMockRecorder) ListUsers(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockKOTSHandler)(nil).ListUsers), w, r)
+}
+
+// ListVersionBlocks mocks base method.
+func (m *MockKOTSHandler) ListVersionBlocks(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ListVersionBlocks", w, r)
+}
+
+// ListVersionBlocks indicates an expected call of ListVersionBlocks.
+func (mr *MockKOTSHandlerMockRecorder) ListVersionBlocks(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVersionBlocks", reflect.TypeOf((*MockKOTSHandler)(nil).ListVersionBlocks), w, r)
+}
+
 // LiveAppConfig mocks base method.
 func (m *MockKOTSHandler) LiveAppConfig(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -1258,6 +1606,18 @@ func (mr *MockKOTSHandlerMockRecorder) ResetGitOps(w, r interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetGitOps", reflect.TypeOf((*MockKOTSHandler)(nil).ResetGitOps), w, r)
 }
 
+// ResetUserPassword mocks base method.
+func (m *MockKOTSHandler) ResetUserPassword(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResetUserPassword", w, r)
+}
+
+// ResetUserPassword indicates an expected call of ResetUserPassword.
+func (mr *MockKOTSHandlerMockRecorder) ResetUserPassword(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetUserPassword", reflect.TypeOf((*MockKOTSHandler)(nil).ResetUserPassword), w, r)
+}
+
 // RestoreApps mocks base method.
 func (m *MockKOTSHandler) RestoreApps(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -1282,6 +1642,42 @@ func (mr *MockKOTSHandlerMockRecorder) ResumeInstallOnline(w, r interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResumeInstallOnline", reflect.TypeOf((*MockKOTSHandler)(nil).ResumeInstallOnline), w, r)
 }
 
+// RevokeAPIToken mocks base method.
+func (m *MockKOTSHandler) RevokeAPIToken(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeAPIToken", w, r)
+}
+
+// RevokeAPIToken indicates an expected call of RevokeAPIToken.
+func (mr *MockKOTSHandlerMockRecorder) RevokeAPIToken(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAPIToken", reflect.TypeOf((*MockKOTSHandler)(nil).RevokeAPIToken), w, r)
+}
+
+// RevokeAllSessions mocks base method.
+func (m *MockKOTSHandler) RevokeAllSessions(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeAllSessions", w, r)
+}
+
+// RevokeAllSessions indicates an expected call of RevokeAllSessions.
+func (mr *MockKOTSHandlerMockRecorder) RevokeAllSessions(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockKOTSHandler)(nil).RevokeAllSessions), w, r)
+}
+
+// RevokeSession mocks base method.
+func (m *MockKOTSHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RevokeSession", w, r)
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockKOTSHandlerMockRecorder) RevokeSession(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockKOTSHandler)(nil).RevokeSession), w, r)
+}
+
 // SaveInstanceSnapshotRetention mocks base method.
 func (m *MockKOTSHandler) SaveInstanceSnapshotRetention(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -1354,6 +1750,30 @@ func (mr *MockKOTSHandlerMockRecorder) SetAutomaticUpdatesConfig(w, r interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAutomaticUpdatesConfig", reflect.TypeOf((*MockKOTSHandler)(nil).SetAutomaticUpdatesConfig), w, r)
 }
 
+// SetDriftDetection mocks base method.
+func (m *MockKOTSHandler) SetDriftDetection(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetDriftDetection", w, r)
+}
+
+// SetDriftDetection indicates an expected call of SetDriftDetection.
+func (mr *MockKOTSHandlerMockRecorder) SetDriftDetection(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDriftDetection", reflect.TypeOf((*MockKOTSHandler)(nil).SetDriftDetection), w, r)
+}
+
+// SetHealthGate mocks base method.
+func (m *MockKOTSHandler) SetHealthGate(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetHealthGate", w, r)
+}
+
+// SetHealthGate indicates an expected call of SetHealthGate.
+func (mr *MockKOTSHandlerMockRecorder) SetHealthGate(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHealthGate", reflect.TypeOf((*MockKOTSHandler)(nil).SetHealthGate), w, r)
+}
+
 // SetPrometheusAddress mocks base method.
 func (m *MockKOTSHandler) SetPrometheusAddress(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
@@ -1522,6 +1942,18 @@ func (mr *MockKOTSHandlerMockRecorder) UpdateRedact(w, r interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRedact", reflect.TypeOf((*MockKOTSHandler)(nil).UpdateRedact), w, r)
 }
 
+// UpdateUser mocks base method.
+func (m *MockKOTSHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "UpdateUser", w, r)
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockKOTSHandlerMockRecorder) UpdateUser(w, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockKOTSHandler)(nil).UpdateUser), w, r)
+}
+
 // UploadAirgapBundleChunk mocks base method.
 func (m *MockKOTSHandler) UploadAirgapBundleChunk(w http.ResponseWriter, r *http.Request) {
 	m.ctrl.T.Helper()
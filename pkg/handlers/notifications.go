@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/handlers/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/notifications"
+	notificationtypes "github.com/replicatedhq/kots/pkg/notifications/types"
+	"github.com/replicatedhq/kots/pkg/store"
+)
+
+const defaultNotificationDeliveriesLimit = 100
+
+type ListNotificationSinksResponse struct {
+	Sinks []notificationtypes.Sink `json:"sinks"`
+}
+
+type CreateNotificationSinkRequest struct {
+	Name    string                           `json:"name"`
+	Type    notificationtypes.SinkType       `json:"type"`
+	Events  []notificationtypes.EventType    `json:"events,omitempty"`
+	Webhook *notificationtypes.WebhookConfig `json:"webhook,omitempty"`
+	SMTP    *notificationtypes.SMTPConfig    `json:"smtp,omitempty"`
+}
+
+type CreateNotificationSinkResponse struct {
+	Sink notificationtypes.Sink `json:"sink"`
+}
+
+type ListNotificationDeliveriesResponse struct {
+	Deliveries []*notificationtypes.Delivery `json:"deliveries"`
+}
+
+func (h *Handler) ListNotificationSinks(w http.ResponseWriter, r *http.Request) {
+	foundApp, err := store.GetStore().GetAppFromSlug(mux.Vars(r)["appSlug"])
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get app from slug"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	sinks, err := store.GetStore().ListNotificationSinks(foundApp.ID)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to list notification sinks"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response := ListNotificationSinksResponse{
+		Sinks: []notificationtypes.Sink{},
+	}
+	for _, sink := range sinks {
+		response.Sinks = append(response.Sinks, sink.Masked())
+	}
+
+	JSON(w, http.StatusOK, response)
+}
+
+func (h *Handler) CreateNotificationSink(w http.ResponseWriter, r *http.Request) {
+	createNotificationSinkRequest := CreateNotificationSinkRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&createNotificationSinkRequest); err != nil {
+		logger.Error(errors.Wrap(err, "failed to decode request body"))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	foundApp, err := store.GetStore().GetAppFromSlug(mux.Vars(r)["appSlug"])
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get app from slug"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	sink := &notificationtypes.Sink{
+		AppID:   foundApp.ID,
+		Name:    strings.TrimSpace(createNotificationSinkRequest.Name),
+		Type:    createNotificationSinkRequest.Type,
+		Events:  createNotificationSinkRequest.Events,
+		Webhook: createNotificationSinkRequest.Webhook,
+		SMTP:    createNotificationSinkRequest.SMTP,
+	}
+	if err := notifications.ValidateSink(sink); err != nil {
+		JSON(w, http.StatusBadRequest, types.NewErrorResponse(err))
+		return
+	}
+
+	if err := store.GetStore().CreateNotificationSink(sink); err != nil {
+		logger.Error(errors.Wrap(err, "failed to create notification sink"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusCreated, CreateNotificationSinkResponse{Sink: sink.Masked()})
+}
+
+func (h *Handler) DeleteNotificationSink(w http.ResponseWriter, r *http.Request) {
+	foundApp, err := store.GetStore().GetAppFromSlug(mux.Vars(r)["appSlug"])
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get app from slug"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := store.GetStore().DeleteNotificationSink(foundApp.ID, mux.Vars(r)["sinkId"]); err != nil {
+		if store.GetStore().IsNotFound(err) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		logger.Error(errors.Wrap(err, "failed to delete notification sink"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) ListNotificationDeliveries(w http.ResponseWriter, r *http.Request) {
+	limit := defaultNotificationDeliveriesLimit
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		l, err := strconv.Atoi(limitStr)
+		if err != nil || l < 0 {
+			JSON(w, http.StatusBadRequest, types.NewErrorResponse(errors.Errorf("invalid limit %q", limitStr)))
+			return
+		}
+		limit = l
+	}
+
+	foundApp, err := store.GetStore().GetAppFromSlug(mux.Vars(r)["appSlug"])
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get app from slug"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	deliveries, err := store.GetStore().ListNotificationDeliveries(foundApp.ID, limit)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to list notification deliveries"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, ListNotificationDeliveriesResponse{Deliveries: deliveries})
+}
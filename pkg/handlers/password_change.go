@@ -76,6 +76,11 @@ func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// the sessions secret has already been cleared, this also drops the sessions cached by the store
+	if err := store.GetStore().DeleteAllSessions(); err != nil {
+		logger.Error(errors.Wrap(err, "failed to delete all sessions"))
+	}
+
 	passwordChangeResponse := PasswordChangeResponse{
 		Success: true,
 	}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/replicatedhq/kots/pkg/kotsadmmetrics"
+)
+
+// MetricsBearerTokenEnv - env var with the bearer token that must be sent to scrape the metrics endpoint.
+// The endpoint is not authenticated when it is not set.
+const MetricsBearerTokenEnv = "KOTSADM_METRICS_BEARER_TOKEN"
+
+// GetPrometheusMetrics route is UNAUTHENTICATED unless a metrics bearer token is configured
+func (h *Handler) GetPrometheusMetrics(w http.ResponseWriter, r *http.Request) {
+	if token := os.Getenv(MetricsBearerTokenEnv); token != "" {
+		requestToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(requestToken), []byte(token)) != 1 {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+	}
+
+	kotsadmmetrics.Handler().ServeHTTP(w, r)
+}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/replicatedhq/kots/pkg/rbac"
+	rbactypes "github.com/replicatedhq/kots/pkg/rbac/types"
+)
+
+type ListRBACRolesResponse struct {
+	Roles []RBACRole `json:"roles"`
+}
+
+type RBACRole struct {
+	rbactypes.Role
+	IsDefault bool `json:"isDefault"`
+}
+
+func (h *Handler) ListRBACRoles(w http.ResponseWriter, r *http.Request) {
+	response := ListRBACRolesResponse{
+		Roles: []RBACRole{},
+	}
+	for _, role := range rbac.Roles() {
+		response.Roles = append(response.Roles, RBACRole{
+			Role:      role,
+			IsDefault: rbac.IsDefaultRole(role.ID),
+		})
+	}
+
+	JSON(w, http.StatusOK, response)
+}
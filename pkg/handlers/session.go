@@ -82,6 +82,11 @@ func requireValidSession(kotsStore store.Store, w http.ResponseWriter, r *http.R
 		return nil, err
 	}
 
+	// api tokens are validated when parsed and are not tied to the shared password or a stored session
+	if sess.APITokenID != "" {
+		return sess, nil
+	}
+
 	if time.Now().After(sess.ExpiresAt) {
 		if err := kotsStore.DeleteSession(sess.ID); err != nil {
 			logger.Error(errors.Wrapf(err, "session expired. failed to delete expired session %s", sess.ID))
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/session"
+	sessiontypes "github.com/replicatedhq/kots/pkg/session/types"
+	"github.com/replicatedhq/kots/pkg/store"
+)
+
+type ListSessionsResponse struct {
+	Sessions []*sessiontypes.SessionSummary `json:"sessions"`
+}
+
+func (h *Handler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	sessions, err := store.GetStore().ListSessions()
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to list sessions"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	currentSessionID := ""
+	if sess := session.ContextGetSession(r); sess != nil {
+		currentSessionID = sess.ID
+	}
+
+	summaries := []*sessiontypes.SessionSummary{}
+	for _, s := range sessions {
+		summaries = append(summaries, &sessiontypes.SessionSummary{
+			ID:         s.ID,
+			UserID:     s.UserID,
+			IssuedAt:   s.IssuedAt,
+			ExpiresAt:  s.ExpiresAt,
+			Roles:      s.Roles,
+			AuthMethod: s.AuthMethod,
+			IPAddress:  s.IPAddress,
+			UserAgent:  s.UserAgent,
+			IsCurrent:  s.ID == currentSessionID,
+		})
+	}
+
+	JSON(w, http.StatusOK, ListSessionsResponse{Sessions: summaries})
+}
+
+func (h *Handler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	sessionID := mux.Vars(r)["sessionId"]
+
+	foundSession, err := store.GetStore().GetSession(sessionID)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get session"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if foundSession == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if err := store.GetStore().DeleteSession(sessionID); err != nil {
+		logger.Error(errors.Wrap(err, "failed to delete session"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeAllSessions signs out every user, including the caller. API tokens are not affected.
+func (h *Handler) RevokeAllSessions(w http.ResponseWriter, r *http.Request) {
+	if err := store.GetStore().DeleteAllSessions(); err != nil {
+		logger.Error(errors.Wrap(err, "failed to delete all sessions"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/handlers/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/session"
+	"github.com/replicatedhq/kots/pkg/store"
+	"github.com/replicatedhq/kots/pkg/twofactor"
+	"github.com/replicatedhq/kots/pkg/user"
+)
+
+type GetTwoFactorStatusResponse struct {
+	IsEnabled bool `json:"isEnabled"`
+}
+
+type EnrollTwoFactorResponse struct {
+	Secret string `json:"secret"`
+	// OTPAuthURI can be rendered as a QR code for authenticator apps
+	OTPAuthURI string `json:"otpauthUri"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type ConfirmTwoFactorResponse struct {
+	// RecoveryCodes are only returned once, when enrollment is confirmed
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+func (h *Handler) GetTwoFactorStatus(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := getTwoFactorUser(w, r)
+	if !ok {
+		return
+	}
+
+	isEnabled, err := twofactor.IsEnabled(userID)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get two-factor status"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, GetTwoFactorStatusResponse{IsEnabled: isEnabled})
+}
+
+func (h *Handler) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, accountName, ok := getTwoFactorUser(w, r)
+	if !ok {
+		return
+	}
+
+	secret, uri, err := twofactor.Enroll(userID, accountName)
+	if err != nil {
+		if errors.Is(err, twofactor.ErrAlreadyEnabled) {
+			JSON(w, http.StatusConflict, types.NewErrorResponse(err))
+			return
+		}
+		logger.Error(errors.Wrap(err, "failed to enroll two-factor authentication"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, EnrollTwoFactorResponse{Secret: secret, OTPAuthURI: uri})
+}
+
+func (h *Handler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := getTwoFactorUser(w, r)
+	if !ok {
+		return
+	}
+
+	twoFactorCodeRequest := TwoFactorCodeRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&twoFactorCodeRequest); err != nil {
+		logger.Error(errors.Wrap(err, "failed to decode request body"))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	recoveryCodes, err := twofactor.Confirm(userID, twoFactorCodeRequest.Code)
+	if err != nil {
+		switch errors.Cause(err) {
+		case twofactor.ErrInvalidCode, twofactor.ErrNotEnrolled:
+			JSON(w, http.StatusBadRequest, types.NewErrorResponse(err))
+			return
+		case twofactor.ErrAlreadyEnabled:
+			JSON(w, http.StatusConflict, types.NewErrorResponse(err))
+			return
+		}
+		logger.Error(errors.Wrap(err, "failed to confirm two-factor authentication"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, ConfirmTwoFactorResponse{RecoveryCodes: recoveryCodes})
+}
+
+func (h *Handler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	userID, _, ok := getTwoFactorUser(w, r)
+	if !ok {
+		return
+	}
+
+	twoFactorCodeRequest := TwoFactorCodeRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&twoFactorCodeRequest); err != nil {
+		logger.Error(errors.Wrap(err, "failed to decode request body"))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if err := twofactor.Disable(userID, twoFactorCodeRequest.Code); err != nil {
+		switch errors.Cause(err) {
+		case twofactor.ErrInvalidCode, twofactor.ErrNotEnabled, twofactor.ErrTooManyAttempts:
+			JSON(w, http.StatusBadRequest, types.NewErrorResponse(err))
+			return
+		}
+		logger.Error(errors.Wrap(err, "failed to disable two-factor authentication"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// getTwoFactorUser returns the user ID and account name for the current session. Two-factor authentication
+// only applies to password logins, so it cannot be managed from api token or identity service sessions.
+func getTwoFactorUser(w http.ResponseWriter, r *http.Request) (string, string, bool) {
+	sess := session.ContextGetSession(r)
+	if sess == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return "", "", false
+	}
+
+	if sess.APITokenID != "" || sess.UserID == "" {
+		JSON(w, http.StatusBadRequest, types.NewErrorResponse(errors.New("two-factor authentication can only be managed from a password login")))
+		return "", "", false
+	}
+
+	if sess.UserID == user.SharedPasswordUserID {
+		return sess.UserID, "admin", true
+	}
+
+	foundUser, err := store.GetStore().GetUser(sess.UserID)
+	if store.GetStore().IsNotFound(err) {
+		JSON(w, http.StatusBadRequest, types.NewErrorResponse(errors.New("two-factor authentication can only be managed from a password login")))
+		return "", "", false
+	} else if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get user"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return "", "", false
+	}
+
+	return foundUser.ID, foundUser.Email, true
+}
@@ -3,9 +3,11 @@ package handlers
 import (
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/app"
 	apptypes "github.com/replicatedhq/kots/pkg/app/types"
 	"github.com/replicatedhq/kots/pkg/kotsutil"
 	"github.com/replicatedhq/kots/pkg/logger"
@@ -18,6 +20,12 @@ import (
 type SetAutomaticUpdatesConfigRequest struct {
 	UpdateCheckerSpec string              `json:"updateCheckerSpec"`
 	AutoDeploy        apptypes.AutoDeploy `json:"autoDeploy"`
+	// DeployWindow restricts when automatic deployments can happen. The stored window is kept when not set,
+	// and a window with an empty schedule removes it.
+	DeployWindow *apptypes.DeployWindow `json:"deployWindow,omitempty"`
+	// SoakDuration is how long a release must have been published before it is deployed automatically, e.g. "72h" or "3d".
+	// The stored duration is kept when not set, and an empty duration removes it.
+	SoakDuration *string `json:"soakDuration,omitempty"`
 }
 
 type SetAutomaticUpdatesConfigResponse struct {
@@ -25,9 +33,12 @@ type SetAutomaticUpdatesConfigResponse struct {
 }
 
 type GetAutomaticUpdatesConfigResponse struct {
-	UpdateCheckerSpec string              `json:"updateCheckerSpec"`
-	AutoDeploy        apptypes.AutoDeploy `json:"autoDeploy"`
-	Error             string              `json:"error"`
+	UpdateCheckerSpec string                    `json:"updateCheckerSpec"`
+	AutoDeploy        apptypes.AutoDeploy       `json:"autoDeploy"`
+	DeployWindow      *apptypes.DeployWindow    `json:"deployWindow,omitempty"`
+	SoakDuration      string                    `json:"soakDuration,omitempty"`
+	ScheduledDeploy   *apptypes.ScheduledDeploy `json:"scheduledDeploy,omitempty"`
+	Error             string                    `json:"error"`
 }
 
 func (h *Handler) SetAutomaticUpdatesConfig(w http.ResponseWriter, r *http.Request) {
@@ -99,6 +110,29 @@ func (h *Handler) SetAutomaticUpdatesConfig(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
+	deployWindow := foundApp.DeployWindow
+	if configureAutomaticUpdatesRequest.DeployWindow != nil {
+		deployWindow = configureAutomaticUpdatesRequest.DeployWindow
+		if deployWindow.Schedule == "" {
+			deployWindow = nil
+		}
+		if err := updatechecker.ValidateDeployWindow(deployWindow); err != nil {
+			updateCheckerSpecResponse.Error = err.Error()
+			logger.Error(errors.Wrap(err, "failed to validate deploy window"))
+			JSON(w, http.StatusBadRequest, updateCheckerSpecResponse)
+			return
+		}
+	}
+
+	if configureAutomaticUpdatesRequest.SoakDuration != nil {
+		if _, err := app.ParseSoakDuration(*configureAutomaticUpdatesRequest.SoakDuration); err != nil {
+			updateCheckerSpecResponse.Error = err.Error()
+			logger.Error(errors.Wrap(err, "failed to validate soak duration"))
+			JSON(w, http.StatusBadRequest, updateCheckerSpecResponse)
+			return
+		}
+	}
+
 	if err := store.GetStore().SetUpdateCheckerSpec(foundApp.ID, cronSpec); err != nil {
 		updateCheckerSpecResponse.Error = "failed to set update checker spec"
 		logger.Error(errors.Wrap(err, updateCheckerSpecResponse.Error))
@@ -113,6 +147,33 @@ func (h *Handler) SetAutomaticUpdatesConfig(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if configureAutomaticUpdatesRequest.DeployWindow != nil {
+		if err := store.GetStore().SetDeployWindow(foundApp.ID, deployWindow); err != nil {
+			updateCheckerSpecResponse.Error = "failed to set deploy window"
+			logger.Error(errors.Wrap(err, updateCheckerSpecResponse.Error))
+			JSON(w, http.StatusInternalServerError, updateCheckerSpecResponse)
+			return
+		}
+	}
+
+	if configureAutomaticUpdatesRequest.SoakDuration != nil {
+		if err := store.GetStore().SetSoakDuration(foundApp.ID, *configureAutomaticUpdatesRequest.SoakDuration); err != nil {
+			updateCheckerSpecResponse.Error = "failed to set soak duration"
+			logger.Error(errors.Wrap(err, updateCheckerSpecResponse.Error))
+			JSON(w, http.StatusInternalServerError, updateCheckerSpecResponse)
+			return
+		}
+	}
+
+	if foundApp.ScheduledDeploy != nil {
+		if err := rescheduleDeploy(foundApp.ID, foundApp.ScheduledDeploy, configureAutomaticUpdatesRequest.AutoDeploy, deployWindow); err != nil {
+			updateCheckerSpecResponse.Error = "failed to reschedule deploy"
+			logger.Error(errors.Wrap(err, updateCheckerSpecResponse.Error))
+			JSON(w, http.StatusInternalServerError, updateCheckerSpecResponse)
+			return
+		}
+	}
+
 	// reconfigure update checker for the app
 	if err := updatechecker.Configure(foundApp, cronSpec); err != nil {
 		updateCheckerSpecResponse.Error = "failed to reconfigure update checker cron job"
@@ -136,6 +197,25 @@ func (h *Handler) GetAutomaticUpdatesConfig(w http.ResponseWriter, r *http.Reque
 	}
 	getCheckerSpecResponse.UpdateCheckerSpec = foundApp.UpdateCheckerSpec
 	getCheckerSpecResponse.AutoDeploy = foundApp.AutoDeploy
+	getCheckerSpecResponse.DeployWindow = foundApp.DeployWindow
+	getCheckerSpecResponse.SoakDuration = foundApp.SoakDuration
+	getCheckerSpecResponse.ScheduledDeploy = foundApp.ScheduledDeploy
 
 	JSON(w, http.StatusOK, getCheckerSpecResponse)
 }
+
+// rescheduleDeploy updates a deploy that is waiting for the deploy window after the automatic update config changes.
+// The deploy is dropped if auto deploy was disabled.
+func rescheduleDeploy(appID string, scheduledDeploy *apptypes.ScheduledDeploy, autoDeploy apptypes.AutoDeploy, deployWindow *apptypes.DeployWindow) error {
+	if autoDeploy == "" || autoDeploy == apptypes.AutoDeployDisabled {
+		return store.GetStore().SetScheduledDeploy(appID, nil)
+	}
+
+	deployAt, err := updatechecker.NextDeployWindow(deployWindow, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "failed to get next deploy window")
+	}
+	scheduledDeploy.DeployAt = deployAt
+
+	return store.GetStore().SetScheduledDeploy(appID, scheduledDeploy)
+}
@@ -0,0 +1,222 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/handlers/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/password"
+	"github.com/replicatedhq/kots/pkg/session"
+	"github.com/replicatedhq/kots/pkg/store"
+	"github.com/replicatedhq/kots/pkg/user"
+	usertypes "github.com/replicatedhq/kots/pkg/user/types"
+)
+
+type ListUsersResponse struct {
+	Users []*usertypes.User `json:"users"`
+}
+
+type CreateUserRequest struct {
+	Email     string   `json:"email"`
+	FirstName string   `json:"firstName,omitempty"`
+	LastName  string   `json:"lastName,omitempty"`
+	Password  string   `json:"password"`
+	Roles     []string `json:"roles"`
+}
+
+type CreateUserResponse struct {
+	User *usertypes.User `json:"user"`
+}
+
+type UpdateUserRequest struct {
+	IsDisabled *bool    `json:"isDisabled,omitempty"`
+	Roles      []string `json:"roles,omitempty"`
+}
+
+type UpdateUserResponse struct {
+	User *usertypes.User `json:"user"`
+}
+
+type ResetUserPasswordRequest struct {
+	NewPassword string `json:"newPassword"`
+}
+
+func (h *Handler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := store.GetStore().ListLocalUsers()
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to list users"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, ListUsersResponse{Users: users})
+}
+
+func (h *Handler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	createUserRequest := CreateUserRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&createUserRequest); err != nil {
+		logger.Error(errors.Wrap(err, "failed to decode request body"))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !canGrantRoles(w, r, createUserRequest.Roles) {
+		return
+	}
+
+	createdUser, err := user.CreateLocalUser(createUserRequest.Email, createUserRequest.FirstName, createUserRequest.LastName, createUserRequest.Password, createUserRequest.Roles)
+	if err != nil {
+		if isUserInputError(err) {
+			JSON(w, http.StatusBadRequest, types.NewErrorResponse(err))
+			return
+		}
+		if errors.Is(err, user.ErrUserExists) {
+			JSON(w, http.StatusConflict, types.NewErrorResponse(err))
+			return
+		}
+		logger.Error(errors.Wrap(err, "failed to create user"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusCreated, CreateUserResponse{User: createdUser})
+}
+
+func (h *Handler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["userId"]
+
+	updateUserRequest := UpdateUserRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&updateUserRequest); err != nil {
+		logger.Error(errors.Wrap(err, "failed to decode request body"))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if !canGrantRoles(w, r, updateUserRequest.Roles) {
+		return
+	}
+
+	if _, err := store.GetStore().GetUser(userID); err != nil {
+		if store.GetStore().IsNotFound(err) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		logger.Error(errors.Wrap(err, "failed to get user"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if updateUserRequest.Roles != nil {
+		if err := user.SetLocalUserRoles(userID, updateUserRequest.Roles); err != nil {
+			if isUserInputError(err) {
+				JSON(w, http.StatusBadRequest, types.NewErrorResponse(err))
+				return
+			}
+			logger.Error(errors.Wrap(err, "failed to set user roles"))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	if updateUserRequest.IsDisabled != nil {
+		if err := user.SetLocalUserDisabled(userID, *updateUserRequest.IsDisabled); err != nil {
+			logger.Error(errors.Wrap(err, "failed to set user disabled"))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	updatedUser, err := store.GetStore().GetUser(userID)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get updated user"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, UpdateUserResponse{User: updatedUser})
+}
+
+func (h *Handler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["userId"]
+
+	if _, err := store.GetStore().GetUser(userID); err != nil {
+		if store.GetStore().IsNotFound(err) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		logger.Error(errors.Wrap(err, "failed to get user"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := user.DeleteLocalUser(userID); err != nil {
+		logger.Error(errors.Wrap(err, "failed to delete user"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) ResetUserPassword(w http.ResponseWriter, r *http.Request) {
+	userID := mux.Vars(r)["userId"]
+
+	resetUserPasswordRequest := ResetUserPasswordRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&resetUserPasswordRequest); err != nil {
+		logger.Error(errors.Wrap(err, "failed to decode request body"))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	if _, err := store.GetStore().GetUser(userID); err != nil {
+		if store.GetStore().IsNotFound(err) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		logger.Error(errors.Wrap(err, "failed to get user"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := user.ResetLocalUserPassword(userID, resetUserPasswordRequest.NewPassword); err != nil {
+		if isUserInputError(err) {
+			JSON(w, http.StatusBadRequest, types.NewErrorResponse(err))
+			return
+		}
+		logger.Error(errors.Wrap(err, "failed to reset user password"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// canGrantRoles writes an error response and returns false if the session does not have all of the roles,
+// since a user cannot be granted roles that the session creating or updating it does not have
+func canGrantRoles(w http.ResponseWriter, r *http.Request, roles []string) bool {
+	sess := session.ContextGetSession(r)
+	if sess == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return false
+	}
+
+	for _, roleID := range roles {
+		if !sessionHasRole(sess.Roles, roleID) {
+			JSON(w, http.StatusForbidden, types.NewErrorResponse(errors.Errorf("cannot grant role %q", roleID)))
+			return false
+		}
+	}
+
+	return true
+}
+
+func isUserInputError(err error) bool {
+	switch errors.Cause(err) {
+	case user.ErrInvalidEmail, user.ErrNoRoles, user.ErrUnknownRole, password.ErrNewPasswordTooShort:
+		return true
+	}
+	return false
+}
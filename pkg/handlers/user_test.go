@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/replicatedhq/kots/pkg/session"
+	sessiontypes "github.com/replicatedhq/kots/pkg/session/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_userHandlersCannotGrantMissingRoles(t *testing.T) {
+	sess := &sessiontypes.Session{
+		ID:    "session-id",
+		Roles: []string{"support"},
+	}
+
+	tests := []struct {
+		name    string
+		handler func(h *Handler, w http.ResponseWriter, r *http.Request)
+		body    interface{}
+	}{
+		{
+			name:    "create user",
+			handler: (*Handler).CreateUser,
+			body: CreateUserRequest{
+				Email:    "user@example.com",
+				Password: "password1234",
+				Roles:    []string{"cluster-admin"},
+			},
+		},
+		{
+			name:    "update user",
+			handler: (*Handler).UpdateUser,
+			body: UpdateUserRequest{
+				Roles: []string{"support", "cluster-admin"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(tt.body)
+			require.NoError(t, err)
+
+			// the store is not set, so the request must be rejected before any user is read or written
+			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(body))
+			r = mux.SetURLVars(r, map[string]string{"userId": "user-id"})
+			r = session.ContextSetSession(r, sess)
+			w := httptest.NewRecorder()
+
+			tt.handler(&Handler{}, w, r)
+
+			require.Equal(t, http.StatusForbidden, w.Code)
+			require.Contains(t, w.Body.String(), `cannot grant role \"cluster-admin\"`)
+		})
+	}
+}
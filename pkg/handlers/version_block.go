@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	apptypes "github.com/replicatedhq/kots/pkg/app/types"
+	"github.com/replicatedhq/kots/pkg/handlers/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/session"
+	"github.com/replicatedhq/kots/pkg/store"
+)
+
+type ListVersionBlocksResponse struct {
+	Blocks apptypes.VersionBlocks `json:"blocks"`
+}
+
+type CreateVersionBlockRequest struct {
+	// VersionLabel or Sequence identifies the version to block. Exactly one must be set.
+	VersionLabel string `json:"versionLabel,omitempty"`
+	Sequence     *int64 `json:"sequence,omitempty"`
+	Reason       string `json:"reason"`
+}
+
+type CreateVersionBlockResponse struct {
+	Block *apptypes.VersionBlock `json:"block"`
+}
+
+func (h *Handler) ListVersionBlocks(w http.ResponseWriter, r *http.Request) {
+	foundApp, err := store.GetStore().GetAppFromSlug(mux.Vars(r)["appSlug"])
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get app from slug"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	blocks, err := store.GetStore().ListVersionBlocks(foundApp.ID)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to list version blocks"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusOK, ListVersionBlocksResponse{Blocks: blocks})
+}
+
+func (h *Handler) CreateVersionBlock(w http.ResponseWriter, r *http.Request) {
+	createVersionBlockRequest := CreateVersionBlockRequest{}
+	if err := json.NewDecoder(r.Body).Decode(&createVersionBlockRequest); err != nil {
+		logger.Error(errors.Wrap(err, "failed to decode request body"))
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	versionLabel := strings.TrimSpace(createVersionBlockRequest.VersionLabel)
+	reason := strings.TrimSpace(createVersionBlockRequest.Reason)
+	if (versionLabel == "") == (createVersionBlockRequest.Sequence == nil) {
+		JSON(w, http.StatusBadRequest, types.NewErrorResponse(errors.New("either a version label or a sequence is required")))
+		return
+	}
+	if reason == "" {
+		JSON(w, http.StatusBadRequest, types.NewErrorResponse(errors.New("a reason is required")))
+		return
+	}
+
+	sess := session.ContextGetSession(r)
+	if sess == nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	createdBy := sess.UserID
+	if createdBy == "" {
+		createdBy = sess.ID
+	}
+
+	foundApp, err := store.GetStore().GetAppFromSlug(mux.Vars(r)["appSlug"])
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get app from slug"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	block, err := store.GetStore().CreateVersionBlock(foundApp.ID, versionLabel, createVersionBlockRequest.Sequence, reason, createdBy)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to create version block"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	JSON(w, http.StatusCreated, CreateVersionBlockResponse{Block: block})
+}
+
+func (h *Handler) DeleteVersionBlock(w http.ResponseWriter, r *http.Request) {
+	foundApp, err := store.GetStore().GetAppFromSlug(mux.Vars(r)["appSlug"])
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to get app from slug"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := store.GetStore().DeleteVersionBlock(foundApp.ID, mux.Vars(r)["blockId"]); err != nil {
+		if store.GetStore().IsNotFound(err) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		logger.Error(errors.Wrap(err, "failed to delete version block"))
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
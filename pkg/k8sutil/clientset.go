@@ -18,6 +18,7 @@ import (
 	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/restmapper"
 	kbclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -92,6 +93,19 @@ func GetDynamicClient() (dynamic.Interface, error) {
 	return dynamicClient, nil
 }
 
+func GetMetadataClient() (metadata.Interface, error) {
+	cfg, err := GetClusterConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cluster config")
+	}
+
+	metadataClient, err := metadata.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create metadata client")
+	}
+	return metadataClient, nil
+}
+
 func GetK8sVersion(clientset kubernetes.Interface) (string, error) {
 	k8sVersion, err := clientset.Discovery().ServerVersion()
 	if err != nil {
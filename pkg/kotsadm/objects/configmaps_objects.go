@@ -40,6 +40,11 @@ func KotsadmConfigMap(deployOptions types.DeployOptions) *corev1.ConfigMap {
 		"additional-labels":         strings.Join(additionalLabelsArray, ","),
 	}
 
+	// only set when requested so that upgrades do not reset the applier selected at install time
+	if deployOptions.Applier != "" {
+		data["applier"] = deployOptions.Applier
+	}
+
 	if kotsadmversion.KotsadmPullSecret(deployOptions.Namespace, deployOptions.RegistryConfig) != nil {
 		data["kotsadm-registry"] = kotsadmversion.KotsadmRegistry(deployOptions.RegistryConfig)
 	}
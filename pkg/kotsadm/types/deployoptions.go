@@ -51,6 +51,7 @@ type DeployOptions struct {
 	SkipRBACCheck          bool
 	UseMinimalRBAC         bool
 	StrictSecurityContext  bool
+	Applier                string
 	InstallID              string
 	SimultaneousUploads    int
 	DisableImagePush       bool
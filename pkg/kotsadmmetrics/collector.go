@@ -0,0 +1,259 @@
+package kotsadmmetrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
+	snapshot "github.com/replicatedhq/kots/pkg/kotsadmsnapshot"
+	snapshottypes "github.com/replicatedhq/kots/pkg/kotsadmsnapshot/types"
+	"github.com/replicatedhq/kots/pkg/license"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/store"
+	"github.com/replicatedhq/kots/pkg/util"
+)
+
+const (
+	// appsRefreshInterval is how often the state of the installed apps is read from the store
+	appsRefreshInterval = time.Minute
+	// snapshotsRefreshInterval is how often backups are listed, which is slower because it queries velero
+	snapshotsRefreshInterval = 15 * time.Minute
+)
+
+// appStates are all the states reported by kotsadm_app_state, so that a state the app is not in reads 0
+// rather than having no series
+var appStates = []appstatetypes.State{
+	appstatetypes.StateReady,
+	appstatetypes.StateUpdating,
+	appstatetypes.StateDegraded,
+	appstatetypes.StateUnavailable,
+	appstatetypes.StateMissing,
+}
+
+var (
+	appInfoDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "app_info"),
+		"Installed apps, always 1. Maps the app_id label of the other metrics to the app slug.",
+		[]string{"app_id", "app"}, nil,
+	)
+	appStateDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "app_state"),
+		"Current state of the app, 1 for the state it is in and 0 for the others.",
+		[]string{"app_id", "state"}, nil,
+	)
+	appDeployedSequenceDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "app_deployed_sequence"),
+		"Sequence of the currently deployed app version.",
+		[]string{"app_id"}, nil,
+	)
+	appAvailableUpdatesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "app_available_updates"),
+		"Number of downloaded app versions newer than the deployed version.",
+		[]string{"app_id"}, nil,
+	)
+	appLastUpdateCheckDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "app_last_update_check_timestamp_seconds"),
+		"Time of the last update check of the app.",
+		[]string{"app_id"}, nil,
+	)
+	appLicenseExpiryDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "app_license_expiry_seconds"),
+		"Seconds until the license of the app expires, negative once it has expired. Not reported for licenses that do not expire.",
+		[]string{"app_id"}, nil,
+	)
+	snapshotAgeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "snapshot_age_seconds"),
+		"Seconds since the last completed snapshot finished.",
+		nil, nil,
+	)
+	snapshotLastResultDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "snapshot_last_result"),
+		"Status of the most recently finished snapshot, always 1.",
+		[]string{"status"}, nil,
+	)
+)
+
+type appMetrics struct {
+	id                string
+	slug              string
+	state             appstatetypes.State
+	deployedSequence  *int64
+	availableUpdates  int
+	lastUpdateCheckAt *time.Time
+	licenseExpiresAt  *time.Time
+}
+
+// appCollector reports metrics that describe the current state of kotsadm. The state is refreshed in the
+// background so that scrapes do not query the store or the cluster.
+type appCollector struct {
+	mu                         sync.Mutex
+	apps                       []appMetrics
+	lastFinishedSnapshotAt     *time.Time
+	lastFinishedSnapshotStatus snapshottypes.BackupStatus
+	lastCompletedSnapshotAt    *time.Time
+	now                        func() time.Time
+}
+
+var defaultAppCollector = &appCollector{now: time.Now}
+
+// Start refreshes the app and snapshot metrics until the process exits
+func Start() {
+	go func() {
+		refreshSnapshots := time.Time{}
+		for {
+			if err := defaultAppCollector.refreshApps(store.GetStore()); err != nil {
+				logger.Error(errors.Wrap(err, "failed to refresh app metrics"))
+			}
+			if time.Since(refreshSnapshots) >= snapshotsRefreshInterval {
+				if err := defaultAppCollector.refreshSnapshots(context.Background(), listBackups); err != nil {
+					logger.Debugf("failed to refresh snapshot metrics: %v", err)
+				}
+				refreshSnapshots = time.Now()
+			}
+			time.Sleep(appsRefreshInterval)
+		}
+	}()
+}
+
+func listBackups(ctx context.Context) ([]*snapshottypes.Backup, error) {
+	return snapshot.ListInstanceBackups(ctx, util.PodNamespace)
+}
+
+func (c *appCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- appInfoDesc
+	ch <- appStateDesc
+	ch <- appDeployedSequenceDesc
+	ch <- appAvailableUpdatesDesc
+	ch <- appLastUpdateCheckDesc
+	ch <- appLicenseExpiryDesc
+	ch <- snapshotAgeDesc
+	ch <- snapshotLastResultDesc
+}
+
+func (c *appCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+
+	for _, a := range c.apps {
+		ch <- prometheus.MustNewConstMetric(appInfoDesc, prometheus.GaugeValue, 1, a.id, a.slug)
+
+		for _, state := range appStates {
+			val := 0.0
+			if a.state == state {
+				val = 1
+			}
+			ch <- prometheus.MustNewConstMetric(appStateDesc, prometheus.GaugeValue, val, a.id, string(state))
+		}
+
+		if a.deployedSequence != nil {
+			ch <- prometheus.MustNewConstMetric(appDeployedSequenceDesc, prometheus.GaugeValue, float64(*a.deployedSequence), a.id)
+		}
+		ch <- prometheus.MustNewConstMetric(appAvailableUpdatesDesc, prometheus.GaugeValue, float64(a.availableUpdates), a.id)
+		if a.lastUpdateCheckAt != nil {
+			ch <- prometheus.MustNewConstMetric(appLastUpdateCheckDesc, prometheus.GaugeValue, float64(a.lastUpdateCheckAt.Unix()), a.id)
+		}
+		if a.licenseExpiresAt != nil {
+			ch <- prometheus.MustNewConstMetric(appLicenseExpiryDesc, prometheus.GaugeValue, a.licenseExpiresAt.Sub(now).Seconds(), a.id)
+		}
+	}
+
+	if c.lastCompletedSnapshotAt != nil {
+		ch <- prometheus.MustNewConstMetric(snapshotAgeDesc, prometheus.GaugeValue, now.Sub(*c.lastCompletedSnapshotAt).Seconds())
+	}
+	if c.lastFinishedSnapshotAt != nil {
+		ch <- prometheus.MustNewConstMetric(snapshotLastResultDesc, prometheus.GaugeValue, 1, string(c.lastFinishedSnapshotStatus))
+	}
+}
+
+func (c *appCollector) refreshApps(kotsStore store.Store) error {
+	apps, err := kotsStore.ListInstalledApps()
+	if err != nil {
+		return errors.Wrap(err, "failed to list installed apps")
+	}
+
+	appsMetrics := []appMetrics{}
+	for _, a := range apps {
+		m := appMetrics{
+			id:                a.ID,
+			slug:              a.Slug,
+			lastUpdateCheckAt: a.LastUpdateCheckAt,
+		}
+
+		appStatus, err := kotsStore.GetAppStatus(a.ID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get status of app %s", a.Slug)
+		}
+		m.state = appStatus.State
+
+		downstreams, err := kotsStore.ListDownstreamsForApp(a.ID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to list downstreams of app %s", a.Slug)
+		}
+		if len(downstreams) > 0 {
+			versions, err := kotsStore.GetDownstreamVersions(a.ID, downstreams[0].ClusterID, true)
+			if err != nil {
+				return errors.Wrapf(err, "failed to get versions of app %s", a.Slug)
+			}
+			if versions.CurrentVersion != nil {
+				sequence := versions.CurrentVersion.ParentSequence
+				m.deployedSequence = &sequence
+			}
+			m.availableUpdates = len(versions.PendingVersions)
+		}
+
+		l, err := kotsStore.GetLatestLicenseForApp(a.ID)
+		if err != nil {
+			return errors.Wrapf(err, "failed to get license of app %s", a.Slug)
+		}
+		if l != nil {
+			expiresAt, err := license.GetLicenseExpiration(l)
+			if err != nil {
+				logger.Debugf("failed to get license expiration of app %s: %v", a.Slug, err)
+			}
+			m.licenseExpiresAt = expiresAt
+		}
+
+		appsMetrics = append(appsMetrics, m)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.apps = appsMetrics
+
+	return nil
+}
+
+func (c *appCollector) refreshSnapshots(ctx context.Context, list func(ctx context.Context) ([]*snapshottypes.Backup, error)) error {
+	backups, err := list(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to list backups")
+	}
+
+	var lastAt, lastCompleted *time.Time
+	var lastStatus snapshottypes.BackupStatus
+	for _, b := range backups {
+		if b.FinishedAt == nil || b.Status == snapshottypes.BackupStatusInProgress || b.Status == snapshottypes.BackupStatusDeleting {
+			continue
+		}
+		if lastAt == nil || b.FinishedAt.After(*lastAt) {
+			lastAt = b.FinishedAt
+			lastStatus = b.Status
+		}
+		if b.Status == snapshottypes.BackupStatusCompleted && (lastCompleted == nil || b.FinishedAt.After(*lastCompleted)) {
+			lastCompleted = b.FinishedAt
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lastFinishedSnapshotAt = lastAt
+	c.lastFinishedSnapshotStatus = lastStatus
+	c.lastCompletedSnapshotAt = lastCompleted
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package kotsadmmetrics
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	snapshottypes "github.com/replicatedhq/kots/pkg/kotsadmsnapshot/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_appCollector_refreshSnapshots(t *testing.T) {
+	now := time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		ts := now.Add(-d)
+		return &ts
+	}
+
+	tests := []struct {
+		name    string
+		backups []*snapshottypes.Backup
+		want    string
+	}{
+		{
+			name:    "no backups",
+			backups: []*snapshottypes.Backup{},
+			want:    "",
+		},
+		{
+			name: "last backup failed after a completed backup",
+			backups: []*snapshottypes.Backup{
+				{Name: "a", Status: snapshottypes.BackupStatusCompleted, FinishedAt: at(2 * time.Hour)},
+				{Name: "b", Status: snapshottypes.BackupStatusFailed, FinishedAt: at(time.Hour)},
+				{Name: "c", Status: snapshottypes.BackupStatusInProgress},
+			},
+			want: `
+# HELP kotsadm_snapshot_age_seconds Seconds since the last completed snapshot finished.
+# TYPE kotsadm_snapshot_age_seconds gauge
+kotsadm_snapshot_age_seconds 7200
+# HELP kotsadm_snapshot_last_result Status of the most recently finished snapshot, always 1.
+# TYPE kotsadm_snapshot_last_result gauge
+kotsadm_snapshot_last_result{status="Failed"} 1
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := require.New(t)
+
+			c := &appCollector{now: func() time.Time { return now }}
+			err := c.refreshSnapshots(context.Background(), func(ctx context.Context) ([]*snapshottypes.Backup, error) {
+				return tt.backups, nil
+			})
+			req.NoError(err)
+
+			err = testutil.CollectAndCompare(c, strings.NewReader(tt.want), "kotsadm_snapshot_age_seconds", "kotsadm_snapshot_last_result")
+			req.NoError(err)
+		})
+	}
+}
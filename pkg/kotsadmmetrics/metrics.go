@@ -0,0 +1,119 @@
+package kotsadmmetrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "kotsadm"
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailed  = "failed"
+)
+
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of kotsadm API requests by route name.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"route", "method", "code"})
+
+	deploysTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "deploys_total",
+		Help:      "Number of app version deploys by outcome.",
+	}, []string{"app_id", "outcome"})
+
+	deployDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "deploy_duration_seconds",
+		Help:      "Duration of app version deploys by outcome.",
+		Buckets:   []float64{5, 15, 30, 60, 120, 300, 600, 1200, 1800, 3600},
+	}, []string{"app_id", "outcome"})
+
+	updateChecksTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "update_checks_total",
+		Help:      "Number of app update checks by result.",
+	}, []string{"app_id", "result"})
+
+	lastUpdateCheckSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "app_last_update_check_success",
+		Help:      "Whether the last update check of the app succeeded (1) or failed (0).",
+	}, []string{"app_id"})
+
+	preflightRunsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "preflight_runs_total",
+		Help:      "Number of preflight check runs by outcome.",
+	}, []string{"app_id", "outcome"})
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestDuration,
+		deploysTotal,
+		deployDuration,
+		updateChecksTotal,
+		lastUpdateCheckSuccess,
+		preflightRunsTotal,
+		defaultAppCollector,
+	)
+}
+
+// Handler returns the http handler that serves all kotsadm metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest records the latency of an API request. The route should be the name of the matched
+// route, or its path template, and never the raw path so that the number of series stays bounded.
+func ObserveHTTPRequest(route string, method string, code int, duration time.Duration) {
+	httpRequestDuration.WithLabelValues(route, method, strconv.Itoa(code)).Observe(duration.Seconds())
+}
+
+// RecordDeployResult counts the result of deploying an app version, including deploys done by an agent
+func RecordDeployResult(appID string, succeeded bool) {
+	deploysTotal.WithLabelValues(appID, outcome(succeeded)).Inc()
+}
+
+// ObserveDeployDuration records how long kotsadm took to deploy an app version
+func ObserveDeployDuration(appID string, succeeded bool, duration time.Duration) {
+	deployDuration.WithLabelValues(appID, outcome(succeeded)).Observe(duration.Seconds())
+}
+
+// RecordUpdateCheck counts an update check of the app and sets the result of its last update check
+func RecordUpdateCheck(appID string, checkErr error) {
+	succeeded := checkErr == nil
+	updateChecksTotal.WithLabelValues(appID, outcome(succeeded)).Inc()
+	if succeeded {
+		lastUpdateCheckSuccess.WithLabelValues(appID).Set(1)
+	} else {
+		lastUpdateCheckSuccess.WithLabelValues(appID).Set(0)
+	}
+}
+
+// RecordPreflightRun counts a completed preflight run. The outcome is the state of the preflight results,
+// one of pass, warn or fail.
+func RecordPreflightRun(appID string, outcome string) {
+	preflightRunsTotal.WithLabelValues(appID, outcome).Inc()
+}
+
+func outcome(succeeded bool) string {
+	if succeeded {
+		return OutcomeSuccess
+	}
+	return OutcomeFailed
+}
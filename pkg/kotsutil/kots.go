@@ -1292,6 +1292,7 @@ type InstallationParams struct {
 	RequestedChannelSlug   string
 	AdditionalAnnotations  map[string]string
 	AdditionalLabels       map[string]string
+	Applier                string
 
 	// Prune is an operator-controlled escape hatch (set directly on the kotsadm-confg
 	// ConfigMap) for cleaning up old support bundle and app version archives.
@@ -1348,6 +1349,7 @@ func GetInstallationParamsWithClientset(clientset kubernetes.Interface, configMa
 	autoConfig.WithMinio, _ = strconv.ParseBool(kotsadmConfigMap.Data["with-minio"])
 	autoConfig.AppVersionLabel = kotsadmConfigMap.Data["app-version-label"]
 	autoConfig.RequestedChannelSlug = kotsadmConfigMap.Data["requested-channel-slug"]
+	autoConfig.Applier = kotsadmConfigMap.Data["applier"]
 
 	autoConfig.PruneEnabled, _ = strconv.ParseBool(kotsadmConfigMap.Data["prune-enabled"])
 	if v, ok := kotsadmConfigMap.Data["prune-support-bundle-count"]; ok {
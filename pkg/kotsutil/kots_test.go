@@ -1493,6 +1493,29 @@ func TestGetInstallationParamsWithClientset(t *testing.T) {
 				PruneAppVersionCount:    50,
 			},
 		},
+		{
+			name: "server-side applier",
+			args: args{
+				configMapName: "kotsadm",
+				namespace:     "test-namespace",
+				clientSet: fake.NewClientset(&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "kotsadm",
+						Namespace: "test-namespace",
+					},
+					Data: map[string]string{
+						"applier": "server-side",
+					},
+				}),
+			},
+			want: kotsutil.InstallationParams{
+				AdditionalAnnotations:   map[string]string{},
+				AdditionalLabels:        map[string]string{},
+				Applier:                 "server-side",
+				PruneSupportBundleCount: 25,
+				PruneAppVersionCount:    50,
+			},
+		},
 		{
 			name: "prune enabled with custom counts",
 			args: args{
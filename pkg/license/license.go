@@ -11,29 +11,41 @@ import (
 // LicenseIsExpired checks if a license has expired based on the expires_at entitlement.
 // Works with both v1beta1 and v1beta2 licenses via the wrapper.
 func LicenseIsExpired(license *licensewrapper.LicenseWrapper) (bool, error) {
+	expiresAt, err := GetLicenseExpiration(license)
+	if err != nil {
+		return false, err
+	}
+	if expiresAt == nil {
+		return false, nil
+	}
+	return expiresAt.Before(time.Now()), nil
+}
+
+// GetLicenseExpiration returns the time from the expires_at entitlement, or nil if the license does not expire.
+func GetLicenseExpiration(license *licensewrapper.LicenseWrapper) (*time.Time, error) {
 	// Use wrapper method to get entitlements (works for both v1beta1 and v1beta2)
 	entitlements := license.GetEntitlements()
 
 	val, found := entitlements["expires_at"]
 	if !found {
-		return false, nil
+		return nil, nil
 	}
 	if val.GetValueType() != "" && val.GetValueType() != "String" {
-		return false, errors.Errorf("expires_at must be type String: %s", val.GetValueType())
+		return nil, errors.Errorf("expires_at must be type String: %s", val.GetValueType())
 	}
 	expiry, ok := val.GetValue().(string)
 	if !ok {
-		return false, errors.New("expires_at value is not a string")
+		return nil, errors.New("expires_at value is not a string")
 	}
 	if expiry == "" {
-		return false, nil
+		return nil, nil
 	}
 
 	parsed, err := time.Parse(time.RFC3339, expiry)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to parse expiration time")
+		return nil, errors.Wrap(err, "failed to parse expiration time")
 	}
-	return parsed.Before(time.Now()), nil
+	return &parsed, nil
 }
 
 // Deprecated: Use LicenseIsExpired with LicenseWrapper instead.
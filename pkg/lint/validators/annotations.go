@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/replicatedhq/kots/pkg/lint/types"
@@ -59,6 +60,17 @@ func ValidateAnnotations(specFiles types.SpecFiles) ([]types.LintExpression, err
 					}
 					lintExpressions = append(lintExpressions, lintExpression)
 				}
+			case kotsoperatortypes.PhaseReadyTimeoutAnnotation:
+				// check that the value is a positive duration
+				if timeout, err := time.ParseDuration(value); err != nil || timeout <= 0 {
+					lintExpression := types.LintExpression{
+						Rule:    "phase-ready-timeout-annotation",
+						Type:    "error",
+						Path:    spec.Path,
+						Message: fmt.Sprintf("Resource annotation %s should be a positive duration, e.g. 10m", key),
+					}
+					lintExpressions = append(lintExpressions, lintExpression)
+				}
 			case kotsoperatortypes.WaitForPropertiesAnnotation:
 				// check that the value is a comma separated list of key=value pairs
 				// where the key is a valid jsonpath and the value is not empty
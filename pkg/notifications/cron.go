@@ -0,0 +1,95 @@
+package notifications
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/license"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/notifications/types"
+	"github.com/replicatedhq/kots/pkg/store"
+	"github.com/robfig/cron/v3"
+)
+
+const (
+	// notificationsCronSpec - daily cron spec for the license expiration check and delivery log retention
+	notificationsCronSpec = "0 12 * * *"
+
+	// licenseExpiringWindow - licenses that expire within this window raise a license-expiring event every day
+	licenseExpiringWindow = 30 * 24 * time.Hour
+
+	// deliveryRetention - how long delivery log entries are kept
+	deliveryRetention = 30 * 24 * time.Hour
+)
+
+func startCronJob() error {
+	logger.Debug("starting notifications cron job")
+
+	cronJob := cron.New(cron.WithChain(
+		cron.Recover(cron.DefaultLogger),
+	))
+
+	_, err := cronJob.AddFunc(notificationsCronSpec, func() {
+		if err := checkLicenseExpiration(time.Now()); err != nil {
+			logger.Error(errors.Wrap(err, "failed to check license expiration"))
+		}
+		if err := store.GetStore().DeleteNotificationDeliveriesBefore(time.Now().Add(-deliveryRetention)); err != nil {
+			logger.Error(errors.Wrap(err, "failed to delete expired notification deliveries"))
+		}
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to add cron job")
+	}
+	cronJob.Start()
+	return nil
+}
+
+// checkLicenseExpiration raises a license-expiring event for every installed app whose license expires soon
+func checkLicenseExpiration(now time.Time) error {
+	apps, err := store.GetStore().ListInstalledApps()
+	if err != nil {
+		return errors.Wrap(err, "failed to list installed apps")
+	}
+
+	for _, a := range apps {
+		l, err := store.GetStore().GetLatestLicenseForApp(a.ID)
+		if err != nil {
+			logger.Error(errors.Wrapf(err, "failed to get license for app %s", a.Slug))
+			continue
+		}
+
+		expiresAt, err := license.GetLicenseExpiration(l)
+		if err != nil {
+			logger.Error(errors.Wrapf(err, "failed to get license expiration for app %s", a.Slug))
+			continue
+		}
+
+		event := licenseExpiringEvent(a.ID, a.Slug, expiresAt, now)
+		if event != nil {
+			Notify(*event)
+		}
+	}
+
+	return nil
+}
+
+// licenseExpiringEvent returns the event to raise for a license expiring at the given time, or nil
+// if the license does not expire, has already expired or expires after the window
+func licenseExpiringEvent(appID string, appSlug string, expiresAt *time.Time, now time.Time) *types.Event {
+	if expiresAt == nil || !expiresAt.After(now) || expiresAt.Sub(now) > licenseExpiringWindow {
+		return nil
+	}
+
+	days := int(math.Ceil(expiresAt.Sub(now).Hours() / 24))
+	return &types.Event{
+		Type:    types.EventLicenseExpiring,
+		AppID:   appID,
+		AppSlug: appSlug,
+		Message: fmt.Sprintf("The license for %s expires in %d day(s), on %s.", appSlug, days, expiresAt.UTC().Format(time.RFC3339)),
+		Data: map[string]string{
+			"expiresAt": expiresAt.UTC().Format(time.RFC3339),
+		},
+	}
+}
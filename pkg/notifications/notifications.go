@@ -0,0 +1,225 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/notifications/types"
+	"github.com/replicatedhq/kots/pkg/store"
+	"github.com/segmentio/ksuid"
+)
+
+const (
+	// maxDeliveryAttempts is how many times an event is sent to a sink before the delivery is marked as failed
+	maxDeliveryAttempts = 5
+	// initialRetryBackoff is the wait before the first retry, it doubles after every failed attempt
+	initialRetryBackoff = 10 * time.Second
+	// sendTimeout bounds a single delivery attempt
+	sendTimeout = 30 * time.Second
+)
+
+type sendFunc func(ctx context.Context, sink *types.Sink, event *types.Event, delivery *types.Delivery) error
+
+type dispatcher struct {
+	store          store.Store
+	senders        map[types.SinkType]sendFunc
+	maxAttempts    int
+	initialBackoff time.Duration
+}
+
+var defaultDispatcher *dispatcher
+
+func newDispatcher(kotsStore store.Store) *dispatcher {
+	httpClient := &http.Client{Timeout: sendTimeout}
+	return &dispatcher{
+		store: kotsStore,
+		senders: map[types.SinkType]sendFunc{
+			types.SinkTypeWebhook: func(ctx context.Context, sink *types.Sink, event *types.Event, delivery *types.Delivery) error {
+				return sendWebhook(ctx, httpClient, sink.Webhook, event, delivery)
+			},
+			types.SinkTypeSMTP: func(ctx context.Context, sink *types.Sink, event *types.Event, delivery *types.Delivery) error {
+				return sendSMTP(ctx, sink.SMTP, event)
+			},
+		},
+		maxAttempts:    maxDeliveryAttempts,
+		initialBackoff: initialRetryBackoff,
+	}
+}
+
+// Init enables event delivery and starts the daily license expiration check
+func Init() error {
+	defaultDispatcher = newDispatcher(store.GetStore())
+
+	if err := startCronJob(); err != nil {
+		return errors.Wrap(err, "failed to start notifications cron job")
+	}
+
+	return nil
+}
+
+// Notify delivers an event to the notification sinks of its app in the background.
+// It does nothing if notifications have not been initialized.
+func Notify(event types.Event) {
+	d := defaultDispatcher
+	if d == nil {
+		return
+	}
+	go d.dispatch(event)
+}
+
+// dispatch delivers an event to every sink of its app that subscribes to the event type,
+// and returns when all deliveries have either succeeded or run out of attempts
+func (d *dispatcher) dispatch(event types.Event) {
+	if event.ID == "" {
+		event.ID = ksuid.New().String()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+	if event.AppSlug == "" {
+		a, err := d.store.GetApp(event.AppID)
+		if err != nil {
+			logger.Error(errors.Wrapf(err, "failed to get app %s for %s notification", event.AppID, event.Type))
+			return
+		}
+		event.AppSlug = a.Slug
+	}
+
+	sinks, err := d.store.ListNotificationSinks(event.AppID)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "failed to list notification sinks for app %s", event.AppSlug))
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, sink := range sinks {
+		if !sink.Subscribes(event.Type) {
+			continue
+		}
+
+		delivery := &types.Delivery{
+			AppID:     event.AppID,
+			SinkID:    sink.ID,
+			EventID:   event.ID,
+			EventType: event.Type,
+			Message:   event.Message,
+			Status:    types.DeliveryPending,
+		}
+		if err := d.store.CreateNotificationDelivery(delivery); err != nil {
+			logger.Error(errors.Wrapf(err, "failed to create notification delivery for sink %s", sink.ID))
+			continue
+		}
+
+		wg.Add(1)
+		go func(sink *types.Sink) {
+			defer wg.Done()
+			d.deliver(sink, &event, delivery)
+		}(sink)
+	}
+	wg.Wait()
+}
+
+// deliver sends an event to a sink, retrying with exponential backoff.
+// The delivery record is updated after every attempt.
+func (d *dispatcher) deliver(sink *types.Sink, event *types.Event, delivery *types.Delivery) {
+	send, ok := d.senders[sink.Type]
+	if !ok {
+		delivery.Status = types.DeliveryFailed
+		delivery.LastError = fmt.Sprintf("unsupported sink type %q", sink.Type)
+		if err := d.store.UpdateNotificationDelivery(delivery); err != nil {
+			logger.Error(errors.Wrapf(err, "failed to update notification delivery %s", delivery.ID))
+		}
+		return
+	}
+
+	backoff := d.initialBackoff
+	for delivery.Attempts < d.maxAttempts {
+		if delivery.Attempts > 0 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		err := send(ctx, sink, event, delivery)
+		cancel()
+
+		delivery.Attempts++
+		if err == nil {
+			delivery.Status = types.DeliveryDelivered
+			delivery.LastError = ""
+		} else {
+			delivery.LastError = err.Error()
+			if delivery.Attempts >= d.maxAttempts {
+				delivery.Status = types.DeliveryFailed
+				logger.Errorf("failed to deliver %s notification to sink %s after %d attempts: %v", event.Type, sink.ID, delivery.Attempts, err)
+			}
+		}
+
+		if err := d.store.UpdateNotificationDelivery(delivery); err != nil {
+			logger.Error(errors.Wrapf(err, "failed to update notification delivery %s", delivery.ID))
+		}
+
+		if delivery.Status != types.DeliveryPending {
+			return
+		}
+	}
+}
+
+// ValidateSink checks that a sink has a name, known event types and a complete configuration for its type
+func ValidateSink(sink *types.Sink) error {
+	if sink.Name == "" {
+		return errors.New("name is required")
+	}
+
+	for _, eventType := range sink.Events {
+		if !types.IsValidEventType(eventType) {
+			return errors.Errorf("unknown event type %q", eventType)
+		}
+	}
+
+	switch sink.Type {
+	case types.SinkTypeWebhook:
+		if sink.Webhook == nil || sink.SMTP != nil {
+			return errors.New("webhook sinks require only the webhook configuration")
+		}
+		u, err := url.Parse(sink.Webhook.URL)
+		if err != nil {
+			return errors.Wrap(err, "failed to parse webhook url")
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("webhook url must be an absolute http or https url")
+		}
+	case types.SinkTypeSMTP:
+		if sink.SMTP == nil || sink.Webhook != nil {
+			return errors.New("smtp sinks require only the smtp configuration")
+		}
+		if sink.SMTP.Host == "" {
+			return errors.New("smtp host is required")
+		}
+		if sink.SMTP.Port < 0 || sink.SMTP.Port > 65535 {
+			return errors.Errorf("invalid smtp port %d", sink.SMTP.Port)
+		}
+		if _, err := mail.ParseAddress(sink.SMTP.From); err != nil {
+			return errors.Wrap(err, "invalid from address")
+		}
+		if len(sink.SMTP.To) == 0 {
+			return errors.New("at least one recipient is required")
+		}
+		for _, to := range sink.SMTP.To {
+			if _, err := mail.ParseAddress(to); err != nil {
+				return errors.Wrapf(err, "invalid recipient %q", to)
+			}
+		}
+	default:
+		return errors.Errorf("unsupported sink type %q", sink.Type)
+	}
+
+	return nil
+}
@@ -0,0 +1,332 @@
+package notifications
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/replicatedhq/kots/pkg/notifications/types"
+	mock_store "github.com/replicatedhq/kots/pkg/store/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookServer is a stand-in webhook receiver that fails the first failures requests
+type webhookServer struct {
+	*httptest.Server
+
+	mtx      sync.Mutex
+	failures int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newWebhookServer(failures int) *webhookServer {
+	s := &webhookServer{failures: failures}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		s.mtx.Lock()
+		defer s.mtx.Unlock()
+
+		s.requests = append(s.requests, r)
+		s.bodies = append(s.bodies, body)
+		if len(s.requests) <= s.failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	return s
+}
+
+// recordDeliveries records a copy of the delivery on every update
+func recordDeliveries(mockStore *mock_store.MockStore) *[]types.Delivery {
+	updates := []types.Delivery{}
+	mockStore.EXPECT().CreateNotificationDelivery(gomock.Any()).DoAndReturn(func(delivery *types.Delivery) error {
+		delivery.ID = "delivery-id"
+		return nil
+	}).AnyTimes()
+	mockStore.EXPECT().UpdateNotificationDelivery(gomock.Any()).DoAndReturn(func(delivery *types.Delivery) error {
+		updates = append(updates, *delivery)
+		return nil
+	}).AnyTimes()
+	return &updates
+}
+
+func Test_dispatchWebhook(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newWebhookServer(1)
+	defer server.Close()
+
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().ListNotificationSinks("app-id").Return([]*types.Sink{
+		{
+			ID:      "webhook",
+			Type:    types.SinkTypeWebhook,
+			Webhook: &types.WebhookConfig{URL: server.URL, Secret: "shh"},
+		},
+		{
+			ID:      "unsubscribed",
+			Type:    types.SinkTypeWebhook,
+			Events:  []types.EventType{types.EventBackupFailed},
+			Webhook: &types.WebhookConfig{URL: server.URL},
+		},
+	}, nil)
+	updates := recordDeliveries(mockStore)
+
+	d := newDispatcher(mockStore)
+	d.initialBackoff = time.Millisecond
+
+	sequence := int64(3)
+	d.dispatch(types.Event{
+		Type:     types.EventDeployFailed,
+		AppID:    "app-id",
+		AppSlug:  "my-app",
+		Sequence: &sequence,
+		Message:  "Failed to deploy sequence 3.",
+	})
+
+	// the first attempt fails and is retried
+	require.Len(t, server.requests, 2)
+	require.Len(t, *updates, 2)
+	assert.Equal(t, types.DeliveryPending, (*updates)[0].Status)
+	assert.Equal(t, 1, (*updates)[0].Attempts)
+	assert.Contains(t, (*updates)[0].LastError, "503")
+	assert.Equal(t, types.DeliveryDelivered, (*updates)[1].Status)
+	assert.Equal(t, 2, (*updates)[1].Attempts)
+	assert.Empty(t, (*updates)[1].LastError)
+
+	req, body := server.requests[1], server.bodies[1]
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	assert.Equal(t, string(types.EventDeployFailed), req.Header.Get(EventHeader))
+	assert.Equal(t, "delivery-id", req.Header.Get(DeliveryHeader))
+	assert.True(t, hmac.Equal([]byte(Sign("shh", body)), []byte(req.Header.Get(SignatureHeader))))
+	assert.False(t, hmac.Equal([]byte(Sign("wrong", body)), []byte(req.Header.Get(SignatureHeader))))
+
+	event := types.Event{}
+	require.NoError(t, json.Unmarshal(body, &event))
+	assert.NotEmpty(t, event.ID)
+	assert.Equal(t, types.EventDeployFailed, event.Type)
+	assert.Equal(t, "my-app", event.AppSlug)
+	assert.Equal(t, int64(3), *event.Sequence)
+	assert.Equal(t, "Failed to deploy sequence 3.", event.Message)
+}
+
+func Test_dispatchGivesUp(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	server := newWebhookServer(100)
+	defer server.Close()
+
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().ListNotificationSinks("app-id").Return([]*types.Sink{
+		{ID: "webhook", Type: types.SinkTypeWebhook, Webhook: &types.WebhookConfig{URL: server.URL}},
+	}, nil)
+	updates := recordDeliveries(mockStore)
+
+	d := newDispatcher(mockStore)
+	d.initialBackoff = time.Millisecond
+	d.maxAttempts = 3
+
+	d.dispatch(types.Event{Type: types.EventUpdateAvailable, AppID: "app-id", AppSlug: "my-app"})
+
+	assert.Len(t, server.requests, 3)
+	require.Len(t, *updates, 3)
+	last := (*updates)[2]
+	assert.Equal(t, types.DeliveryFailed, last.Status)
+	assert.Equal(t, 3, last.Attempts)
+	assert.Contains(t, last.LastError, "503")
+	assert.Empty(t, server.requests[0].Header.Get(SignatureHeader))
+}
+
+// smtpServer is a stand-in smtp server that accepts a single message
+type smtpServer struct {
+	listener net.Listener
+	from     string
+	to       []string
+	data     string
+	done     chan struct{}
+}
+
+func newSMTPServer(t *testing.T) *smtpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &smtpServer{listener: listener, done: make(chan struct{})}
+	go s.serve()
+	return s
+}
+
+func (s *smtpServer) serve() {
+	defer close(s.done)
+
+	conn, err := s.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	tp := textproto.NewConn(conn)
+	tp.PrintfLine("220 localhost ready")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			tp.PrintfLine("250 localhost")
+		case "MAIL":
+			s.from = strings.Trim(strings.TrimPrefix(line, "MAIL FROM:"), "<>")
+			tp.PrintfLine("250 OK")
+		case "RCPT":
+			s.to = append(s.to, strings.Trim(strings.TrimPrefix(line, "RCPT TO:"), "<>"))
+			tp.PrintfLine("250 OK")
+		case "DATA":
+			tp.PrintfLine("354 go ahead")
+			data, err := io.ReadAll(tp.DotReader())
+			if err != nil {
+				return
+			}
+			s.data = string(data)
+			tp.PrintfLine("250 OK")
+		case "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("502 not implemented")
+		}
+	}
+}
+
+func Test_sendSMTP(t *testing.T) {
+	server := newSMTPServer(t)
+	defer server.listener.Close()
+
+	host, portStr, err := net.SplitHostPort(server.listener.Addr().String())
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+
+	config := &types.SMTPConfig{
+		Host: host,
+		Port: port,
+		From: "kots@example.com",
+		To:   []string{"ops@example.com", "oncall@example.com"},
+	}
+	sequence := int64(5)
+	event := &types.Event{
+		Type:      types.EventAppStateChanged,
+		AppSlug:   "my-app",
+		Sequence:  &sequence,
+		Message:   "App state changed from ready to degraded.",
+		Data:      map[string]string{"state": "degraded", "previousState": "ready"},
+		CreatedAt: time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC),
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, sendSMTP(ctx, config, event))
+	<-server.done
+
+	assert.Equal(t, "kots@example.com", server.from)
+	assert.Equal(t, []string{"ops@example.com", "oncall@example.com"}, server.to)
+
+	msg, err := textproto.NewReader(bufio.NewReader(strings.NewReader(server.data))).ReadMIMEHeader()
+	require.NoError(t, err)
+	assert.Equal(t, "[my-app] app-state-changed", msg.Get("Subject"))
+	assert.Equal(t, "ops@example.com, oncall@example.com", msg.Get("To"))
+	assert.Contains(t, server.data, "App state changed from ready to degraded.\n")
+	assert.Contains(t, server.data, "Sequence: 5\n")
+	assert.Contains(t, server.data, "previousState: ready\nstate: degraded\n")
+}
+
+func Test_ValidateSink(t *testing.T) {
+	tests := []struct {
+		name    string
+		sink    types.Sink
+		wantErr string
+	}{
+		{
+			name: "valid webhook",
+			sink: types.Sink{Name: "hook", Type: types.SinkTypeWebhook, Webhook: &types.WebhookConfig{URL: "https://example.com/hook"}},
+		},
+		{
+			name: "valid smtp",
+			sink: types.Sink{Name: "mail", Type: types.SinkTypeSMTP, Events: []types.EventType{types.EventDeployFailed}, SMTP: &types.SMTPConfig{Host: "smtp.example.com", From: "kots@example.com", To: []string{"ops@example.com"}}},
+		},
+		{
+			name:    "missing name",
+			sink:    types.Sink{Type: types.SinkTypeWebhook, Webhook: &types.WebhookConfig{URL: "https://example.com/hook"}},
+			wantErr: "name is required",
+		},
+		{
+			name:    "unknown event",
+			sink:    types.Sink{Name: "hook", Type: types.SinkTypeWebhook, Events: []types.EventType{"deploy-exploded"}, Webhook: &types.WebhookConfig{URL: "https://example.com/hook"}},
+			wantErr: `unknown event type "deploy-exploded"`,
+		},
+		{
+			name:    "relative webhook url",
+			sink:    types.Sink{Name: "hook", Type: types.SinkTypeWebhook, Webhook: &types.WebhookConfig{URL: "/hook"}},
+			wantErr: "webhook url must be an absolute http or https url",
+		},
+		{
+			name:    "webhook without config",
+			sink:    types.Sink{Name: "hook", Type: types.SinkTypeWebhook},
+			wantErr: "webhook sinks require only the webhook configuration",
+		},
+		{
+			name:    "smtp without recipients",
+			sink:    types.Sink{Name: "mail", Type: types.SinkTypeSMTP, SMTP: &types.SMTPConfig{Host: "smtp.example.com", From: "kots@example.com"}},
+			wantErr: "at least one recipient is required",
+		},
+		{
+			name:    "unknown type",
+			sink:    types.Sink{Name: "pager", Type: "pager"},
+			wantErr: `unsupported sink type "pager"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSink(&tt.sink)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_licenseExpiringEvent(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	inTenDays := now.Add(10 * 24 * time.Hour)
+	inSixtyDays := now.Add(60 * 24 * time.Hour)
+	yesterday := now.Add(-24 * time.Hour)
+
+	assert.Nil(t, licenseExpiringEvent("app-id", "my-app", nil, now))
+	assert.Nil(t, licenseExpiringEvent("app-id", "my-app", &inSixtyDays, now))
+	assert.Nil(t, licenseExpiringEvent("app-id", "my-app", &yesterday, now))
+
+	event := licenseExpiringEvent("app-id", "my-app", &inTenDays, now)
+	require.NotNil(t, event)
+	assert.Equal(t, types.EventLicenseExpiring, event.Type)
+	assert.Equal(t, "The license for my-app expires in 10 day(s), on 2024-05-20T12:00:00Z.", event.Message)
+}
@@ -0,0 +1,112 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/notifications/types"
+)
+
+const defaultSMTPPort = 587
+
+// sendSMTP emails an event to the configured recipients.
+// STARTTLS is used when the server supports it, and credentials are only sent if a username is set.
+func sendSMTP(ctx context.Context, config *types.SMTPConfig, event *types.Event) error {
+	if config == nil {
+		return errors.New("smtp is not configured")
+	}
+
+	port := config.Port
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+	addr := net.JoinHostPort(config.Host, strconv.Itoa(port))
+
+	dialer := &net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to connect to %s", addr)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	c, err := smtp.NewClient(conn, config.Host)
+	if err != nil {
+		conn.Close()
+		return errors.Wrap(err, "failed to create smtp client")
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: config.Host}); err != nil {
+			return errors.Wrap(err, "failed to start tls")
+		}
+	}
+
+	if config.Username != "" {
+		if err := c.Auth(smtp.PlainAuth("", config.Username, config.Password, config.Host)); err != nil {
+			return errors.Wrap(err, "failed to authenticate")
+		}
+	}
+
+	if err := c.Mail(config.From); err != nil {
+		return errors.Wrap(err, "failed to set sender")
+	}
+	for _, to := range config.To {
+		if err := c.Rcpt(to); err != nil {
+			return errors.Wrapf(err, "failed to add recipient %s", to)
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return errors.Wrap(err, "failed to start message")
+	}
+	if _, err := w.Write(buildEmail(config, event)); err != nil {
+		return errors.Wrap(err, "failed to write message")
+	}
+	if err := w.Close(); err != nil {
+		return errors.Wrap(err, "failed to send message")
+	}
+
+	return c.Quit()
+}
+
+func buildEmail(config *types.SMTPConfig, event *types.Event) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "From: %s\r\n", config.From)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(config.To, ", "))
+	fmt.Fprintf(&b, "Subject: [%s] %s\r\n", event.AppSlug, event.Type)
+	fmt.Fprintf(&b, "Date: %s\r\n", event.CreatedAt.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: text/plain; charset=UTF-8\r\n")
+	fmt.Fprintf(&b, "\r\n")
+
+	fmt.Fprintf(&b, "%s\r\n\r\n", event.Message)
+	fmt.Fprintf(&b, "App: %s\r\n", event.AppSlug)
+	if event.Sequence != nil {
+		fmt.Fprintf(&b, "Sequence: %d\r\n", *event.Sequence)
+	}
+
+	keys := make([]string, 0, len(event.Data))
+	for k := range event.Data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s: %s\r\n", k, event.Data[k])
+	}
+
+	return b.Bytes()
+}
@@ -0,0 +1,148 @@
+package types
+
+import (
+	"time"
+)
+
+type EventType string
+
+const (
+	EventUpdateAvailable EventType = "update-available"
+	EventDeployStarted   EventType = "deploy-started"
+	EventDeploySucceeded EventType = "deploy-succeeded"
+	EventDeployFailed    EventType = "deploy-failed"
+	EventPreflightFailed EventType = "preflight-failed"
+	EventBackupFailed    EventType = "backup-failed"
+	EventLicenseExpiring EventType = "license-expiring"
+	EventAppStateChanged EventType = "app-state-changed"
+	EventDriftDetected   EventType = "drift-detected"
+)
+
+// EventTypes returns all event types that sinks can subscribe to
+func EventTypes() []EventType {
+	return []EventType{
+		EventUpdateAvailable,
+		EventDeployStarted,
+		EventDeploySucceeded,
+		EventDeployFailed,
+		EventPreflightFailed,
+		EventBackupFailed,
+		EventLicenseExpiring,
+		EventAppStateChanged,
+		EventDriftDetected,
+	}
+}
+
+func IsValidEventType(eventType EventType) bool {
+	for _, t := range EventTypes() {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is a single app lifecycle event. It is the json body of webhook notifications.
+type Event struct {
+	ID        string            `json:"id"`
+	Type      EventType         `json:"type"`
+	AppID     string            `json:"appId"`
+	AppSlug   string            `json:"appSlug,omitempty"`
+	Sequence  *int64            `json:"sequence,omitempty"`
+	Message   string            `json:"message"`
+	Data      map[string]string `json:"data,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+type SinkType string
+
+const (
+	SinkTypeWebhook SinkType = "webhook"
+	SinkTypeSMTP    SinkType = "smtp"
+)
+
+// SecretMask replaces webhook secrets and smtp passwords in api responses
+const SecretMask = "***HIDDEN***"
+
+// Sink is a destination that the events of an app are delivered to
+type Sink struct {
+	ID    string   `json:"id"`
+	AppID string   `json:"appId"`
+	Name  string   `json:"name"`
+	Type  SinkType `json:"type"`
+	// Events are the event types delivered to the sink. All events are delivered if empty.
+	Events    []EventType    `json:"events,omitempty"`
+	Webhook   *WebhookConfig `json:"webhook,omitempty"`
+	SMTP      *SMTPConfig    `json:"smtp,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+type WebhookConfig struct {
+	URL string `json:"url"`
+	// Secret is the key used to sign the request body. Requests are not signed if empty.
+	Secret string `json:"secret,omitempty"`
+}
+
+type SMTPConfig struct {
+	Host     string   `json:"host"`
+	Port     int      `json:"port"`
+	Username string   `json:"username,omitempty"`
+	Password string   `json:"password,omitempty"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+}
+
+// Subscribes returns true if events of the given type are delivered to the sink
+func (s Sink) Subscribes(eventType EventType) bool {
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, t := range s.Events {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Masked returns a copy of the sink with its secrets replaced by SecretMask
+func (s Sink) Masked() Sink {
+	if s.Webhook != nil {
+		webhook := *s.Webhook
+		if webhook.Secret != "" {
+			webhook.Secret = SecretMask
+		}
+		s.Webhook = &webhook
+	}
+	if s.SMTP != nil {
+		smtp := *s.SMTP
+		if smtp.Password != "" {
+			smtp.Password = SecretMask
+		}
+		s.SMTP = &smtp
+	}
+	return s
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	DeliveryFailed    DeliveryStatus = "failed"
+)
+
+// Delivery is the record of delivering one event to one sink
+type Delivery struct {
+	ID        string         `json:"id"`
+	AppID     string         `json:"appId"`
+	SinkID    string         `json:"sinkId"`
+	EventID   string         `json:"eventId"`
+	EventType EventType      `json:"eventType"`
+	Message   string         `json:"message"`
+	Status    DeliveryStatus `json:"status"`
+	Attempts  int            `json:"attempts"`
+	LastError string         `json:"lastError,omitempty"`
+	CreatedAt time.Time      `json:"createdAt"`
+	UpdatedAt time.Time      `json:"updatedAt"`
+}
@@ -0,0 +1,69 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/buildversion"
+	"github.com/replicatedhq/kots/pkg/notifications/types"
+)
+
+const (
+	// EventHeader is the type of the event in the request body
+	EventHeader = "X-Kots-Event"
+	// DeliveryHeader is the id of the delivery, it is the same for every retry of the delivery
+	DeliveryHeader = "X-Kots-Delivery"
+	// SignatureHeader is the signature of the request body, only set if the sink has a secret
+	SignatureHeader = "X-Kots-Signature"
+)
+
+// Sign returns the value of the signature header for a webhook body.
+// It is "sha256=" followed by the hex encoded HMAC-SHA256 of the body, keyed with the sink secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func sendWebhook(ctx context.Context, client *http.Client, config *types.WebhookConfig, event *types.Event, delivery *types.Delivery) error {
+	if config == nil {
+		return errors.New("webhook is not configured")
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, config.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", buildversion.GetUserAgent())
+	req.Header.Set(EventHeader, string(event.Type))
+	req.Header.Set(DeliveryHeader, delivery.ID)
+	if config.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(config.Secret, body))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to send request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("unexpected status code %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
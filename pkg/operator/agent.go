@@ -0,0 +1,163 @@
+package operator
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	apptypes "github.com/replicatedhq/kots/pkg/app/types"
+	"github.com/replicatedhq/kots/pkg/kotsutil"
+	"github.com/replicatedhq/kots/pkg/logger"
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+	"github.com/replicatedhq/kots/pkg/render"
+	storetypes "github.com/replicatedhq/kots/pkg/store/types"
+	"github.com/replicatedhq/kots/pkg/util"
+)
+
+// isRemoteApp returns true if the app is assigned to a cluster other than the one kotsadm runs in. Remote apps
+// are deployed by the agent running in their cluster.
+func (o *Operator) isRemoteApp(appID string) (bool, error) {
+	downstreams, err := o.store.ListDownstreamsForApp(appID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to list downstreams for app")
+	}
+	if len(downstreams) == 0 {
+		return false, nil
+	}
+	for _, d := range downstreams {
+		if d.ClusterID == o.clusterID {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// GetAgentDesiredState returns the app versions that the agent of the given remote cluster should deploy,
+// which are the current versions of its apps that are waiting to be deployed. If includeInformers is true,
+// the status informers of the current versions are included as well.
+func (o *Operator) GetAgentDesiredState(clusterID string, includeInformers bool) (*operatortypes.AgentDesiredState, error) {
+	apps, err := o.store.ListAppsForDownstream(clusterID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list apps for cluster")
+	}
+
+	desiredState := &operatortypes.AgentDesiredState{
+		Deploys: []operatortypes.DeployAppArgs{},
+	}
+
+	for _, app := range apps {
+		if app.RestoreInProgressName != "" {
+			continue
+		}
+
+		currentVersion, err := o.store.GetCurrentDownstreamVersion(app.ID, clusterID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get current version of app %s", app.Slug)
+		}
+		if currentVersion == nil {
+			continue
+		}
+		sequence := currentVersion.ParentSequence
+
+		if currentVersion.Status == storetypes.VersionDeploying {
+			rendered, err := o.renderAppVersion(app, clusterID, sequence)
+			if err != nil {
+				// the version can't be deployed, so the agent never picks it up
+				logger.Error(errors.Wrapf(err, "failed to render sequence %d of app %s for cluster %s", sequence, app.Slug, clusterID))
+				o.recordDeployResult(app.ID, sequence, false, err)
+				continue
+			}
+
+			deployArgs := rendered.deployArgs
+			deployArgs.KotsKinds = getAgentKotsKinds(deployArgs.KotsKinds)
+			deployArgs.PreviousKotsKinds = getAgentKotsKinds(deployArgs.PreviousKotsKinds)
+			desiredState.Deploys = append(desiredState.Deploys, deployArgs)
+		}
+
+		if includeInformers {
+			informersArgs, err := o.getAgentInformers(app, sequence)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get status informers of app %s", app.Slug)
+			}
+			if informersArgs != nil {
+				desiredState.Informers = append(desiredState.Informers, *informersArgs)
+			}
+		}
+	}
+
+	return desiredState, nil
+}
+
+// SetAgentDeployStatus records the result of the agent of a remote cluster deploying an app version
+func (o *Operator) SetAgentDeployStatus(clusterID string, status operatortypes.AgentDeployStatus) error {
+	currentVersion, err := o.store.GetCurrentDownstreamVersion(status.AppID, clusterID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current downstream version")
+	}
+	if currentVersion == nil || currentVersion.ParentSequence != status.Sequence {
+		return errors.Errorf("sequence %d is not the current version of the app", status.Sequence)
+	}
+
+	var deployError error
+	if status.Error != "" {
+		deployError = errors.New(status.Error)
+	}
+	o.recordDeployResult(status.AppID, status.Sequence, status.Deployed, deployError)
+
+	return nil
+}
+
+// getAgentInformers returns the status informers of the app version deployed to a remote cluster. Apps
+// without status informers are set to ready, and nil is returned.
+func (o *Operator) getAgentInformers(app *apptypes.App, sequence int64) (*operatortypes.AppInformersArgs, error) {
+	archiveDir, err := os.MkdirTemp("", "kotsadm")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create temp dir")
+	}
+	defer os.RemoveAll(archiveDir)
+
+	if err := o.store.GetAppVersionArchive(app.ID, sequence, archiveDir); err != nil {
+		return nil, errors.Wrap(err, "failed to get app version archive")
+	}
+
+	kotsKinds, err := kotsutil.LoadKotsKinds(archiveDir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load kotskinds")
+	}
+
+	registrySettings, err := o.store.GetRegistryDetailsForApp(app.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get registry settings for app")
+	}
+
+	builder, err := render.NewBuilder(kotsKinds, registrySettings, app.Slug, sequence, app.IsAirgap, util.PodNamespace)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get template builder")
+	}
+
+	informers := getStatusInformers(app, kotsKinds, builder)
+	if len(informers) == 0 {
+		if err := o.setDefaultReadyState(app, sequence); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	return &operatortypes.AppInformersArgs{
+		AppID:     app.ID,
+		Informers: informers,
+		Sequence:  sequence,
+	}, nil
+}
+
+// getAgentKotsKinds returns the kinds that the operator client needs to deploy an app. The rest, such as the
+// license and config values, are not sent to remote clusters.
+func getAgentKotsKinds(kotsKinds *kotsutil.KotsKinds) *kotsutil.KotsKinds {
+	if kotsKinds == nil {
+		return nil
+	}
+	return &kotsutil.KotsKinds{
+		KotsApplication:   kotsKinds.KotsApplication,
+		V1Beta1HelmCharts: kotsKinds.V1Beta1HelmCharts,
+		V1Beta2HelmCharts: kotsKinds.V1Beta2HelmCharts,
+	}
+}
@@ -27,7 +27,7 @@ type Kubectl struct {
 	config    *rest.Config
 }
 
-func NewKubectl(kubectl, kustomize string, config *rest.Config) KubectlInterface {
+func NewKubectl(kubectl, kustomize string, config *rest.Config) *Kubectl {
 	return &Kubectl{
 		kubectl:   kubectl,
 		kustomize: kustomize,
@@ -143,6 +143,35 @@ commonAnnotations:
 	return stdout, stderr, errors.Wrap(err, "failed to run kubectl apply")
 }
 
+// DryRunApply runs a server-side dry run of a client-side apply of the yaml document, and returns the resulting
+// object as json. Unlike Apply with dryRun, the result includes the changes the server makes, such as defaulting.
+func (c *Kubectl) DryRunApply(targetNamespace string, yamlDoc []byte) ([]byte, []byte, error) {
+	args := []string{
+		"apply",
+		"--dry-run=server",
+		"-o",
+		"json",
+	}
+
+	if targetNamespace != "" {
+		args = append(args, []string{
+			"-n",
+			targetNamespace,
+		}...)
+	}
+
+	args = append(args, []string{
+		"-f",
+		"-",
+	}...)
+
+	cmd := c.kubectlCommand(args...)
+	cmd.Stdin = bytes.NewReader(yamlDoc)
+
+	stdout, stderr, err := Run(cmd)
+	return stdout, stderr, errors.Wrap(err, "failed to run kubectl apply --dry-run=server")
+}
+
 // ApplyCreateOrPatch attempts to run a `kubectl apply` on the yaml document. If it fails
 // it will try to split a multi-doc and try again. As a last resort it will try create and patch.
 // It's important to use patch as a last resort because it can trigger load balancer services
@@ -0,0 +1,438 @@
+package applier
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/util"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	rest "k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// ApplierKubectl applies manifests with the kubectl binary. This is the default.
+	ApplierKubectl = "kubectl"
+	// ApplierServerSide applies manifests with server-side apply using the dynamic client
+	ApplierServerSide = "server-side"
+
+	// FieldManager is the field manager of the fields applied by the server-side applier
+	FieldManager = "kots"
+
+	removeWaitInterval = time.Second
+	removeWaitTimeout  = 10 * time.Minute
+)
+
+// kubectlFieldManagers are the field managers of the fields set by the kubectl applier. Conflicts with
+// these are resolved in favor of the server-side applier so that installs can switch between appliers.
+var kubectlFieldManagers = []string{"kubectl-client-side-apply", "kubectl", "kubectl-create", "kubectl-patch"}
+
+var conflictManagerRegexp = regexp.MustCompile(`conflict with "([^"]+)"`)
+
+func IsValidApplier(name string) bool {
+	return name == "" || name == ApplierKubectl || name == ApplierServerSide
+}
+
+// Conflict is a field that the applied manifest sets to a different value than its current field manager
+type Conflict struct {
+	Manager string `json:"manager"`
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ConflictError is returned by the server-side applier when fields in a manifest are owned by other field managers
+type ConflictError struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Conflicts []Conflict
+	Err       error
+}
+
+func (e *ConflictError) Error() string {
+	name := e.Name
+	if e.Namespace != "" {
+		name = fmt.Sprintf("%s/%s", e.Namespace, e.Name)
+	}
+
+	fields := []string{}
+	for _, c := range e.Conflicts {
+		fields = append(fields, fmt.Sprintf("%s (managed by %s)", c.Field, c.Manager))
+	}
+
+	return fmt.Sprintf("failed to apply %s %s, fields are managed by other field managers: %s", e.Kind, name, strings.Join(fields, ", "))
+}
+
+func (e *ConflictError) Unwrap() error {
+	return e.Err
+}
+
+// ownedByKubectl returns true if all of the conflicting fields were set by the kubectl applier
+func (e *ConflictError) ownedByKubectl() bool {
+	for _, c := range e.Conflicts {
+		found := false
+		for _, m := range kubectlFieldManagers {
+			if c.Manager == m {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return len(e.Conflicts) > 0
+}
+
+// ServerSideApplier applies manifests with server-side apply using the dynamic client, instead of running
+// kubectl and kustomize for every document
+type ServerSideApplier struct {
+	dynamicClient dynamic.Interface
+	mapper        meta.RESTMapper
+}
+
+func NewServerSideApplier(config *rest.Config) (KubectlInterface, error) {
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create dynamic client")
+	}
+
+	disc, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create discovery client")
+	}
+
+	return &ServerSideApplier{
+		dynamicClient: dynamicClient,
+		mapper:        restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(disc)),
+	}, nil
+}
+
+// Apply server-side applies every document in the yaml. Conflicts with fields set by the kubectl applier are forced,
+// and other conflicts are returned as a *ConflictError. As with kubectl apply, wait has no effect since nothing is pruned.
+func (a *ServerSideApplier) Apply(targetNamespace string, slug string, yamlDoc []byte, dryRun bool, wait bool, annotateSlug bool) ([]byte, []byte, error) {
+	objs, err := decodeObjects(yamlDoc)
+	if err != nil {
+		return nil, []byte(err.Error()), errors.Wrap(err, "failed to decode yaml")
+	}
+
+	var stdout, stderr bytes.Buffer
+	errs := []error{}
+
+	for _, obj := range objs {
+		if annotateSlug {
+			annotations := obj.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations["kots.io/app-slug"] = slug
+			obj.SetAnnotations(annotations)
+		}
+
+		action, err := a.applyObject(obj, targetNamespace, dryRun)
+		if err != nil {
+			fmt.Fprintf(&stderr, "%s\n", err.Error())
+			errs = append(errs, err)
+			continue
+		}
+
+		fmt.Fprintf(&stdout, "%s %s%s\n", objectRef(obj), action, dryRunSuffix(dryRun))
+	}
+
+	return stdout.Bytes(), stderr.Bytes(), aggregateErrors(errs)
+}
+
+// ApplyCreateOrPatch is the same as Apply. The kubectl applier falls back to create and patch when the
+// last-applied-configuration annotation is too long, but server-side apply does not use that annotation.
+func (a *ServerSideApplier) ApplyCreateOrPatch(targetNamespace string, slug string, yamlDoc []byte, dryRun bool, wait bool, annotateSlug bool) ([]byte, []byte, error) {
+	return a.Apply(targetNamespace, slug, yamlDoc, dryRun, wait, annotateSlug)
+}
+
+// Remove deletes every document in the yaml. If wait is true, it waits for the objects to be removed from the cluster.
+func (a *ServerSideApplier) Remove(targetNamespace string, yamlDoc []byte, wait bool) ([]byte, []byte, error) {
+	objs, err := decodeObjects(yamlDoc)
+	if err != nil {
+		return nil, []byte(err.Error()), errors.Wrap(err, "failed to decode yaml")
+	}
+
+	var stdout, stderr bytes.Buffer
+	errs := []error{}
+
+	for _, obj := range objs {
+		if err := a.removeObject(obj, targetNamespace, wait); err != nil {
+			fmt.Fprintf(&stderr, "%s\n", err.Error())
+			errs = append(errs, err)
+			continue
+		}
+
+		fmt.Fprintf(&stdout, "%s deleted\n", objectRef(obj))
+	}
+
+	return stdout.Bytes(), stderr.Bytes(), aggregateErrors(errs)
+}
+
+// applyObject server-side applies the object and returns the action in the same format as kubectl:
+// created, configured or unchanged. The action is found by comparing the resourceVersion of the object
+// before and after the apply, since the api server does not bump it when nothing changed.
+func (a *ServerSideApplier) applyObject(obj *unstructured.Unstructured, targetNamespace string, dryRun bool) (string, error) {
+	ri, err := a.getResourceInterface(obj, targetNamespace)
+	if err != nil {
+		return "", err
+	}
+
+	exists := true
+	existingResourceVersion := ""
+	existing, err := ri.Get(context.TODO(), obj.GetName(), metav1.GetOptions{})
+	if kuberneteserrors.IsNotFound(err) {
+		exists = false
+	} else if err != nil {
+		return "", errors.Wrapf(err, "failed to get %s", objectRef(obj))
+	} else {
+		existingResourceVersion = existing.GetResourceVersion()
+	}
+
+	applied, err := serverSideApply(ri, obj, dryRun)
+	if err != nil {
+		return "", err
+	}
+
+	if !exists {
+		return "created", nil
+	}
+	if applied != nil && applied.GetResourceVersion() == existingResourceVersion {
+		return "unchanged", nil
+	}
+	return "configured", nil
+}
+
+// DryRunServerSideApply returns the object that server-side applying obj would result in, without changing
+// the cluster. Conflicts are handled the same way as by the server-side applier, so a plan shows the conflicts
+// that would fail the deploy.
+func DryRunServerSideApply(ri dynamic.ResourceInterface, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	return serverSideApply(ri, obj, true)
+}
+
+// serverSideApply applies the object with the kots field manager. Conflicts with fields set by the kubectl applier
+// are forced, and other conflicts are returned as a *ConflictError.
+func serverSideApply(ri dynamic.ResourceInterface, obj *unstructured.Unstructured, dryRun bool) (*unstructured.Unstructured, error) {
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal %s", objectRef(obj))
+	}
+
+	opts := metav1.PatchOptions{
+		FieldManager: FieldManager,
+	}
+	if dryRun {
+		opts.DryRun = []string{metav1.DryRunAll}
+	}
+
+	applied, err := ri.Patch(context.TODO(), obj.GetName(), types.ApplyPatchType, data, opts)
+	if err == nil {
+		return applied, nil
+	}
+
+	conflictErr := getConflictError(obj, err)
+	if conflictErr == nil {
+		return nil, errors.Wrapf(err, "failed to apply %s", objectRef(obj))
+	}
+	if !conflictErr.ownedByKubectl() {
+		return nil, conflictErr
+	}
+
+	if !dryRun {
+		logger.Infof("taking ownership of fields in %s from the kubectl applier", objectRef(obj))
+	}
+
+	force := true
+	opts.Force = &force
+	applied, err = ri.Patch(context.TODO(), obj.GetName(), types.ApplyPatchType, data, opts)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to force apply %s", objectRef(obj))
+	}
+
+	return applied, nil
+}
+
+func (a *ServerSideApplier) removeObject(obj *unstructured.Unstructured, targetNamespace string, waitForRemoval bool) error {
+	ri, err := a.getResourceInterface(obj, targetNamespace)
+	if err != nil {
+		return err
+	}
+
+	propagation := metav1.DeletePropagationBackground
+	if err := ri.Delete(context.TODO(), obj.GetName(), metav1.DeleteOptions{PropagationPolicy: &propagation}); err != nil {
+		return errors.Wrapf(err, "failed to delete %s", objectRef(obj))
+	}
+
+	if !waitForRemoval {
+		return nil
+	}
+
+	err = wait.PollUntilContextTimeout(context.TODO(), removeWaitInterval, removeWaitTimeout, true, func(ctx context.Context) (bool, error) {
+		_, err := ri.Get(ctx, obj.GetName(), metav1.GetOptions{})
+		if kuberneteserrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to wait for %s to be deleted", objectRef(obj))
+	}
+
+	return nil
+}
+
+// getResourceInterface returns the dynamic client for the object. The namespace of namespaced objects
+// defaults to the target namespace.
+func (a *ServerSideApplier) getResourceInterface(obj *unstructured.Unstructured, targetNamespace string) (dynamic.ResourceInterface, error) {
+	gvk := obj.GroupVersionKind()
+
+	mapping, err := a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// the type may have been added by a crd since the mapper was last refreshed
+		if resettable, ok := a.mapper.(meta.ResettableRESTMapper); ok {
+			resettable.Reset()
+			mapping, err = a.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		}
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get rest mapping for %s", gvk.String())
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return a.dynamicClient.Resource(mapping.Resource), nil
+	}
+
+	if obj.GetNamespace() == "" {
+		namespace := targetNamespace
+		if namespace == "" {
+			namespace = util.PodNamespace
+		}
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		obj.SetNamespace(namespace)
+	}
+
+	return a.dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+}
+
+// getConflictError returns a *ConflictError if err is a server-side apply conflict, or nil otherwise
+func getConflictError(obj *unstructured.Unstructured, err error) *ConflictError {
+	if !kuberneteserrors.IsConflict(err) {
+		return nil
+	}
+
+	status, ok := err.(kuberneteserrors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		return nil
+	}
+
+	conflicts := []Conflict{}
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		conflict := Conflict{
+			Field:   cause.Field,
+			Message: cause.Message,
+		}
+		if matches := conflictManagerRegexp.FindStringSubmatch(cause.Message); len(matches) == 2 {
+			conflict.Manager = matches[1]
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+
+	return &ConflictError{
+		Kind:      obj.GetKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Conflicts: conflicts,
+		Err:       err,
+	}
+}
+
+// decodeObjects decodes the documents in the yaml, expanding lists into their items
+func decodeObjects(yamlDoc []byte) ([]*unstructured.Unstructured, error) {
+	objs := []*unstructured.Unstructured{}
+
+	for _, doc := range util.ConvertToSingleDocs(yamlDoc) {
+		m := map[string]interface{}{}
+		if err := yaml.Unmarshal(doc, &m); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal document")
+		}
+		if len(m) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{Object: m}
+		if obj.GetKind() == "" {
+			return nil, errors.New("document is missing kind")
+		}
+
+		if !obj.IsList() {
+			objs = append(objs, obj)
+			continue
+		}
+
+		err := obj.EachListItem(func(item runtime.Object) error {
+			u, ok := item.(*unstructured.Unstructured)
+			if !ok {
+				return errors.Errorf("unexpected list item type %T", item)
+			}
+			objs = append(objs, u)
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode list")
+		}
+	}
+
+	return objs, nil
+}
+
+// objectRef formats the object the same way as kubectl, e.g. deployment.apps/my-app
+func objectRef(obj *unstructured.Unstructured) string {
+	gk := schema.GroupKind{Group: obj.GroupVersionKind().Group, Kind: strings.ToLower(obj.GetKind())}
+	return fmt.Sprintf("%s/%s", gk.String(), obj.GetName())
+}
+
+func dryRunSuffix(dryRun bool) string {
+	if dryRun {
+		return " (server dry run)"
+	}
+	return ""
+}
+
+func aggregateErrors(errs []error) error {
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return utilerrors.NewAggregate(errs)
+	}
+}
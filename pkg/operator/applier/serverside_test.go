@@ -0,0 +1,227 @@
+package applier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var (
+	configMapGVK = schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	configMapGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+)
+
+const configMapYAML = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  key: value
+`
+
+func newTestServerSideApplier(objs ...runtime.Object) (*ServerSideApplier, *dynamicfake.FakeDynamicClient) {
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		configMapGVR: "ConfigMapList",
+	}, objs...)
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(configMapGVK, meta.RESTScopeNamespace)
+
+	return &ServerSideApplier{
+		dynamicClient: dynamicClient,
+		mapper:        mapper,
+	}, dynamicClient
+}
+
+func TestServerSideApplier_Apply(t *testing.T) {
+	a, dynamicClient := newTestServerSideApplier()
+
+	// the fake client does not support server-side apply, so record the patches instead
+	patches := []k8stesting.PatchActionImpl{}
+	dynamicClient.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patches = append(patches, action.(k8stesting.PatchActionImpl))
+		return true, &unstructured.Unstructured{}, nil
+	})
+
+	stdout, _, err := a.Apply("app", "my-app", []byte(configMapYAML), true, false, true)
+	require.NoError(t, err)
+	require.Equal(t, "configmap/config created (server dry run)\n", string(stdout))
+
+	require.Len(t, patches, 1)
+	require.Equal(t, "app", patches[0].GetNamespace())
+	require.Equal(t, "config", patches[0].GetName())
+	require.Equal(t, FieldManager, patches[0].PatchOptions.FieldManager)
+	require.Equal(t, []string{metav1.DryRunAll}, patches[0].PatchOptions.DryRun)
+	require.Nil(t, patches[0].PatchOptions.Force)
+
+	applied := &unstructured.Unstructured{}
+	require.NoError(t, applied.UnmarshalJSON(patches[0].GetPatch()))
+	require.Equal(t, "my-app", applied.GetAnnotations()["kots.io/app-slug"])
+	require.Equal(t, "app", applied.GetNamespace())
+}
+
+func TestServerSideApplier_ApplyActions(t *testing.T) {
+	tests := []struct {
+		name                   string
+		existing               bool
+		appliedResourceVersion string
+		want                   string
+	}{
+		{
+			name:                   "new object",
+			appliedResourceVersion: "1",
+			want:                   "configmap/config created\n",
+		},
+		{
+			name:                   "changed object",
+			existing:               true,
+			appliedResourceVersion: "2",
+			want:                   "configmap/config configured\n",
+		},
+		{
+			name:                   "unchanged object",
+			existing:               true,
+			appliedResourceVersion: "1",
+			want:                   "configmap/config unchanged\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := []runtime.Object{}
+			if tt.existing {
+				existing := &unstructured.Unstructured{}
+				existing.SetGroupVersionKind(configMapGVK)
+				existing.SetName("config")
+				existing.SetNamespace("app")
+				existing.SetResourceVersion("1")
+				objs = append(objs, existing)
+			}
+
+			a, dynamicClient := newTestServerSideApplier(objs...)
+			dynamicClient.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				applied := &unstructured.Unstructured{}
+				applied.SetGroupVersionKind(configMapGVK)
+				applied.SetResourceVersion(tt.appliedResourceVersion)
+				return true, applied, nil
+			})
+
+			stdout, _, err := a.Apply("app", "my-app", []byte(configMapYAML), false, false, false)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, string(stdout))
+		})
+	}
+}
+
+func TestServerSideApplier_ApplyConflicts(t *testing.T) {
+	conflictErr := func(manager string) error {
+		return kuberneteserrors.NewApplyConflict([]metav1.StatusCause{
+			{
+				Type:    metav1.CauseTypeFieldManagerConflict,
+				Message: `conflict with "` + manager + `" using v1`,
+				Field:   ".data.key",
+			},
+		}, "Apply failed with 1 conflict")
+	}
+
+	tests := []struct {
+		name          string
+		manager       string
+		wantForced    bool
+		wantConflicts []Conflict
+	}{
+		{
+			name:       "fields set by the kubectl applier are taken over",
+			manager:    "kubectl-client-side-apply",
+			wantForced: true,
+		},
+		{
+			name:    "fields set by other managers are reported",
+			manager: "helm",
+			wantConflicts: []Conflict{
+				{Manager: "helm", Field: ".data.key", Message: `conflict with "helm" using v1`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, dynamicClient := newTestServerSideApplier()
+
+			forced := false
+			dynamicClient.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				opts := action.(k8stesting.PatchActionImpl).PatchOptions
+				if opts.Force != nil && *opts.Force {
+					forced = true
+					return true, &unstructured.Unstructured{}, nil
+				}
+				return true, nil, conflictErr(tt.manager)
+			})
+
+			_, stderr, err := a.Apply("app", "my-app", []byte(configMapYAML), false, false, false)
+			require.Equal(t, tt.wantForced, forced)
+
+			if tt.wantConflicts == nil {
+				require.NoError(t, err)
+				return
+			}
+
+			var ce *ConflictError
+			require.True(t, errors.As(err, &ce))
+			require.Equal(t, tt.wantConflicts, ce.Conflicts)
+			require.Equal(t, "app", ce.Namespace)
+			require.Contains(t, string(stderr), ".data.key (managed by helm)")
+		})
+	}
+}
+
+func TestServerSideApplier_Remove(t *testing.T) {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(configMapGVK)
+	existing.SetName("config")
+	existing.SetNamespace("app")
+
+	a, dynamicClient := newTestServerSideApplier(existing)
+
+	stdout, _, err := a.Remove("app", []byte(configMapYAML), true)
+	require.NoError(t, err)
+	require.Equal(t, "configmap/config deleted\n", string(stdout))
+
+	_, err = dynamicClient.Resource(configMapGVR).Namespace("app").Get(t.Context(), "config", metav1.GetOptions{})
+	require.True(t, kuberneteserrors.IsNotFound(err))
+
+	_, _, err = a.Remove("app", []byte(configMapYAML), false)
+	require.True(t, kuberneteserrors.IsNotFound(err))
+}
+
+func Test_decodeObjects(t *testing.T) {
+	list := `apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: one
+- apiVersion: v1
+  kind: Secret
+  metadata:
+    name: two
+`
+
+	objs, err := decodeObjects([]byte(configMapYAML + "---\n# comment only\n---\n" + list))
+	require.NoError(t, err)
+	require.Len(t, objs, 3)
+	require.Equal(t, "config", objs[0].GetName())
+	require.Equal(t, "one", objs[1].GetName())
+	require.Equal(t, "Secret", objs[2].GetKind())
+
+	_, err = decodeObjects([]byte("metadata:\n  name: missing-kind\n"))
+	require.Error(t, err)
+}
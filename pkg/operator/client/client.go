@@ -2,6 +2,7 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"log"
@@ -20,17 +21,16 @@ import (
 	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
 	"github.com/replicatedhq/kots/pkg/binaries"
 	"github.com/replicatedhq/kots/pkg/k8sutil"
+	kotsadmtypes "github.com/replicatedhq/kots/pkg/kotsadm/types"
 	"github.com/replicatedhq/kots/pkg/logger"
 	"github.com/replicatedhq/kots/pkg/operator/applier"
 	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
-	"github.com/replicatedhq/kots/pkg/registry"
-	"github.com/replicatedhq/kots/pkg/reporting"
 	"github.com/replicatedhq/kots/pkg/store"
-	"github.com/replicatedhq/kots/pkg/supportbundle"
-	supportbundletypes "github.com/replicatedhq/kots/pkg/supportbundle/types"
 	"github.com/replicatedhq/kots/pkg/util"
 	"github.com/replicatedhq/kotskinds/pkg/helmchart"
 	"go.uber.org/zap"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type DeployResults struct {
@@ -51,6 +51,8 @@ type DesiredState struct {
 
 type Client struct {
 	TargetNamespace string
+	// Reporter records deploy results and app statuses. The kotsadm store is used if it is not set.
+	Reporter Reporter
 
 	watchedNamespaces []string
 	imagePullSecrets  []string
@@ -165,7 +167,9 @@ func (c *Client) ApplyHooksInformer(namespaces []string) {
 	}
 }
 
-func (c *Client) DeployApp(deployArgs operatortypes.DeployAppArgs) (deployed bool, finalError error) {
+// DeployApp applies the manifests and helm charts of the app. If ctx is cancelled, the deploy stops before
+// the next resource or helm chart, and a running helm command is interrupted.
+func (c *Client) DeployApp(ctx context.Context, deployArgs operatortypes.DeployAppArgs) (deployed bool, finalError error) {
 	log.Println("received a deploy request for", deployArgs.AppSlug)
 
 	var deployRes *deployResult
@@ -182,7 +186,7 @@ func (c *Client) DeployApp(deployArgs operatortypes.DeployAppArgs) (deployed boo
 		}
 	}()
 
-	deployRes, deployError = c.deployManifests(deployArgs)
+	deployRes, deployError = c.deployManifests(ctx, deployArgs)
 	if deployError != nil {
 		deployRes = &deployResult{}
 		deployRes.applyResult.hasErr = true
@@ -191,7 +195,7 @@ func (c *Client) DeployApp(deployArgs operatortypes.DeployAppArgs) (deployed boo
 		return
 	}
 
-	helmResult, helmError = c.deployHelmCharts(deployArgs)
+	helmResult, helmError = c.deployHelmCharts(ctx, deployArgs)
 	if helmError != nil {
 		helmResult = &commandResult{}
 		helmResult.hasErr = true
@@ -232,7 +236,8 @@ func (c *Client) UndeployApp(undeployArgs operatortypes.UndeployAppArgs) (finalE
 	return nil
 }
 
-func (c *Client) deployManifests(deployArgs operatortypes.DeployAppArgs) (*deployResult, error) {
+func (c *Client) deployManifests(ctx context.Context, deployArgs operatortypes.DeployAppArgs) (*deployResult, error) {
+	deleted := []downstreamtypes.DeployResourceResult{}
 	if deployArgs.PreviousManifests != "" {
 		opts := DiffAndDeleteOptions{
 			PreviousManifests:    deployArgs.PreviousManifests,
@@ -242,20 +247,23 @@ func (c *Client) deployManifests(deployArgs operatortypes.DeployAppArgs) (*deplo
 			RestoreLabelSelector: deployArgs.RestoreLabelSelector,
 			Wait:                 deployArgs.Wait,
 		}
-		if err := c.diffAndDeleteManifests(opts); err != nil {
+		results, err := c.diffAndDeleteManifests(opts)
+		if err != nil {
 			return nil, errors.Wrapf(err, "failed to diff and delete manifests")
 		}
+		deleted = results
 	}
 
-	result, err := c.ensureResourcesPresent(deployArgs)
+	result, err := c.ensureResourcesPresent(ctx, deployArgs)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to deploy")
 	}
+	result.applyResult.resources = append(deleted, result.applyResult.resources...)
 
 	return result, nil
 }
 
-func (c *Client) deployHelmCharts(deployArgs operatortypes.DeployAppArgs) (*commandResult, error) {
+func (c *Client) deployHelmCharts(ctx context.Context, deployArgs operatortypes.DeployAppArgs) (*commandResult, error) {
 	// extract previous v1beta1 helm charts
 	prevV1Beta1HelmDir, err := extractHelmCharts(deployArgs.PreviousV1Beta1ChartsArchive, "prev-v1beta1")
 	if err != nil {
@@ -332,7 +340,12 @@ func (c *Client) deployHelmCharts(deployArgs operatortypes.DeployAppArgs) (*comm
 		return nil, errors.Wrap(err, "failed to find removed charts")
 	}
 
+	if err := ctx.Err(); err != nil {
+		return nil, errors.Wrap(err, "deploy was cancelled")
+	}
+
 	// uninstall removed charts
+	uninstalledReleases := []downstreamtypes.HelmReleaseResult{}
 	if len(removedCharts) > 0 {
 		v1Beta1ChartsDir := ""
 		if prevV1Beta1HelmDir != "" {
@@ -343,10 +356,11 @@ func (c *Client) deployHelmCharts(deployArgs operatortypes.DeployAppArgs) (*comm
 			v1Beta2ChartsDir = filepath.Join(prevV1Beta2HelmDir, "helm")
 		}
 
-		err := c.uninstallWithHelm(v1Beta1ChartsDir, v1Beta2ChartsDir, removedCharts)
+		uninstalled, err := c.uninstallWithHelm(v1Beta1ChartsDir, v1Beta2ChartsDir, removedCharts)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to uninstall helm charts")
 		}
+		uninstalledReleases = uninstalled
 	}
 
 	var installResult *commandResult
@@ -364,12 +378,19 @@ func (c *Client) deployHelmCharts(deployArgs operatortypes.DeployAppArgs) (*comm
 			v1Beta2ChartsDir = filepath.Join(curV1Beta2HelmDir, "helm")
 		}
 
-		installResult, err = c.installWithHelm(v1Beta1ChartsDir, v1Beta2ChartsDir, kotsCharts)
+		installResult, err = c.installWithHelm(ctx, v1Beta1ChartsDir, v1Beta2ChartsDir, kotsCharts)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to install helm charts")
 		}
 	}
 
+	if len(uninstalledReleases) > 0 {
+		if installResult == nil {
+			installResult = &commandResult{}
+		}
+		installResult.helmReleases = append(uninstalledReleases, installResult.helmReleases...)
+	}
+
 	return installResult, nil
 }
 
@@ -412,7 +433,7 @@ func (c *Client) undeployManifests(undeployArgs operatortypes.UndeployAppArgs) e
 			RestoreLabelSelector: undeployArgs.RestoreLabelSelector,
 			Wait:                 undeployArgs.Wait,
 		}
-		if err := c.diffAndDeleteManifests(opts); err != nil {
+		if _, err := c.diffAndDeleteManifests(opts); err != nil {
 			return errors.Wrapf(err, "failed to diff and delete manifests")
 		}
 	}
@@ -476,13 +497,29 @@ func (c *Client) undeployHelmCharts(undeployArgs operatortypes.UndeployAppArgs)
 		v1Beta2ChartsDir = filepath.Join(v1Beta2HelmDir, "helm")
 	}
 
-	if err := c.uninstallWithHelm(v1Beta1ChartsDir, v1Beta2ChartsDir, kotsCharts); err != nil {
+	if _, err := c.uninstallWithHelm(v1Beta1ChartsDir, v1Beta2ChartsDir, kotsCharts); err != nil {
 		return errors.Wrap(err, "failed to uninstall helm charts")
 	}
 
 	return nil
 }
 
+// getDeployResults combines the per-resource and helm release results of the deploy commands
+func getDeployResults(results ...*commandResult) *downstreamtypes.DeployResults {
+	deployResults := &downstreamtypes.DeployResults{
+		Resources:    []downstreamtypes.DeployResourceResult{},
+		HelmReleases: []downstreamtypes.HelmReleaseResult{},
+	}
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		deployResults.Resources = append(deployResults.Resources, result.resources...)
+		deployResults.HelmReleases = append(deployResults.HelmReleases, result.helmReleases...)
+	}
+	return deployResults
+}
+
 func (c *Client) setDeployResults(args operatortypes.DeployAppArgs, dryRunResult *commandResult, applyResult *commandResult, helmResult *commandResult) (*DeployResults, error) {
 	results := &DeployResults{}
 
@@ -504,29 +541,8 @@ func (c *Client) setDeployResults(args operatortypes.DeployAppArgs, dryRunResult
 		results.HelmStderr = bytes.Join(helmResult.multiStderr, []byte("\n"))
 	}
 
-	app, err := store.GetStore().GetApp(args.AppID)
-	if err != nil {
-		logger.Error(errors.Wrapf(err, "failed to get app after deploying"))
-	} else {
-		troubleshootOpts := supportbundletypes.TroubleshootOptions{
-			InCluster: true,
-		}
-		if _, err := supportbundle.CreateSupportBundleDependencies(app, args.Sequence, troubleshootOpts); err != nil {
-			// support bundle is not essential. keep processing deployment request
-			logger.Error(errors.Wrapf(err, "failed to create support bundle for sequence %d after deploying", args.Sequence))
-		}
-	}
-
-	alreadySuccessful, err := store.GetStore().IsDownstreamDeploySuccessful(args.AppID, args.ClusterID, args.Sequence)
-	if err != nil {
-		return results, errors.Wrap(err, "failed to check deploy successful")
-	}
-
-	if alreadySuccessful {
-		return results, nil
-	}
-
 	downstreamOutput := downstreamtypes.DownstreamOutput{
+		Results:      getDeployResults(dryRunResult, applyResult, helmResult),
 		DryrunStdout: base64.StdEncoding.EncodeToString(results.DryrunStdout),
 		DryrunStderr: base64.StdEncoding.EncodeToString(results.DryrunStderr),
 		ApplyStdout:  base64.StdEncoding.EncodeToString(results.ApplyStdout),
@@ -535,22 +551,8 @@ func (c *Client) setDeployResults(args operatortypes.DeployAppArgs, dryRunResult
 		HelmStderr:   base64.StdEncoding.EncodeToString(results.HelmStderr),
 		RenderError:  "",
 	}
-	err = store.GetStore().UpdateDownstreamDeployStatus(args.AppID, args.ClusterID, args.Sequence, results.IsError, downstreamOutput)
-	if err != nil {
-		return results, errors.Wrap(err, "failed to update downstream deploy status")
-	}
-
-	if !results.IsError {
-		go func() {
-			err := registry.DeleteUnusedImages(args.AppID, false)
-			if err != nil {
-				if _, ok := err.(registry.AppRollbackError); ok {
-					logger.Infof("not garbage collecting images because version allows rollbacks: %v", err)
-				} else {
-					logger.Infof("failed to delete unused images: %v", err)
-				}
-			}
-		}()
+	if err := c.getReporter().SetDeployResults(args, results.IsError, downstreamOutput); err != nil {
+		return results, err
 	}
 
 	return results, nil
@@ -603,37 +605,95 @@ func (c *Client) ApplyAppInformers(args operatortypes.AppInformersArgs) {
 }
 
 func (c *Client) setAppStatus(newAppStatus appstatetypes.AppStatus) error {
-	currentAppStatus, err := store.GetStore().GetAppStatus(newAppStatus.AppID)
-	if err != nil {
-		return errors.Wrap(err, "failed to get current app status")
+	return c.getReporter().SetAppStatus(newAppStatus)
+}
+
+func (c *Client) getReporter() Reporter {
+	if c.Reporter == nil {
+		return StoreReporter{}
 	}
+	return c.Reporter
+}
 
-	err = store.GetStore().SetAppStatus(newAppStatus.AppID, newAppStatus.ResourceStates, newAppStatus.UpdatedAt, newAppStatus.Sequence)
+func (c *Client) getApplier() (applier.KubectlInterface, error) {
+	config, err := k8sutil.GetClusterConfig()
 	if err != nil {
-		return errors.Wrap(err, "failed to set app status")
+		return nil, errors.Wrap(err, "failed to get cluster config")
 	}
 
-	newAppState := appstatetypes.GetState(newAppStatus.ResourceStates)
-	if currentAppStatus != nil && newAppState != currentAppStatus.State {
-		go func() {
-			err := reporting.GetReporter().SubmitAppInfo(newAppStatus.AppID)
-			if err != nil {
-				logger.Debugf("failed to submit app info: %v", err)
-			}
-		}()
+	applierName, err := getApplierName()
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
-}
+	if applierName == applier.ApplierServerSide {
+		serverSideApplier, err := applier.NewServerSideApplier(config)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create server-side applier")
+		}
+		return serverSideApplier, nil
+	}
 
-func (c *Client) getApplier() (applier.KubectlInterface, error) {
 	kubectl := binaries.GetKubectlBinPath()
 	kustomize := binaries.GetKustomizeBinPath()
 
-	config, err := k8sutil.GetClusterConfig()
+	return applier.NewKubectl(kubectl, kustomize, config), nil
+}
+
+// applierNames caches the applier that was selected for the install, since it can't change after the install
+var applierNames applierNameCache
+
+type applierNameCache struct {
+	mu   sync.Mutex
+	name string
+}
+
+// get returns the cached applier name, or reads it if it has not been read yet. Errors are not cached, so the
+// applier is read again on the next call instead of falling back to the wrong applier for the life of the pod.
+func (c *applierNameCache) get(read func() (string, error)) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.name != "" {
+		return c.name, nil
+	}
+
+	name, err := read()
 	if err != nil {
-		return nil, errors.Wrap(err, "failed to get cluster config")
+		return "", err
 	}
+	c.name = name
 
-	return applier.NewKubectl(kubectl, kustomize, config), nil
+	return c.name, nil
+}
+
+// getApplierName returns the applier that was selected for the install in the kotsadm config map
+func getApplierName() (string, error) {
+	name, err := applierNames.get(readApplierName)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read applier")
+	}
+	return name, nil
+}
+
+// readApplierName reads the applier from the kotsadm config map. The kubectl applier is used if the config map
+// does not exist.
+func readApplierName() (string, error) {
+	clientset, err := k8sutil.GetClientset()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get k8s clientset")
+	}
+
+	configMap, err := clientset.CoreV1().ConfigMaps(util.PodNamespace).Get(context.TODO(), kotsadmtypes.KotsadmConfigMap, metav1.GetOptions{})
+	if err != nil {
+		if kuberneteserrors.IsNotFound(err) {
+			return applier.ApplierKubectl, nil
+		}
+		return "", errors.Wrap(err, "failed to get kotsadm config map")
+	}
+
+	if configMap.Data["applier"] == applier.ApplierServerSide {
+		return applier.ApplierServerSide, nil
+	}
+	return applier.ApplierKubectl, nil
 }
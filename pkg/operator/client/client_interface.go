@@ -1,14 +1,17 @@
 package client
 
 import (
+	"context"
+
 	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
 )
 
 type ClientInterface interface {
 	Init() error
 	Shutdown()
-	DeployApp(deployArgs operatortypes.DeployAppArgs) (deployed bool, finalError error)
+	DeployApp(ctx context.Context, deployArgs operatortypes.DeployAppArgs) (deployed bool, finalError error)
 	UndeployApp(undeployArgs operatortypes.UndeployAppArgs) error
+	PlanApp(deployArgs operatortypes.DeployAppArgs) (*operatortypes.DeployPlan, error)
 	ApplyAppInformers(args operatortypes.AppInformersArgs)
 	ApplyNamespacesInformer(namespaces []string, imagePullSecrets []string)
 	ApplyHooksInformer(namespaces []string)
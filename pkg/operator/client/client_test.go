@@ -0,0 +1,36 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/replicatedhq/kots/pkg/operator/applier"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_applierNameCache(t *testing.T) {
+	c := &applierNameCache{}
+
+	reads := 0
+	_, err := c.get(func() (string, error) {
+		reads++
+		return "", errors.New("connection refused")
+	})
+	require.Error(t, err)
+
+	// errors are not cached, so the applier is read again
+	name, err := c.get(func() (string, error) {
+		reads++
+		return applier.ApplierServerSide, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, applier.ApplierServerSide, name)
+
+	name, err = c.get(func() (string, error) {
+		reads++
+		return applier.ApplierKubectl, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, applier.ApplierServerSide, name)
+	require.Equal(t, 2, reads)
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
 	"github.com/replicatedhq/kots/pkg/k8sutil"
 	"github.com/replicatedhq/kots/pkg/logger"
 	"github.com/replicatedhq/kots/pkg/operator/applier"
@@ -29,15 +30,34 @@ type DiffAndDeleteOptions struct {
 	Wait                 bool
 }
 
-func (c *Client) diffAndDeleteManifests(opts DiffAndDeleteOptions) error {
+// diffAndDeleteManifests deletes the resources that were removed from the manifests, and returns the results of deleting them
+func (c *Client) diffAndDeleteManifests(opts DiffAndDeleteOptions) ([]downstreamtypes.DeployResourceResult, error) {
+	manifestsToDelete, err := c.getManifestsToDelete(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get manifests to delete")
+	}
+
+	// this is pretty raw, and required kubectl...  we should
+	// consider some other options here?
+	kubernetesApplier, err := c.getApplier()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get applier")
+	}
+
+	// TODO: return error here?
+	return c.deleteManifests(manifestsToDelete, kubernetesApplier, opts.Wait), nil
+}
+
+// getManifestsToDelete returns the previous manifests that are not in the current manifests and can be deleted
+func (c *Client) getManifestsToDelete(opts DiffAndDeleteOptions) ([][]byte, error) {
 	decodedPrevious, err := base64.StdEncoding.DecodeString(opts.PreviousManifests)
 	if err != nil {
-		return errors.Wrap(err, "failed to base64 decode previous manifests")
+		return nil, errors.Wrap(err, "failed to base64 decode previous manifests")
 	}
 
 	decodedCurrent, err := base64.StdEncoding.DecodeString(opts.CurrentManifests)
 	if err != nil {
-		return errors.Wrap(err, "failed to base64 decode manifests")
+		return nil, errors.Wrap(err, "failed to base64 decode manifests")
 	}
 
 	// we need to find the gvk+names that are present in the previous, but not in the current and then remove them
@@ -71,7 +91,7 @@ func (c *Client) diffAndDeleteManifests(opts DiffAndDeleteOptions) error {
 			if opts.RestoreLabelSelector != nil {
 				s, err := metav1.LabelSelectorAsSelector(opts.RestoreLabelSelector)
 				if err != nil {
-					return errors.Wrap(err, "failed to convert label selector to a selector")
+					return nil, errors.Wrap(err, "failed to convert label selector to a selector")
 				}
 				if !s.Matches(labels.Set(o.Metadata.Labels)) {
 					delete = false
@@ -100,13 +120,6 @@ func (c *Client) diffAndDeleteManifests(opts DiffAndDeleteOptions) error {
 		decodedCurrentMap[k] = string(decodedCurrentDoc)
 	}
 
-	// this is pretty raw, and required kubectl...  we should
-	// consider some other options here?
-	kubernetesApplier, err := c.getApplier()
-	if err != nil {
-		return errors.Wrap(err, "failed to get applier")
-	}
-
 	// now remove anything that's in previous but not in current
 	manifestsToDelete := [][]byte{}
 	for k, previous := range decodedPreviousMap {
@@ -119,28 +132,27 @@ func (c *Client) diffAndDeleteManifests(opts DiffAndDeleteOptions) error {
 		manifestsToDelete = append(manifestsToDelete, []byte(previous.spec))
 	}
 
-	// TODO: return error here?
-	c.deleteManifests(manifestsToDelete, kubernetesApplier, opts.Wait)
-
-	return nil
+	return manifestsToDelete, nil
 }
 
-func (c *Client) deleteManifests(manifests [][]byte, kubernetesApplier applier.KubectlInterface, waitFlag bool) {
+func (c *Client) deleteManifests(manifests [][]byte, kubernetesApplier applier.KubectlInterface, waitFlag bool) []downstreamtypes.DeployResourceResult {
 	resources := decodeManifests(manifests)
-	c.deleteResources(resources, kubernetesApplier, waitFlag)
+	return c.deleteResources(resources, kubernetesApplier, waitFlag)
 }
 
-func (c *Client) deleteResources(resources types.Resources, kubernetesApplier applier.KubectlInterface, waitFlag bool) {
+func (c *Client) deleteResources(resources types.Resources, kubernetesApplier applier.KubectlInterface, waitFlag bool) []downstreamtypes.DeployResourceResult {
+	results := []downstreamtypes.DeployResourceResult{}
 	phases := groupAndSortResourcesForDeletion(resources)
 	for _, phase := range phases {
 		logger.Infof("deleting resources in phase %s", phase.Name)
 		for _, r := range phase.Resources {
-			c.deleteResource(r, waitFlag, kubernetesApplier)
+			results = append(results, c.deleteResource(r, waitFlag, kubernetesApplier))
 		}
 	}
+	return results
 }
 
-func (c *Client) deleteResource(resource types.Resource, waitFlag bool, kubernetesApplier applier.KubectlInterface) {
+func (c *Client) deleteResource(resource types.Resource, waitFlag bool, kubernetesApplier applier.KubectlInterface) downstreamtypes.DeployResourceResult {
 	group := resource.GetGroup()
 	version := resource.GetVersion()
 	kind := resource.GetKind()
@@ -163,13 +175,19 @@ func (c *Client) deleteResource(resource types.Resource, waitFlag bool, kubernet
 		logger.Infof("stdout (delete) = %s", stdout)
 		logger.Infof("stderr (delete) = %s", stderr)
 		logger.Infof("error: %s", err.Error())
+
+		result := newDeployResourceResult(resource, namespace, downstreamtypes.DeployResourceFailed)
+		result.Error = getCommandError(stderr, err)
+		return result
+	}
+
+	if resource.DecodeErrMsg == "" {
+		logger.Infof("deleted resource %s/%s/%s/%s from namespace %s", group, version, kind, name, namespace)
 	} else {
-		if resource.DecodeErrMsg == "" {
-			logger.Infof("deleted resource %s/%s/%s/%s from namespace %s", group, version, kind, name, namespace)
-		} else {
-			logger.Info("deleted unidentified resource")
-		}
+		logger.Info("deleted unidentified resource")
 	}
+
+	return newDeployResourceResult(resource, namespace, downstreamtypes.DeployResourceDeleted)
 }
 
 func shouldWaitForResourceDeletion(kind string, waitFlag bool) bool {
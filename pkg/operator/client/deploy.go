@@ -11,8 +11,11 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"syscall"
+	"time"
 
 	"github.com/pkg/errors"
+	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
 	"github.com/replicatedhq/kots/pkg/appstate"
 	"github.com/replicatedhq/kots/pkg/archives"
 	"github.com/replicatedhq/kots/pkg/archiveutil"
@@ -32,10 +35,18 @@ import (
 
 var imagePullSecretsMtx sync.Mutex
 
+// helmCancelGracePeriod is how long helm has to exit after a deploy is cancelled
+const helmCancelGracePeriod = 30 * time.Second
+
+// waitForResourceToBeReady is replaced in tests
+var waitForResourceToBeReady = appstate.WaitForResourceToBeReady
+
 type commandResult struct {
-	hasErr      bool
-	multiStdout [][]byte
-	multiStderr [][]byte
+	hasErr       bool
+	multiStdout  [][]byte
+	multiStderr  [][]byte
+	resources    []downstreamtypes.DeployResourceResult
+	helmReleases []downstreamtypes.HelmReleaseResult
 }
 
 type deployResult struct {
@@ -173,7 +184,7 @@ func (c *Client) ensureEmbeddedClusterCAPresent(namespace string) error {
 	return nil
 }
 
-func (c *Client) ensureResourcesPresent(deployArgs operatortypes.DeployAppArgs) (*deployResult, error) {
+func (c *Client) ensureResourcesPresent(ctx context.Context, deployArgs operatortypes.DeployAppArgs) (*deployResult, error) {
 	var deployRes deployResult
 
 	kubernetesApplier, err := c.getApplier()
@@ -197,6 +208,10 @@ func (c *Client) ensureResourcesPresent(deployArgs operatortypes.DeployAppArgs)
 		for _, phase := range phases {
 			logger.Infof("dry run applying phase %s", phase.Name)
 			for _, resource := range phase.Resources {
+				if err := ctx.Err(); err != nil {
+					return nil, errors.Wrap(err, "deploy was cancelled")
+				}
+
 				group := resource.GetGroup()
 				version := resource.GetVersion()
 				kind := resource.GetKind()
@@ -226,6 +241,9 @@ func (c *Client) ensureResourcesPresent(deployArgs operatortypes.DeployAppArgs)
 					logger.Infof("stderr (dryrun) = %s", dryrunStderr)
 					logger.Infof("error: %s", dryRunErr.Error())
 
+					resourceResult := newDeployResourceResult(resource, namespace, downstreamtypes.DeployResourceFailed)
+					resourceResult.Error = getCommandError(dryrunStderr, dryRunErr)
+					deployRes.dryRunResult.resources = append(deployRes.dryRunResult.resources, resourceResult)
 					deployRes.dryRunResult.hasErr = true
 					return &deployRes, nil
 				}
@@ -242,6 +260,10 @@ func (c *Client) ensureResourcesPresent(deployArgs operatortypes.DeployAppArgs)
 	for _, phase := range phases {
 		logger.Infof("applying phase %s", phase.Name)
 		for _, resource := range phase.Resources {
+			if err := ctx.Err(); err != nil {
+				return nil, errors.Wrap(err, "deploy was cancelled")
+			}
+
 			group := resource.GetGroup()
 			version := resource.GetVersion()
 			kind := resource.GetKind()
@@ -272,10 +294,15 @@ func (c *Client) ensureResourcesPresent(deployArgs operatortypes.DeployAppArgs)
 				logger.Infof("stderr (apply) = %s", applyStderr)
 				logger.Infof("error: %s", applyErr.Error())
 
+				resourceResult := newDeployResourceResult(resource, namespace, downstreamtypes.DeployResourceFailed)
+				resourceResult.Error = getCommandError(applyStderr, applyErr)
+				deployRes.applyResult.resources = append(deployRes.applyResult.resources, resourceResult)
 				deployRes.applyResult.hasErr = true
 				return &deployRes, nil
 			}
 
+			deployRes.applyResult.resources = append(deployRes.applyResult.resources, newDeployResourceResult(resource, namespace, parseApplyAction(applyStdout)))
+
 			if resource.DecodeErrMsg == "" {
 				logger.Infof("applied resource %s/%s/%s/%s in namespace %s", group, version, kind, name, namespace)
 			} else {
@@ -284,7 +311,7 @@ func (c *Client) ensureResourcesPresent(deployArgs operatortypes.DeployAppArgs)
 
 			if resource.ShouldWaitForReady() {
 				logger.Infof("waiting for resource %s/%s/%s/%s in namespace %s to be ready", group, version, kind, name, namespace)
-				err := appstate.WaitForResourceToBeReady(namespace, name, resource.GVK)
+				err := appstate.WaitForResourceToBeReady(ctx, namespace, name, resource.GVK)
 				if err != nil {
 					return nil, errors.Wrapf(err, "failed to wait for resource %s/%s/%s/%s in namespace %s to be ready", group, version, kind, name, namespace)
 				}
@@ -294,7 +321,7 @@ func (c *Client) ensureResourcesPresent(deployArgs operatortypes.DeployAppArgs)
 			if resource.ShouldWaitForProperties() {
 				for _, prop := range resource.GetWaitForProperties() {
 					logger.Infof("waiting for resource %s/%s/%s/%s in namespace %s to have property %s=%s", group, version, kind, name, namespace, prop.Path, prop.Value)
-					err := appstate.WaitForProperty(namespace, name, resource.GVK, prop.Path, prop.Value)
+					err := appstate.WaitForProperty(ctx, namespace, name, resource.GVK, prop.Path, prop.Value)
 					if err != nil {
 						return nil, errors.Wrapf(err, "failed to wait for resource %s/%s/%s/%s in namespace %s to have property %s=%s", group, version, kind, name, namespace, prop.Path, prop.Value)
 					}
@@ -302,12 +329,121 @@ func (c *Client) ensureResourcesPresent(deployArgs operatortypes.DeployAppArgs)
 				}
 			}
 		}
+
+		var phaseDefaults map[string]string
+		if deployArgs.KotsKinds != nil {
+			phaseDefaults = deployArgs.KotsKinds.KotsApplication.GetAnnotations()
+		}
+		if wait, timeout := phase.GetReadiness(phaseDefaults); wait {
+			logger.Infof("waiting up to %s for phase %s to be ready", timeout, phase.Name)
+			notReady := c.waitForPhaseToBeReady(ctx, phase, timeout)
+			if err := ctx.Err(); err != nil {
+				return nil, errors.Wrap(err, "deploy was cancelled")
+			}
+			if len(notReady) > 0 {
+				msg := fmt.Sprintf("phase %s was not ready after %s:\n  %s\n", phase.Name, timeout, strings.Join(notReady, "\n  "))
+				logger.Info(msg)
+
+				deployRes.applyResult.multiStderr = append(deployRes.applyResult.multiStderr, []byte(msg))
+				deployRes.applyResult.hasErr = true
+				return &deployRes, nil
+			}
+			logger.Infof("phase %s is ready", phase.Name)
+		}
 	}
 
 	return &deployRes, nil
 }
 
-func (c *Client) installWithHelm(v1Beta1ChartsDir, v1beta2ChartsDir string, kotsCharts []helmchart.HelmChartInterface) (*commandResult, error) {
+func newDeployResourceResult(resource operatortypes.Resource, namespace string, action downstreamtypes.DeployResourceAction) downstreamtypes.DeployResourceResult {
+	return downstreamtypes.DeployResourceResult{
+		Group:     resource.GetGroup(),
+		Version:   resource.GetVersion(),
+		Kind:      resource.GetKind(),
+		Namespace: namespace,
+		Name:      resource.GetName(),
+		Action:    action,
+	}
+}
+
+// parseApplyAction returns the action reported by the applier for a single resource,
+// e.g. "configured" for "deployment.apps/web configured"
+func parseApplyAction(stdout []byte) downstreamtypes.DeployResourceAction {
+	lines := strings.Split(strings.TrimSpace(string(stdout)), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		fields := strings.Fields(lines[i])
+		if len(fields) < 2 {
+			continue
+		}
+		switch fields[1] {
+		case "created":
+			return downstreamtypes.DeployResourceCreated
+		case "unchanged":
+			return downstreamtypes.DeployResourceUnchanged
+		case "configured", "patched", "replaced":
+			return downstreamtypes.DeployResourceConfigured
+		}
+	}
+	return downstreamtypes.DeployResourceConfigured
+}
+
+// getCommandError returns the last line of stderr, which is usually the most specific error,
+// or the error itself if there is no stderr
+func getCommandError(stderr []byte, err error) string {
+	lines := strings.Split(strings.TrimSpace(string(stderr)), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return last
+	}
+	return err.Error()
+}
+
+// waitForPhaseToBeReady waits for the resources in the phase to be ready, and returns the ones
+// that were not ready before the timeout
+func (c *Client) waitForPhaseToBeReady(ctx context.Context, phase operatortypes.Phase, timeout time.Duration) []string {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var mtx sync.Mutex
+	var wg sync.WaitGroup
+	notReady := []string{}
+
+	for _, resource := range phase.Resources {
+		if resource.DecodeErrMsg != "" {
+			continue
+		}
+
+		namespace := resource.GetNamespace()
+		if namespace == "" {
+			namespace = c.TargetNamespace
+		}
+
+		wg.Add(1)
+		go func(resource operatortypes.Resource, namespace string) {
+			defer wg.Done()
+
+			err := waitForResourceToBeReady(ctx, namespace, resource.GetName(), resource.GVK)
+			if err == nil {
+				return
+			}
+
+			reason := "not ready"
+			if !errors.Is(err, context.DeadlineExceeded) {
+				reason = err.Error()
+			}
+
+			mtx.Lock()
+			defer mtx.Unlock()
+			notReady = append(notReady, fmt.Sprintf("%s/%s in namespace %s: %s", strings.ToLower(resource.GetKind()), resource.GetName(), namespace, reason))
+		}(resource, namespace)
+	}
+
+	wg.Wait()
+	sort.Strings(notReady)
+
+	return notReady
+}
+
+func (c *Client) installWithHelm(ctx context.Context, v1Beta1ChartsDir, v1beta2ChartsDir string, kotsCharts []helmchart.HelmChartInterface) (*commandResult, error) {
 	orderedDirs, err := getSortedCharts(v1Beta1ChartsDir, v1beta2ChartsDir, kotsCharts, c.TargetNamespace, false)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get sorted charts")
@@ -315,8 +451,13 @@ func (c *Client) installWithHelm(v1Beta1ChartsDir, v1beta2ChartsDir string, kots
 
 	var hasErr bool
 	var multiStdout, multiStderr [][]byte
+	helmReleases := []downstreamtypes.HelmReleaseResult{}
 
 	for _, dir := range orderedDirs {
+		if err := ctx.Err(); err != nil {
+			return nil, errors.Wrap(err, "deploy was cancelled")
+		}
+
 		args := []string{"upgrade", "-i", dir.ReleaseName}
 		if dir.APIVersion == "kots.io/v1beta1" {
 			installDir := filepath.Join(v1Beta1ChartsDir, dir.Name)
@@ -355,17 +496,28 @@ func (c *Client) installWithHelm(v1Beta1ChartsDir, v1beta2ChartsDir string, kots
 			args = append(args, "--debug")
 		}
 
+		releaseResult := downstreamtypes.HelmReleaseResult{
+			ReleaseName:  dir.ReleaseName,
+			Namespace:    dir.Namespace,
+			ChartName:    dir.ChartName,
+			ChartVersion: dir.ChartVersion,
+			Action:       downstreamtypes.HelmReleaseDeployed,
+		}
+
 		logger.Infof("running helm with arguments %v", args)
-		cmd := exec.Command("helm", args...)
+		cmd := newHelmCommand(ctx, args...)
 		stdout, stderr, err := applier.Run(cmd)
 		if err != nil {
 			logger.Infof("stdout (helm install) = %s", stdout)
 			logger.Infof("stderr (helm install) = %s", stderr)
 			logger.Infof("error: %s", err.Error())
 			hasErr = true
+			releaseResult.Action = downstreamtypes.HelmReleaseFailed
+			releaseResult.Error = getCommandError(stderr, err)
 		} else {
 			logger.Infof("helm upgrade -i command completed successfully")
 		}
+		helmReleases = append(helmReleases, releaseResult)
 
 		if len(stdout) > 0 {
 			multiStdout = append(multiStdout, []byte(fmt.Sprintf("------- %s -------", dir.Name)), stdout)
@@ -376,13 +528,25 @@ func (c *Client) installWithHelm(v1Beta1ChartsDir, v1beta2ChartsDir string, kots
 	}
 
 	result := &commandResult{
-		hasErr:      hasErr,
-		multiStderr: multiStderr,
-		multiStdout: multiStdout,
+		hasErr:       hasErr,
+		multiStderr:  multiStderr,
+		multiStdout:  multiStdout,
+		helmReleases: helmReleases,
 	}
 	return result, nil
 }
 
+// newHelmCommand returns a helm command that is interrupted when ctx is cancelled. Helm is given
+// helmCancelGracePeriod to mark the release as failed before it is killed.
+func newHelmCommand(ctx context.Context, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, "helm", args...)
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(syscall.SIGTERM)
+	}
+	cmd.WaitDelay = helmCancelGracePeriod
+	return cmd
+}
+
 // appendServerSideArgs appends --server-side=false when --take-ownership, --force, or
 // --force-replace is in upgradeFlags. kubectl apply (used by Replicated helm) uses client-side
 // apply by default, so when taking ownership of resources already deployed, using SSA will result
@@ -575,12 +739,14 @@ func findChartNameAndVersionInArchive(archivePath string) (string, string, error
 	return findChartNameAndVersion(tmpDir)
 }
 
-func (c *Client) uninstallWithHelm(v1Beta1ChartsDir, v1Beta2ChartsDir string, kotsCharts []helmchart.HelmChartInterface) error {
+// uninstallWithHelm uninstalls the releases of the charts, and returns the releases that were uninstalled
+func (c *Client) uninstallWithHelm(v1Beta1ChartsDir, v1Beta2ChartsDir string, kotsCharts []helmchart.HelmChartInterface) ([]downstreamtypes.HelmReleaseResult, error) {
 	orderedDirs, err := getSortedCharts(v1Beta1ChartsDir, v1Beta2ChartsDir, kotsCharts, c.TargetNamespace, true)
 	if err != nil {
-		return errors.Wrap(err, "failed to get sorted charts")
+		return nil, errors.Wrap(err, "failed to get sorted charts")
 	}
 
+	helmReleases := []downstreamtypes.HelmReleaseResult{}
 	for _, dir := range orderedDirs {
 		args := []string{"uninstall", dir.ReleaseName}
 
@@ -598,11 +764,19 @@ func (c *Client) uninstallWithHelm(v1Beta1ChartsDir, v1Beta2ChartsDir string, ko
 				continue
 			}
 			logger.Errorf("error: %s", err.Error())
-			return errors.Wrapf(err, "failed to uninstall release %s for chart %s: %s", dir.ReleaseName, dir.ChartName, stderr)
+			return helmReleases, errors.Wrapf(err, "failed to uninstall release %s for chart %s: %s", dir.ReleaseName, dir.ChartName, stderr)
 		}
+
+		helmReleases = append(helmReleases, downstreamtypes.HelmReleaseResult{
+			ReleaseName:  dir.ReleaseName,
+			Namespace:    dir.Namespace,
+			ChartName:    dir.ChartName,
+			ChartVersion: dir.ChartVersion,
+			Action:       downstreamtypes.HelmReleaseUninstalled,
+		})
 	}
 
-	return nil
+	return helmReleases, nil
 }
 
 type getRemovedChartsOptions struct {
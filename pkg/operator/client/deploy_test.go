@@ -4,19 +4,24 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/pmezard/go-difflib/difflib"
+	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
 	"github.com/replicatedhq/kots/pkg/archiveutil"
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
 	"github.com/replicatedhq/kotskinds/apis/kots/v1beta1"
 	"github.com/replicatedhq/kotskinds/apis/kots/v1beta2"
 	"github.com/replicatedhq/kotskinds/pkg/helmchart"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func Test_getSortedCharts(t *testing.T) {
@@ -2621,3 +2626,107 @@ func fmtJSONDiff(got, want interface{}) string {
 	diffStr, _ := difflib.GetUnifiedDiffString(diff)
 	return fmt.Sprintf("got:\n%s \n\nwant:\n%s \n\ndiff:\n%s", a, b, diffStr)
 }
+
+func Test_waitForPhaseToBeReady(t *testing.T) {
+	defer func(fn func(context.Context, string, string, *schema.GroupVersionKind) error) {
+		waitForResourceToBeReady = fn
+	}(waitForResourceToBeReady)
+
+	// the database never becomes ready, and the secret cannot be read
+	waitForResourceToBeReady = func(ctx context.Context, namespace, name string, gvk *schema.GroupVersionKind) error {
+		switch name {
+		case "db":
+			<-ctx.Done()
+			return ctx.Err()
+		case "creds":
+			return errors.New("forbidden")
+		}
+		return nil
+	}
+
+	manifests := [][]byte{
+		[]byte("apiVersion: apps/v1\nkind: StatefulSet\nmetadata:\n  name: db\n"),
+		[]byte("apiVersion: v1\nkind: Secret\nmetadata:\n  name: creds\n  namespace: other\n"),
+		[]byte("apiVersion: v1\nkind: Service\nmetadata:\n  name: db\n  namespace: app\n"),
+		[]byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n"),
+		[]byte("not a manifest"),
+	}
+	phase := operatortypes.Phase{Name: "0", Resources: decodeManifests(manifests)}
+
+	c := &Client{TargetNamespace: "app"}
+	notReady := c.waitForPhaseToBeReady(context.Background(), phase, 100*time.Millisecond)
+
+	require.Equal(t, []string{
+		"secret/creds in namespace other: forbidden",
+		"service/db in namespace app: not ready",
+		"statefulset/db in namespace app: not ready",
+	}, notReady)
+}
+
+func Test_parseApplyAction(t *testing.T) {
+	tests := []struct {
+		name   string
+		stdout string
+		want   downstreamtypes.DeployResourceAction
+	}{
+		{
+			name:   "created",
+			stdout: "deployment.apps/web created\n",
+			want:   downstreamtypes.DeployResourceCreated,
+		},
+		{
+			name:   "unchanged",
+			stdout: "configmap/config unchanged",
+			want:   downstreamtypes.DeployResourceUnchanged,
+		},
+		{
+			name:   "create or patch fallback",
+			stdout: "Warning: metadata too long\nsecret/big patched\n",
+			want:   downstreamtypes.DeployResourceConfigured,
+		},
+		{
+			name:   "server dry run",
+			stdout: "service/web created (server dry run)\n",
+			want:   downstreamtypes.DeployResourceCreated,
+		},
+		{
+			name:   "no output",
+			stdout: "",
+			want:   downstreamtypes.DeployResourceConfigured,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, parseApplyAction([]byte(tt.stdout)))
+		})
+	}
+}
+
+func Test_getCommandError(t *testing.T) {
+	stderr := "Warning: something\nError from server (Invalid): spec.replicas: Invalid value: -1\n"
+	require.Equal(t, "Error from server (Invalid): spec.replicas: Invalid value: -1", getCommandError([]byte(stderr), errors.New("exit status 1")))
+	require.Equal(t, "exit status 1", getCommandError(nil, errors.New("exit status 1")))
+}
+
+func Test_getDeployResults(t *testing.T) {
+	dryRun := &commandResult{}
+	apply := &commandResult{
+		resources: []downstreamtypes.DeployResourceResult{
+			{Kind: "Secret", Name: "old", Action: downstreamtypes.DeployResourceDeleted},
+			{Kind: "Deployment", Name: "web", Action: downstreamtypes.DeployResourceCreated},
+		},
+	}
+	helm := &commandResult{
+		helmReleases: []downstreamtypes.HelmReleaseResult{
+			{ReleaseName: "db", Action: downstreamtypes.HelmReleaseDeployed},
+		},
+	}
+
+	got := getDeployResults(dryRun, apply, nil, helm)
+	require.Equal(t, apply.resources, got.Resources)
+	require.Equal(t, helm.helmReleases, got.HelmReleases)
+
+	empty := getDeployResults(nil)
+	require.NotNil(t, empty.Resources)
+	require.NotNil(t, empty.HelmReleases)
+}
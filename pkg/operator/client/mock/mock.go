@@ -5,6 +5,7 @@
 package mock_client
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -71,18 +72,18 @@ func (mr *MockClientInterfaceMockRecorder) ApplyNamespacesInformer(namespaces, i
 }
 
 // DeployApp mocks base method.
-func (m *MockClientInterface) DeployApp(deployArgs types.DeployAppArgs) (bool, error) {
+func (m *MockClientInterface) DeployApp(ctx context.Context, deployArgs types.DeployAppArgs) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeployApp", deployArgs)
+	ret := m.ctrl.Call(m, "DeployApp", ctx, deployArgs)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeployApp indicates an expected call of DeployApp.
-func (mr *MockClientInterfaceMockRecorder) DeployApp(deployArgs interface{}) *gomock.Call {
+func (mr *MockClientInterfaceMockRecorder) DeployApp(ctx, deployArgs interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployApp", reflect.TypeOf((*MockClientInterface)(nil).DeployApp), deployArgs)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeployApp", reflect.TypeOf((*MockClientInterface)(nil).DeployApp), ctx, deployArgs)
 }
 
 // Init mocks base method.
@@ -99,6 +100,21 @@ func (mr *MockClientInterfaceMockRecorder) Init() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Init", reflect.TypeOf((*MockClientInterface)(nil).Init))
 }
 
+// PlanApp mocks base method.
+func (m *MockClientInterface) PlanApp(deployArgs types.DeployAppArgs) (*types.DeployPlan, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanApp", deployArgs)
+	ret0, _ := ret[0].(*types.DeployPlan)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlanApp indicates an expected call of PlanApp.
+func (mr *MockClientInterfaceMockRecorder) PlanApp(deployArgs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanApp", reflect.TypeOf((*MockClientInterface)(nil).PlanApp), deployArgs)
+}
+
 // Shutdown mocks base method.
 func (m *MockClientInterface) Shutdown() {
 	m.ctrl.T.Helper()
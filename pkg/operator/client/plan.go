@@ -0,0 +1,563 @@
+package client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/binaries"
+	"github.com/replicatedhq/kots/pkg/k8sutil"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/operator/applier"
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+	"github.com/replicatedhq/kots/pkg/util"
+	"github.com/replicatedhq/kotskinds/pkg/helmchart"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// PlanApp returns the changes that deploying the given app version would make to the cluster, without making them.
+// Every resource is dry-run on the server with the configured applier, and the result is compared to the live object.
+func (c *Client) PlanApp(deployArgs operatortypes.DeployAppArgs) (*operatortypes.DeployPlan, error) {
+	cfg, err := k8sutil.GetClusterConfig()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get cluster config")
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create dynamic client")
+	}
+
+	disc, err := discovery.NewDiscoveryClientForConfig(cfg)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create discovery client")
+	}
+
+	applierName, err := getApplierName()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &planner{
+		dynamicClient:   dynamicClient,
+		mapper:          restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(disc)),
+		targetNamespace: c.TargetNamespace,
+	}
+	if applierName != applier.ApplierServerSide {
+		p.kubectlDryRun = applier.NewKubectl(binaries.GetKubectlBinPath(), binaries.GetKustomizeBinPath(), cfg).DryRunApply
+	}
+
+	plan := &operatortypes.DeployPlan{
+		AppSlug:   deployArgs.AppSlug,
+		Sequence:  deployArgs.Sequence,
+		Resources: []operatortypes.ResourcePlan{},
+	}
+
+	manifestPlans, err := c.planManifests(p, deployArgs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to plan manifests")
+	}
+	plan.Resources = append(plan.Resources, manifestPlans...)
+
+	chartPlans, err := c.planV1Beta2Charts(p, deployArgs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to plan helm charts")
+	}
+	plan.Resources = append(plan.Resources, chartPlans...)
+
+	return plan, nil
+}
+
+func (c *Client) planManifests(p *planner, deployArgs operatortypes.DeployAppArgs) ([]operatortypes.ResourcePlan, error) {
+	decoded, err := base64.StdEncoding.DecodeString(deployArgs.Manifests)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to base64 decode manifests")
+	}
+
+	plans := []operatortypes.ResourcePlan{}
+
+	resources := decodeManifests(util.ConvertToSingleDocs(decoded))
+	for _, phase := range groupAndSortResourcesForCreation(resources) {
+		for _, r := range phase.Resources {
+			plans = append(plans, p.planResource(r, "", ""))
+		}
+	}
+
+	if deployArgs.PreviousManifests != "" {
+		opts := DiffAndDeleteOptions{
+			PreviousManifests:    deployArgs.PreviousManifests,
+			CurrentManifests:     deployArgs.Manifests,
+			AdditionalNamespaces: deployArgs.AdditionalNamespaces,
+		}
+		manifestsToDelete, err := c.getManifestsToDelete(opts)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get manifests to delete")
+		}
+		for _, r := range decodeManifests(manifestsToDelete) {
+			if plan := p.planDeletion(r, "", ""); plan != nil {
+				plans = append(plans, *plan)
+			}
+		}
+	}
+
+	return plans, nil
+}
+
+// planV1Beta2Charts templates the current v1beta2 charts and plans their resources. Resources that were
+// deployed by a release but are no longer in its chart, and the resources of removed charts, are planned for deletion.
+func (c *Client) planV1Beta2Charts(p *planner, deployArgs operatortypes.DeployAppArgs) ([]operatortypes.ResourcePlan, error) {
+	prevV1Beta2HelmDir, err := extractHelmCharts(deployArgs.PreviousV1Beta2ChartsArchive, "prev-v1beta2")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to extract previous helm charts")
+	}
+	defer os.RemoveAll(prevV1Beta2HelmDir)
+
+	curV1Beta2HelmDir, err := extractHelmCharts(deployArgs.V1Beta2ChartsArchive, "curr-v1beta2")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to extract current helm charts")
+	}
+	defer os.RemoveAll(curV1Beta2HelmDir)
+
+	prevKotsV1Beta2Charts := []helmchart.HelmChartInterface{}
+	if deployArgs.PreviousKotsKinds != nil && deployArgs.PreviousKotsKinds.V1Beta2HelmCharts != nil {
+		for _, kotsChart := range deployArgs.PreviousKotsKinds.V1Beta2HelmCharts.Items {
+			kc := kotsChart
+			prevKotsV1Beta2Charts = append(prevKotsV1Beta2Charts, &kc)
+		}
+	}
+
+	curV1Beta2KotsCharts := []helmchart.HelmChartInterface{}
+	if deployArgs.KotsKinds != nil && deployArgs.KotsKinds.V1Beta2HelmCharts != nil {
+		for _, kotsChart := range deployArgs.KotsKinds.V1Beta2HelmCharts.Items {
+			kc := kotsChart
+			curV1Beta2KotsCharts = append(curV1Beta2KotsCharts, &kc)
+		}
+	}
+
+	plans := []operatortypes.ResourcePlan{}
+
+	if curV1Beta2HelmDir != "" {
+		chartsDir := filepath.Join(curV1Beta2HelmDir, "helm")
+		orderedDirs, err := getSortedCharts("", chartsDir, curV1Beta2KotsCharts, c.TargetNamespace, false)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get sorted charts")
+		}
+
+		for _, dir := range orderedDirs {
+			deployed, err := helmGetManifest(dir.ReleaseName, dir.Namespace)
+			if err != nil {
+				logger.Debugf("failed to get manifest for helm release %s, assuming it is not installed: %v", dir.ReleaseName, err)
+			}
+			isUpgrade := err == nil
+
+			templated, err := helmTemplate(chartsDir, dir, isUpgrade)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to template chart %s", dir.Name)
+			}
+
+			currentDocs := util.ConvertToSingleDocs(templated)
+			for _, r := range decodeManifests(currentDocs) {
+				plans = append(plans, p.planResource(r, dir.Namespace, dir.ReleaseName))
+			}
+
+			if isUpgrade {
+				removed := getRemovedManifests(util.ConvertToSingleDocs(deployed), currentDocs, dir.Namespace)
+				for _, r := range decodeManifests(removed) {
+					if plan := p.planDeletion(r, dir.Namespace, dir.ReleaseName); plan != nil {
+						plans = append(plans, *plan)
+					}
+				}
+			}
+		}
+	}
+
+	opts := getRemovedChartsOptions{
+		prevV1Beta2Dir:            prevV1Beta2HelmDir,
+		curV1Beta2Dir:             curV1Beta2HelmDir,
+		previousV1Beta2KotsCharts: prevKotsV1Beta2Charts,
+		currentV1Beta2KotsCharts:  curV1Beta2KotsCharts,
+	}
+	removedCharts, err := getRemovedCharts(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to find removed charts")
+	}
+
+	for _, removedChart := range removedCharts {
+		namespace := removedChart.GetNamespace()
+		if namespace == "" {
+			namespace = c.TargetNamespace
+		}
+		deployed, err := helmGetManifest(removedChart.GetReleaseName(), namespace)
+		if err != nil {
+			logger.Debugf("failed to get manifest for removed helm release %s: %v", removedChart.GetReleaseName(), err)
+			continue
+		}
+		for _, r := range decodeManifests(util.ConvertToSingleDocs(deployed)) {
+			if plan := p.planDeletion(r, namespace, removedChart.GetReleaseName()); plan != nil {
+				plans = append(plans, *plan)
+			}
+		}
+	}
+
+	return plans, nil
+}
+
+// helmTemplate renders a v1beta2 chart the same way that it is installed. Only the upgrade flags that set values
+// are passed to helm, since the others do not change the rendered manifests.
+func helmTemplate(chartsDir string, dir orderedDir, isUpgrade bool) ([]byte, error) {
+	installDir := filepath.Join(chartsDir, dir.Name)
+	chartPath := filepath.Join(installDir, fmt.Sprintf("%s-%s.tgz", dir.ChartName, dir.ChartVersion))
+	valuesPath := filepath.Join(installDir, "values.yaml")
+
+	args := []string{"template", dir.ReleaseName, chartPath, "-f", valuesPath}
+	if dir.Namespace != "" {
+		args = append(args, "-n", dir.Namespace)
+	}
+	if isUpgrade {
+		args = append(args, "--is-upgrade")
+	}
+	args = append(args, getHelmTemplateValuesFlags(dir.UpgradeFlags)...)
+
+	stdout, stderr, err := applier.Run(exec.Command("helm", args...))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to run helm template: %s", stderr)
+	}
+
+	return stdout, nil
+}
+
+func helmGetManifest(releaseName string, namespace string) ([]byte, error) {
+	args := []string{"get", "manifest", releaseName}
+	if namespace != "" {
+		args = append(args, "-n", namespace)
+	}
+
+	stdout, stderr, err := applier.Run(exec.Command("helm", args...))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to run helm get manifest: %s", stderr)
+	}
+
+	return stdout, nil
+}
+
+// getHelmTemplateValuesFlags returns the flags that set chart values from a list of helm upgrade flags
+func getHelmTemplateValuesFlags(upgradeFlags []string) []string {
+	valuesFlags := []string{"--set", "--set-string", "--set-file", "--set-json", "--set-literal", "--values", "-f"}
+
+	isValuesFlag := func(flag string) bool {
+		for _, f := range valuesFlags {
+			if flag == f {
+				return true
+			}
+		}
+		return false
+	}
+
+	flags := []string{}
+	for i := 0; i < len(upgradeFlags); i++ {
+		flag := upgradeFlags[i]
+		name, _, hasValue := strings.Cut(flag, "=")
+		if !isValuesFlag(name) {
+			continue
+		}
+		flags = append(flags, flag)
+		if !hasValue && i+1 < len(upgradeFlags) {
+			flags = append(flags, upgradeFlags[i+1])
+			i++
+		}
+	}
+
+	return flags
+}
+
+// getRemovedManifests returns the previous manifests that are not in the current manifests
+func getRemovedManifests(previous [][]byte, current [][]byte, namespace string) [][]byte {
+	currentKeys := map[string]bool{}
+	for _, doc := range current {
+		k, _ := GetGVKWithNameAndNs(doc, namespace)
+		currentKeys[k] = true
+	}
+
+	removed := [][]byte{}
+	for _, doc := range previous {
+		k, o := GetGVKWithNameAndNs(doc, namespace)
+		if o.Kind == "" || currentKeys[k] {
+			continue
+		}
+		removed = append(removed, doc)
+	}
+
+	return removed
+}
+
+type planner struct {
+	dynamicClient   dynamic.Interface
+	mapper          meta.RESTMapper
+	targetNamespace string
+	// kubectlDryRun is set when the kubectl applier is configured, so that resources are planned with the
+	// client-side apply that the deploy runs instead of a server-side apply
+	kubectlDryRun func(targetNamespace string, yamlDoc []byte) ([]byte, []byte, error)
+}
+
+// planResource dry-runs the apply of a resource and diffs the result against the live object
+func (p *planner) planResource(r operatortypes.Resource, defaultNamespace string, helmRelease string) operatortypes.ResourcePlan {
+	plan := newResourcePlan(r, helmRelease)
+	if r.DecodeErrMsg != "" {
+		plan.Error = fmt.Sprintf("failed to decode manifest: %s", r.DecodeErrMsg)
+		return plan
+	}
+
+	ri, namespace, err := p.getResourceInterface(r, defaultNamespace)
+	if err != nil {
+		plan.Action = operatortypes.PlanActionCreate
+		plan.Error = err.Error()
+		return plan
+	}
+	plan.Namespace = namespace
+
+	live, err := ri.Get(context.TODO(), r.GetName(), metav1.GetOptions{})
+	if err != nil && !kuberneteserrors.IsNotFound(err) {
+		plan.Error = errors.Wrap(err, "failed to get live object").Error()
+		return plan
+	}
+	exists := err == nil
+
+	obj := r.Unstructured.DeepCopy()
+	if namespace != "" {
+		obj.SetNamespace(namespace)
+	}
+	dryRun, err := p.dryRunApply(ri, namespace, obj)
+
+	if !exists {
+		plan.Action = operatortypes.PlanActionCreate
+		// the namespace of a new resource may not exist until the deploy creates it
+		if err != nil && !kuberneteserrors.IsNotFound(err) {
+			plan.Error = errors.Wrap(err, "dry-run apply failed").Error()
+		}
+		return plan
+	}
+
+	if err != nil {
+		plan.Action = operatortypes.PlanActionUpdate
+		plan.Error = errors.Wrap(err, "dry-run apply failed").Error()
+		return plan
+	}
+
+	plan.Diffs = redactSecretDiffs(plan.APIVersion, plan.Kind, diffFields("", normalizeForDiff(live.Object), normalizeForDiff(dryRun.Object)))
+	if len(plan.Diffs) > 0 {
+		plan.Action = operatortypes.PlanActionUpdate
+	} else {
+		plan.Action = operatortypes.PlanActionUnchanged
+	}
+
+	return plan
+}
+
+// dryRunApply returns the object that applying the resource with the configured applier would result in
+func (p *planner) dryRunApply(ri dynamic.ResourceInterface, namespace string, obj *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	if p.kubectlDryRun == nil {
+		return applier.DryRunServerSideApply(ri, obj)
+	}
+
+	data, err := obj.MarshalJSON()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal object")
+	}
+
+	stdout, stderr, err := p.kubectlDryRun(namespace, data)
+	if err != nil {
+		if strings.Contains(string(stderr), "(NotFound)") {
+			// e.g. the namespace does not exist yet
+			return nil, kuberneteserrors.NewNotFound(schema.GroupResource{}, obj.GetName())
+		}
+		return nil, errors.Wrap(err, strings.TrimSpace(string(stderr)))
+	}
+
+	dryRun := &unstructured.Unstructured{}
+	if err := dryRun.UnmarshalJSON(stdout); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal dry-run result")
+	}
+	return dryRun, nil
+}
+
+// planDeletion returns a delete plan for the resource, or nil if it does not exist in the cluster
+func (p *planner) planDeletion(r operatortypes.Resource, defaultNamespace string, helmRelease string) *operatortypes.ResourcePlan {
+	if r.DecodeErrMsg != "" {
+		return nil
+	}
+
+	plan := newResourcePlan(r, helmRelease)
+	plan.Action = operatortypes.PlanActionDelete
+
+	ri, namespace, err := p.getResourceInterface(r, defaultNamespace)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		plan.Error = err.Error()
+		return &plan
+	}
+	plan.Namespace = namespace
+
+	if _, err := ri.Get(context.TODO(), r.GetName(), metav1.GetOptions{}); err != nil {
+		if kuberneteserrors.IsNotFound(err) {
+			return nil
+		}
+		plan.Error = errors.Wrap(err, "failed to get live object").Error()
+	}
+
+	return &plan
+}
+
+// getResourceInterface returns the dynamic client for the resource, and the namespace it is in.
+// The namespace is empty for cluster scoped resources.
+func (p *planner) getResourceInterface(r operatortypes.Resource, defaultNamespace string) (dynamic.ResourceInterface, string, error) {
+	mapping, err := p.mapper.RESTMapping(r.GVK.GroupKind(), r.GVK.Version)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "failed to get rest mapping for %s", r.GVK.String())
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		return p.dynamicClient.Resource(mapping.Resource), "", nil
+	}
+
+	namespace := r.GetNamespace()
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	if namespace == "" {
+		namespace = p.targetNamespace
+	}
+
+	return p.dynamicClient.Resource(mapping.Resource).Namespace(namespace), namespace, nil
+}
+
+func newResourcePlan(r operatortypes.Resource, helmRelease string) operatortypes.ResourcePlan {
+	plan := operatortypes.ResourcePlan{
+		Kind:        r.GetKind(),
+		Name:        r.GetName(),
+		Namespace:   r.GetNamespace(),
+		HelmRelease: helmRelease,
+	}
+	if r.GVK != nil {
+		plan.APIVersion = schema.GroupVersion{Group: r.GVK.Group, Version: r.GVK.Version}.String()
+	}
+	return plan
+}
+
+// normalizeForDiff returns a copy of the object without the fields that are managed by the api server
+func normalizeForDiff(obj map[string]interface{}) map[string]interface{} {
+	obj = runtime.DeepCopyJSON(obj)
+	delete(obj, "status")
+
+	metadata, ok := obj["metadata"].(map[string]interface{})
+	if !ok {
+		return obj
+	}
+	for _, field := range []string{"managedFields", "resourceVersion", "generation", "uid", "creationTimestamp", "selfLink"} {
+		delete(metadata, field)
+	}
+	if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+		delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+		if len(annotations) == 0 {
+			delete(metadata, "annotations")
+		}
+	}
+
+	return obj
+}
+
+// diffFields returns the leaf fields that differ between the old and new values, sorted by path
+func diffFields(path string, oldValue interface{}, newValue interface{}) []operatortypes.FieldDiff {
+	if reflect.DeepEqual(oldValue, newValue) {
+		return nil
+	}
+
+	oldMap, oldIsMap := oldValue.(map[string]interface{})
+	newMap, newIsMap := newValue.(map[string]interface{})
+	if oldIsMap && newIsMap {
+		keys := []string{}
+		for k := range oldMap {
+			keys = append(keys, k)
+		}
+		for k := range newMap {
+			if _, ok := oldMap[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+
+		diffs := []operatortypes.FieldDiff{}
+		for _, k := range keys {
+			diffs = append(diffs, diffFields(joinFieldPath(path, k), oldMap[k], newMap[k])...)
+		}
+		return diffs
+	}
+
+	oldList, oldIsList := oldValue.([]interface{})
+	newList, newIsList := newValue.([]interface{})
+	if oldIsList && newIsList && len(oldList) == len(newList) {
+		diffs := []operatortypes.FieldDiff{}
+		for i := range oldList {
+			diffs = append(diffs, diffFields(fmt.Sprintf("%s[%d]", path, i), oldList[i], newList[i])...)
+		}
+		return diffs
+	}
+
+	return []operatortypes.FieldDiff{
+		{
+			Path: path,
+			Old:  encodeFieldValue(oldValue),
+			New:  encodeFieldValue(newValue),
+		},
+	}
+}
+
+// redactSecretDiffs masks the values of the secret fields that changed, so that only their paths are reported
+func redactSecretDiffs(apiVersion string, kind string, diffs []operatortypes.FieldDiff) []operatortypes.FieldDiff {
+	for i, d := range diffs {
+		if operatortypes.IsSecretField(apiVersion, kind, d.Path) {
+			diffs[i].Old = operatortypes.RedactFieldValue(d.Old)
+			diffs[i].New = operatortypes.RedactFieldValue(d.New)
+		}
+	}
+	return diffs
+}
+
+func joinFieldPath(path string, key string) string {
+	if strings.ContainsAny(key, ".[]") {
+		return fmt.Sprintf("%s[%q]", path, key)
+	}
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func encodeFieldValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
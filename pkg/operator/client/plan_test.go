@@ -0,0 +1,327 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+	"github.com/stretchr/testify/require"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func Test_diffFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldValue interface{}
+		newValue interface{}
+		want     []operatortypes.FieldDiff
+	}{
+		{
+			name: "equal",
+			oldValue: map[string]interface{}{
+				"spec": map[string]interface{}{"replicas": int64(1)},
+			},
+			newValue: map[string]interface{}{
+				"spec": map[string]interface{}{"replicas": int64(1)},
+			},
+			want: nil,
+		},
+		{
+			name: "changed, added and removed fields",
+			oldValue: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"replicas": int64(1),
+					"paused":   true,
+				},
+			},
+			newValue: map[string]interface{}{
+				"spec": map[string]interface{}{
+					"replicas": int64(2),
+					"strategy": map[string]interface{}{"type": "Recreate"},
+				},
+			},
+			want: []operatortypes.FieldDiff{
+				{Path: "spec.paused", Old: "true"},
+				{Path: "spec.replicas", Old: "1", New: "2"},
+				{Path: "spec.strategy", New: `{"type":"Recreate"}`},
+			},
+		},
+		{
+			name: "lists of the same length are diffed by index",
+			oldValue: map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "app", "image": "app:1"},
+				},
+			},
+			newValue: map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "app", "image": "app:2"},
+				},
+			},
+			want: []operatortypes.FieldDiff{
+				{Path: "containers[0].image", Old: `"app:1"`, New: `"app:2"`},
+			},
+		},
+		{
+			name: "lists of different lengths are replaced",
+			oldValue: map[string]interface{}{
+				"args": []interface{}{"a"},
+			},
+			newValue: map[string]interface{}{
+				"args": []interface{}{"a", "b"},
+			},
+			want: []operatortypes.FieldDiff{
+				{Path: "args", Old: `["a"]`, New: `["a","b"]`},
+			},
+		},
+		{
+			name: "keys with dots are quoted",
+			oldValue: map[string]interface{}{
+				"annotations": map[string]interface{}{"kots.io/app-slug": "old"},
+			},
+			newValue: map[string]interface{}{
+				"annotations": map[string]interface{}{"kots.io/app-slug": "new"},
+			},
+			want: []operatortypes.FieldDiff{
+				{Path: `annotations["kots.io/app-slug"]`, Old: `"old"`, New: `"new"`},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffFields("", tt.oldValue, tt.newValue)
+			if len(tt.want) == 0 {
+				require.Empty(t, got)
+				return
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_normalizeForDiff(t *testing.T) {
+	obj := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":              "web",
+			"resourceVersion":   "123",
+			"generation":        int64(4),
+			"uid":               "abc",
+			"creationTimestamp": "2024-01-01T00:00:00Z",
+			"managedFields":     []interface{}{map[string]interface{}{"manager": "kubectl"}},
+			"annotations": map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+		},
+		"spec":   map[string]interface{}{"replicas": int64(1)},
+		"status": map[string]interface{}{"readyReplicas": int64(1)},
+	}
+
+	want := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name": "web",
+		},
+		"spec": map[string]interface{}{"replicas": int64(1)},
+	}
+
+	require.Equal(t, want, normalizeForDiff(obj))
+
+	// the original object is not modified
+	require.Contains(t, obj, "status")
+}
+
+func Test_getHelmTemplateValuesFlags(t *testing.T) {
+	tests := []struct {
+		name         string
+		upgradeFlags []string
+		want         []string
+	}{
+		{
+			name:         "no flags",
+			upgradeFlags: nil,
+			want:         []string{},
+		},
+		{
+			name:         "values flags are kept",
+			upgradeFlags: []string{"--set", "a=b", "--set-string=c=d", "-f", "extra.yaml", "--set-json", `e={"f":1}`},
+			want:         []string{"--set", "a=b", "--set-string=c=d", "-f", "extra.yaml", "--set-json", `e={"f":1}`},
+		},
+		{
+			name:         "other flags are dropped",
+			upgradeFlags: []string{"--skip-crds", "--timeout", "10m", "--set", "a=b", "--wait"},
+			want:         []string{"--set", "a=b"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, getHelmTemplateValuesFlags(tt.upgradeFlags))
+		})
+	}
+}
+
+func Test_getRemovedManifests(t *testing.T) {
+	configMap := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n")
+	secret := []byte("apiVersion: v1\nkind: Secret\nmetadata:\n  name: secret\n")
+	secretInOtherNamespace := []byte("apiVersion: v1\nkind: Secret\nmetadata:\n  name: secret\n  namespace: other\n")
+
+	got := getRemovedManifests([][]byte{configMap, secret, secretInOtherNamespace}, [][]byte{secret}, "default")
+	require.Equal(t, [][]byte{configMap, secretInOtherNamespace}, got)
+}
+
+func Test_planResource(t *testing.T) {
+	configMapGVK := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	configMapGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(configMapGVK, meta.RESTScopeNamespace)
+
+	live := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":            "existing",
+			"namespace":       "app",
+			"resourceVersion": "1",
+		},
+		"data": map[string]interface{}{"key": "old"},
+	}}
+
+	newPlanner := func() *planner {
+		scheme := runtime.NewScheme()
+		dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme, map[schema.GroupVersionResource]string{
+			configMapGVR: "ConfigMapList",
+		}, live.DeepCopy())
+
+		// the fake client does not support server-side apply, so return the applied object as the dry-run result
+		dynamicClient.PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			patchAction := action.(k8stesting.PatchAction)
+			obj := &unstructured.Unstructured{}
+			if err := obj.UnmarshalJSON(patchAction.GetPatch()); err != nil {
+				return true, nil, err
+			}
+			obj.SetResourceVersion("2")
+			return true, obj, nil
+		})
+
+		return &planner{
+			dynamicClient:   dynamicClient,
+			mapper:          mapper,
+			targetNamespace: "app",
+		}
+	}
+
+	configMap := func(name string, value string) operatortypes.Resource {
+		manifest := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: " + name + "\ndata:\n  key: " + value + "\n")
+		return decodeManifests([][]byte{manifest})[0]
+	}
+
+	t.Run("create", func(t *testing.T) {
+		got := newPlanner().planResource(configMap("new", "value"), "", "")
+		require.Equal(t, operatortypes.PlanActionCreate, got.Action)
+		require.Equal(t, "app", got.Namespace)
+		require.Empty(t, got.Error)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		got := newPlanner().planResource(configMap("existing", "new"), "", "")
+		require.Equal(t, operatortypes.PlanActionUpdate, got.Action)
+		require.Equal(t, []operatortypes.FieldDiff{{Path: "data.key", Old: `"old"`, New: `"new"`}}, got.Diffs)
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		got := newPlanner().planResource(configMap("existing", "old"), "", "")
+		require.Equal(t, operatortypes.PlanActionUnchanged, got.Action)
+		require.Empty(t, got.Diffs)
+	})
+
+	t.Run("conflicts", func(t *testing.T) {
+		withConflict := func(manager string) *planner {
+			p := newPlanner()
+			p.dynamicClient.(*dynamicfake.FakeDynamicClient).PrependReactor("patch", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				opts := action.(k8stesting.PatchActionImpl).PatchOptions
+				if opts.Force != nil && *opts.Force {
+					// let the default reactor return the applied object
+					return false, nil, nil
+				}
+				return true, nil, kuberneteserrors.NewApplyConflict([]metav1.StatusCause{
+					{
+						Type:    metav1.CauseTypeFieldManagerConflict,
+						Message: `conflict with "` + manager + `" using v1`,
+						Field:   ".data.key",
+					},
+				}, "Apply failed with 1 conflict")
+			})
+			return p
+		}
+
+		got := withConflict("helm").planResource(configMap("existing", "new"), "", "")
+		require.Equal(t, operatortypes.PlanActionUpdate, got.Action)
+		require.Contains(t, got.Error, ".data.key (managed by helm)")
+
+		// fields set by the kubectl applier are taken over by the deploy
+		got = withConflict("kubectl-client-side-apply").planResource(configMap("existing", "new"), "", "")
+		require.Equal(t, operatortypes.PlanActionUpdate, got.Action)
+		require.Empty(t, got.Error)
+		require.Equal(t, []operatortypes.FieldDiff{{Path: "data.key", Old: `"old"`, New: `"new"`}}, got.Diffs)
+	})
+
+	t.Run("kubectl applier", func(t *testing.T) {
+		p := newPlanner()
+		p.kubectlDryRun = func(targetNamespace string, yamlDoc []byte) ([]byte, []byte, error) {
+			require.Equal(t, "app", targetNamespace)
+			obj := &unstructured.Unstructured{}
+			require.NoError(t, obj.UnmarshalJSON(yamlDoc))
+			// the server defaults a field that is not in the manifest
+			obj.Object["immutable"] = false
+			out, err := obj.MarshalJSON()
+			return out, nil, err
+		}
+
+		got := p.planResource(configMap("existing", "new"), "", "")
+		require.Equal(t, operatortypes.PlanActionUpdate, got.Action)
+		require.Equal(t, []operatortypes.FieldDiff{
+			{Path: "data.key", Old: `"old"`, New: `"new"`},
+			{Path: "immutable", Old: "", New: "false"},
+		}, got.Diffs)
+
+		p.kubectlDryRun = func(targetNamespace string, yamlDoc []byte) ([]byte, []byte, error) {
+			return nil, []byte(`Error from server (NotFound): namespaces "app" not found`), errors.New("exit status 1")
+		}
+		got = p.planResource(configMap("new", "value"), "", "")
+		require.Equal(t, operatortypes.PlanActionCreate, got.Action)
+		require.Empty(t, got.Error)
+	})
+
+	t.Run("secret values are redacted", func(t *testing.T) {
+		got := redactSecretDiffs("v1", "Secret", []operatortypes.FieldDiff{
+			{Path: "data.password", Old: `"b2xk"`, New: `"bmV3"`},
+			{Path: `stringData["config.yaml"]`, New: `"key: value"`},
+			{Path: "metadata.labels.app", Old: `"a"`, New: `"b"`},
+		})
+		require.Equal(t, []operatortypes.FieldDiff{
+			{Path: "data.password", Old: operatortypes.RedactedFieldValue, New: operatortypes.RedactedFieldValue},
+			{Path: `stringData["config.yaml"]`, New: operatortypes.RedactedFieldValue},
+			{Path: "metadata.labels.app", Old: `"a"`, New: `"b"`},
+		}, got)
+
+		configMapDiffs := []operatortypes.FieldDiff{{Path: "data.key", Old: `"old"`, New: `"new"`}}
+		require.Equal(t, configMapDiffs, redactSecretDiffs("v1", "ConfigMap", configMapDiffs))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		p := newPlanner()
+
+		got := p.planDeletion(configMap("existing", "old"), "", "release")
+		require.NotNil(t, got)
+		require.Equal(t, operatortypes.PlanActionDelete, got.Action)
+		require.Equal(t, "release", got.HelmRelease)
+
+		require.Nil(t, p.planDeletion(configMap("missing", "old"), "", ""))
+	})
+}
@@ -0,0 +1,108 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
+	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/notifications"
+	notificationtypes "github.com/replicatedhq/kots/pkg/notifications/types"
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+	"github.com/replicatedhq/kots/pkg/registry"
+	"github.com/replicatedhq/kots/pkg/reporting"
+	"github.com/replicatedhq/kots/pkg/store"
+	"github.com/replicatedhq/kots/pkg/supportbundle"
+	supportbundletypes "github.com/replicatedhq/kots/pkg/supportbundle/types"
+)
+
+// Reporter records the results of the client's deploys and the status of the deployed apps
+type Reporter interface {
+	SetDeployResults(args operatortypes.DeployAppArgs, isError bool, output downstreamtypes.DownstreamOutput) error
+	SetAppStatus(appStatus appstatetypes.AppStatus) error
+}
+
+// StoreReporter records deploy results and app statuses in the kotsadm store. It is used when the
+// client runs in the admin console, and for results reported by the agents of remote clusters.
+type StoreReporter struct{}
+
+var _ Reporter = StoreReporter{}
+
+func (r StoreReporter) SetDeployResults(args operatortypes.DeployAppArgs, isError bool, output downstreamtypes.DownstreamOutput) error {
+	app, err := store.GetStore().GetApp(args.AppID)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "failed to get app after deploying"))
+	} else {
+		troubleshootOpts := supportbundletypes.TroubleshootOptions{
+			InCluster: true,
+		}
+		if _, err := supportbundle.CreateSupportBundleDependencies(app, args.Sequence, troubleshootOpts); err != nil {
+			// support bundle is not essential. keep processing deployment request
+			logger.Error(errors.Wrapf(err, "failed to create support bundle for sequence %d after deploying", args.Sequence))
+		}
+	}
+
+	alreadySuccessful, err := store.GetStore().IsDownstreamDeploySuccessful(args.AppID, args.ClusterID, args.Sequence)
+	if err != nil {
+		return errors.Wrap(err, "failed to check deploy successful")
+	}
+
+	if alreadySuccessful {
+		return nil
+	}
+
+	err = store.GetStore().UpdateDownstreamDeployStatus(args.AppID, args.ClusterID, args.Sequence, isError, output)
+	if err != nil {
+		return errors.Wrap(err, "failed to update downstream deploy status")
+	}
+
+	if !isError {
+		go func() {
+			err := registry.DeleteUnusedImages(args.AppID, false)
+			if err != nil {
+				if _, ok := err.(registry.AppRollbackError); ok {
+					logger.Infof("not garbage collecting images because version allows rollbacks: %v", err)
+				} else {
+					logger.Infof("failed to delete unused images: %v", err)
+				}
+			}
+		}()
+	}
+
+	return nil
+}
+
+func (r StoreReporter) SetAppStatus(newAppStatus appstatetypes.AppStatus) error {
+	currentAppStatus, err := store.GetStore().GetAppStatus(newAppStatus.AppID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current app status")
+	}
+
+	err = store.GetStore().SetAppStatus(newAppStatus.AppID, newAppStatus.ResourceStates, newAppStatus.UpdatedAt, newAppStatus.Sequence)
+	if err != nil {
+		return errors.Wrap(err, "failed to set app status")
+	}
+
+	newAppState := appstatetypes.GetState(newAppStatus.ResourceStates)
+	if currentAppStatus != nil && newAppState != currentAppStatus.State {
+		notifications.Notify(notificationtypes.Event{
+			Type:     notificationtypes.EventAppStateChanged,
+			AppID:    newAppStatus.AppID,
+			Sequence: &newAppStatus.Sequence,
+			Message:  fmt.Sprintf("App state changed from %s to %s.", currentAppStatus.State, newAppState),
+			Data: map[string]string{
+				"previousState": string(currentAppStatus.State),
+				"state":         string(newAppState),
+			},
+		})
+		go func() {
+			err := reporting.GetReporter().SubmitAppInfo(newAppStatus.AppID)
+			if err != nil {
+				logger.Debugf("failed to submit app info: %v", err)
+			}
+		}()
+	}
+
+	return nil
+}
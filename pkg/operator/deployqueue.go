@@ -0,0 +1,253 @@
+package operator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/logger"
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+	"github.com/replicatedhq/kots/pkg/tasks"
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	// ErrDeployNotFound is returned when cancelling a deploy that is not in the deploy queue
+	ErrDeployNotFound = errors.New("deploy not found")
+	// ErrDeployCancelled is returned when a deploy is cancelled while it is queued or in progress
+	ErrDeployCancelled = errors.New("deploy was cancelled")
+)
+
+// deployQueue serializes the deploys of an app. The deploy at the head of the queue is running, and the
+// others wait for the deploys ahead of them to finish. Deploys can be cancelled while queued or running.
+type deployQueue struct {
+	mtx     sync.Mutex
+	deploys []*queuedDeploy
+}
+
+type queuedDeploy struct {
+	operatortypes.QueuedDeploy
+	ctx    context.Context
+	cancel context.CancelFunc
+	// turn is closed when the deploy reaches the head of the queue
+	turn chan struct{}
+}
+
+// enqueue adds a deploy to the end of the queue. The deploy starts right away if the queue is empty.
+func (q *deployQueue) enqueue(appID string, sequence int64, reason operatortypes.DeployReason) *queuedDeploy {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d := &queuedDeploy{
+		QueuedDeploy: operatortypes.QueuedDeploy{
+			ID:       ksuid.New().String(),
+			AppID:    appID,
+			Sequence: sequence,
+			Reason:   reason,
+			State:    operatortypes.DeployStateQueued,
+			QueuedAt: time.Now(),
+		},
+		ctx:    ctx,
+		cancel: cancel,
+		turn:   make(chan struct{}),
+	}
+	q.deploys = append(q.deploys, d)
+
+	if len(q.deploys) == 1 {
+		q.startHead()
+	}
+
+	return d
+}
+
+// wait blocks until the deploy reaches the head of the queue. It returns ErrDeployCancelled if the deploy
+// was cancelled before it started.
+func (q *deployQueue) wait(d *queuedDeploy) error {
+	select {
+	case <-d.turn:
+	case <-d.ctx.Done():
+	}
+
+	if d.ctx.Err() != nil {
+		return ErrDeployCancelled
+	}
+	return nil
+}
+
+// done removes the deploy from the queue and starts the next one
+func (q *deployQueue) done(d *queuedDeploy) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	d.cancel()
+	q.remove(d.ID)
+
+	if len(q.deploys) > 0 && q.deploys[0].State == operatortypes.DeployStateQueued {
+		q.startHead()
+	}
+}
+
+// cancelDeploy cancels the deploy with the given id. A queued deploy is removed from the queue, and a running
+// deploy is marked as cancelling until it stops.
+func (q *deployQueue) cancelDeploy(id string) (*operatortypes.QueuedDeploy, error) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	for i, d := range q.deploys {
+		if d.ID != id {
+			continue
+		}
+
+		if i > 0 {
+			q.remove(id)
+		} else {
+			d.State = operatortypes.DeployStateCancelling
+		}
+		d.cancel()
+
+		cancelled := d.QueuedDeploy
+		return &cancelled, nil
+	}
+
+	return nil, ErrDeployNotFound
+}
+
+func (q *deployQueue) list() []operatortypes.QueuedDeploy {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	deploys := []operatortypes.QueuedDeploy{}
+	for _, d := range q.deploys {
+		deploys = append(deploys, d.QueuedDeploy)
+	}
+	return deploys
+}
+
+func (q *deployQueue) startHead() {
+	head := q.deploys[0]
+	startedAt := time.Now()
+	head.State = operatortypes.DeployStateRunning
+	head.StartedAt = &startedAt
+	close(head.turn)
+}
+
+func (q *deployQueue) remove(id string) {
+	for i, d := range q.deploys {
+		if d.ID == id {
+			q.deploys = append(q.deploys[:i], q.deploys[i+1:]...)
+			return
+		}
+	}
+}
+
+func (o *Operator) getDeployQueue(appID string) *deployQueue {
+	o.mtx.Lock()
+	defer o.mtx.Unlock()
+
+	if _, ok := o.deployQueues[appID]; !ok {
+		o.deployQueues[appID] = &deployQueue{}
+	}
+	return o.deployQueues[appID]
+}
+
+// ListDeploys returns the running and queued deploys of the app, in the order they run
+func (o *Operator) ListDeploys(appID string) []operatortypes.QueuedDeploy {
+	return o.getDeployQueue(appID).list()
+}
+
+// CancelDeploy cancels a deploy of the app. A queued deploy does not run, and a running deploy is stopped
+// before the next resource or helm chart is applied, interrupting helm if it is running.
+func (o *Operator) CancelDeploy(appID string, deployID string) (*operatortypes.QueuedDeploy, error) {
+	cancelled, err := o.getDeployQueue(appID).cancelDeploy(deployID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("cancelled %s of sequence %d of app %s", cancelled.Reason, cancelled.Sequence, appID)
+	o.setDeployTaskStatus(appID)
+
+	return cancelled, nil
+}
+
+// enqueueDeploy adds a deploy to the app's deploy queue. The deploy must be passed to finishDeploy once it
+// is done, including when it was cancelled while queued.
+func (o *Operator) enqueueDeploy(appID string, sequence int64, reason operatortypes.DeployReason) (*deployQueue, *queuedDeploy) {
+	q := o.getDeployQueue(appID)
+	d := q.enqueue(appID, sequence, reason)
+	o.setDeployTaskStatus(appID)
+	return q, d
+}
+
+// waitForDeploy waits for the deploy to reach the head of the queue, and keeps the deploy task status
+// fresh until the deploy is finished.
+func (o *Operator) waitForDeploy(q *deployQueue, d *queuedDeploy) error {
+	if err := q.wait(d); err != nil {
+		return err
+	}
+
+	o.setDeployTaskStatus(d.AppID)
+	if os.Getenv("KOTSADM_ENV") != "test" {
+		go tasks.StartTicker(GetDeployTaskID(d.AppID), d.ctx.Done())
+	}
+
+	return nil
+}
+
+func (o *Operator) finishDeploy(q *deployQueue, d *queuedDeploy) {
+	q.done(d)
+	o.setDeployTaskStatus(d.AppID)
+}
+
+// GetDeployTaskID returns the id of the task that reports the progress of the app's deploy queue
+func GetDeployTaskID(appID string) string {
+	return fmt.Sprintf("deploy.%s", appID)
+}
+
+func (o *Operator) setDeployTaskStatus(appID string) {
+	if os.Getenv("KOTSADM_ENV") == "test" {
+		return
+	}
+
+	taskID := GetDeployTaskID(appID)
+
+	message, running := getDeployTaskMessage(o.ListDeploys(appID))
+	if !running {
+		if err := tasks.ClearTaskStatus(taskID); err != nil {
+			logger.Error(errors.Wrapf(err, "failed to clear %s task status", taskID))
+		}
+		return
+	}
+
+	if err := tasks.SetTaskStatus(taskID, message, "running"); err != nil {
+		logger.Error(errors.Wrapf(err, "failed to set %s task status", taskID))
+	}
+}
+
+// getDeployTaskMessage describes the deploy queue. It returns false if the queue is empty.
+func getDeployTaskMessage(deploys []operatortypes.QueuedDeploy) (string, bool) {
+	if len(deploys) == 0 {
+		return "", false
+	}
+
+	head := deploys[0]
+
+	var message string
+	switch {
+	case head.State == operatortypes.DeployStateCancelling:
+		message = fmt.Sprintf("Cancelling %s of sequence %d", head.Reason, head.Sequence)
+	case head.Reason == operatortypes.DeployReasonUndeploy:
+		message = "Undeploying"
+	default:
+		message = fmt.Sprintf("Running %s of sequence %d", head.Reason, head.Sequence)
+	}
+
+	if queued := len(deploys) - 1; queued > 0 {
+		message = fmt.Sprintf("%s (%d queued)", message, queued)
+	}
+
+	return message, true
+}
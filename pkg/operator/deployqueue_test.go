@@ -0,0 +1,172 @@
+package operator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+	mock_store "github.com/replicatedhq/kots/pkg/store/mock"
+	storetypes "github.com/replicatedhq/kots/pkg/store/types"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_deployQueue(t *testing.T) {
+	q := &deployQueue{}
+
+	first := q.enqueue("app", 1, operatortypes.DeployReasonDeploy)
+	second := q.enqueue("app", 2, operatortypes.DeployReasonDeploy)
+	third := q.enqueue("app", 3, operatortypes.DeployReasonDeploy)
+
+	// the first deploy starts right away
+	require.NoError(t, q.wait(first))
+
+	deploys := q.list()
+	require.Len(t, deploys, 3)
+	require.Equal(t, operatortypes.DeployStateRunning, deploys[0].State)
+	require.NotNil(t, deploys[0].StartedAt)
+	require.Equal(t, operatortypes.DeployStateQueued, deploys[1].State)
+	require.Nil(t, deploys[1].StartedAt)
+
+	// a queued deploy is removed from the queue when cancelled
+	cancelled, err := q.cancelDeploy(second.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), cancelled.Sequence)
+	require.ErrorIs(t, q.wait(second), ErrDeployCancelled)
+	q.done(second)
+
+	_, err = q.cancelDeploy(second.ID)
+	require.ErrorIs(t, err, ErrDeployNotFound)
+
+	// the next deploy starts once the running one is done
+	waitErr := make(chan error, 1)
+	go func() {
+		waitErr <- q.wait(third)
+	}()
+
+	select {
+	case <-waitErr:
+		t.Fatal("deploy started before the running deploy was done")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	q.done(first)
+	require.NoError(t, <-waitErr)
+
+	// a running deploy is marked as cancelling and its context is cancelled
+	_, err = q.cancelDeploy(third.ID)
+	require.NoError(t, err)
+	require.Error(t, third.ctx.Err())
+
+	deploys = q.list()
+	require.Len(t, deploys, 1)
+	require.Equal(t, operatortypes.DeployStateCancelling, deploys[0].State)
+
+	q.done(third)
+	require.Empty(t, q.list())
+}
+
+func TestGoDeployApp_cancelledBeforeStart(t *testing.T) {
+	t.Setenv("KOTSADM_ENV", "test")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the strict mock fails the test if the cancelled version is marked as current or deploying
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().ListDownstreamsForApp("app").Return([]downstreamtypes.Downstream{{ClusterID: "this-cluster"}}, nil)
+
+	o := &Operator{
+		store:        mockStore,
+		clusterID:    "this-cluster",
+		deployQueues: map[string]*deployQueue{},
+	}
+
+	// a running deploy holds the head of the queue
+	q, running := o.enqueueDeploy("app", 1, operatortypes.DeployReasonDeploy)
+	require.NoError(t, q.wait(running))
+
+	require.NoError(t, o.GoDeployApp("app", 2))
+
+	deploys := o.ListDeploys("app")
+	require.Len(t, deploys, 2)
+	require.Equal(t, int64(2), deploys[1].Sequence)
+
+	_, err := o.CancelDeploy("app", deploys[1].ID)
+	require.NoError(t, err)
+
+	// give the queued deploy time to observe the cancellation
+	time.Sleep(50 * time.Millisecond)
+	o.finishDeploy(q, running)
+	require.Empty(t, o.ListDeploys("app"))
+}
+
+func TestGoDeployApp_marksCurrentWhenStarted(t *testing.T) {
+	t.Setenv("KOTSADM_ENV", "test")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().ListDownstreamsForApp("app").Return([]downstreamtypes.Downstream{{ClusterID: "remote-cluster"}}, nil)
+	mockStore.EXPECT().MarkAsCurrentDownstreamVersion("app", int64(2)).Return(nil)
+	mockStore.EXPECT().SetDownstreamVersionStatus("app", int64(2), storetypes.VersionDeploying, "").Return(nil)
+
+	o := &Operator{
+		store:        mockStore,
+		clusterID:    "this-cluster",
+		deployQueues: map[string]*deployQueue{},
+	}
+
+	// remote apps are deployed by the agent, so the version starts deploying right away
+	require.NoError(t, o.GoDeployApp("app", 2))
+	require.Empty(t, o.ListDeploys("app"))
+}
+
+func Test_getDeployTaskMessage(t *testing.T) {
+	tests := []struct {
+		name        string
+		deploys     []operatortypes.QueuedDeploy
+		wantMessage string
+		wantRunning bool
+	}{
+		{
+			name:    "empty queue",
+			deploys: []operatortypes.QueuedDeploy{},
+		},
+		{
+			name: "running deploy with queued deploys",
+			deploys: []operatortypes.QueuedDeploy{
+				{Sequence: 4, Reason: operatortypes.DeployReasonDeploy, State: operatortypes.DeployStateRunning},
+				{Sequence: 5, Reason: operatortypes.DeployReasonDeploy, State: operatortypes.DeployStateQueued},
+				{Sequence: -1, Reason: operatortypes.DeployReasonUndeploy, State: operatortypes.DeployStateQueued},
+			},
+			wantMessage: "Running deploy of sequence 4 (2 queued)",
+			wantRunning: true,
+		},
+		{
+			name: "cancelling",
+			deploys: []operatortypes.QueuedDeploy{
+				{Sequence: 3, Reason: operatortypes.DeployReasonRollback, State: operatortypes.DeployStateCancelling},
+			},
+			wantMessage: "Cancelling rollback of sequence 3",
+			wantRunning: true,
+		},
+		{
+			name: "undeploy",
+			deploys: []operatortypes.QueuedDeploy{
+				{Sequence: -1, Reason: operatortypes.DeployReasonUndeploy, State: operatortypes.DeployStateRunning},
+			},
+			wantMessage: "Undeploying",
+			wantRunning: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message, running := getDeployTaskMessage(tt.deploys)
+			require.Equal(t, tt.wantMessage, message)
+			require.Equal(t, tt.wantRunning, running)
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package operator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/app"
+	apptypes "github.com/replicatedhq/kots/pkg/app/types"
+	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/notifications"
+	notificationtypes "github.com/replicatedhq/kots/pkg/notifications/types"
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+	storetypes "github.com/replicatedhq/kots/pkg/store/types"
+	"github.com/replicatedhq/kots/pkg/util"
+)
+
+// driftLoopInterval is how often apps are checked for being due a drift check, in seconds
+const driftLoopInterval = 60
+
+func (o *Operator) driftLoop() {
+	apps, err := o.store.ListAppsForDownstream(o.clusterID)
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to list installed apps for downstream"))
+		return
+	}
+
+	for _, a := range apps {
+		if err := o.checkDriftForApp(a, time.Now()); err != nil {
+			logger.Error(errors.Wrapf(err, "failed to check drift for app %s", a.ID))
+		}
+	}
+}
+
+// checkDriftForApp compares the live resources of the app against the manifests of the deployed version
+// if the last check is older than the drift detection interval. The result is recorded in the app status,
+// and the deployed version is re-applied if the app has auto-heal enabled.
+func (o *Operator) checkDriftForApp(a *apptypes.App, now time.Time) error {
+	interval, err := app.ParseDriftDetection(a.DriftDetection)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse drift detection")
+	}
+	if interval == 0 || a.RestoreInProgressName != "" {
+		return nil
+	}
+	if len(o.ListDeploys(a.ID)) > 0 {
+		// the live resources are changing while a deploy is running or queued
+		return nil
+	}
+
+	deployedVersion, err := o.store.GetCurrentDownstreamVersion(a.ID, o.clusterID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current downstream version")
+	}
+	if deployedVersion == nil || deployedVersion.Status != storetypes.VersionDeployed {
+		// nothing is deployed, or a deploy is in progress
+		return nil
+	}
+	sequence := deployedVersion.ParentSequence
+
+	if util.IsV3EmbeddedClusterInitialInstall(sequence) {
+		return nil
+	}
+
+	appStatus, err := o.store.GetAppStatus(a.ID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get app status")
+	}
+	previous := appStatus.Drift
+	if previous != nil && previous.Sequence == sequence && now.Sub(previous.CheckedAt) < interval {
+		return nil
+	}
+
+	drift := &appstatetypes.DriftStatus{
+		Sequence:  sequence,
+		CheckedAt: now,
+	}
+
+	plan, err := o.planDeployedVersion(a, sequence)
+	if err != nil {
+		// the failure is recorded so that the check is not retried until the next interval
+		drift.Error = err.Error()
+		if err := o.store.SetAppDriftStatus(a.ID, drift); err != nil {
+			logger.Error(errors.Wrap(err, "failed to set app drift status"))
+		}
+		return err
+	}
+	drift.Resources = getDriftedResources(plan)
+
+	currentSequence, err := o.store.GetCurrentDownstreamSequence(a.ID, o.clusterID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current downstream sequence")
+	}
+	if currentSequence != sequence {
+		// another sequence was deployed while checking, so the result is stale
+		return nil
+	}
+
+	if len(drift.Resources) > 0 {
+		if !hasDrift(previous, sequence) {
+			notifications.Notify(notificationtypes.Event{
+				Type:     notificationtypes.EventDriftDetected,
+				AppID:    a.ID,
+				AppSlug:  a.Slug,
+				Sequence: &sequence,
+				Message:  fmt.Sprintf("%d resources have drifted from sequence %d.", len(drift.Resources), sequence),
+			})
+		}
+
+		if a.DriftDetection != nil && a.DriftDetection.AutoHeal {
+			healed, err := o.healDrift(a.ID, sequence)
+			if err != nil {
+				logger.Error(errors.Wrapf(err, "failed to heal drift of app %s", a.ID))
+			} else if healed {
+				healedAt := time.Now()
+				drift.HealedAt = &healedAt
+			}
+		}
+	}
+
+	if err := o.store.SetAppDriftStatus(a.ID, drift); err != nil {
+		return errors.Wrap(err, "failed to set app drift status")
+	}
+
+	return nil
+}
+
+func (o *Operator) planDeployedVersion(a *apptypes.App, sequence int64) (*operatortypes.DeployPlan, error) {
+	rendered, err := o.renderPlannedAppVersion(a, o.clusterID, sequence)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to render app version")
+	}
+
+	plan, err := o.client.PlanApp(rendered.deployArgs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to plan app")
+	}
+
+	return plan, nil
+}
+
+// healDrift re-applies the deployed sequence. It returns false if another sequence was deployed in the meantime.
+func (o *Operator) healDrift(appID string, sequence int64) (bool, error) {
+	q, d := o.enqueueDeploy(appID, sequence, operatortypes.DeployReasonHeal)
+	defer o.finishDeploy(q, d)
+
+	if err := o.waitForDeploy(q, d); err != nil {
+		return false, err
+	}
+
+	currentSequence, err := o.store.GetCurrentDownstreamSequence(appID, o.clusterID)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get current downstream sequence")
+	}
+	if currentSequence != sequence {
+		return false, nil
+	}
+
+	logger.Infof("re-applying sequence %d of app %s to correct drift", sequence, appID)
+
+	if err := o.store.SetDownstreamVersionStatus(appID, sequence, storetypes.VersionDeploying, ""); err != nil {
+		return false, errors.Wrap(err, "failed to update downstream status")
+	}
+
+	deployed, err := o.deployApp(d.ctx, appID, sequence, false)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to deploy sequence %d", sequence)
+	}
+
+	return deployed, nil
+}
+
+// getDriftedResources returns the resources that a deploy of the already deployed version would change.
+// Resources that the deploy would delete are left over from previous versions and are not considered drift.
+func getDriftedResources(plan *operatortypes.DeployPlan) []appstatetypes.DriftedResource {
+	drifted := []appstatetypes.DriftedResource{}
+	for _, r := range plan.Resources {
+		if r.Error != "" {
+			logger.Debugf("skipping drift check of %s %s: %s", r.Kind, r.Name, r.Error)
+			continue
+		}
+
+		resource := appstatetypes.DriftedResource{
+			APIVersion:  r.APIVersion,
+			Kind:        r.Kind,
+			Name:        r.Name,
+			Namespace:   r.Namespace,
+			HelmRelease: r.HelmRelease,
+		}
+
+		switch r.Action {
+		case operatortypes.PlanActionCreate:
+			resource.Missing = true
+		case operatortypes.PlanActionUpdate:
+			for _, d := range r.Diffs {
+				field := appstatetypes.DriftedField{
+					Path:     d.Path,
+					Expected: d.New,
+					Actual:   d.Old,
+				}
+				// the drift status is stored and shown to anyone that can read the app status
+				if operatortypes.IsSecretField(r.APIVersion, r.Kind, d.Path) {
+					field.Expected = operatortypes.RedactFieldValue(field.Expected)
+					field.Actual = operatortypes.RedactFieldValue(field.Actual)
+				}
+				resource.Fields = append(resource.Fields, field)
+			}
+		default:
+			continue
+		}
+
+		drifted = append(drifted, resource)
+	}
+	return drifted
+}
+
+// hasDrift returns true if the drift status already reported drift of the given sequence
+func hasDrift(drift *appstatetypes.DriftStatus, sequence int64) bool {
+	return drift != nil && drift.Sequence == sequence && len(drift.Resources) > 0
+}
@@ -0,0 +1,138 @@
+package operator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	apptypes "github.com/replicatedhq/kots/pkg/app/types"
+	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+	mock_store "github.com/replicatedhq/kots/pkg/store/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getDriftedResources(t *testing.T) {
+	plan := &operatortypes.DeployPlan{
+		Resources: []operatortypes.ResourcePlan{
+			{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "web",
+				Namespace:  "app",
+				Action:     operatortypes.PlanActionUpdate,
+				Diffs: []operatortypes.FieldDiff{
+					{Path: "spec.replicas", Old: "5", New: "1"},
+				},
+			},
+			{
+				APIVersion:  "v1",
+				Kind:        "Service",
+				Name:        "web",
+				Namespace:   "app",
+				HelmRelease: "web",
+				Action:      operatortypes.PlanActionCreate,
+			},
+			{
+				APIVersion: "v1",
+				Kind:       "Secret",
+				Name:       "credentials",
+				Namespace:  "app",
+				Action:     operatortypes.PlanActionUpdate,
+				Diffs: []operatortypes.FieldDiff{
+					{Path: "data.password", Old: `"bGl2ZQ=="`, New: `"ZGVwbG95ZWQ="`},
+					{Path: "metadata.labels.app", Old: `"other"`, New: `"web"`},
+				},
+			},
+			{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Name:       "config",
+				Namespace:  "app",
+				Action:     operatortypes.PlanActionUnchanged,
+			},
+			{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Name:       "removed",
+				Namespace:  "app",
+				Action:     operatortypes.PlanActionDelete,
+			},
+			{
+				APIVersion: "example.com/v1",
+				Kind:       "Widget",
+				Name:       "widget",
+				Action:     operatortypes.PlanActionCreate,
+				Error:      "no matches for kind",
+			},
+		},
+	}
+
+	want := []appstatetypes.DriftedResource{
+		{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       "web",
+			Namespace:  "app",
+			Fields: []appstatetypes.DriftedField{
+				{Path: "spec.replicas", Expected: "1", Actual: "5"},
+			},
+		},
+		{
+			APIVersion:  "v1",
+			Kind:        "Service",
+			Name:        "web",
+			Namespace:   "app",
+			HelmRelease: "web",
+			Missing:     true,
+		},
+		{
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Name:       "credentials",
+			Namespace:  "app",
+			Fields: []appstatetypes.DriftedField{
+				{Path: "data.password", Expected: operatortypes.RedactedFieldValue, Actual: operatortypes.RedactedFieldValue},
+				{Path: "metadata.labels.app", Expected: `"web"`, Actual: `"other"`},
+			},
+		},
+	}
+
+	assert.Equal(t, want, getDriftedResources(plan))
+	assert.Empty(t, getDriftedResources(&operatortypes.DeployPlan{}))
+}
+
+func Test_hasDrift(t *testing.T) {
+	drifted := &appstatetypes.DriftStatus{
+		Sequence:  2,
+		Resources: []appstatetypes.DriftedResource{{Kind: "Deployment", Name: "web"}},
+	}
+
+	assert.False(t, hasDrift(nil, 2))
+	assert.False(t, hasDrift(&appstatetypes.DriftStatus{Sequence: 2}, 2))
+	assert.False(t, hasDrift(drifted, 3))
+	assert.True(t, hasDrift(drifted, 2))
+}
+
+func Test_checkDriftForApp_skipsWhileDeploying(t *testing.T) {
+	t.Setenv("KOTSADM_ENV", "test")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the strict mock fails the test if drift is checked
+	o := &Operator{
+		store:        mock_store.NewMockStore(ctrl),
+		deployQueues: map[string]*deployQueue{},
+	}
+
+	a := &apptypes.App{
+		ID:             "app",
+		DriftDetection: &apptypes.DriftDetection{Interval: "1h"},
+	}
+
+	q, d := o.enqueueDeploy(a.ID, 1, operatortypes.DeployReasonDeploy)
+	require.NoError(t, o.checkDriftForApp(a, time.Now()))
+	o.finishDeploy(q, d)
+}
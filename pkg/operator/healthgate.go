@@ -0,0 +1,148 @@
+package operator
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/app"
+	apptypes "github.com/replicatedhq/kots/pkg/app/types"
+	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+	storetypes "github.com/replicatedhq/kots/pkg/store/types"
+)
+
+const healthGatePollInterval = 10 * time.Second
+
+// healthTracker tracks how long the app has been continuously degraded or unavailable
+type healthTracker struct {
+	threshold      time.Duration
+	unhealthySince *time.Time
+}
+
+// observe records the app state at the given time and returns how long the app has been unhealthy,
+// and whether that is longer than the threshold
+func (t *healthTracker) observe(state appstatetypes.State, now time.Time) (time.Duration, bool) {
+	if state != appstatetypes.StateDegraded && state != appstatetypes.StateUnavailable {
+		t.unhealthySince = nil
+		return 0, false
+	}
+
+	if t.unhealthySince == nil {
+		t.unhealthySince = &now
+	}
+
+	unhealthyFor := now.Sub(*t.unhealthySince)
+	return unhealthyFor, unhealthyFor >= t.threshold
+}
+
+// watchDeployHealth watches the app status after a deploy for the health gate period, and rolls back
+// to the previously deployed sequence if the app stays degraded or unavailable past the threshold.
+// Watching stops early if another sequence is deployed in the meantime.
+func (o *Operator) watchDeployHealth(appID string, sequence int64, healthGate *apptypes.HealthGate) {
+	period, threshold, err := app.ParseHealthGate(healthGate)
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "failed to parse health gate for app %s", appID))
+		return
+	}
+
+	tracker := &healthTracker{threshold: threshold}
+	deadline := time.Now().Add(period)
+
+	for time.Now().Before(deadline) {
+		time.Sleep(healthGatePollInterval)
+
+		currentSequence, err := o.store.GetCurrentDownstreamSequence(appID, o.clusterID)
+		if err != nil {
+			logger.Error(errors.Wrap(err, "failed to get current downstream sequence"))
+			continue
+		}
+		if currentSequence != sequence {
+			return
+		}
+
+		appStatus, err := o.store.GetAppStatus(appID)
+		if err != nil {
+			logger.Error(errors.Wrap(err, "failed to get app status"))
+			continue
+		}
+		if appStatus == nil || appStatus.Sequence != sequence {
+			// the informers have not reported on this sequence yet
+			continue
+		}
+
+		unhealthyFor, exceeded := tracker.observe(appStatus.State, time.Now())
+		if !exceeded {
+			continue
+		}
+
+		reason := fmt.Sprintf("the app was %s for %s after the deploy", appStatus.State, unhealthyFor.Round(time.Second))
+		if err := o.rollbackUnhealthyDeploy(appID, sequence, reason); err != nil {
+			logger.Error(errors.Wrapf(err, "failed to roll back sequence %d of app %s", sequence, appID))
+		}
+		return
+	}
+
+	logger.Infof("sequence %d of app %s passed the post-deploy health gate", sequence, appID)
+}
+
+// rollbackUnhealthyDeploy redeploys the previously deployed sequence if the app allows rollbacks.
+// The decision and its reason are recorded in the status of the unhealthy sequence.
+func (o *Operator) rollbackUnhealthyDeploy(appID string, sequence int64, reason string) error {
+	allowRollback, err := o.store.IsRollbackSupportedForVersion(appID, sequence)
+	if err != nil {
+		return errors.Wrap(err, "failed to check if rollback is supported")
+	}
+	if !allowRollback {
+		statusInfo := fmt.Sprintf("Automatic rollback skipped because rollback is not enabled for this version: %s.", reason)
+		return o.store.SetDownstreamVersionStatus(appID, sequence, storetypes.VersionDeployed, statusInfo)
+	}
+
+	previousSequence, err := o.store.GetPreviouslyDeployedSequence(appID, o.clusterID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get previously deployed sequence")
+	}
+	if previousSequence == -1 {
+		statusInfo := fmt.Sprintf("Automatic rollback skipped because there is no previously deployed version: %s.", reason)
+		return o.store.SetDownstreamVersionStatus(appID, sequence, storetypes.VersionDeployed, statusInfo)
+	}
+
+	logger.Infof("rolling back app %s from sequence %d to sequence %d because %s", appID, sequence, previousSequence, reason)
+
+	q, d := o.enqueueDeploy(appID, previousSequence, operatortypes.DeployReasonRollback)
+	defer o.finishDeploy(q, d)
+
+	if err := o.waitForDeploy(q, d); err != nil {
+		return err
+	}
+
+	currentSequence, err := o.store.GetCurrentDownstreamSequence(appID, o.clusterID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current downstream sequence")
+	}
+	if currentSequence != sequence {
+		// another sequence was deployed while waiting in the deploy queue
+		return nil
+	}
+
+	statusInfo := fmt.Sprintf("Automatically rolled back to sequence %d: %s.", previousSequence, reason)
+	if err := o.store.SetDownstreamVersionStatus(appID, sequence, storetypes.VersionRolledBack, statusInfo); err != nil {
+		return errors.Wrap(err, "failed to update downstream status")
+	}
+
+	if err := o.store.MarkAsCurrentDownstreamVersion(appID, previousSequence); err != nil {
+		return errors.Wrap(err, "failed to mark as current downstream version")
+	}
+
+	if err := o.store.SetDownstreamVersionStatus(appID, previousSequence, storetypes.VersionDeploying, ""); err != nil {
+		return errors.Wrap(err, "failed to update downstream status")
+	}
+
+	// the version being rolled back to is not gated again, otherwise an unhealthy app could keep rolling back through its history
+	if _, err := o.deployApp(d.ctx, appID, previousSequence, false); err != nil {
+		return errors.Wrapf(err, "failed to deploy sequence %d", previousSequence)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package operator
+
+import (
+	"testing"
+	"time"
+
+	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_healthTracker_observe(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type observation struct {
+		state         appstatetypes.State
+		after         time.Duration
+		wantUnhealthy time.Duration
+		wantExceeded  bool
+	}
+	tests := []struct {
+		name         string
+		observations []observation
+	}{
+		{
+			name: "healthy app is never rolled back",
+			observations: []observation{
+				{state: appstatetypes.StateUpdating, after: 0},
+				{state: appstatetypes.StateReady, after: 10 * time.Minute},
+			},
+		},
+		{
+			name: "degraded past the threshold",
+			observations: []observation{
+				{state: appstatetypes.StateDegraded, after: 0},
+				{state: appstatetypes.StateUnavailable, after: 3 * time.Minute, wantUnhealthy: 3 * time.Minute},
+				{state: appstatetypes.StateDegraded, after: 5 * time.Minute, wantUnhealthy: 5 * time.Minute, wantExceeded: true},
+			},
+		},
+		{
+			name: "recovering resets the timer",
+			observations: []observation{
+				{state: appstatetypes.StateDegraded, after: 0},
+				{state: appstatetypes.StateReady, after: 4 * time.Minute},
+				{state: appstatetypes.StateDegraded, after: 5 * time.Minute},
+				{state: appstatetypes.StateDegraded, after: 9 * time.Minute, wantUnhealthy: 4 * time.Minute},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := &healthTracker{threshold: 5 * time.Minute}
+			for _, o := range tt.observations {
+				unhealthyFor, exceeded := tracker.observe(o.state, start.Add(o.after))
+				assert.Equal(t, o.wantUnhealthy, unhealthyFor)
+				assert.Equal(t, o.wantExceeded, exceeded)
+			}
+		})
+	}
+}
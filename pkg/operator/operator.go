@@ -17,6 +17,7 @@ import (
 	"github.com/replicatedhq/kots/pkg/app"
 	apptypes "github.com/replicatedhq/kots/pkg/app/types"
 	"github.com/replicatedhq/kots/pkg/apparchive"
+	"github.com/replicatedhq/kots/pkg/appstate"
 	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
 	"github.com/replicatedhq/kots/pkg/binaries"
 	"github.com/replicatedhq/kots/pkg/embeddedcluster"
@@ -25,11 +26,14 @@ import (
 	identitytypes "github.com/replicatedhq/kots/pkg/identity/types"
 	"github.com/replicatedhq/kots/pkg/k8sutil"
 	kotsadmobjects "github.com/replicatedhq/kots/pkg/kotsadm/objects"
+	"github.com/replicatedhq/kots/pkg/kotsadmmetrics"
 	snapshot "github.com/replicatedhq/kots/pkg/kotsadmsnapshot"
 	snapshottypes "github.com/replicatedhq/kots/pkg/kotsadmsnapshot/types"
 	"github.com/replicatedhq/kots/pkg/kotsutil"
 	"github.com/replicatedhq/kots/pkg/logger"
 	"github.com/replicatedhq/kots/pkg/midstream"
+	"github.com/replicatedhq/kots/pkg/notifications"
+	notificationtypes "github.com/replicatedhq/kots/pkg/notifications/types"
 	"github.com/replicatedhq/kots/pkg/operator/client"
 	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
 	registrytypes "github.com/replicatedhq/kots/pkg/registry/types"
@@ -68,7 +72,7 @@ type Operator struct {
 	clusterToken string
 	clusterID    string
 	mtx          sync.Mutex
-	deployMtxs   map[string]*sync.Mutex // key is app id
+	deployQueues map[string]*deployQueue // key is app id
 	k8sClientset kubernetes.Interface
 }
 
@@ -77,7 +81,7 @@ func Init(client client.ClientInterface, store store.Store, clusterToken string,
 		client:       client,
 		store:        store,
 		clusterToken: clusterToken,
-		deployMtxs:   map[string]*sync.Mutex{},
+		deployQueues: map[string]*deployQueue{},
 		k8sClientset: k8sClientset,
 	}
 	return operator
@@ -104,7 +108,11 @@ func (o *Operator) Start() error {
 	o.clusterID = id
 
 	go o.resumeInformers()
-	go o.resumeDeployments()
+	go func() {
+		// resumed deploys are done when resumeDeployments returns, so drift is only checked against what they applied
+		o.resumeDeployments()
+		startLoop(o.driftLoop, driftLoopInterval)
+	}()
 	o.watchDeployments()
 	startLoop(o.restoreLoop, 2)
 
@@ -170,57 +178,111 @@ func (o *Operator) resumeDeployment(a *apptypes.App) (bool, error) {
 	return true, nil
 }
 
-// DeployApp deploys the given app and sequence. It returns an error if the deployment fails.
+// DeployApp deploys the given app and sequence once the deploys queued ahead of it are done.
+// It returns an error if the deployment fails or is cancelled. Apps assigned to a remote cluster
+// are only marked as deploying, and are deployed by the agent running in that cluster.
 func (o *Operator) DeployApp(appID string, sequence int64) (deployed bool, deployError error) {
-	deployMtx := o.getDeployMtx(appID)
+	if isRemote, err := o.isRemoteApp(appID); err != nil {
+		return false, errors.Wrap(err, "failed to check if app is remote")
+	} else if isRemote {
+		if err := o.store.SetDownstreamVersionStatus(appID, sequence, storetypes.VersionDeploying, ""); err != nil {
+			return false, errors.Wrap(err, "failed to update downstream status")
+		}
+		return false, nil
+	}
 
-	deployMtx.Lock()
-	defer deployMtx.Unlock()
+	q, d := o.enqueueDeploy(appID, sequence, operatortypes.DeployReasonDeploy)
+	defer o.finishDeploy(q, d)
+
+	if err := o.waitForDeploy(q, d); err != nil {
+		return false, err
+	}
 
 	if err := o.store.SetDownstreamVersionStatus(appID, sequence, storetypes.VersionDeploying, ""); err != nil {
 		return false, errors.Wrap(err, "failed to update downstream status")
 	}
 
-	return o.deployApp(appID, sequence)
+	return o.deployApp(d.ctx, appID, sequence, true)
 }
 
-// GoDeployApp starts a deployment for the given app and sequence. It returns an error if the
-// deployment fails to start. It does not wait for the deployment to complete.
+// GoDeployApp queues a deployment for the given app and sequence. It returns an error if the
+// deployment fails to be queued. It does not wait for the deployment to start or complete.
+// The version becomes the current version when the deployment starts, so a deployment that is
+// cancelled while queued leaves the current version as it was.
+// Apps assigned to a remote cluster are deployed by the agent running in that cluster.
 func (o *Operator) GoDeployApp(appID string, sequence int64) error {
-	startCh := make(chan error, 1)
+	if isRemote, err := o.isRemoteApp(appID); err != nil {
+		return errors.Wrap(err, "failed to check if app is remote")
+	} else if isRemote {
+		return o.startDeploy(appID, sequence)
+	}
+
+	q, d := o.enqueueDeploy(appID, sequence, operatortypes.DeployReasonDeploy)
 
 	go func() {
-		deployMtx := o.getDeployMtx(appID)
+		defer o.finishDeploy(q, d)
 
-		deployMtx.Lock()
-		defer deployMtx.Unlock()
+		if err := o.waitForDeploy(q, d); err != nil {
+			logger.Infof("deploy of app sequence %d was cancelled before it started", sequence)
+			return
+		}
 
-		if err := o.store.SetDownstreamVersionStatus(appID, sequence, storetypes.VersionDeploying, ""); err != nil {
-			startCh <- errors.Wrap(err, "failed to update downstream status to deploying")
+		if err := o.startDeploy(appID, sequence); err != nil {
+			logger.Error(errors.Wrapf(err, "failed to start deploy of app sequence %d", sequence))
 			return
 		}
-		startCh <- nil
 
-		_, err := o.deployApp(appID, sequence)
+		_, err := o.deployApp(d.ctx, appID, sequence, true)
 		if err != nil {
 			logger.Errorf("Failed to deploy app sequence %d: %v", sequence, err)
 		}
 	}()
 
-	return <-startCh
+	return nil
+}
+
+// startDeploy marks the version as the current version of the app and as deploying
+func (o *Operator) startDeploy(appID string, sequence int64) error {
+	if err := o.store.MarkAsCurrentDownstreamVersion(appID, sequence); err != nil {
+		return errors.Wrap(err, "failed to mark as current downstream version")
+	}
+	if err := o.store.SetDownstreamVersionStatus(appID, sequence, storetypes.VersionDeploying, ""); err != nil {
+		return errors.Wrap(err, "failed to update downstream status to deploying")
+	}
+	return nil
 }
 
-func (o *Operator) getDeployMtx(appID string) *sync.Mutex {
-	o.mtx.Lock()
-	defer o.mtx.Unlock()
+// PlanApp returns the changes that deploying the given app and sequence would make to the cluster.
+// Nothing is deployed, and the live objects are compared with the result of a server-side dry run of the configured applier.
+func (o *Operator) PlanApp(appID string, sequence int64) (*operatortypes.DeployPlan, error) {
+	if isRemote, err := o.isRemoteApp(appID); err != nil {
+		return nil, errors.Wrap(err, "failed to check if app is remote")
+	} else if isRemote {
+		return nil, errors.New("planning deploys is not supported for apps in remote clusters")
+	}
+
+	app, err := o.store.GetApp(appID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get app")
+	}
+
+	rendered, err := o.renderPlannedAppVersion(app, o.clusterID, sequence)
+	if err != nil {
+		return nil, err
+	}
 
-	if _, ok := o.deployMtxs[appID]; !ok {
-		o.deployMtxs[appID] = &sync.Mutex{}
+	plan, err := o.client.PlanApp(rendered.deployArgs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to plan app")
 	}
-	return o.deployMtxs[appID]
+
+	return plan, nil
 }
 
-func (o *Operator) deployApp(appID string, sequence int64) (deployed bool, deployError error) {
+// deployApp deploys the given app and sequence. If watchHealth is true and the app has a health gate,
+// the app is watched after the deploy and rolled back if it does not become healthy. The deploy stops
+// early if ctx is cancelled.
+func (o *Operator) deployApp(ctx context.Context, appID string, sequence int64, watchHealth bool) (deployed bool, deployError error) {
 	if os.Getenv("KOTSADM_ENV") != "test" {
 		go func() {
 			err := reporting.GetReporter().SubmitAppInfo(appID)
@@ -237,24 +299,21 @@ func (o *Operator) deployApp(appID string, sequence int64) (deployed bool, deplo
 		}()
 	}
 
+	var healthGate *apptypes.HealthGate
+
+	notifications.Notify(notificationtypes.Event{
+		Type:     notificationtypes.EventDeployStarted,
+		AppID:    appID,
+		Sequence: &sequence,
+		Message:  fmt.Sprintf("Deploying sequence %d.", sequence),
+	})
+
+	startTime := time.Now()
 	defer func() {
-		if deployError != nil {
-			err := o.store.SetDownstreamVersionStatus(appID, sequence, storetypes.VersionFailed, deployError.Error())
-			if err != nil {
-				logger.Error(errors.Wrap(err, "failed to update downstream status"))
-			}
-			return
-		}
-		if !deployed {
-			err := o.store.SetDownstreamVersionStatus(appID, sequence, storetypes.VersionFailed, "")
-			if err != nil {
-				logger.Error(errors.Wrap(err, "failed to update downstream status"))
-			}
-			return
-		}
-		err := o.store.SetDownstreamVersionStatus(appID, sequence, storetypes.VersionDeployed, "")
-		if err != nil {
-			logger.Error(errors.Wrap(err, "failed to update downstream status"))
+		kotsadmmetrics.ObserveDeployDuration(appID, deployed && deployError == nil, time.Since(startTime))
+		o.recordDeployResult(appID, sequence, deployed, deployError)
+		if deployError == nil && deployed && watchHealth && healthGate != nil {
+			go o.watchDeployHealth(appID, sequence, healthGate)
 		}
 	}()
 
@@ -262,25 +321,152 @@ func (o *Operator) deployApp(appID string, sequence int64) (deployed bool, deplo
 	if err != nil {
 		return false, errors.Wrap(err, "failed to get app")
 	}
+	healthGate = app.HealthGate
 
 	if app.RestoreInProgressName != "" {
 		return false, errors.Errorf("failed to deploy version %d because app restore is already in progress", sequence)
 	}
 
-	downstreams, err := o.store.GetDownstream(o.clusterID)
+	rendered, err := o.renderAppVersion(app, o.clusterID, sequence)
+	if err != nil {
+		return false, err
+	}
+	kotsKinds := rendered.deployArgs.KotsKinds
+
+	if err := o.ensureKotsadmApplicationMetadataConfigMap(app, sequence, util.PodNamespace, kotsKinds, rendered.registrySettings); err != nil {
+		return false, errors.Wrap(err, "failed to ensure kotsadm application metadata configmap")
+	}
+
+	if err := o.applyStatusInformers(app, sequence, kotsKinds, rendered.builder); err != nil {
+		return false, errors.Wrap(err, "failed to apply status informers")
+	}
+
+	o.client.ApplyNamespacesInformer(kotsKinds.KotsApplication.Spec.AdditionalNamespaces, rendered.deployArgs.ImagePullSecrets)
+	o.client.ApplyHooksInformer(kotsKinds.KotsApplication.Spec.AdditionalNamespaces)
+
+	deployArgs := rendered.deployArgs
+
+	// Check if this is a V3 EC initial install that should skip deployment
+	if util.IsV3EmbeddedClusterInitialInstall(sequence) {
+		logger.Infof("Skipping deployment for V3 Embedded Cluster initial install (sequence %d)", sequence)
+
+		// Create successful deployment record for admin console
+		emptyOutput := downstreamtypes.DownstreamOutput{
+			DryrunStdout: base64.StdEncoding.EncodeToString([]byte("Skipped - deployed by V3 installer")),
+			ApplyStdout:  base64.StdEncoding.EncodeToString([]byte("Skipped - deployed by V3 installer")),
+		}
+		err := o.store.UpdateDownstreamDeployStatus(app.ID, o.clusterID, sequence, false, emptyOutput)
+		if err != nil {
+			return false, errors.Wrap(err, "failed to update downstream deploy status")
+		}
+
+		return true, nil
+	}
+
+	if ctx.Err() != nil {
+		return false, ErrDeployCancelled
+	}
+
+	deployed, err = o.client.DeployApp(ctx, deployArgs)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to get downstream")
+		return false, errors.Wrap(err, "failed to deploy app")
+	}
+	if ctx.Err() != nil {
+		return false, ErrDeployCancelled
+	}
+
+	return deployed, nil
+}
+
+// recordDeployResult sets the status of the deployed version and notifies about the result of the deploy
+func (o *Operator) recordDeployResult(appID string, sequence int64, deployed bool, deployError error) {
+	kotsadmmetrics.RecordDeployResult(appID, deployed && deployError == nil)
+
+	if deployError != nil {
+		err := o.store.SetDownstreamVersionStatus(appID, sequence, storetypes.VersionFailed, deployError.Error())
+		if err != nil {
+			logger.Error(errors.Wrap(err, "failed to update downstream status"))
+		}
+		notifications.Notify(notificationtypes.Event{
+			Type:     notificationtypes.EventDeployFailed,
+			AppID:    appID,
+			Sequence: &sequence,
+			Message:  fmt.Sprintf("Failed to deploy sequence %d: %s", sequence, deployError.Error()),
+		})
+		return
+	}
+	if !deployed {
+		err := o.store.SetDownstreamVersionStatus(appID, sequence, storetypes.VersionFailed, "")
+		if err != nil {
+			logger.Error(errors.Wrap(err, "failed to update downstream status"))
+		}
+		notifications.Notify(notificationtypes.Event{
+			Type:     notificationtypes.EventDeployFailed,
+			AppID:    appID,
+			Sequence: &sequence,
+			Message:  fmt.Sprintf("Failed to deploy sequence %d.", sequence),
+		})
+		return
+	}
+	err := o.store.SetDownstreamVersionStatus(appID, sequence, storetypes.VersionDeployed, "")
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to update downstream status"))
+	}
+	notifications.Notify(notificationtypes.Event{
+		Type:     notificationtypes.EventDeploySucceeded,
+		AppID:    appID,
+		Sequence: &sequence,
+		Message:  fmt.Sprintf("Deployed sequence %d.", sequence),
+	})
+}
+
+// renderedAppVersion is an app version rendered for deployment
+type renderedAppVersion struct {
+	deployArgs       operatortypes.DeployAppArgs
+	builder          *template.Builder
+	registrySettings registrytypes.RegistrySettings
+}
+
+// renderAppVersion renders the given sequence, along with the sequence previously deployed to the cluster, into
+// the args used to deploy it. The sequence being deployed is already marked as current, so the previous manifests
+// are those of the sequence deployed before it. It does not make any changes to the cluster.
+func (o *Operator) renderAppVersion(app *apptypes.App, clusterID string, sequence int64) (*renderedAppVersion, error) {
+	previouslyDeployedSequence, err := o.store.GetPreviouslyDeployedSequence(app.ID, clusterID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get previously deployed sequence")
+	}
+
+	return o.renderAppVersionWithBaseline(app, clusterID, sequence, previouslyDeployedSequence)
+}
+
+// renderPlannedAppVersion is the same as renderAppVersion, but the previous manifests are those of the sequence
+// currently deployed to the cluster, since nothing is marked as current when planning a deploy.
+func (o *Operator) renderPlannedAppVersion(app *apptypes.App, clusterID string, sequence int64) (*renderedAppVersion, error) {
+	currentSequence, err := o.store.GetCurrentDownstreamSequence(app.ID, clusterID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get current downstream sequence")
+	}
+
+	return o.renderAppVersionWithBaseline(app, clusterID, sequence, currentSequence)
+}
+
+// renderAppVersionWithBaseline renders the given sequence into the args used to deploy it. The previous manifests,
+// used to find the resources to delete, are those of the given downstream sequence, or none if it is -1.
+func (o *Operator) renderAppVersionWithBaseline(app *apptypes.App, clusterID string, sequence int64, previouslyDeployedSequence int64) (*renderedAppVersion, error) {
+	downstreams, err := o.store.GetDownstream(clusterID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get downstream")
 	}
 
 	deployedVersionArchive, err := os.MkdirTemp("", "kotsadm")
 	if err != nil {
-		return false, errors.Wrap(err, "failed to create temp dir")
+		return nil, errors.Wrap(err, "failed to create temp dir")
 	}
 	defer os.RemoveAll(deployedVersionArchive)
 
 	err = o.store.GetAppVersionArchive(app.ID, sequence, deployedVersionArchive)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to get app version archive")
+		return nil, errors.Wrap(err, "failed to get app version archive")
 	}
 
 	// ensure disaster recovery label transformer in midstream
@@ -288,40 +474,36 @@ func (o *Operator) deployApp(appID string, sequence int64) (deployed bool, deplo
 		"kots.io/app-slug": app.Slug,
 	}
 	if err := midstream.EnsureDisasterRecoveryLabelTransformer(deployedVersionArchive, additionalLabels); err != nil {
-		return false, errors.Wrap(err, "failed to ensure disaster recovery label transformer")
+		return nil, errors.Wrap(err, "failed to ensure disaster recovery label transformer")
 	}
 
 	kotsKinds, err := kotsutil.LoadKotsKinds(deployedVersionArchive)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to load kotskinds")
+		return nil, errors.Wrap(err, "failed to load kotskinds")
 	}
 
 	registrySettings, err := o.store.GetRegistryDetailsForApp(app.ID)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to get registry settings for app")
-	}
-
-	if err := o.ensureKotsadmApplicationMetadataConfigMap(app, sequence, util.PodNamespace, kotsKinds, registrySettings); err != nil {
-		return false, errors.Wrap(err, "failed to ensure kotsadm application metadata configmap")
+		return nil, errors.Wrap(err, "failed to get registry settings for app")
 	}
 
 	builder, err := render.NewBuilder(kotsKinds, registrySettings, app.Slug, sequence, app.IsAirgap, util.PodNamespace)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to get template builder")
+		return nil, errors.Wrap(err, "failed to get template builder")
 	}
 
 	if kotsKinds.V1Beta1HelmCharts != nil {
 		for i, helmChart := range kotsKinds.V1Beta1HelmCharts.Items {
 			renderedNamespace, err := builder.String(helmChart.Spec.Namespace)
 			if err != nil {
-				return false, errors.Wrapf(err, "failed to render namespace %s for chart %s", helmChart.Spec.Namespace, helmChart.GetReleaseName())
+				return nil, errors.Wrapf(err, "failed to render namespace %s for chart %s", helmChart.Spec.Namespace, helmChart.GetReleaseName())
 			}
 			kotsKinds.V1Beta1HelmCharts.Items[i].Spec.Namespace = renderedNamespace
 
 			for j, upgradeFlag := range helmChart.Spec.HelmUpgradeFlags {
 				renderedUpgradeFlag, err := builder.String(upgradeFlag)
 				if err != nil {
-					return false, errors.Wrapf(err, "failed to render upgrade flag %s for chart %s", upgradeFlag, helmChart.GetReleaseName())
+					return nil, errors.Wrapf(err, "failed to render upgrade flag %s for chart %s", upgradeFlag, helmChart.GetReleaseName())
 				}
 				kotsKinds.V1Beta1HelmCharts.Items[i].Spec.HelmUpgradeFlags[j] = renderedUpgradeFlag
 			}
@@ -332,14 +514,14 @@ func (o *Operator) deployApp(appID string, sequence int64) (deployed bool, deplo
 		for i, helmChart := range kotsKinds.V1Beta2HelmCharts.Items {
 			renderedNamespace, err := builder.String(helmChart.Spec.Namespace)
 			if err != nil {
-				return false, errors.Wrapf(err, "failed to render namespace %s for chart %s", helmChart.Spec.Namespace, helmChart.GetReleaseName())
+				return nil, errors.Wrapf(err, "failed to render namespace %s for chart %s", helmChart.Spec.Namespace, helmChart.GetReleaseName())
 			}
 			kotsKinds.V1Beta2HelmCharts.Items[i].Spec.Namespace = renderedNamespace
 
 			for j, upgradeFlag := range helmChart.Spec.HelmUpgradeFlags {
 				renderedUpgradeFlag, err := builder.String(upgradeFlag)
 				if err != nil {
-					return false, errors.Wrapf(err, "failed to render upgrade flag %s for chart %s", upgradeFlag, helmChart.GetReleaseName())
+					return nil, errors.Wrapf(err, "failed to render upgrade flag %s for chart %s", upgradeFlag, helmChart.GetReleaseName())
 				}
 				kotsKinds.V1Beta2HelmCharts.Items[i].Spec.HelmUpgradeFlags[j] = renderedUpgradeFlag
 			}
@@ -351,7 +533,7 @@ func (o *Operator) deployApp(appID string, sequence int64) (deployed bool, deplo
 		if kotsKinds.Identity.Spec.RequireIdentityProvider.Type == multitype.String {
 			requireIdentityProvider, err = builder.Bool(kotsKinds.Identity.Spec.RequireIdentityProvider.StrVal, false)
 			if err != nil {
-				return false, errors.Wrap(err, "failed to build kotsv1beta1.Identity.spec.requireIdentityProvider")
+				return nil, errors.Wrap(err, "failed to build kotsv1beta1.Identity.spec.requireIdentityProvider")
 			}
 		} else {
 			requireIdentityProvider = kotsKinds.Identity.Spec.RequireIdentityProvider.BoolVal
@@ -359,30 +541,30 @@ func (o *Operator) deployApp(appID string, sequence int64) (deployed bool, deplo
 	}
 
 	if requireIdentityProvider && !identitydeploy.IsEnabled(kotsKinds.Identity, kotsKinds.IdentityConfig) {
-		return false, errors.New("identity service is required but is not enabled")
+		return nil, errors.New("identity service is required but is not enabled")
 	}
 
 	kustomizeBinPath := binaries.GetKustomizeBinPath()
 
 	renderedManifests, _, err := apparchive.GetRenderedApp(deployedVersionArchive, downstreams.Name, kustomizeBinPath)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to get rendered app")
+		return nil, errors.Wrap(err, "failed to get rendered app")
 	}
 	base64EncodedManifests := base64.StdEncoding.EncodeToString(renderedManifests)
 
 	v1beta1ChartsArchive, _, err := apparchive.GetRenderedV1Beta1ChartsArchive(deployedVersionArchive, downstreams.Name, kustomizeBinPath)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to get rendered charts archive")
+		return nil, errors.Wrap(err, "failed to get rendered charts archive")
 	}
 
 	v1beta2ChartsArchive, err := apparchive.GetV1Beta2ChartsArchive(deployedVersionArchive)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to get v1beta2 charts archive")
+		return nil, errors.Wrap(err, "failed to get v1beta2 charts archive")
 	}
 
 	imagePullSecrets, err := getImagePullSecrets(deployedVersionArchive)
 	if err != nil {
-		return false, errors.Wrap(err, "failed to get image pull secrets")
+		return nil, errors.Wrap(err, "failed to get image pull secrets")
 	}
 
 	// get previous manifests (if any)
@@ -390,31 +572,27 @@ func (o *Operator) deployApp(appID string, sequence int64) (deployed bool, deplo
 	base64EncodedPreviousManifests := ""
 	previousV1beta1ChartsArchive := []byte{}
 	previousV1beta2ChartsArchive := []byte{}
-	previouslyDeployedSequence, err := o.store.GetPreviouslyDeployedSequence(app.ID, o.clusterID)
-	if err != nil {
-		return false, errors.Wrap(err, "failed to get previously deployed sequence")
-	}
 	if previouslyDeployedSequence != -1 {
-		previouslyDeployedParentSequence, err := o.store.GetParentSequenceForSequence(app.ID, o.clusterID, previouslyDeployedSequence)
+		previouslyDeployedParentSequence, err := o.store.GetParentSequenceForSequence(app.ID, clusterID, previouslyDeployedSequence)
 		if err != nil {
-			return false, errors.Wrap(err, "failed to get previously deployed parent sequence")
+			return nil, errors.Wrap(err, "failed to get previously deployed parent sequence")
 		}
 
 		if previouslyDeployedParentSequence != -1 {
 			previouslyDeployedVersionArchive, err := os.MkdirTemp("", "kotsadm")
 			if err != nil {
-				return false, errors.Wrap(err, "failed to create temp dir")
+				return nil, errors.Wrap(err, "failed to create temp dir")
 			}
 			defer os.RemoveAll(previouslyDeployedVersionArchive)
 
 			err = o.store.GetAppVersionArchive(app.ID, previouslyDeployedParentSequence, previouslyDeployedVersionArchive)
 			if err != nil {
-				return false, errors.Wrap(err, "failed to get previously deployed app version archive")
+				return nil, errors.Wrap(err, "failed to get previously deployed app version archive")
 			}
 
 			previousKotsKinds, err = kotsutil.LoadKotsKinds(previouslyDeployedVersionArchive)
 			if err != nil {
-				return false, errors.Wrap(err, "failed to load kotskinds for previously deployed app version")
+				return nil, errors.Wrap(err, "failed to load kotskinds for previously deployed app version")
 			}
 
 			previousRenderedManifests, _, err := apparchive.GetRenderedApp(previouslyDeployedVersionArchive, downstreams.Name, kustomizeBinPath)
@@ -424,28 +602,21 @@ func (o *Operator) deployApp(appID string, sequence int64) (deployed bool, deplo
 				base64EncodedPreviousManifests = base64.StdEncoding.EncodeToString(previousRenderedManifests)
 				previousV1beta1ChartsArchive, _, err = apparchive.GetRenderedV1Beta1ChartsArchive(previouslyDeployedVersionArchive, downstreams.Name, kustomizeBinPath)
 				if err != nil {
-					return false, errors.Wrap(err, "failed to get previously deployed rendered charts archive")
+					return nil, errors.Wrap(err, "failed to get previously deployed rendered charts archive")
 				}
 
 				previousV1beta2ChartsArchive, err = apparchive.GetV1Beta2ChartsArchive(previouslyDeployedVersionArchive)
 				if err != nil {
-					return false, errors.Wrap(err, "failed to get previously deployed v1beta2 charts archive")
+					return nil, errors.Wrap(err, "failed to get previously deployed v1beta2 charts archive")
 				}
 			}
 		}
 	}
 
-	if err := o.applyStatusInformers(app, sequence, kotsKinds, builder); err != nil {
-		return false, errors.Wrap(err, "failed to apply status informers")
-	}
-
-	o.client.ApplyNamespacesInformer(kotsKinds.KotsApplication.Spec.AdditionalNamespaces, imagePullSecrets)
-	o.client.ApplyHooksInformer(kotsKinds.KotsApplication.Spec.AdditionalNamespaces)
-
 	deployArgs := operatortypes.DeployAppArgs{
 		AppID:                        app.ID,
 		AppSlug:                      app.Slug,
-		ClusterID:                    o.clusterID,
+		ClusterID:                    clusterID,
 		Sequence:                     sequence,
 		AdditionalNamespaces:         kotsKinds.KotsApplication.Spec.AdditionalNamespaces,
 		ImagePullSecrets:             imagePullSecrets,
@@ -462,32 +633,32 @@ func (o *Operator) deployApp(appID string, sequence int64) (deployed bool, deplo
 		PreviousKotsKinds:            previousKotsKinds,
 	}
 
-	// Check if this is a V3 EC initial install that should skip deployment
-	if util.IsV3EmbeddedClusterInitialInstall(sequence) {
-		logger.Infof("Skipping deployment for V3 Embedded Cluster initial install (sequence %d)", sequence)
-
-		// Create successful deployment record for admin console
-		emptyOutput := downstreamtypes.DownstreamOutput{
-			DryrunStdout: base64.StdEncoding.EncodeToString([]byte("Skipped - deployed by V3 installer")),
-			ApplyStdout:  base64.StdEncoding.EncodeToString([]byte("Skipped - deployed by V3 installer")),
-		}
-		err := o.store.UpdateDownstreamDeployStatus(app.ID, o.clusterID, sequence, false, emptyOutput)
-		if err != nil {
-			return false, errors.Wrap(err, "failed to update downstream deploy status")
-		}
+	return &renderedAppVersion{
+		deployArgs:       deployArgs,
+		builder:          builder,
+		registrySettings: registrySettings,
+	}, nil
+}
 
-		return true, nil
-	}
+func (o *Operator) applyStatusInformers(a *apptypes.App, sequence int64, kotsKinds *kotsutil.KotsKinds, builder *template.Builder) error {
+	renderedInformers := getStatusInformers(a, kotsKinds, builder)
 
-	deployed, err = o.client.DeployApp(deployArgs)
-	if err != nil {
-		return false, errors.Wrap(err, "failed to deploy app")
+	if len(renderedInformers) > 0 {
+		informersArgs := operatortypes.AppInformersArgs{
+			AppID:     a.ID,
+			Informers: renderedInformers,
+			Sequence:  sequence,
+		}
+		o.client.ApplyAppInformers(informersArgs)
+	} else if err := o.setDefaultReadyState(a, sequence); err != nil {
+		return err
 	}
 
-	return deployed, nil
+	return nil
 }
 
-func (o *Operator) applyStatusInformers(a *apptypes.App, sequence int64, kotsKinds *kotsutil.KotsKinds, builder *template.Builder) error {
+// getStatusInformers renders the status informers of the app
+func getStatusInformers(a *apptypes.App, kotsKinds *kotsutil.KotsKinds, builder *template.Builder) []appstatetypes.StatusInformerString {
 	renderedInformers := []appstatetypes.StatusInformerString{}
 
 	// deploy status informers
@@ -510,37 +681,84 @@ func (o *Operator) applyStatusInformers(a *apptypes.App, sequence int64, kotsKin
 		renderedInformers = append(renderedInformers, appstatetypes.StatusInformerString(fmt.Sprintf("deployment/%s", identitytypes.DeploymentName(a.Slug))))
 	}
 
-	if len(renderedInformers) > 0 {
-		informersArgs := operatortypes.AppInformersArgs{
-			AppID:     a.ID,
-			Informers: renderedInformers,
-			Sequence:  sequence,
+	renderedInformers = append(renderedInformers, getHelmReleaseInformers(kotsKinds, builder, renderedInformers)...)
+
+	return renderedInformers
+}
+
+// getHelmReleaseInformers returns a status informer for the release of each v1beta2 helm chart that is not excluded,
+// unless the application already has one. Applications without any status informers get none, so that they keep
+// being reported as ready once deployed.
+func getHelmReleaseInformers(kotsKinds *kotsutil.KotsKinds, builder *template.Builder, existing []appstatetypes.StatusInformerString) []appstatetypes.StatusInformerString {
+	if kotsKinds.V1Beta2HelmCharts == nil || len(existing) == 0 {
+		return nil
+	}
+
+	existingReleases := map[string]bool{}
+	for _, informerString := range existing {
+		informer, err := informerString.Parse()
+		if err == nil && appstate.IsHelmReleaseKind(informer.Kind) {
+			existingReleases[fmt.Sprintf("%s/%s", informer.Namespace, informer.Name)] = true
 		}
-		o.client.ApplyAppInformers(informersArgs)
-	} else {
-		// no informers, set state to ready
-		defaultReadyState := appstatetypes.ResourceStates{
-			{
-				Kind:      "EMPTY",
-				Name:      "EMPTY",
-				Namespace: "EMPTY",
-				State:     appstatetypes.StateReady,
-			},
+	}
+
+	informers := []appstatetypes.StatusInformerString{}
+	for _, helmChart := range kotsKinds.V1Beta2HelmCharts.Items {
+		if !helmChart.Spec.Exclude.IsEmpty() {
+			exclude, err := helmChart.Spec.Exclude.Boolean()
+			if err != nil {
+				logger.Error(errors.Wrapf(err, "failed to parse exclude for chart %s", helmChart.GetReleaseName()))
+				continue
+			}
+			if exclude {
+				continue
+			}
 		}
 
-		err := o.store.SetAppStatus(a.ID, defaultReadyState, time.Now(), sequence)
+		namespace, err := builder.String(helmChart.Spec.Namespace)
 		if err != nil {
-			return errors.Wrap(err, "failed to set app status")
+			logger.Error(errors.Wrapf(err, "failed to render namespace %s for chart %s", helmChart.Spec.Namespace, helmChart.GetReleaseName()))
+			continue
 		}
 
-		if os.Getenv("KOTSADM_ENV") != "test" {
-			go func() {
-				err := reporting.GetReporter().SubmitAppInfo(a.ID)
-				if err != nil {
-					logger.Debugf("failed to submit app info: %v", err)
-				}
-			}()
+		releaseName := helmChart.GetReleaseName()
+		if existingReleases[fmt.Sprintf("%s/%s", namespace, releaseName)] {
+			continue
+		}
+
+		informer := fmt.Sprintf("%s/%s", appstate.HelmReleaseResourceKind, releaseName)
+		if namespace != "" {
+			informer = fmt.Sprintf("%s/%s", namespace, informer)
 		}
+		informers = append(informers, appstatetypes.StatusInformerString(informer))
+	}
+
+	return informers
+}
+
+// setDefaultReadyState sets the status of an app without status informers to ready
+func (o *Operator) setDefaultReadyState(a *apptypes.App, sequence int64) error {
+	defaultReadyState := appstatetypes.ResourceStates{
+		{
+			Kind:      "EMPTY",
+			Name:      "EMPTY",
+			Namespace: "EMPTY",
+			State:     appstatetypes.StateReady,
+		},
+	}
+
+	err := o.store.SetAppStatus(a.ID, defaultReadyState, time.Now(), sequence)
+	if err != nil {
+		return errors.Wrap(err, "failed to set app status")
+	}
+
+	if os.Getenv("KOTSADM_ENV") != "test" {
+		go func() {
+			err := reporting.GetReporter().SubmitAppInfo(a.ID)
+			if err != nil {
+				logger.Debugf("failed to submit app info: %v", err)
+			}
+		}()
 	}
 
 	return nil
@@ -778,10 +996,12 @@ func (o *Operator) checkRestoreComplete(a *apptypes.App, restore *velerov1.Resto
 }
 
 func (o *Operator) UndeployApp(a *apptypes.App, d *downstreamtypes.Downstream, isRestore bool) error {
-	deployMtx := o.getDeployMtx(a.ID)
+	q, queued := o.enqueueDeploy(a.ID, -1, operatortypes.DeployReasonUndeploy)
+	defer o.finishDeploy(q, queued)
 
-	deployMtx.Lock()
-	defer deployMtx.Unlock()
+	if err := o.waitForDeploy(q, queued); err != nil {
+		return err
+	}
 
 	deployedVersion, err := o.store.GetCurrentDownstreamVersion(a.ID, d.ClusterID)
 	if err != nil {
@@ -1093,10 +1313,6 @@ func (o *Operator) reconcileDeployment(cm *corev1.ConfigMap) (finalError error)
 		return errors.Wrap(err, "failed to reset channel changed flag")
 	}
 
-	if err := o.store.MarkAsCurrentDownstreamVersion(appID, sequence); err != nil {
-		return errors.Wrap(err, "failed to mark as current downstream version")
-	}
-
 	if err := o.GoDeployApp(appID, sequence); err != nil {
 		return errors.Wrap(err, "failed to start app deployment")
 	}
@@ -0,0 +1,77 @@
+package operator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	apptypes "github.com/replicatedhq/kots/pkg/app/types"
+	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
+	"github.com/replicatedhq/kots/pkg/kotsutil"
+	mock_store "github.com/replicatedhq/kots/pkg/store/mock"
+	"github.com/replicatedhq/kots/pkg/template"
+	kotsv1beta2 "github.com/replicatedhq/kotskinds/apis/kots/v1beta2"
+	"github.com/replicatedhq/kotskinds/multitype"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_getHelmReleaseInformers(t *testing.T) {
+	kotsKinds := &kotsutil.KotsKinds{
+		V1Beta2HelmCharts: &kotsv1beta2.HelmChartList{
+			Items: []kotsv1beta2.HelmChart{
+				{
+					Spec: kotsv1beta2.HelmChartSpec{
+						Chart: kotsv1beta2.ChartIdentifier{Name: "postgres"},
+					},
+				},
+				{
+					Spec: kotsv1beta2.HelmChartSpec{
+						Chart:       kotsv1beta2.ChartIdentifier{Name: "redis"},
+						ReleaseName: "cache",
+						Namespace:   "data",
+					},
+				},
+				{
+					Spec: kotsv1beta2.HelmChartSpec{
+						Chart:   kotsv1beta2.ChartIdentifier{Name: "excluded"},
+						Exclude: multitype.FromBool(true),
+					},
+				},
+				{
+					Spec: kotsv1beta2.HelmChartSpec{
+						Chart: kotsv1beta2.ChartIdentifier{Name: "web"},
+					},
+				},
+			},
+		},
+	}
+	existing := []appstatetypes.StatusInformerString{
+		"deployment/postgres",
+		"helmreleases/web",
+	}
+
+	got := getHelmReleaseInformers(kotsKinds, &template.Builder{}, existing)
+	require.Equal(t, []appstatetypes.StatusInformerString{
+		"helmrelease/postgres",
+		"data/helmrelease/cache",
+	}, got)
+
+	require.Nil(t, getHelmReleaseInformers(&kotsutil.KotsKinds{}, &template.Builder{}, existing))
+	require.Nil(t, getHelmReleaseInformers(kotsKinds, &template.Builder{}, nil))
+}
+
+func Test_renderPlannedAppVersion_usesCurrentSequence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the strict mock fails the test if the previously deployed sequence is used as the baseline
+	mockStore := mock_store.NewMockStore(ctrl)
+	o := &Operator{store: mockStore, clusterID: "cluster"}
+
+	stopErr := errors.New("stop rendering")
+	mockStore.EXPECT().GetCurrentDownstreamSequence("app", "cluster").Return(int64(3), nil)
+	mockStore.EXPECT().GetDownstream("cluster").Return(nil, stopErr)
+
+	_, err := o.renderPlannedAppVersion(&apptypes.App{ID: "app"}, "cluster", 4)
+	require.ErrorIs(t, err, stopErr)
+}
@@ -1,6 +1,7 @@
 package operator_test
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"os"
@@ -274,6 +275,7 @@ spec:
 				previouslyDeployedSequence = -1
 				mockCtrl = gomock.NewController(GinkgoT())
 				mockStore = mock_store.NewMockStore(mockCtrl)
+				mockStore.EXPECT().ListDownstreamsForApp(appID).AnyTimes().Return([]downstreamtypes.Downstream{}, nil)
 
 				mockClient = mock_client.NewMockClientInterface(mockCtrl)
 				mockK8sClientset := fake.NewSimpleClientset()
@@ -326,7 +328,7 @@ spec:
 
 				mockClient.EXPECT().ApplyHooksInformer(gomock.Any()).Times(1)
 
-				mockClient.EXPECT().DeployApp(gomock.Any()).Return(true, nil)
+				mockClient.EXPECT().DeployApp(gomock.Any(), gomock.Any()).Return(true, nil)
 
 				deployed, err := testOperator.DeployApp(appID, sequence)
 				Expect(err).ToNot(HaveOccurred())
@@ -421,7 +423,7 @@ spec:
 
 					mockClient.EXPECT().ApplyHooksInformer(gomock.Any()).Times(1)
 
-					mockClient.EXPECT().DeployApp(gomock.Any()).DoAndReturn(func(deployArgs operatortypes.DeployAppArgs) (bool, error) {
+					mockClient.EXPECT().DeployApp(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, deployArgs operatortypes.DeployAppArgs) (bool, error) {
 						Expect(deployArgs.PreviousManifests).To(BeEmpty())
 						return true, nil
 					})
@@ -579,6 +581,7 @@ spec:
 				previouslyDeployedSequence = -1
 				mockCtrl = gomock.NewController(GinkgoT())
 				mockStore = mock_store.NewMockStore(mockCtrl)
+				mockStore.EXPECT().ListDownstreamsForApp(appID).AnyTimes().Return([]downstreamtypes.Downstream{}, nil)
 
 				mockClient = mock_client.NewMockClientInterface(mockCtrl)
 				mockK8sClientset := fake.NewSimpleClientset()
@@ -631,7 +634,7 @@ spec:
 
 				mockClient.EXPECT().ApplyHooksInformer(gomock.Any()).Times(1)
 
-				mockClient.EXPECT().DeployApp(gomock.Any()).Do(func(deployArgs operatortypes.DeployAppArgs) (bool, error) {
+				mockClient.EXPECT().DeployApp(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, deployArgs operatortypes.DeployAppArgs) (bool, error) {
 					// validate that the namespace and helm upgrade flags are templated when deploying
 					Expect(deployArgs.KotsKinds.V1Beta1HelmCharts.Items[0].Spec.Namespace).To(Equal(expectedNamespace))
 					Expect(deployArgs.KotsKinds.V1Beta1HelmCharts.Items[0].Spec.HelmUpgradeFlags).To(Equal(expectedHelmUpgradeFlags))
@@ -685,6 +688,7 @@ spec: {}`,
 				_ = os.Setenv("KOTSADM_ENV", "test")
 				mockCtrl = gomock.NewController(GinkgoT())
 				mockStore = mock_store.NewMockStore(mockCtrl)
+				mockStore.EXPECT().ListDownstreamsForApp(appID).AnyTimes().Return([]downstreamtypes.Downstream{}, nil)
 				mockClient = mock_client.NewMockClientInterface(mockCtrl)
 
 				// Set V3 environment variable
@@ -756,7 +760,7 @@ spec: {}`,
 				mockClient.EXPECT().ApplyHooksInformer(gomock.Any()).Times(1)
 
 				// These should NOT be called for V3 initial install (they happen after V3 check)
-				mockClient.EXPECT().DeployApp(gomock.Any()).Times(0)
+				mockClient.EXPECT().DeployApp(gomock.Any(), gomock.Any()).Times(0)
 				mockClient.EXPECT().ApplyAppInformers(gomock.Any()).Times(0)
 
 				deployed, err := testOperator.DeployApp(appID, sequence)
@@ -804,6 +808,7 @@ spec: {}`,
 				_ = os.Setenv("KOTSADM_ENV", "test")
 				mockCtrl = gomock.NewController(GinkgoT())
 				mockStore = mock_store.NewMockStore(mockCtrl)
+				mockStore.EXPECT().ListDownstreamsForApp(appID).AnyTimes().Return([]downstreamtypes.Downstream{}, nil)
 				mockClient = mock_client.NewMockClientInterface(mockCtrl)
 
 				// Set V3 environment variable
@@ -862,7 +867,7 @@ spec: {}`,
 				mockStore.EXPECT().SetAppStatus(appID, gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 
 				// Client.DeployApp SHOULD be called for upgrades
-				mockClient.EXPECT().DeployApp(gomock.Any()).Return(true, nil)
+				mockClient.EXPECT().DeployApp(gomock.Any(), gomock.Any()).Return(true, nil)
 
 				deployed, err := testOperator.DeployApp(appID, sequence)
 				Expect(err).ToNot(HaveOccurred())
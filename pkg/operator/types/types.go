@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
 	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
 	"github.com/replicatedhq/kots/pkg/kotsutil"
 	"github.com/replicatedhq/kots/pkg/logger"
@@ -19,8 +21,15 @@ const (
 	DeletionPhaseAnnotation     = "kots.io/deletion-phase"
 	WaitForReadyAnnotation      = "kots.io/wait-for-ready"
 	WaitForPropertiesAnnotation = "kots.io/wait-for-properties"
+	// PhaseWaitForReadyAnnotation makes the deployer wait for every resource in the creation phase of the annotated
+	// resource to be ready before the next phase is created. When set on the Application, it applies to every phase.
+	PhaseWaitForReadyAnnotation = "kots.io/phase-wait-for-ready"
+	// PhaseReadyTimeoutAnnotation is how long to wait for a creation phase to be ready, e.g. "15m".
+	PhaseReadyTimeoutAnnotation = "kots.io/phase-ready-timeout"
 )
 
+const DefaultPhaseReadyTimeout = 10 * time.Minute
+
 type DeployAppArgs struct {
 	AppID                        string                `json:"app_id"`
 	AppSlug                      string                `json:"app_slug"`
@@ -67,6 +76,30 @@ type AppInformersArgs struct {
 	Informers []appstatetypes.StatusInformerString `json:"informers"`
 }
 
+// AgentDesiredState is what the agent of a remote cluster should deploy and monitor
+type AgentDesiredState struct {
+	// Deploys are the app versions waiting to be deployed to the cluster
+	Deploys []DeployAppArgs `json:"deploys"`
+	// Informers are the status informers of the apps deployed to the cluster. They are only included when requested.
+	Informers []AppInformersArgs `json:"informers,omitempty"`
+}
+
+// AgentDeployResults are the results of the agent of a remote cluster applying an app version
+type AgentDeployResults struct {
+	AppID    string                           `json:"appId"`
+	Sequence int64                            `json:"sequence"`
+	IsError  bool                             `json:"isError"`
+	Output   downstreamtypes.DownstreamOutput `json:"output"`
+}
+
+// AgentDeployStatus is reported by the agent of a remote cluster once it is done deploying an app version
+type AgentDeployStatus struct {
+	AppID    string `json:"appId"`
+	Sequence int64  `json:"sequence"`
+	Deployed bool   `json:"deployed"`
+	Error    string `json:"error,omitempty"`
+}
+
 type Phases []Phase
 
 type Phase struct {
@@ -74,6 +107,45 @@ type Phase struct {
 	Resources Resources
 }
 
+// GetReadiness returns whether the phase must be ready before the next phase is created, and how long to wait for it.
+// The defaults are the annotations of the Application. Annotations on the resources in the phase can enable waiting
+// and extend the timeout.
+func (p Phase) GetReadiness(defaults map[string]string) (bool, time.Duration) {
+	wait := defaults[PhaseWaitForReadyAnnotation] == "true"
+	timeout := parsePhaseReadyTimeout(defaults[PhaseReadyTimeoutAnnotation], DefaultPhaseReadyTimeout)
+
+	resourceTimeout := time.Duration(0)
+	for _, resource := range p.Resources {
+		if resource.Unstructured == nil {
+			continue
+		}
+		annotations := resource.Unstructured.GetAnnotations()
+		if annotations[PhaseWaitForReadyAnnotation] == "true" {
+			wait = true
+		}
+		if t := parsePhaseReadyTimeout(annotations[PhaseReadyTimeoutAnnotation], 0); t > resourceTimeout {
+			resourceTimeout = t
+		}
+	}
+	if resourceTimeout > 0 {
+		timeout = resourceTimeout
+	}
+
+	return wait, timeout
+}
+
+func parsePhaseReadyTimeout(value string, defaultTimeout time.Duration) time.Duration {
+	if value == "" {
+		return defaultTimeout
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		logger.Errorf("invalid phase ready timeout %q", value)
+		return defaultTimeout
+	}
+	return timeout
+}
+
 type Resources []Resource
 
 type Resource struct {
@@ -242,3 +314,105 @@ func (r Resources) GroupByPhaseAnnotation(annotation string) map[string]Resource
 
 	return grouped
 }
+
+type PlanAction string
+
+const (
+	PlanActionCreate    PlanAction = "create"
+	PlanActionUpdate    PlanAction = "update"
+	PlanActionDelete    PlanAction = "delete"
+	PlanActionUnchanged PlanAction = "unchanged"
+)
+
+// DeployPlan is the set of changes that deploying an app version would make to the cluster
+type DeployPlan struct {
+	AppSlug   string         `json:"appSlug"`
+	Sequence  int64          `json:"sequence"`
+	Resources []ResourcePlan `json:"resources"`
+}
+
+// ResourcePlan is the change that deploying an app version would make to a single resource
+type ResourcePlan struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Namespace  string `json:"namespace,omitempty"`
+	// HelmRelease is the name of the helm release that the resource belongs to, if any
+	HelmRelease string      `json:"helmRelease,omitempty"`
+	Action      PlanAction  `json:"action"`
+	Diffs       []FieldDiff `json:"diffs,omitempty"`
+	// Error is set if the resource could not be planned, e.g. if the dry-run apply was rejected
+	Error string `json:"error,omitempty"`
+}
+
+// FieldDiff is a change to a single field. Old and New are json encoded and empty if the field is absent.
+type FieldDiff struct {
+	Path string `json:"path"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+// RedactedFieldValue replaces the values of secret fields in plans and drift reports, which can be read by users
+// that are not allowed to read the secrets themselves
+const RedactedFieldValue = `"(redacted)"`
+
+// IsSecretField returns true if the field of a resource holds a secret value
+func IsSecretField(apiVersion string, kind string, path string) bool {
+	if apiVersion != "v1" || kind != "Secret" {
+		return false
+	}
+	for _, field := range []string{"data", "stringData"} {
+		if path == field || strings.HasPrefix(path, field+".") || strings.HasPrefix(path, field+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactFieldValue returns the redacted value of a secret field, keeping whether the field is set
+func RedactFieldValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	return RedactedFieldValue
+}
+
+// Count returns the number of resources in the plan with the given action
+func (p DeployPlan) Count(action PlanAction) int {
+	count := 0
+	for _, r := range p.Resources {
+		if r.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+type DeployState string
+
+const (
+	DeployStateQueued     DeployState = "queued"
+	DeployStateRunning    DeployState = "running"
+	DeployStateCancelling DeployState = "cancelling"
+)
+
+type DeployReason string
+
+const (
+	DeployReasonDeploy   DeployReason = "deploy"
+	DeployReasonRollback DeployReason = "rollback"
+	DeployReasonHeal     DeployReason = "heal"
+	DeployReasonUndeploy DeployReason = "undeploy"
+)
+
+// QueuedDeploy is a deploy of an app that is waiting in the app's deploy queue or in progress
+type QueuedDeploy struct {
+	ID    string `json:"id"`
+	AppID string `json:"appId"`
+	// Sequence is -1 for undeploys
+	Sequence  int64        `json:"sequence"`
+	Reason    DeployReason `json:"reason"`
+	State     DeployState  `json:"state"`
+	QueuedAt  time.Time    `json:"queuedAt"`
+	StartedAt *time.Time   `json:"startedAt,omitempty"`
+}
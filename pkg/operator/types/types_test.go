@@ -3,6 +3,7 @@ package types
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
@@ -321,3 +322,79 @@ func TestResources_GroupByPhaseAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestPhase_GetReadiness(t *testing.T) {
+	tests := []struct {
+		name        string
+		phase       Phase
+		defaults    map[string]string
+		wantWait    bool
+		wantTimeout time.Duration
+	}{
+		{
+			name: "not enabled",
+			phase: Phase{Resources: Resources{
+				{Unstructured: &unstructured.Unstructured{}},
+			}},
+			wantWait:    false,
+			wantTimeout: DefaultPhaseReadyTimeout,
+		},
+		{
+			name: "enabled by a resource in the phase",
+			phase: Phase{Resources: Resources{
+				{Unstructured: &unstructured.Unstructured{}},
+				{Unstructured: unstructuredWithAnnotation(PhaseWaitForReadyAnnotation, "true")},
+			}},
+			wantWait:    true,
+			wantTimeout: DefaultPhaseReadyTimeout,
+		},
+		{
+			name: "enabled by the application with a timeout",
+			phase: Phase{Resources: Resources{
+				{Unstructured: &unstructured.Unstructured{}},
+			}},
+			defaults: map[string]string{
+				PhaseWaitForReadyAnnotation: "true",
+				PhaseReadyTimeoutAnnotation: "5m",
+			},
+			wantWait:    true,
+			wantTimeout: 5 * time.Minute,
+		},
+		{
+			name: "resource timeout overrides the application timeout",
+			phase: Phase{Resources: Resources{
+				{Unstructured: unstructuredWithAnnotation(PhaseReadyTimeoutAnnotation, "30m")},
+				{Unstructured: unstructuredWithAnnotation(PhaseReadyTimeoutAnnotation, "20m")},
+			}},
+			defaults: map[string]string{
+				PhaseWaitForReadyAnnotation: "true",
+				PhaseReadyTimeoutAnnotation: "5m",
+			},
+			wantWait:    true,
+			wantTimeout: 30 * time.Minute,
+		},
+		{
+			name: "invalid timeout is ignored",
+			phase: Phase{Resources: Resources{
+				{Unstructured: unstructuredWithAnnotation(PhaseReadyTimeoutAnnotation, "soon")},
+				{DecodeErrMsg: "failed to decode"},
+			}},
+			defaults: map[string]string{
+				PhaseWaitForReadyAnnotation: "true",
+			},
+			wantWait:    true,
+			wantTimeout: DefaultPhaseReadyTimeout,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wait, timeout := tt.phase.GetReadiness(tt.defaults)
+			if wait != tt.wantWait {
+				t.Errorf("Phase.GetReadiness() wait = %v, want %v", wait, tt.wantWait)
+			}
+			if timeout != tt.wantTimeout {
+				t.Errorf("Phase.GetReadiness() timeout = %v, want %v", timeout, tt.wantTimeout)
+			}
+		})
+	}
+}
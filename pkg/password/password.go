@@ -28,8 +28,8 @@ var (
 
 // ValidatePasswordInput - will validate length and complexity of new password and check if it is different from current password
 func ValidatePasswordInput(currentPassword string, newPassword string) error {
-	if len(newPassword) < 6 {
-		return ErrNewPasswordTooShort
+	if err := ValidateNewPassword(newPassword); err != nil {
+		return err
 	}
 
 	if newPassword == currentPassword {
@@ -38,6 +38,14 @@ func ValidatePasswordInput(currentPassword string, newPassword string) error {
 	return nil
 }
 
+// ValidateNewPassword - will validate length and complexity of a new password
+func ValidateNewPassword(newPassword string) error {
+	if len(newPassword) < 6 {
+		return ErrNewPasswordTooShort
+	}
+	return nil
+}
+
 // ValidateCurrentPassword - will compare the password with the stored password and return an error if they don't match
 func ValidateCurrentPassword(kotsStore store.Store, currentPassword string) error {
 	passwordLock.Lock()
@@ -78,9 +86,10 @@ func ChangePassword(clientset *kubernetes.Clientset, namespace string, newPasswo
 
 // setSharedPasswordBcrypt - set the shared password bcrypt hash in the kotsadm secret
 func setSharedPasswordBcrypt(clientset kubernetes.Interface, namespace string, bcryptPassword []byte) error {
+	// sessions issued before passwordUpdatedAt are rejected, so sub-second precision is kept
 	secretData := map[string][]byte{
 		"passwordBcrypt":    []byte(bcryptPassword),
-		"passwordUpdatedAt": []byte(time.Now().Format(time.RFC3339)),
+		"passwordUpdatedAt": []byte(time.Now().Format(time.RFC3339Nano)),
 	}
 
 	existingPasswordSecret, err := clientset.CoreV1().Secrets(namespace).Get(context.TODO(), util.PasswordSecretName, metav1.GetOptions{})
@@ -139,6 +148,6 @@ func deleteAllSessions(clientset kubernetes.Interface, namespace string) {
 	_, err := clientset.CoreV1().Secrets(namespace).Update(context.TODO(), sessionSecret, metav1.UpdateOptions{})
 	if err != nil {
 		// as the password is already changed, log the error but don't fail (false positive case)
-		logger.Errorf("failed to delete all sessions: %s", namespace, util.SessionsSecretName, err)
+		logger.Errorf("failed to delete all sessions in secret %s/%s: %v", namespace, util.SessionsSecretName, err)
 	}
 }
@@ -0,0 +1,90 @@
+package policy
+
+import (
+	"bufio"
+	"net"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/pkg/errors"
+	audittypes "github.com/replicatedhq/kots/pkg/audit/types"
+	"github.com/replicatedhq/kots/pkg/logger"
+	sessiontypes "github.com/replicatedhq/kots/pkg/session/types"
+)
+
+// statusRecorder captures the status code written by a handler so that it can be audited
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (sr *statusRecorder) WriteHeader(code int) {
+	if sr.statusCode == 0 {
+		sr.statusCode = code
+	}
+	sr.ResponseWriter.WriteHeader(code)
+}
+
+func (sr *statusRecorder) Write(b []byte) (int, error) {
+	if sr.statusCode == 0 {
+		sr.statusCode = http.StatusOK
+	}
+	return sr.ResponseWriter.Write(b)
+}
+
+func (sr *statusRecorder) Flush() {
+	if f, ok := sr.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (sr *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := sr.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer does not support hijacking")
+	}
+	return h.Hijack()
+}
+
+func (sr *statusRecorder) Unwrap() http.ResponseWriter {
+	return sr.ResponseWriter
+}
+
+func (sr *statusRecorder) status() int {
+	if sr.statusCode == 0 {
+		return http.StatusOK
+	}
+	return sr.statusCode
+}
+
+// recordAuditEvent writes an audit log entry for a request. Failures are logged and never fail the request.
+func (m *Middleware) recordAuditEvent(r *http.Request, sess *sessiontypes.Session, action string, resource string, statusCode int, outcome audittypes.Outcome) {
+	vars := mux.Vars(r)
+
+	event := &audittypes.Event{
+		UserID:     sess.UserID,
+		SessionID:  sess.ID,
+		APITokenID: sess.APITokenID,
+		Roles:      sess.Roles,
+		Action:     action,
+		Resource:   resource,
+		Method:     r.Method,
+		Path:       r.URL.Path,
+		AppSlug:    vars["appSlug"],
+		StatusCode: statusCode,
+		Outcome:    outcome,
+	}
+	if route := mux.CurrentRoute(r); route != nil {
+		event.RouteName = route.GetName()
+	}
+	if val, ok := vars["sequence"]; ok {
+		if sequence, err := strconv.ParseInt(val, 10, 64); err == nil {
+			event.Sequence = &sequence
+		}
+	}
+
+	if err := m.KOTSStore.CreateAuditEvent(event); err != nil {
+		logger.Error(errors.Wrapf(err, "failed to record audit event for %s %s", r.Method, r.URL.Path))
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/pkg/errors"
+	audittypes "github.com/replicatedhq/kots/pkg/audit/types"
 	"github.com/replicatedhq/kots/pkg/logger"
 	"github.com/replicatedhq/kots/pkg/rbac"
 	rbactypes "github.com/replicatedhq/kots/pkg/rbac/types"
@@ -30,7 +31,11 @@ func (e RBACError) Abort(w http.ResponseWriter) error {
 
 type Middleware struct {
 	KOTSStore store.Store
-	Roles     []rbactypes.Role
+	// Roles are the roles to check access against. When nil, the currently loaded
+	// roles are used so that custom roles can be reloaded at runtime.
+	Roles []rbactypes.Role
+	// AuditLog enables recording every write action request in the audit log
+	AuditLog bool
 }
 
 func NewMiddleware(kotsStore store.Store, roles []rbactypes.Role) *Middleware {
@@ -49,17 +54,23 @@ func (m *Middleware) EnforceAccess(p *Policy, handler http.HandlerFunc) http.Han
 			return
 		}
 
-		if sess.HasRBAC { // handle pre-rbac sessions
-			action, resource, err := p.execute(r, m.KOTSStore)
+		audit := m.AuditLog && p.action == ActionWrite
+
+		action, resource := p.action, ""
+		if sess.HasRBAC || audit {
+			var err error
+			action, resource, err = p.execute(r, m.KOTSStore)
 			if err != nil {
 				logger.Error(errors.Wrapf(err, "failed to execute policy template %q", p.resource))
 				w.WriteHeader(http.StatusInternalServerError)
 				return
 			}
+		}
 
+		if sess.HasRBAC { // handle pre-rbac sessions
 			rbacErr := NewRBACError(resource)
 
-			allow, err := rbac.CheckAccess(r.Context(), m.Roles, action, resource, sess.Roles)
+			allow, err := rbac.CheckAccess(r.Context(), m.roles(), action, resource, sess.Roles)
 			if err != nil {
 				logger.Error(errors.Wrapf(err, "failed to check access to resource %q", resource))
 				w.WriteHeader(http.StatusInternalServerError)
@@ -67,12 +78,34 @@ func (m *Middleware) EnforceAccess(p *Policy, handler http.HandlerFunc) http.Han
 			}
 			if !allow {
 				logger.Error(rbacErr.Abort(w))
+				if audit {
+					m.recordAuditEvent(r, sess, action, resource, http.StatusForbidden, audittypes.OutcomeDenied)
+				}
 				return
 			}
 		}
 
-		handler(w, r)
+		if !audit {
+			handler(w, r)
+			return
+		}
+
+		sr := &statusRecorder{ResponseWriter: w}
+		handler(sr, r)
+
+		outcome := audittypes.OutcomeSuccess
+		if sr.status() >= http.StatusBadRequest {
+			outcome = audittypes.OutcomeFailure
+		}
+		m.recordAuditEvent(r, sess, action, resource, sr.status(), outcome)
+	}
+}
+
+func (m *Middleware) roles() []rbactypes.Role {
+	if m.Roles != nil {
+		return m.Roles
 	}
+	return rbac.Roles()
 }
 
 // TODO: move everything below here to a shared package
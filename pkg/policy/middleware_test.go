@@ -0,0 +1,106 @@
+package policy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	audittypes "github.com/replicatedhq/kots/pkg/audit/types"
+	"github.com/replicatedhq/kots/pkg/rbac"
+	rbactypes "github.com/replicatedhq/kots/pkg/rbac/types"
+	"github.com/replicatedhq/kots/pkg/session"
+	sessiontypes "github.com/replicatedhq/kots/pkg/session/types"
+	mock_store "github.com/replicatedhq/kots/pkg/store/mock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddleware_EnforceAccessAuditLog(t *testing.T) {
+	tests := []struct {
+		name          string
+		policy        *Policy
+		sessionRoles  []string
+		handlerStatus int
+		wantStatus    int
+		wantOutcome   audittypes.Outcome
+		wantAudit     bool
+	}{
+		{
+			name:          "allowed write",
+			policy:        Must(NewPolicy(ActionWrite, "app.{{.appSlug}}.downstream.")),
+			sessionRoles:  []string{rbac.ClusterAdminRoleID},
+			handlerStatus: http.StatusOK,
+			wantStatus:    http.StatusOK,
+			wantOutcome:   audittypes.OutcomeSuccess,
+			wantAudit:     true,
+		},
+		{
+			name:          "failed write",
+			policy:        Must(NewPolicy(ActionWrite, "app.{{.appSlug}}.downstream.")),
+			sessionRoles:  []string{rbac.ClusterAdminRoleID},
+			handlerStatus: http.StatusInternalServerError,
+			wantStatus:    http.StatusInternalServerError,
+			wantOutcome:   audittypes.OutcomeFailure,
+			wantAudit:     true,
+		},
+		{
+			name:         "denied write",
+			policy:       Must(NewPolicy(ActionWrite, "app.{{.appSlug}}.downstream.")),
+			sessionRoles: []string{rbac.SupportRole.ID},
+			wantStatus:   http.StatusForbidden,
+			wantOutcome:  audittypes.OutcomeDenied,
+			wantAudit:    true,
+		},
+		{
+			name:          "read is not audited",
+			policy:        Must(NewPolicy(ActionRead, "app.{{.appSlug}}.downstream.")),
+			sessionRoles:  []string{rbac.ClusterAdminRoleID},
+			handlerStatus: http.StatusOK,
+			wantStatus:    http.StatusOK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockStore := mock_store.NewMockStore(ctrl)
+			if tt.wantAudit {
+				mockStore.EXPECT().CreateAuditEvent(gomock.Any()).DoAndReturn(func(event *audittypes.Event) error {
+					assert.Equal(t, "user-id", event.UserID)
+					assert.Equal(t, "session-id", event.SessionID)
+					assert.Equal(t, "app.my-app.downstream.", event.Resource)
+					assert.Equal(t, "my-app", event.AppSlug)
+					if assert.NotNil(t, event.Sequence) {
+						assert.Equal(t, int64(3), *event.Sequence)
+					}
+					assert.Equal(t, tt.wantStatus, event.StatusCode)
+					assert.Equal(t, tt.wantOutcome, event.Outcome)
+					return nil
+				})
+			}
+
+			m := NewMiddleware(mockStore, []rbactypes.Role{rbac.ClusterAdminRole, rbac.SupportRole})
+			m.AuditLog = true
+
+			handler := m.EnforceAccess(tt.policy, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.handlerStatus)
+			})
+
+			req := httptest.NewRequest("POST", "/api/v1/app/my-app/sequence/3/deploy", nil)
+			req = mux.SetURLVars(req, map[string]string{"appSlug": "my-app", "sequence": "3"})
+			req = session.ContextSetSession(req, &sessiontypes.Session{
+				ID:      "session-id",
+				UserID:  "user-id",
+				Roles:   tt.sessionRoles,
+				HasRBAC: true,
+			})
+
+			w := httptest.NewRecorder()
+			handler(w, req)
+
+			assert.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -63,6 +63,46 @@ var (
 	PasswordChange = Must(NewPolicy(ActionWrite, "passwordupdate."))
 )
 
+// Users
+
+var (
+	UserRead  = Must(NewPolicy(ActionRead, "user."))
+	UserWrite = Must(NewPolicy(ActionWrite, "user."))
+)
+
+// API tokens
+
+var (
+	TokenRead  = Must(NewPolicy(ActionRead, "token."))
+	TokenWrite = Must(NewPolicy(ActionWrite, "token."))
+)
+
+// RBAC
+
+var (
+	RBACRead = Must(NewPolicy(ActionRead, "rbac."))
+)
+
+// Audit log
+
+var (
+	AuditRead = Must(NewPolicy(ActionRead, "audit."))
+)
+
+// Sessions
+
+var (
+	SessionRead  = Must(NewPolicy(ActionRead, "session."))
+	SessionWrite = Must(NewPolicy(ActionWrite, "session."))
+)
+
+// Two-factor authentication
+
+var (
+	TwoFactorRead  = Must(NewPolicy(ActionRead, "twofactor."))
+	TwoFactorWrite = Must(NewPolicy(ActionWrite, "twofactor."))
+)
+
 // Kotsadm Identity Service
 
 var (
@@ -130,6 +170,13 @@ var (
 	AppGitopsWrite = Must(NewPolicy(ActionWrite, "app.{{.appSlug}}.gitops.", appSlugFromAppIDGetter))
 )
 
+// App notifications
+
+var (
+	AppNotificationsRead  = Must(NewPolicy(ActionRead, "app.{{.appSlug}}.notifications."))
+	AppNotificationsWrite = Must(NewPolicy(ActionWrite, "app.{{.appSlug}}.notifications."))
+)
+
 // App downstream
 
 var (
@@ -13,8 +13,11 @@ import (
 	"github.com/replicatedhq/kots/pkg/installers"
 	"github.com/replicatedhq/kots/pkg/k8sutil"
 	kotstypes "github.com/replicatedhq/kots/pkg/kotsadm/types"
+	"github.com/replicatedhq/kots/pkg/kotsadmmetrics"
 	"github.com/replicatedhq/kots/pkg/kotsutil"
 	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/notifications"
+	notificationtypes "github.com/replicatedhq/kots/pkg/notifications/types"
 	"github.com/replicatedhq/kots/pkg/preflight/types"
 	"github.com/replicatedhq/kots/pkg/registry"
 	registrytypes "github.com/replicatedhq/kots/pkg/registry/types"
@@ -127,6 +130,15 @@ func Run(appID string, appSlug string, sequence int64, isAirgap bool, ignoreNonS
 		}
 		logger.Info("preflight checks completed")
 
+		if uploadPreflightResults != nil && GetPreflightState(uploadPreflightResults, false) == "fail" {
+			notifications.Notify(notificationtypes.Event{
+				Type:     notificationtypes.EventPreflightFailed,
+				AppID:    appID,
+				Sequence: &sequence,
+				Message:  fmt.Sprintf("Preflight checks failed for sequence %d.", sequence),
+			})
+		}
+
 		go func() {
 			err := reporting.GetReporter().SubmitAppInfo(appID) // send app and preflight info when preflights finish
 			if err != nil {
@@ -181,6 +193,7 @@ func setPreflightResults(appID string, sequence int64, preflightResults *types.P
 	if err := store.GetStore().SetPreflightResults(appID, sequence, b); err != nil {
 		return errors.Wrap(err, "failed to set preflight results")
 	}
+	kotsadmmetrics.RecordPreflightRun(appID, GetPreflightState(preflightResults, false))
 	return nil
 }
 
@@ -0,0 +1,74 @@
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
+)
+
+// appStatesInOrder is the order in which time spent in each state is printed
+var appStatesInOrder = []appstatetypes.State{
+	appstatetypes.StateReady,
+	appstatetypes.StateUpdating,
+	appstatetypes.StateDegraded,
+	appstatetypes.StateUnavailable,
+	appstatetypes.StateMissing,
+}
+
+func AppStatusHistory(history *appstatetypes.AppStatusHistory, format string) {
+	switch format {
+	case "json":
+		printAppStatusHistoryJSON(history)
+	default:
+		printAppStatusHistoryTable(history)
+	}
+}
+
+func printAppStatusHistoryJSON(history *appstatetypes.AppStatusHistory) {
+	str, _ := json.MarshalIndent(history, "", "    ")
+	fmt.Println(string(str))
+}
+
+func printAppStatusHistoryTable(history *appstatetypes.AppStatusHistory) {
+	if len(history.Transitions) == 0 {
+		fmt.Println("No app status history found.")
+		return
+	}
+
+	fmt.Printf("App availability from %s to %s: %.2f%%\n\n", history.Start.Format(time.RFC3339), history.End.Format(time.RFC3339), history.Availability.Percentage)
+
+	w := NewTabWriter()
+
+	fmtColumns := "%s\t%s\n"
+	fmt.Fprintf(w, fmtColumns, "STATE", "DURATION")
+	for _, state := range appStatesInOrder {
+		if seconds, ok := history.Availability.StateSeconds[state]; ok {
+			fmt.Fprintf(w, fmtColumns, state, time.Duration(seconds)*time.Second)
+		}
+	}
+	w.Flush()
+
+	if len(history.Resources) > 0 {
+		fmt.Println()
+		fmtColumns = "%s\t%s\t%s\t%s\n"
+		fmt.Fprintf(w, fmtColumns, "KIND", "NAMESPACE", "NAME", "AVAILABILITY")
+		for _, r := range history.Resources {
+			fmt.Fprintf(w, fmtColumns, r.Kind, r.Namespace, r.Name, fmt.Sprintf("%.2f%%", r.Availability.Percentage))
+		}
+		w.Flush()
+	}
+
+	fmt.Println()
+	fmtColumns = "%s\t%s\t%s\t%s\t%s\t%s\n"
+	fmt.Fprintf(w, fmtColumns, "TIME", "KIND", "NAMESPACE", "NAME", "STATE", "MESSAGE")
+	for _, t := range history.Transitions {
+		kind := t.Kind
+		if kind == "" {
+			kind = "app"
+		}
+		fmt.Fprintf(w, fmtColumns, t.CreatedAt.Format(time.RFC3339), kind, t.Namespace, t.Name, t.State, t.Message)
+	}
+	w.Flush()
+}
@@ -0,0 +1,48 @@
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	audittypes "github.com/replicatedhq/kots/pkg/audit/types"
+)
+
+func AuditEvents(events []*audittypes.Event, format string) {
+	switch format {
+	case "json":
+		printAuditEventsJSON(events)
+	default:
+		printAuditEventsTable(events)
+	}
+}
+
+func printAuditEventsJSON(events []*audittypes.Event) {
+	str, _ := json.MarshalIndent(events, "", "    ")
+	fmt.Println(string(str))
+}
+
+func printAuditEventsTable(events []*audittypes.Event) {
+	w := NewTabWriter()
+	defer w.Flush()
+
+	fmtColumns := "%s\t%s\t%s\t%s\t%s\t%s\t%s\n"
+	fmt.Fprintf(w, fmtColumns, "TIME", "ACTOR", "ROUTE", "RESOURCE", "APP", "SEQUENCE", "OUTCOME")
+	for _, event := range events {
+		actor := event.UserID
+		if event.APITokenID != "" {
+			actor = fmt.Sprintf("token:%s", event.APITokenID)
+		} else if actor == "" {
+			actor = event.SessionID
+		}
+		sequence := ""
+		if event.Sequence != nil {
+			sequence = fmt.Sprintf("%d", *event.Sequence)
+		}
+		outcome := string(event.Outcome)
+		if event.StatusCode != 0 {
+			outcome = fmt.Sprintf("%s (%d)", outcome, event.StatusCode)
+		}
+		fmt.Fprintf(w, fmtColumns, event.CreatedAt.Format(time.RFC3339), actor, event.RouteName, event.Resource, event.AppSlug, sequence, outcome)
+	}
+}
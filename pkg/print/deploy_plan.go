@@ -0,0 +1,68 @@
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+)
+
+func DeployPlan(plan *operatortypes.DeployPlan, format string) {
+	switch format {
+	case "json":
+		printDeployPlanJSON(plan)
+	default:
+		printDeployPlanTable(plan)
+	}
+}
+
+func printDeployPlanJSON(plan *operatortypes.DeployPlan) {
+	str, _ := json.MarshalIndent(plan, "", "    ")
+	fmt.Println(string(str))
+}
+
+func printDeployPlanTable(plan *operatortypes.DeployPlan) {
+	w := NewTabWriter()
+
+	fmtColumns := "%s\t%s\t%s\t%s\t%s\n"
+	fmt.Fprintf(w, fmtColumns, "ACTION", "KIND", "NAMESPACE", "NAME", "HELM RELEASE")
+	for _, r := range plan.Resources {
+		action := string(r.Action)
+		if r.Error != "" {
+			action = fmt.Sprintf("%s (error)", action)
+		}
+		fmt.Fprintf(w, fmtColumns, action, r.Kind, r.Namespace, r.Name, r.HelmRelease)
+	}
+	w.Flush()
+
+	for _, r := range plan.Resources {
+		if len(r.Diffs) == 0 && r.Error == "" {
+			continue
+		}
+		name := r.Name
+		if r.Namespace != "" {
+			name = fmt.Sprintf("%s/%s", r.Namespace, r.Name)
+		}
+		fmt.Printf("\n%s %s:\n", r.Kind, name)
+		if r.Error != "" {
+			fmt.Printf("  error: %s\n", r.Error)
+		}
+		for _, d := range r.Diffs {
+			fmt.Printf("  %s: %s -> %s\n", d.Path, formatFieldValue(d.Old), formatFieldValue(d.New))
+		}
+	}
+
+	fmt.Printf("\nPlan: %d to create, %d to update, %d to delete, %d unchanged.\n",
+		plan.Count(operatortypes.PlanActionCreate),
+		plan.Count(operatortypes.PlanActionUpdate),
+		plan.Count(operatortypes.PlanActionDelete),
+		plan.Count(operatortypes.PlanActionUnchanged),
+	)
+}
+
+func formatFieldValue(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
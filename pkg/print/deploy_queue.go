@@ -0,0 +1,42 @@
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	operatortypes "github.com/replicatedhq/kots/pkg/operator/types"
+)
+
+func DeployQueue(deploys []operatortypes.QueuedDeploy, format string) {
+	switch format {
+	case "json":
+		printDeployQueueJSON(deploys)
+	default:
+		printDeployQueueTable(deploys)
+	}
+}
+
+func printDeployQueueJSON(deploys []operatortypes.QueuedDeploy) {
+	str, _ := json.MarshalIndent(deploys, "", "    ")
+	fmt.Println(string(str))
+}
+
+func printDeployQueueTable(deploys []operatortypes.QueuedDeploy) {
+	w := NewTabWriter()
+	defer w.Flush()
+
+	fmtColumns := "%s\t%s\t%s\t%s\t%s\t%s\n"
+	fmt.Fprintf(w, fmtColumns, "ID", "SEQUENCE", "REASON", "STATE", "QUEUED", "STARTED")
+	for _, d := range deploys {
+		sequence := fmt.Sprintf("%d", d.Sequence)
+		if d.Sequence < 0 {
+			sequence = ""
+		}
+		started := ""
+		if d.StartedAt != nil {
+			started = d.StartedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, fmtColumns, d.ID, sequence, d.Reason, d.State, d.QueuedAt.Format(time.RFC3339), started)
+	}
+}
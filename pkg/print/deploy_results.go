@@ -0,0 +1,64 @@
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+
+	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
+)
+
+func DeployResults(results *downstreamtypes.DeployResults, format string, failedOnly bool) {
+	if failedOnly {
+		failed := results.Failed()
+		results = &failed
+	}
+
+	switch format {
+	case "json":
+		printDeployResultsJSON(results)
+	default:
+		printDeployResultsTable(results)
+	}
+}
+
+func printDeployResultsJSON(results *downstreamtypes.DeployResults) {
+	str, _ := json.MarshalIndent(results, "", "    ")
+	fmt.Println(string(str))
+}
+
+func printDeployResultsTable(results *downstreamtypes.DeployResults) {
+	w := NewTabWriter()
+
+	if len(results.Resources) > 0 {
+		fmtColumns := "%s\t%s\t%s\t%s\t%s\n"
+		fmt.Fprintf(w, fmtColumns, "ACTION", "KIND", "NAMESPACE", "NAME", "ERROR")
+		for _, r := range results.Resources {
+			kind := r.Kind
+			if r.Group != "" {
+				kind = fmt.Sprintf("%s.%s", r.Kind, r.Group)
+			}
+			fmt.Fprintf(w, fmtColumns, r.Action, kind, r.Namespace, r.Name, r.Error)
+		}
+		w.Flush()
+	}
+
+	if len(results.HelmReleases) > 0 {
+		if len(results.Resources) > 0 {
+			fmt.Println()
+		}
+		fmtColumns := "%s\t%s\t%s\t%s\t%s\n"
+		fmt.Fprintf(w, fmtColumns, "ACTION", "RELEASE", "NAMESPACE", "CHART", "ERROR")
+		for _, r := range results.HelmReleases {
+			chart := r.ChartName
+			if r.ChartVersion != "" {
+				chart = fmt.Sprintf("%s-%s", r.ChartName, r.ChartVersion)
+			}
+			fmt.Fprintf(w, fmtColumns, r.Action, r.ReleaseName, r.Namespace, chart, r.Error)
+		}
+		w.Flush()
+	}
+
+	if len(results.Resources) == 0 && len(results.HelmReleases) == 0 {
+		fmt.Println("No resources or helm releases found.")
+	}
+}
@@ -0,0 +1,42 @@
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sessiontypes "github.com/replicatedhq/kots/pkg/session/types"
+)
+
+func Sessions(sessions []*sessiontypes.SessionSummary, format string) {
+	switch format {
+	case "json":
+		printSessionsJSON(sessions)
+	default:
+		printSessionsTable(sessions)
+	}
+}
+
+func printSessionsJSON(sessions []*sessiontypes.SessionSummary) {
+	str, _ := json.MarshalIndent(sessions, "", "    ")
+	fmt.Println(string(str))
+}
+
+func printSessionsTable(sessions []*sessiontypes.SessionSummary) {
+	w := NewTabWriter()
+	defer w.Flush()
+
+	fmtColumns := "%s\t%s\t%s\t%s\t%s\t%s\t%s\n"
+	fmt.Fprintf(w, fmtColumns, "ID", "USER", "METHOD", "IP ADDRESS", "ISSUED", "EXPIRES", "USER AGENT")
+	for _, s := range sessions {
+		authMethod := string(s.AuthMethod)
+		if authMethod == "" {
+			authMethod = "unknown"
+		}
+		id := s.ID
+		if s.IsCurrent {
+			id = fmt.Sprintf("%s (current)", id)
+		}
+		fmt.Fprintf(w, fmtColumns, id, s.UserID, authMethod, s.IPAddress, s.IssuedAt.Format(time.RFC3339), s.ExpiresAt.Format(time.RFC3339), s.UserAgent)
+	}
+}
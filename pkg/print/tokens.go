@@ -0,0 +1,43 @@
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	apitokentypes "github.com/replicatedhq/kots/pkg/apitoken/types"
+)
+
+func APITokens(tokens []*apitokentypes.APIToken, format string) {
+	switch format {
+	case "json":
+		printAPITokensJSON(tokens)
+	default:
+		printAPITokensTable(tokens)
+	}
+}
+
+func printAPITokensJSON(tokens []*apitokentypes.APIToken) {
+	str, _ := json.MarshalIndent(tokens, "", "    ")
+	fmt.Println(string(str))
+}
+
+func printAPITokensTable(tokens []*apitokentypes.APIToken) {
+	w := NewTabWriter()
+	defer w.Flush()
+
+	fmtColumns := "%s\t%s\t%s\t%s\t%s\n"
+	fmt.Fprintf(w, fmtColumns, "ID", "NAME", "ROLES", "EXPIRES", "LAST USED")
+	for _, token := range tokens {
+		expires := "never"
+		if token.ExpiresAt != nil {
+			expires = token.ExpiresAt.Format(time.RFC3339)
+		}
+		lastUsed := "never"
+		if token.LastUsedAt != nil {
+			lastUsed = token.LastUsedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, fmtColumns, token.ID, token.Name, strings.Join(token.Roles, ","), expires, lastUsed)
+	}
+}
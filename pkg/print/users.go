@@ -0,0 +1,39 @@
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	usertypes "github.com/replicatedhq/kots/pkg/user/types"
+)
+
+func Users(users []*usertypes.User, format string) {
+	switch format {
+	case "json":
+		printUsersJSON(users)
+	default:
+		printUsersTable(users)
+	}
+}
+
+func printUsersJSON(users []*usertypes.User) {
+	str, _ := json.MarshalIndent(users, "", "    ")
+	fmt.Println(string(str))
+}
+
+func printUsersTable(users []*usertypes.User) {
+	w := NewTabWriter()
+	defer w.Flush()
+
+	fmtColumns := "%s\t%s\t%s\t%s\n"
+	fmt.Fprintf(w, fmtColumns, "EMAIL", "NAME", "ROLES", "STATUS")
+	for _, user := range users {
+		name := strings.TrimSpace(fmt.Sprintf("%s %s", user.FirstName, user.LastName))
+		status := "enabled"
+		if user.IsDisabled {
+			status = "disabled"
+		}
+		fmt.Fprintf(w, fmtColumns, user.Email, name, strings.Join(user.Roles, ","), status)
+	}
+}
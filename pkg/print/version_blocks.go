@@ -0,0 +1,39 @@
+package print
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	apptypes "github.com/replicatedhq/kots/pkg/app/types"
+)
+
+func VersionBlocks(blocks apptypes.VersionBlocks, format string) {
+	switch format {
+	case "json":
+		printVersionBlocksJSON(blocks)
+	default:
+		printVersionBlocksTable(blocks)
+	}
+}
+
+func printVersionBlocksJSON(blocks apptypes.VersionBlocks) {
+	str, _ := json.MarshalIndent(blocks, "", "    ")
+	fmt.Println(string(str))
+}
+
+func printVersionBlocksTable(blocks apptypes.VersionBlocks) {
+	w := NewTabWriter()
+	defer w.Flush()
+
+	fmtColumns := "%s\t%s\t%s\t%s\t%s\n"
+	fmt.Fprintf(w, fmtColumns, "ID", "VERSION", "SEQUENCE", "REASON", "CREATED AT")
+	for _, block := range blocks {
+		sequence := ""
+		if block.Sequence != nil {
+			sequence = strconv.FormatInt(*block.Sequence, 10)
+		}
+		fmt.Fprintf(w, fmtColumns, block.ID, block.VersionLabel, sequence, block.Reason, block.CreatedAt.Format(time.RFC3339))
+	}
+}
@@ -15,6 +15,7 @@ type AppVersionResponse struct {
 	Source          string     `json:"source"`
 	ChannelID       string     `json:"channelId"`
 	ChannelSequence int64      `json:"channelSequence"`
+	EligibleAt      *time.Time `json:"eligibleAt,omitempty"`
 }
 
 func Versions(versions []AppVersionResponse, format string) {
@@ -35,9 +36,13 @@ func printVersionsTable(versions []AppVersionResponse) {
 	w := NewTabWriter()
 	defer w.Flush()
 
-	fmtColumns := "%s\t%v\t%s\t%s\t%s\t%v\n"
-	fmt.Fprintf(w, fmtColumns, "VERSION", "SEQUENCE", "STATUS", "SOURCE", "CHANNEL ID", "CHANNEL SEQUENCE")
+	fmtColumns := "%s\t%v\t%s\t%s\t%s\t%v\t%s\n"
+	fmt.Fprintf(w, fmtColumns, "VERSION", "SEQUENCE", "STATUS", "SOURCE", "CHANNEL ID", "CHANNEL SEQUENCE", "ELIGIBLE AT")
 	for _, version := range versions {
-		fmt.Fprintf(w, fmtColumns, version.VersionLabel, version.Sequence, version.Status, version.Source, version.ChannelID, version.ChannelSequence)
+		eligibleAt := ""
+		if version.EligibleAt != nil {
+			eligibleAt = version.EligibleAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, fmtColumns, version.VersionLabel, version.Sequence, version.Status, version.Source, version.ChannelID, version.ChannelSequence, eligibleAt)
 	}
 }
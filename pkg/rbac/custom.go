@@ -0,0 +1,131 @@
+package rbac
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gobwas/glob"
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/rbac/types"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// CustomRolesConfigMapName is the name of the kotsadm configmap that custom roles are loaded from
+	CustomRolesConfigMapName = "kotsadm-rbac-roles"
+	// CustomRolesConfigMapKey is the key in the configmap data that holds the yaml list of roles
+	CustomRolesConfigMapKey = "roles.yaml"
+)
+
+var (
+	customRoles     []types.Role
+	customRolesLock sync.RWMutex
+)
+
+// Roles returns the default roles followed by any custom roles that have been loaded
+func Roles() []types.Role {
+	customRolesLock.RLock()
+	defer customRolesLock.RUnlock()
+
+	roles := DefaultRoles()
+	roles = append(roles, customRoles...)
+	return roles
+}
+
+// CustomRoles returns the custom roles that have been loaded
+func CustomRoles() []types.Role {
+	customRolesLock.RLock()
+	defer customRolesLock.RUnlock()
+
+	return append([]types.Role{}, customRoles...)
+}
+
+// IsDefaultRole returns true if the role ID is one of the built in roles
+func IsDefaultRole(roleID string) bool {
+	for _, role := range DefaultRoles() {
+		if role.ID == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+// SetCustomRoles replaces the custom roles after validating them. The current roles are
+// left unchanged if validation fails.
+func SetCustomRoles(roles []types.Role) error {
+	if err := ValidateCustomRoles(roles); err != nil {
+		return err
+	}
+
+	customRolesLock.Lock()
+	defer customRolesLock.Unlock()
+
+	customRoles = roles
+	return nil
+}
+
+// ParseCustomRoles parses and validates a yaml list of roles
+func ParseCustomRoles(data []byte) ([]types.Role, error) {
+	roles := []types.Role{}
+	if err := yaml.UnmarshalStrict(data, &roles); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal roles")
+	}
+
+	if err := ValidateCustomRoles(roles); err != nil {
+		return nil, err
+	}
+
+	return roles, nil
+}
+
+// ValidateCustomRoles checks that every role has a unique ID that does not shadow a default
+// role, and that every policy has an action and a resource that compile as glob patterns
+func ValidateCustomRoles(roles []types.Role) error {
+	seen := map[string]bool{}
+	for i, role := range roles {
+		if role.ID == "" {
+			return errors.Errorf("role at index %d is missing an id", i)
+		}
+		if IsDefaultRole(role.ID) {
+			return errors.Errorf("role %q conflicts with a default role", role.ID)
+		}
+		if seen[role.ID] {
+			return errors.Errorf("role %q is defined more than once", role.ID)
+		}
+		seen[role.ID] = true
+
+		if len(role.Allow) == 0 {
+			return errors.Errorf("role %q must allow at least one policy", role.ID)
+		}
+		if err := validatePolicies(role.Allow); err != nil {
+			return errors.Wrapf(err, "role %q allow", role.ID)
+		}
+		if err := validatePolicies(role.Deny); err != nil {
+			return errors.Wrapf(err, "role %q deny", role.ID)
+		}
+	}
+	return nil
+}
+
+func validatePolicies(policies []types.Policy) error {
+	for i, policy := range policies {
+		name := policy.Name
+		if name == "" {
+			name = fmt.Sprintf("%d", i)
+		}
+		if policy.Action == "" {
+			return errors.Errorf("policy %s is missing an action", name)
+		}
+		if policy.Resource == "" {
+			return errors.Errorf("policy %s is missing a resource", name)
+		}
+		// rego's glob.match uses "." as the default delimiter
+		if _, err := glob.Compile(policy.Action, '.'); err != nil {
+			return errors.Wrapf(err, "policy %s has an invalid action", name)
+		}
+		if _, err := glob.Compile(policy.Resource, '.'); err != nil {
+			return errors.Wrapf(err, "policy %s has an invalid resource", name)
+		}
+	}
+	return nil
+}
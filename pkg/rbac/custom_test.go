@@ -0,0 +1,146 @@
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCustomRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantIDs []string
+		wantErr bool
+	}{
+		{
+			name: "snapshot operator",
+			data: `
+- id: snapshot-operator
+  name: Snapshot Operator
+  allow:
+  - action: "**"
+    resource: backup.*
+  - action: read
+    resource: "**"
+`,
+			wantIDs: []string{"snapshot-operator"},
+		},
+		{
+			name:    "empty",
+			data:    ``,
+			wantIDs: []string{},
+		},
+		{
+			name: "missing id",
+			data: `
+- name: No ID
+  allow:
+  - action: read
+    resource: "**"
+`,
+			wantErr: true,
+		},
+		{
+			name: "shadows default role",
+			data: `
+- id: cluster-admin
+  allow:
+  - action: read
+    resource: "**"
+`,
+			wantErr: true,
+		},
+		{
+			name: "duplicate id",
+			data: `
+- id: viewer
+  allow:
+  - action: read
+    resource: "**"
+- id: viewer
+  allow:
+  - action: read
+    resource: "**"
+`,
+			wantErr: true,
+		},
+		{
+			name: "no allow policies",
+			data: `
+- id: nothing
+  deny:
+  - action: "**"
+    resource: "**"
+`,
+			wantErr: true,
+		},
+		{
+			name: "invalid glob",
+			data: `
+- id: broken
+  allow:
+  - action: read
+    resource: "app.[a"
+`,
+			wantErr: true,
+		},
+		{
+			name: "unknown field",
+			data: `
+- id: typo
+  alow:
+  - action: read
+    resource: "**"
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles, err := ParseCustomRoles([]byte(tt.data))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			ids := []string{}
+			for _, role := range roles {
+				ids = append(ids, role.ID)
+			}
+			assert.Equal(t, tt.wantIDs, ids)
+		})
+	}
+}
+
+func TestSetCustomRoles(t *testing.T) {
+	defer SetCustomRoles(nil)
+
+	roles, err := ParseCustomRoles([]byte(`
+- id: snapshot-operator
+  allow:
+  - action: "**"
+    resource: backup.*
+  - action: read
+    resource: "**"
+`))
+	require.NoError(t, err)
+	require.NoError(t, SetCustomRoles(roles))
+
+	assert.Len(t, Roles(), len(DefaultRoles())+1)
+
+	allow, err := CheckAccess(context.Background(), Roles(), "write", "backup.my-backup", []string{"snapshot-operator"})
+	require.NoError(t, err)
+	assert.True(t, allow)
+
+	allow, err = CheckAccess(context.Background(), Roles(), "write", "app.my-app", []string{"snapshot-operator"})
+	require.NoError(t, err)
+	assert.False(t, allow)
+
+	// invalid roles do not replace the loaded roles
+	require.Error(t, SetCustomRoles(DefaultRoles()))
+	assert.Len(t, CustomRoles(), 1)
+}
@@ -3,6 +3,7 @@ package session
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/apitoken"
 	"github.com/replicatedhq/kots/pkg/identity"
 	"github.com/replicatedhq/kots/pkg/k8sutil"
 	"github.com/replicatedhq/kots/pkg/session/types"
@@ -21,6 +23,8 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const twoFactorPurpose = "2fa"
+
 func Parse(kotsStore store.Store, signedToken string) (*types.Session, error) {
 	if signedToken == "" {
 		return nil, errors.New("missing token")
@@ -70,6 +74,26 @@ func Parse(kotsStore store.Store, signedToken string) (*types.Session, error) {
 		return &s, nil
 	}
 
+	if apitoken.IsAPIToken(tokenParts[1]) {
+		// api tokens are long-lived credentials for automation. there is no stored session for them,
+		// so a short-lived session carrying the token's roles is returned, like with the kots cli token
+		apiToken, err := apitoken.Authenticate(kotsStore, tokenParts[1])
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to authenticate api token")
+		}
+
+		s := types.Session{
+			ID:         fmt.Sprintf("apitoken-%s", apiToken.ID),
+			IssuedAt:   time.Now(),
+			ExpiresAt:  time.Now().Add(time.Minute),
+			Roles:      apiToken.Roles,
+			HasRBAC:    true,
+			APITokenID: apiToken.ID,
+		}
+
+		return &s, nil
+	}
+
 	token, err := jwt.Parse(tokenParts[1], func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
@@ -84,7 +108,11 @@ func Parse(kotsStore store.Store, signedToken string) (*types.Session, error) {
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		return kotsStore.GetSession(claims["sessionId"].(string))
+		sessionID, ok := claims["sessionId"].(string)
+		if !ok {
+			return nil, errors.New("no session id in jwt token")
+		}
+		return kotsStore.GetSession(sessionID)
 	}
 
 	return nil, errors.New("not a valid jwt token")
@@ -102,6 +130,49 @@ func SignJWT(s *types.Session) (string, error) {
 	return signedToken, nil
 }
 
+// SignTwoFactorJWT returns a short-lived token proving that the user's password has been verified.
+// It is exchanged for a session once the second factor has been verified and cannot be used as a session token.
+func SignTwoFactorJWT(userID string, expiresAt time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"userId":  userID,
+		"purpose": twoFactorPurpose,
+		"exp":     expiresAt.Unix(),
+	})
+	signedToken, err := token.SignedString([]byte(os.Getenv("SESSION_KEY")))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to sign jwt")
+	}
+
+	return signedToken, nil
+}
+
+// ParseTwoFactorJWT validates a token created by SignTwoFactorJWT and returns the user ID
+func ParseTwoFactorJWT(signedToken string) (string, error) {
+	token, err := jwt.Parse(signedToken, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(os.Getenv("SESSION_KEY")), nil
+	}, jwt.WithExpirationRequired())
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse jwt token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return "", errors.New("not a valid jwt token")
+	}
+	if purpose, _ := claims["purpose"].(string); purpose != twoFactorPurpose {
+		return "", errors.New("not a two-factor token")
+	}
+	userID, ok := claims["userId"].(string)
+	if !ok || userID == "" {
+		return "", errors.New("no user id in jwt token")
+	}
+
+	return userID, nil
+}
+
 func GetSessionRolesFromRBAC(sessionGroupIDs []string, groups []kotsv1beta1.IdentityConfigGroup) []string {
 	var sessionRolesIDs []string
 	for _, group := range groups {
@@ -119,6 +190,24 @@ func GetSessionRolesFromRBAC(sessionGroupIDs []string, groups []kotsv1beta1.Iden
 	return sessionRolesIDs
 }
 
+// GetSessionSource returns the client details recorded with a new session. The ip address is taken
+// from X-Forwarded-For when the admin console is behind a proxy, so it is informational only.
+func GetSessionSource(r *http.Request, authMethod types.AuthMethod) types.Source {
+	ipAddress := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		ipAddress = host
+	}
+	if forwardedFor := r.Header.Get("X-Forwarded-For"); forwardedFor != "" {
+		ipAddress = strings.TrimSpace(strings.Split(forwardedFor, ",")[0])
+	}
+
+	return types.Source{
+		AuthMethod: authMethod,
+		IPAddress:  ipAddress,
+		UserAgent:  r.UserAgent(),
+	}
+}
+
 func GetSessionCookie(responseToken string, expirationTime time.Time, origin string) (*http.Cookie, error) {
 	sessionCookie := http.Cookie{
 		Name:     "signed-token",
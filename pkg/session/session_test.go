@@ -0,0 +1,69 @@
+package session
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/replicatedhq/kots/pkg/session/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSessionSource(t *testing.T) {
+	tests := []struct {
+		name          string
+		remoteAddr    string
+		forwardedFor  string
+		wantIPAddress string
+	}{
+		{
+			name:          "remote addr",
+			remoteAddr:    "10.0.0.1:51234",
+			wantIPAddress: "10.0.0.1",
+		},
+		{
+			name:          "forwarded for takes the client address",
+			remoteAddr:    "10.0.0.1:51234",
+			forwardedFor:  "203.0.113.7, 10.0.0.2",
+			wantIPAddress: "203.0.113.7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/api/v1/login", nil)
+			r.RemoteAddr = tt.remoteAddr
+			r.Header.Set("User-Agent", "test-agent")
+			if tt.forwardedFor != "" {
+				r.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+
+			source := GetSessionSource(r, types.AuthMethodPassword)
+			assert.Equal(t, tt.wantIPAddress, source.IPAddress)
+			assert.Equal(t, "test-agent", source.UserAgent)
+			assert.Equal(t, types.AuthMethodPassword, source.AuthMethod)
+		})
+	}
+}
+
+func TestParseTwoFactorJWT(t *testing.T) {
+	t.Setenv("SESSION_KEY", "test-key")
+
+	token, err := SignTwoFactorJWT("user-1", time.Now().Add(time.Minute))
+	require.NoError(t, err)
+
+	userID, err := ParseTwoFactorJWT(token)
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", userID)
+
+	expired, err := SignTwoFactorJWT("user-1", time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	_, err = ParseTwoFactorJWT(expired)
+	assert.Error(t, err)
+
+	// a session token cannot be used as a two-factor token
+	sessionToken, err := SignJWT(&types.Session{ID: "session-1"})
+	require.NoError(t, err)
+	_, err = ParseTwoFactorJWT(sessionToken)
+	assert.Error(t, err)
+}
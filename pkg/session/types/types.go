@@ -2,10 +2,46 @@ package types
 
 import "time"
 
+type AuthMethod string
+
+const (
+	AuthMethodPassword AuthMethod = "password"
+	AuthMethodOIDC     AuthMethod = "oidc"
+
+	// SessionTimeout is how long a session is valid for after it was issued or last refreshed
+	SessionTimeout = time.Hour * 12
+)
+
 type Session struct {
 	ID        string
+	UserID    string
 	IssuedAt  time.Time
 	ExpiresAt time.Time
 	Roles     []string
 	HasRBAC   bool
+	// APITokenID is set when the request was authenticated with an api token rather than a login session
+	APITokenID string
+	// Source is empty for sessions created before it was recorded
+	Source
+}
+
+// Source describes how and from where a session was created
+type Source struct {
+	AuthMethod AuthMethod
+	IPAddress  string
+	UserAgent  string
+}
+
+// SessionSummary is the view of a stored session returned by the sessions api. The session id
+// cannot be used to authenticate on its own, since session tokens are signed with the session key.
+type SessionSummary struct {
+	ID         string     `json:"id"`
+	UserID     string     `json:"userId"`
+	IssuedAt   time.Time  `json:"issuedAt"`
+	ExpiresAt  time.Time  `json:"expiresAt"`
+	Roles      []string   `json:"roles"`
+	AuthMethod AuthMethod `json:"authMethod,omitempty"`
+	IPAddress  string     `json:"ipAddress,omitempty"`
+	UserAgent  string     `json:"userAgent,omitempty"`
+	IsCurrent  bool       `json:"isCurrent"`
 }
@@ -2,6 +2,7 @@ package snapshotscheduler
 
 import (
 	"context"
+	"fmt"
 	"strings"
 	"time"
 
@@ -11,6 +12,8 @@ import (
 	snapshot "github.com/replicatedhq/kots/pkg/kotsadmsnapshot"
 	snapshottypes "github.com/replicatedhq/kots/pkg/kotsadmsnapshot/types"
 	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/notifications"
+	notificationtypes "github.com/replicatedhq/kots/pkg/notifications/types"
 	"github.com/replicatedhq/kots/pkg/store"
 	"github.com/replicatedhq/kots/pkg/util"
 	"k8s.io/apimachinery/pkg/util/rand"
@@ -122,6 +125,12 @@ func handleApp(a *apptypes.App) error {
 
 	backup, err := snapshot.CreateApplicationBackup(context.Background(), a, true)
 	if err != nil {
+		notifications.Notify(notificationtypes.Event{
+			Type:    notificationtypes.EventBackupFailed,
+			AppID:   a.ID,
+			AppSlug: a.Slug,
+			Message: fmt.Sprintf("Failed to create scheduled backup: %s", err.Error()),
+		})
 		return errors.Wrap(err, "failed to create backup")
 	}
 
@@ -0,0 +1,138 @@
+package kotsstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	apitokentypes "github.com/replicatedhq/kots/pkg/apitoken/types"
+	"github.com/replicatedhq/kots/pkg/persistence"
+	"github.com/rqlite/gorqlite"
+	"github.com/segmentio/ksuid"
+)
+
+func (s *KOTSStore) CreateAPIToken(name string, tokenSHA256 string, roles []string, createdBy string, expiresAt *time.Time) (*apitokentypes.APIToken, error) {
+	db := persistence.MustGetDBSession()
+
+	marshalledRoles, err := json.Marshal(roles)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal roles")
+	}
+
+	var expiresAtUnix interface{}
+	if expiresAt != nil {
+		expiresAtUnix = expiresAt.Unix()
+	}
+
+	token := apitokentypes.APIToken{
+		ID:        ksuid.New().String(),
+		Name:      name,
+		Roles:     roles,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+		ExpiresAt: expiresAt,
+	}
+
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `insert into api_token (id, name, token_sha256, roles, created_by, created_at, expires_at) values (?, ?, ?, ?, ?, ?, ?)`,
+		Arguments: []interface{}{token.ID, name, tokenSHA256, string(marshalledRoles), createdBy, token.CreatedAt.Unix(), expiresAtUnix},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return &token, nil
+}
+
+func (s *KOTSStore) GetAPITokenBySHA256(tokenSHA256 string) (*apitokentypes.APIToken, error) {
+	tokens, err := s.listAPITokens(`where token_sha256 = ?`, tokenSHA256)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, ErrNotFound
+	}
+	return tokens[0], nil
+}
+
+func (s *KOTSStore) ListAPITokens() ([]*apitokentypes.APIToken, error) {
+	return s.listAPITokens("")
+}
+
+func (s *KOTSStore) UpdateAPITokenLastUsedAt(tokenID string, lastUsedAt time.Time) error {
+	db := persistence.MustGetDBSession()
+
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `update api_token set last_used_at = ? where id = ?`,
+		Arguments: []interface{}{lastUsedAt.Unix(), tokenID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return nil
+}
+
+func (s *KOTSStore) DeleteAPIToken(tokenID string) error {
+	db := persistence.MustGetDBSession()
+
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `delete from api_token where id = ?`,
+		Arguments: []interface{}{tokenID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+	if wr.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *KOTSStore) listAPITokens(where string, args ...interface{}) ([]*apitokentypes.APIToken, error) {
+	db := persistence.MustGetDBSession()
+
+	query := fmt.Sprintf(`select id, name, roles, created_by, created_at, expires_at, last_used_at from api_token %s order by created_at`, where)
+	rows, err := db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: args,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %v: %v", err, rows.Err)
+	}
+
+	tokens := []*apitokentypes.APIToken{}
+	for rows.Next() {
+		token := apitokentypes.APIToken{}
+
+		var rolesStr string
+		var createdBy gorqlite.NullString
+		var createdAt int64
+		var expiresAt gorqlite.NullInt64
+		var lastUsedAt gorqlite.NullInt64
+		if err := rows.Scan(&token.ID, &token.Name, &rolesStr, &createdBy, &createdAt, &expiresAt, &lastUsedAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+
+		if err := json.Unmarshal([]byte(rolesStr), &token.Roles); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal roles")
+		}
+
+		token.CreatedBy = createdBy.String
+		token.CreatedAt = time.Unix(createdAt, 0)
+		if expiresAt.Valid {
+			t := time.Unix(expiresAt.Int64, 0)
+			token.ExpiresAt = &t
+		}
+		if lastUsedAt.Valid {
+			t := time.Unix(lastUsedAt.Int64, 0)
+			token.LastUsedAt = &t
+		}
+
+		tokens = append(tokens, &token)
+	}
+
+	return tokens, nil
+}
@@ -150,7 +150,7 @@ func (s *KOTSStore) GetAppIDFromSlug(slug string) (string, error) {
 
 func (s *KOTSStore) GetApp(id string) (*apptypes.App, error) {
 	db := persistence.MustGetDBSession()
-	query := `select id, name, license, upstream_uri, icon_uri, created_at, updated_at, slug, current_sequence, last_update_check_at, last_license_sync, is_airgap, snapshot_ttl_new, snapshot_schedule, restore_in_progress_name, restore_undeploy_status, update_checker_spec, semver_auto_deploy, install_state, channel_changed, selected_channel_id from app where id = ?`
+	query := `select id, name, license, upstream_uri, icon_uri, created_at, updated_at, slug, current_sequence, last_update_check_at, last_license_sync, is_airgap, snapshot_ttl_new, snapshot_schedule, restore_in_progress_name, restore_undeploy_status, update_checker_spec, semver_auto_deploy, deploy_window, scheduled_deploy, soak_duration, health_gate, drift_detection, install_state, channel_changed, selected_channel_id from app where id = ?`
 	rows, err := db.QueryOneParameterized(gorqlite.ParameterizedStatement{
 		Query:     query,
 		Arguments: []interface{}{id},
@@ -177,9 +177,14 @@ func (s *KOTSStore) GetApp(id string) (*apptypes.App, error) {
 	var restoreUndeployStatus gorqlite.NullString
 	var updateCheckerSpec gorqlite.NullString
 	var autoDeploy gorqlite.NullString
+	var deployWindow gorqlite.NullString
+	var scheduledDeploy gorqlite.NullString
+	var soakDuration gorqlite.NullString
+	var healthGate gorqlite.NullString
+	var driftDetection gorqlite.NullString
 	var selectedChannelId gorqlite.NullString
 
-	if err := rows.Scan(&app.ID, &app.Name, &licenseStr, &upstreamURI, &iconURI, &app.CreatedAt, &updatedAt, &app.Slug, &currentSequence, &lastUpdateCheckAt, &lastLicenseSync, &app.IsAirgap, &snapshotTTLNew, &snapshotSchedule, &restoreInProgressName, &restoreUndeployStatus, &updateCheckerSpec, &autoDeploy, &app.InstallState, &app.ChannelChanged, &selectedChannelId); err != nil {
+	if err := rows.Scan(&app.ID, &app.Name, &licenseStr, &upstreamURI, &iconURI, &app.CreatedAt, &updatedAt, &app.Slug, &currentSequence, &lastUpdateCheckAt, &lastLicenseSync, &app.IsAirgap, &snapshotTTLNew, &snapshotSchedule, &restoreInProgressName, &restoreUndeployStatus, &updateCheckerSpec, &autoDeploy, &deployWindow, &scheduledDeploy, &soakDuration, &healthGate, &driftDetection, &app.InstallState, &app.ChannelChanged, &selectedChannelId); err != nil {
 		return nil, errors.Wrap(err, "failed to scan app")
 	}
 
@@ -193,6 +198,35 @@ func (s *KOTSStore) GetApp(id string) (*apptypes.App, error) {
 	app.UpdateCheckerSpec = updateCheckerSpec.String
 	app.AutoDeploy = apptypes.AutoDeploy(autoDeploy.String)
 	app.SelectedChannelID = selectedChannelId.String
+	app.SoakDuration = soakDuration.String
+
+	if deployWindow.String != "" {
+		app.DeployWindow = &apptypes.DeployWindow{}
+		if err := json.Unmarshal([]byte(deployWindow.String), app.DeployWindow); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal deploy window")
+		}
+	}
+
+	if scheduledDeploy.String != "" {
+		app.ScheduledDeploy = &apptypes.ScheduledDeploy{}
+		if err := json.Unmarshal([]byte(scheduledDeploy.String), app.ScheduledDeploy); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal scheduled deploy")
+		}
+	}
+
+	if healthGate.String != "" {
+		app.HealthGate = &apptypes.HealthGate{}
+		if err := json.Unmarshal([]byte(healthGate.String), app.HealthGate); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal health gate")
+		}
+	}
+
+	if driftDetection.String != "" {
+		app.DriftDetection = &apptypes.DriftDetection{}
+		if err := json.Unmarshal([]byte(driftDetection.String), app.DriftDetection); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal drift detection")
+		}
+	}
 
 	if lastLicenseSync.Valid {
 		app.LastLicenseSync = lastLicenseSync.Time.Format(time.RFC3339)
@@ -509,6 +543,132 @@ func (s *KOTSStore) SetAutoDeploy(appID string, autoDeploy apptypes.AutoDeploy)
 	return nil
 }
 
+// SetDeployWindow sets the window in which automatic deployments are allowed. A nil window allows them at any time.
+func (s *KOTSStore) SetDeployWindow(appID string, deployWindow *apptypes.DeployWindow) error {
+	logger.Debug("setting deploy window",
+		zap.String("appID", appID))
+
+	var deployWindowStr interface{}
+	if deployWindow != nil {
+		b, err := json.Marshal(deployWindow)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal deploy window")
+		}
+		deployWindowStr = string(b)
+	}
+
+	db := persistence.MustGetDBSession()
+	query := `update app set deploy_window = ? where id = ?`
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: []interface{}{deployWindowStr, appID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return nil
+}
+
+// SetSoakDuration sets how long a release must have been published before it is deployed automatically
+func (s *KOTSStore) SetSoakDuration(appID string, soakDuration string) error {
+	logger.Debug("setting soak duration",
+		zap.String("appID", appID))
+
+	db := persistence.MustGetDBSession()
+	query := `update app set soak_duration = ? where id = ?`
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: []interface{}{soakDuration, appID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return nil
+}
+
+// SetHealthGate sets the post-deploy health gate for the app. A nil value disables automatic rollbacks.
+func (s *KOTSStore) SetHealthGate(appID string, healthGate *apptypes.HealthGate) error {
+	logger.Debug("setting health gate",
+		zap.String("appID", appID))
+
+	var healthGateStr interface{}
+	if healthGate != nil {
+		b, err := json.Marshal(healthGate)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal health gate")
+		}
+		healthGateStr = string(b)
+	}
+
+	db := persistence.MustGetDBSession()
+	query := `update app set health_gate = ? where id = ?`
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: []interface{}{healthGateStr, appID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return nil
+}
+
+// SetDriftDetection sets the drift detection settings for the app. A nil value restores the defaults.
+func (s *KOTSStore) SetDriftDetection(appID string, driftDetection *apptypes.DriftDetection) error {
+	logger.Debug("setting drift detection",
+		zap.String("appID", appID))
+
+	var driftDetectionStr interface{}
+	if driftDetection != nil {
+		b, err := json.Marshal(driftDetection)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal drift detection")
+		}
+		driftDetectionStr = string(b)
+	}
+
+	db := persistence.MustGetDBSession()
+	query := `update app set drift_detection = ? where id = ?`
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: []interface{}{driftDetectionStr, appID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return nil
+}
+
+// SetScheduledDeploy sets the version that is waiting for the deploy window to open. A nil value clears it.
+func (s *KOTSStore) SetScheduledDeploy(appID string, scheduledDeploy *apptypes.ScheduledDeploy) error {
+	logger.Debug("setting scheduled deploy",
+		zap.String("appID", appID))
+
+	var scheduledDeployStr interface{}
+	if scheduledDeploy != nil {
+		b, err := json.Marshal(scheduledDeploy)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal scheduled deploy")
+		}
+		scheduledDeployStr = string(b)
+	}
+
+	db := persistence.MustGetDBSession()
+	query := `update app set scheduled_deploy = ? where id = ?`
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: []interface{}{scheduledDeployStr, appID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return nil
+}
+
 func (s *KOTSStore) SetSnapshotTTL(appID string, snapshotTTL string) error {
 	logger.Debug("Setting snapshot TTL",
 		zap.String("appID", appID))
@@ -633,6 +793,11 @@ func (s *KOTSStore) RemoveApp(appID string) error {
 		Arguments: []interface{}{appID},
 	})
 
+	statements = append(statements, gorqlite.ParameterizedStatement{
+		Query:     "delete from app_status_history where app_id = ?",
+		Arguments: []interface{}{appID},
+	})
+
 	statements = append(statements, gorqlite.ParameterizedStatement{
 		Query:     "delete from app_downstream_output where app_id = ?",
 		Arguments: []interface{}{appID},
@@ -663,6 +828,21 @@ func (s *KOTSStore) RemoveApp(appID string) error {
 		Arguments: []interface{}{appID},
 	})
 
+	statements = append(statements, gorqlite.ParameterizedStatement{
+		Query:     "delete from app_version_block where app_id = ?",
+		Arguments: []interface{}{appID},
+	})
+
+	statements = append(statements, gorqlite.ParameterizedStatement{
+		Query:     "delete from notification_delivery where app_id = ?",
+		Arguments: []interface{}{appID},
+	})
+
+	statements = append(statements, gorqlite.ParameterizedStatement{
+		Query:     "delete from notification_sink where app_id = ?",
+		Arguments: []interface{}{appID},
+	})
+
 	statements = append(statements, gorqlite.ParameterizedStatement{
 		Query:     "delete from app where id = ?",
 		Arguments: []interface{}{appID},
@@ -9,11 +9,12 @@ import (
 	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
 	"github.com/replicatedhq/kots/pkg/persistence"
 	"github.com/rqlite/gorqlite"
+	"github.com/segmentio/ksuid"
 )
 
 func (s *KOTSStore) GetAppStatus(appID string) (*appstatetypes.AppStatus, error) {
 	db := persistence.MustGetDBSession()
-	query := `select resource_states, updated_at, sequence from app_status where app_id = ?`
+	query := `select resource_states, updated_at, sequence, drift from app_status where app_id = ?`
 	rows, err := db.QueryOneParameterized(gorqlite.ParameterizedStatement{
 		Query:     query,
 		Arguments: []interface{}{appID},
@@ -35,8 +36,9 @@ func (s *KOTSStore) GetAppStatus(appID string) (*appstatetypes.AppStatus, error)
 	var updatedAt gorqlite.NullTime
 	var resourceStatesStr gorqlite.NullString
 	var sequence gorqlite.NullInt64
+	var driftStr gorqlite.NullString
 
-	if err := rows.Scan(&resourceStatesStr, &updatedAt, &sequence); err != nil {
+	if err := rows.Scan(&resourceStatesStr, &updatedAt, &sequence, &driftStr); err != nil {
 		return nil, errors.Wrap(err, "failed to scan")
 	}
 
@@ -57,18 +59,35 @@ func (s *KOTSStore) GetAppStatus(appID string) (*appstatetypes.AppStatus, error)
 		appStatus.ResourceStates = resourceStates
 	}
 
+	if driftStr.Valid && driftStr.String != "" {
+		var drift appstatetypes.DriftStatus
+		if err := json.Unmarshal([]byte(driftStr.String), &drift); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal drift")
+		}
+		appStatus.Drift = &drift
+	}
+
 	appStatus.State = appstatetypes.GetState(appStatus.ResourceStates)
 
 	return &appStatus, nil
 }
 
+// SetAppStatus sets the current status of the app, and appends any state transitions from the previous
+// status to the app status history
 func (s *KOTSStore) SetAppStatus(appID string, resourceStates appstatetypes.ResourceStates, updatedAt time.Time, sequence int64) error {
+	currentAppStatus, err := s.GetAppStatus(appID)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current app status")
+	}
+
 	marshalledResourceStates, err := json.Marshal(resourceStates)
 	if err != nil {
 		return errors.Wrap(err, "failed to json marshal resource states")
 	}
 
 	db := persistence.MustGetDBSession()
+	statements := []gorqlite.ParameterizedStatement{}
+
 	query := `
 	insert into app_status (app_id, resource_states, updated_at, sequence)
 	values (?, ?, ?, ?)
@@ -76,10 +95,120 @@ func (s *KOTSStore) SetAppStatus(appID string, resourceStates appstatetypes.Reso
 	  resource_states = EXCLUDED.resource_states,
 	  updated_at = EXCLUDED.updated_at,
 	  sequence = EXCLUDED.sequence`
-	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+	statements = append(statements, gorqlite.ParameterizedStatement{
 		Query:     query,
 		Arguments: []interface{}{appID, string(marshalledResourceStates), updatedAt.Unix(), sequence},
 	})
+
+	transitions := appstatetypes.GetStateTransitions(currentAppStatus.ResourceStates, resourceStates, sequence, updatedAt)
+	for _, t := range transitions {
+		statements = append(statements, gorqlite.ParameterizedStatement{
+			Query: `insert into app_status_history (id, app_id, created_at, kind, namespace, name, state, reason, message, sequence)
+			values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+			Arguments: []interface{}{ksuid.New().String(), appID, t.CreatedAt.Unix(), t.Kind, t.Namespace, t.Name, string(t.State), t.Reason, t.Message, t.Sequence},
+		})
+	}
+
+	if wrs, err := db.WriteParameterized(statements); err != nil {
+		wrErrs := []error{}
+		for _, wr := range wrs {
+			wrErrs = append(wrErrs, wr.Err)
+		}
+		return fmt.Errorf("failed to write: %v: %v", err, wrErrs)
+	}
+
+	return nil
+}
+
+// ListAppStatusTransitions returns the state transitions of the app and its resources between start and end
+// in chronological order, along with the last transition before start of the app and of each resource
+func (s *KOTSStore) ListAppStatusTransitions(appID string, start time.Time, end time.Time) ([]appstatetypes.StateTransition, error) {
+	db := persistence.MustGetDBSession()
+	query := `select h.kind, h.namespace, h.name, h.state, h.reason, h.message, h.sequence, h.created_at
+	from app_status_history h
+	where h.app_id = ? and h.created_at <= ? and (
+	  h.created_at >= ? or h.created_at = (
+	    select max(l.created_at) from app_status_history l
+	    where l.app_id = h.app_id and l.kind = h.kind and l.namespace = h.namespace and l.name = h.name and l.created_at < ?
+	  )
+	)
+	order by h.created_at, h.id`
+	rows, err := db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: []interface{}{appID, end.Unix(), start.Unix(), start.Unix()},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %v: %v", err, rows.Err)
+	}
+
+	transitions := []appstatetypes.StateTransition{}
+	for rows.Next() {
+		t := appstatetypes.StateTransition{}
+
+		var state string
+		var reason, message gorqlite.NullString
+		var sequence gorqlite.NullInt64
+		var createdAt int64
+		if err := rows.Scan(&t.Kind, &t.Namespace, &t.Name, &state, &reason, &message, &sequence, &createdAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+
+		t.State = appstatetypes.State(state)
+		t.Reason = reason.String
+		t.Message = message.String
+		t.Sequence = sequence.Int64
+		t.CreatedAt = time.Unix(createdAt, 0)
+
+		transitions = append(transitions, t)
+	}
+
+	return transitions, nil
+}
+
+// DeleteAppStatusHistoryBefore deletes the state transitions older than before. The last transition of the app
+// and of each resource is kept, since it is the state they are still in.
+func (s *KOTSStore) DeleteAppStatusHistoryBefore(before time.Time) error {
+	db := persistence.MustGetDBSession()
+	query := `delete from app_status_history
+	where created_at < ? and id not in (
+	  select h.id from app_status_history h
+	  where h.created_at = (
+	    select max(l.created_at) from app_status_history l
+	    where l.app_id = h.app_id and l.kind = h.kind and l.namespace = h.namespace and l.name = h.name
+	  )
+	)`
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: []interface{}{before.Unix()},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return nil
+}
+
+// SetAppDriftStatus sets the result of the last drift check for the app. A nil value clears it.
+func (s *KOTSStore) SetAppDriftStatus(appID string, drift *appstatetypes.DriftStatus) error {
+	var driftStr interface{}
+	if drift != nil {
+		b, err := json.Marshal(drift)
+		if err != nil {
+			return errors.Wrap(err, "failed to json marshal drift")
+		}
+		driftStr = string(b)
+	}
+
+	db := persistence.MustGetDBSession()
+	query := `
+	insert into app_status (app_id, drift)
+	values (?, ?)
+	on conflict (app_id) do update set
+	  drift = EXCLUDED.drift`
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: []interface{}{appID, driftStr},
+	})
 	if err != nil {
 		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
 	}
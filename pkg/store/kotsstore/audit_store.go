@@ -0,0 +1,164 @@
+package kotsstore
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	audittypes "github.com/replicatedhq/kots/pkg/audit/types"
+	"github.com/replicatedhq/kots/pkg/persistence"
+	"github.com/rqlite/gorqlite"
+	"github.com/segmentio/ksuid"
+)
+
+func (s *KOTSStore) CreateAuditEvent(event *audittypes.Event) error {
+	db := persistence.MustGetDBSession()
+
+	if event.ID == "" {
+		event.ID = ksuid.New().String()
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	marshalledRoles, err := json.Marshal(event.Roles)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal roles")
+	}
+
+	var sequence interface{}
+	if event.Sequence != nil {
+		sequence = *event.Sequence
+	}
+
+	query := `insert into audit_log (id, created_at, user_id, session_id, api_token_id, roles, action, resource, route_name, method, path, app_slug, sequence, status_code, outcome)
+	values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query: query,
+		Arguments: []interface{}{
+			event.ID,
+			event.CreatedAt.Unix(),
+			event.UserID,
+			event.SessionID,
+			event.APITokenID,
+			string(marshalledRoles),
+			event.Action,
+			event.Resource,
+			event.RouteName,
+			event.Method,
+			event.Path,
+			event.AppSlug,
+			sequence,
+			event.StatusCode,
+			string(event.Outcome),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return nil
+}
+
+func (s *KOTSStore) ListAuditEvents(opts audittypes.ListOptions) (*audittypes.EventList, error) {
+	db := persistence.MustGetDBSession()
+
+	conditions := []string{}
+	args := []interface{}{}
+	if opts.Since != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, opts.Since.Unix())
+	}
+	if opts.AppSlug != "" {
+		conditions = append(conditions, "app_slug = ?")
+		args = append(args, opts.AppSlug)
+	}
+	where := ""
+	if len(conditions) > 0 {
+		where = "where " + strings.Join(conditions, " and ")
+	}
+
+	rows, err := db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     fmt.Sprintf(`select count(1) from audit_log %s`, where),
+		Arguments: args,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %v: %v", err, rows.Err)
+	}
+	if !rows.Next() {
+		return nil, ErrNotFound
+	}
+
+	eventList := &audittypes.EventList{
+		Events: []*audittypes.Event{},
+	}
+	if err := rows.Scan(&eventList.TotalCount); err != nil {
+		return nil, errors.Wrap(err, "failed to scan count")
+	}
+
+	query := fmt.Sprintf(`select id, created_at, user_id, session_id, api_token_id, roles, action, resource, route_name, method, path, app_slug, sequence, status_code, outcome
+	from audit_log %s order by created_at desc, id desc`, where)
+	if opts.PageSize > 0 {
+		query = fmt.Sprintf("%s limit %d offset %d", query, opts.PageSize, opts.CurrentPage*opts.PageSize)
+	}
+
+	rows, err = db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: args,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %v: %v", err, rows.Err)
+	}
+
+	for rows.Next() {
+		event := audittypes.Event{}
+
+		var createdAt int64
+		var userID, sessionID, apiTokenID, rolesStr, resource, routeName, method, path, appSlug gorqlite.NullString
+		var sequence, statusCode gorqlite.NullInt64
+		var outcome string
+		if err := rows.Scan(&event.ID, &createdAt, &userID, &sessionID, &apiTokenID, &rolesStr, &event.Action, &resource, &routeName, &method, &path, &appSlug, &sequence, &statusCode, &outcome); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+
+		event.CreatedAt = time.Unix(createdAt, 0)
+		event.UserID = userID.String
+		event.SessionID = sessionID.String
+		event.APITokenID = apiTokenID.String
+		event.Resource = resource.String
+		event.RouteName = routeName.String
+		event.Method = method.String
+		event.Path = path.String
+		event.AppSlug = appSlug.String
+		event.StatusCode = int(statusCode.Int64)
+		event.Outcome = audittypes.Outcome(outcome)
+		if sequence.Valid {
+			event.Sequence = &sequence.Int64
+		}
+		if rolesStr.Valid && rolesStr.String != "" {
+			if err := json.Unmarshal([]byte(rolesStr.String), &event.Roles); err != nil {
+				return nil, errors.Wrap(err, "failed to unmarshal roles")
+			}
+		}
+
+		eventList.Events = append(eventList.Events, &event)
+	}
+
+	return eventList, nil
+}
+
+func (s *KOTSStore) DeleteAuditEventsBefore(before time.Time) error {
+	db := persistence.MustGetDBSession()
+
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `delete from audit_log where created_at < ?`,
+		Arguments: []interface{}{before.Unix()},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return nil
+}
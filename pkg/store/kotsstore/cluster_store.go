@@ -14,11 +14,18 @@ import (
 	"go.uber.org/zap"
 )
 
+// agentClusterType is the cluster type of remote clusters that are deployed to by an agent
+const agentClusterType = "agent"
+
 func (s *KOTSStore) ListClusters() ([]*downstreamtypes.Downstream, error) {
 	db := persistence.MustGetDBSession()
 
-	query := `select id, slug, title, snapshot_schedule, snapshot_ttl from cluster` // TODO the current sequence
-	rows, err := db.QueryOne(query)
+	// clusters with agents are remote, and are not part of the admin console's own instance
+	query := `select id, slug, title, snapshot_schedule, snapshot_ttl from cluster where cluster_type != ?` // TODO the current sequence
+	rows, err := db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: []interface{}{agentClusterType},
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to query: %v: %v", err, rows.Err)
 	}
@@ -105,6 +112,20 @@ func (s *KOTSStore) GetClusterIDFromDeployToken(deployToken string) (string, err
 }
 
 func (s *KOTSStore) CreateNewCluster(userID string, isAllUsers bool, title string, token string) (string, error) {
+	return s.createCluster(userID, isAllUsers, title, token, "ship")
+}
+
+// CreateAgentCluster creates a remote cluster, and returns the deploy token that the cluster's agent authenticates with
+func (s *KOTSStore) CreateAgentCluster(title string) (string, string, error) {
+	token := rand.StringWithCharset(32, rand.LOWER_CASE)
+	clusterID, err := s.createCluster("", true, title, token, agentClusterType)
+	if err != nil {
+		return "", "", err
+	}
+	return clusterID, token, nil
+}
+
+func (s *KOTSStore) createCluster(userID string, isAllUsers bool, title string, token string, clusterType string) (string, error) {
 	clusterID := rand.StringWithCharset(32, rand.LOWER_CASE)
 	clusterSlug := slug.Make(title)
 
@@ -148,7 +169,7 @@ func (s *KOTSStore) CreateNewCluster(userID string, isAllUsers bool, title strin
 	statements := []gorqlite.ParameterizedStatement{}
 	statements = append(statements, gorqlite.ParameterizedStatement{
 		Query:     `insert into cluster (id, title, slug, created_at, cluster_type, is_all_users, token) values (?, ?, ?, ?, ?, ?, ?)`,
-		Arguments: []interface{}{clusterID, title, clusterSlug, time.Now().Unix(), "ship", isAllUsers, token},
+		Arguments: []interface{}{clusterID, title, clusterSlug, time.Now().Unix(), clusterType, isAllUsers, token},
 	})
 
 	if userID != "" {
@@ -169,6 +190,122 @@ func (s *KOTSStore) CreateNewCluster(userID string, isAllUsers bool, title strin
 	return clusterID, nil
 }
 
+func (s *KOTSStore) ListAgentClusters() ([]*downstreamtypes.AgentCluster, error) {
+	db := persistence.MustGetDBSession()
+
+	query := `select id, slug, title, created_at, agent_last_seen_at from cluster where cluster_type = ? order by created_at`
+	rows, err := db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: []interface{}{agentClusterType},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %v: %v", err, rows.Err)
+	}
+
+	clusters := []*downstreamtypes.AgentCluster{}
+	for rows.Next() {
+		cluster := downstreamtypes.AgentCluster{}
+
+		var createdAt int64
+		var lastSeenAt gorqlite.NullInt64
+		if err := rows.Scan(&cluster.ID, &cluster.Slug, &cluster.Title, &createdAt, &lastSeenAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan row")
+		}
+
+		cluster.CreatedAt = time.Unix(createdAt, 0)
+		if lastSeenAt.Valid {
+			t := time.Unix(lastSeenAt.Int64, 0)
+			cluster.LastSeenAt = &t
+		}
+
+		apps, err := s.ListAppsForDownstream(cluster.ID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list apps for cluster %s", cluster.ID)
+		}
+		cluster.Apps = []string{}
+		for _, a := range apps {
+			cluster.Apps = append(cluster.Apps, a.Slug)
+		}
+
+		clusters = append(clusters, &cluster)
+	}
+
+	return clusters, nil
+}
+
+// GetAgentClusterIDFromToken returns the id of the remote cluster with the given deploy token
+func (s *KOTSStore) GetAgentClusterIDFromToken(deployToken string) (string, error) {
+	db := persistence.MustGetDBSession()
+	query := `select id from cluster where token = ? and cluster_type = ?`
+	rows, err := db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: []interface{}{deployToken, agentClusterType},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to query: %v: %v", err, rows.Err)
+	}
+
+	if !rows.Next() {
+		return "", ErrNotFound
+	}
+
+	var clusterID string
+	if err := rows.Scan(&clusterID); err != nil {
+		return "", errors.Wrap(err, "failed to scan")
+	}
+
+	return clusterID, nil
+}
+
+func (s *KOTSStore) SetAgentClusterLastSeen(clusterID string, lastSeenAt time.Time) error {
+	db := persistence.MustGetDBSession()
+	query := `update cluster set agent_last_seen_at = ? where id = ?`
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: []interface{}{lastSeenAt.Unix(), clusterID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return nil
+}
+
+// AssignAppToCluster moves the app, along with its versions and their deploy output, to the given cluster.
+// The downstream name is kept, since the app's rendered manifests are stored under it.
+func (s *KOTSStore) AssignAppToCluster(appID string, clusterID string) error {
+	logger.Debug("Assigning app to cluster",
+		zap.String("appID", appID),
+		zap.String("clusterID", clusterID))
+
+	db := persistence.MustGetDBSession()
+
+	statements := []gorqlite.ParameterizedStatement{
+		{
+			Query:     `update app_downstream set cluster_id = ? where app_id = ?`,
+			Arguments: []interface{}{clusterID, appID},
+		},
+		{
+			Query:     `update app_downstream_version set cluster_id = ? where app_id = ?`,
+			Arguments: []interface{}{clusterID, appID},
+		},
+		{
+			Query:     `update app_downstream_output set cluster_id = ? where app_id = ?`,
+			Arguments: []interface{}{clusterID, appID},
+		},
+	}
+
+	if wrs, err := db.WriteParameterized(statements); err != nil {
+		wrErrs := []error{}
+		for _, wr := range wrs {
+			wrErrs = append(wrErrs, wr.Err)
+		}
+		return fmt.Errorf("failed to write: %v: %v", err, wrErrs)
+	}
+
+	return nil
+}
+
 func (s *KOTSStore) SetInstanceSnapshotTTL(clusterID string, snapshotTTL string) error {
 	logger.Debug("Setting instance snapshot TTL",
 		zap.String("clusterID", clusterID))
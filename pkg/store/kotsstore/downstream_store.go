@@ -417,6 +417,12 @@ func (s *KOTSStore) GetDownstreamVersions(appID string, clusterID string, downlo
 
 	downstreamtypes.SortDownstreamVersions(result.AllVersions, license.IsSemverRequired())
 
+	blocks, err := s.ListVersionBlocks(appID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list version blocks")
+	}
+	setVersionsBlocked(result.AllVersions, blocks)
+
 	// retrieve additional details about the latest downloaded version,
 	// since it's used for detecting things like if a certain feature is enabled or not.
 	for _, v := range result.AllVersions {
@@ -432,6 +438,7 @@ func (s *KOTSStore) GetDownstreamVersions(appID string, clusterID string, downlo
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to check if version %s is deployable", v.VersionLabel)
 		}
+		applyVersionBlock(v)
 		break
 	}
 
@@ -682,12 +689,18 @@ func (s *KOTSStore) AddDownstreamVersionsDetails(appID string, clusterID string,
 		if err != nil {
 			return errors.Wrap(err, "failed to get app license")
 		}
+		blocks, err := s.ListVersionBlocks(appID)
+		if err != nil {
+			return errors.Wrap(err, "failed to list version blocks")
+		}
+		setVersionsBlocked(versions, blocks)
 
 		for _, v := range versions {
 			v.IsDeployable, v.NonDeployableCause, err = isAppVersionDeployable(s, appID, v, allVersions, license.IsSemverRequired())
 			if err != nil {
 				return errors.Wrapf(err, "failed to check if version %s is deployable", v.VersionLabel)
 			}
+			applyVersionBlock(v)
 		}
 	}
 
@@ -720,8 +733,8 @@ func (s *KOTSStore) getLatestDeployableDownstreamVersion(appID string, clusterID
 	}
 
 	if versions.CurrentVersion == nil {
-		// no version has been deployed yet, next app version is the latest version
-		latestDeployableVersion = versions.AllVersions[0]
+		// no version has been deployed yet, next app version is the latest version that is not blocked
+		latestDeployableVersion = downstreamtypes.FirstUnblockedVersion(versions.AllVersions)
 		return
 	}
 
@@ -739,11 +752,17 @@ func (s *KOTSStore) getLatestDeployableDownstreamVersion(appID string, clusterID
 	}
 
 	if len(requiredVersions) > 0 {
-		// next app version is the earliest pending required version
-		latestDeployableVersion = requiredVersions[len(requiredVersions)-1]
+		// next app version is the earliest pending required version that is not blocked
+		latestDeployableVersion = downstreamtypes.LastUnblockedVersion(requiredVersions)
+		if latestDeployableVersion == nil {
+			return
+		}
 	} else {
-		// next app version is the latest pending version
-		latestDeployableVersion = versions.PendingVersions[0]
+		// next app version is the latest pending version that is not blocked
+		latestDeployableVersion = downstreamtypes.FirstUnblockedVersion(versions.PendingVersions)
+		if latestDeployableVersion == nil {
+			return
+		}
 	}
 
 	latestDeployableVersionIndex := -1
@@ -1107,7 +1126,8 @@ func (s *KOTSStore) GetDownstreamOutput(appID string, clusterID string, sequence
 	ado.apply_stdout,
 	ado.apply_stderr,
 	ado.helm_stdout,
-	ado.helm_stderr
+	ado.helm_stderr,
+	ado.deploy_results
 FROM
 	app_downstream_version adv
 LEFT JOIN
@@ -1138,8 +1158,9 @@ WHERE
 	var applyStderr gorqlite.NullString
 	var helmStdout gorqlite.NullString
 	var helmStderr gorqlite.NullString
+	var deployResults gorqlite.NullString
 
-	if err := rows.Scan(&status, &statusInfo, &dryrunStdout, &dryrunStderr, &applyStdout, &applyStderr, &helmStdout, &helmStderr); err != nil {
+	if err := rows.Scan(&status, &statusInfo, &dryrunStdout, &dryrunStderr, &applyStdout, &applyStderr, &helmStdout, &helmStderr, &deployResults); err != nil {
 		return nil, errors.Wrap(err, "failed to select downstream")
 	}
 
@@ -1194,6 +1215,13 @@ WHERE
 		RenderError:  string(renderError),
 	}
 
+	if deployResults.String != "" {
+		output.Results = &downstreamtypes.DeployResults{}
+		if err := json.Unmarshal([]byte(deployResults.String), output.Results); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal deploy results")
+		}
+	}
+
 	return output, nil
 }
 
@@ -1224,16 +1252,25 @@ func (s *KOTSStore) IsDownstreamDeploySuccessful(appID string, clusterID string,
 }
 
 func (s *KOTSStore) UpdateDownstreamDeployStatus(appID string, clusterID string, sequence int64, isError bool, output downstreamtypes.DownstreamOutput) error {
+	var deployResults interface{}
+	if output.Results != nil {
+		b, err := json.Marshal(output.Results)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal deploy results")
+		}
+		deployResults = string(b)
+	}
+
 	db := persistence.MustGetDBSession()
 
-	query := `insert into app_downstream_output (app_id, cluster_id, downstream_sequence, is_error, dryrun_stdout, dryrun_stderr, apply_stdout, apply_stderr, helm_stdout, helm_stderr)
-	values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?) on conflict (app_id, cluster_id, downstream_sequence) do update set is_error = EXCLUDED.is_error,
+	query := `insert into app_downstream_output (app_id, cluster_id, downstream_sequence, is_error, dryrun_stdout, dryrun_stderr, apply_stdout, apply_stderr, helm_stdout, helm_stderr, deploy_results)
+	values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) on conflict (app_id, cluster_id, downstream_sequence) do update set is_error = EXCLUDED.is_error,
 	dryrun_stdout = EXCLUDED.dryrun_stdout, dryrun_stderr = EXCLUDED.dryrun_stderr, apply_stdout = EXCLUDED.apply_stdout, apply_stderr = EXCLUDED.apply_stderr,
-	helm_stdout = EXCLUDED.helm_stdout, helm_stderr = EXCLUDED.helm_stderr`
+	helm_stdout = EXCLUDED.helm_stdout, helm_stderr = EXCLUDED.helm_stderr, deploy_results = EXCLUDED.deploy_results`
 
 	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
 		Query:     query,
-		Arguments: []interface{}{appID, clusterID, sequence, isError, output.DryrunStdout, output.DryrunStderr, output.ApplyStdout, output.ApplyStderr, output.HelmStdout, output.HelmStderr},
+		Arguments: []interface{}{appID, clusterID, sequence, isError, output.DryrunStdout, output.DryrunStderr, output.ApplyStdout, output.ApplyStderr, output.HelmStdout, output.HelmStderr, deployResults},
 	})
 	if err != nil {
 		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
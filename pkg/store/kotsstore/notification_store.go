@@ -0,0 +1,234 @@
+package kotsstore
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/crypto"
+	notificationtypes "github.com/replicatedhq/kots/pkg/notifications/types"
+	"github.com/replicatedhq/kots/pkg/persistence"
+	"github.com/rqlite/gorqlite"
+	"github.com/segmentio/ksuid"
+)
+
+// sinkConfig is the part of a sink that may contain secrets, it is stored encrypted
+type sinkConfig struct {
+	Webhook *notificationtypes.WebhookConfig `json:"webhook,omitempty"`
+	SMTP    *notificationtypes.SMTPConfig    `json:"smtp,omitempty"`
+}
+
+func (s *KOTSStore) CreateNotificationSink(sink *notificationtypes.Sink) error {
+	db := persistence.MustGetDBSession()
+
+	if sink.ID == "" {
+		sink.ID = ksuid.New().String()
+	}
+	if sink.CreatedAt.IsZero() {
+		sink.CreatedAt = time.Now()
+	}
+
+	var events interface{}
+	if len(sink.Events) > 0 {
+		marshalledEvents, err := json.Marshal(sink.Events)
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal events")
+		}
+		events = string(marshalledEvents)
+	}
+
+	marshalledConfig, err := json.Marshal(sinkConfig{Webhook: sink.Webhook, SMTP: sink.SMTP})
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal config")
+	}
+	configEnc := base64.StdEncoding.EncodeToString(crypto.Encrypt(marshalledConfig))
+
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `insert into notification_sink (id, app_id, name, type, events, config_enc, created_at) values (?, ?, ?, ?, ?, ?, ?)`,
+		Arguments: []interface{}{sink.ID, sink.AppID, sink.Name, string(sink.Type), events, configEnc, sink.CreatedAt.Unix()},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return nil
+}
+
+func (s *KOTSStore) ListNotificationSinks(appID string) ([]*notificationtypes.Sink, error) {
+	db := persistence.MustGetDBSession()
+
+	rows, err := db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `select id, app_id, name, type, events, config_enc, created_at from notification_sink where app_id = ? order by created_at`,
+		Arguments: []interface{}{appID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %v: %v", err, rows.Err)
+	}
+
+	sinks := []*notificationtypes.Sink{}
+	for rows.Next() {
+		sink := notificationtypes.Sink{}
+
+		var sinkType, configEnc string
+		var events gorqlite.NullString
+		var createdAt int64
+		if err := rows.Scan(&sink.ID, &sink.AppID, &sink.Name, &sinkType, &events, &configEnc, &createdAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+
+		sink.Type = notificationtypes.SinkType(sinkType)
+		sink.CreatedAt = time.Unix(createdAt, 0)
+
+		if events.Valid && events.String != "" {
+			if err := json.Unmarshal([]byte(events.String), &sink.Events); err != nil {
+				return nil, errors.Wrap(err, "failed to unmarshal events")
+			}
+		}
+
+		decodedConfig, err := base64.StdEncoding.DecodeString(configEnc)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode config")
+		}
+		decryptedConfig, err := crypto.Decrypt(decodedConfig)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decrypt config")
+		}
+		config := sinkConfig{}
+		if err := json.Unmarshal(decryptedConfig, &config); err != nil {
+			return nil, errors.Wrap(err, "failed to unmarshal config")
+		}
+		sink.Webhook = config.Webhook
+		sink.SMTP = config.SMTP
+
+		sinks = append(sinks, &sink)
+	}
+
+	return sinks, nil
+}
+
+func (s *KOTSStore) DeleteNotificationSink(appID string, sinkID string) error {
+	db := persistence.MustGetDBSession()
+
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `delete from notification_sink where app_id = ? and id = ?`,
+		Arguments: []interface{}{appID, sinkID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+	if wr.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *KOTSStore) CreateNotificationDelivery(delivery *notificationtypes.Delivery) error {
+	db := persistence.MustGetDBSession()
+
+	if delivery.ID == "" {
+		delivery.ID = ksuid.New().String()
+	}
+	if delivery.CreatedAt.IsZero() {
+		delivery.CreatedAt = time.Now()
+	}
+	if delivery.UpdatedAt.IsZero() {
+		delivery.UpdatedAt = delivery.CreatedAt
+	}
+
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query: `insert into notification_delivery (id, app_id, sink_id, event_id, event_type, message, status, attempts, last_error, created_at, updated_at)
+		values (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		Arguments: []interface{}{
+			delivery.ID,
+			delivery.AppID,
+			delivery.SinkID,
+			delivery.EventID,
+			string(delivery.EventType),
+			delivery.Message,
+			string(delivery.Status),
+			delivery.Attempts,
+			delivery.LastError,
+			delivery.CreatedAt.Unix(),
+			delivery.UpdatedAt.Unix(),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return nil
+}
+
+func (s *KOTSStore) UpdateNotificationDelivery(delivery *notificationtypes.Delivery) error {
+	db := persistence.MustGetDBSession()
+
+	delivery.UpdatedAt = time.Now()
+
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `update notification_delivery set status = ?, attempts = ?, last_error = ?, updated_at = ? where id = ?`,
+		Arguments: []interface{}{string(delivery.Status), delivery.Attempts, delivery.LastError, delivery.UpdatedAt.Unix(), delivery.ID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return nil
+}
+
+func (s *KOTSStore) ListNotificationDeliveries(appID string, limit int) ([]*notificationtypes.Delivery, error) {
+	db := persistence.MustGetDBSession()
+
+	query := `select id, app_id, sink_id, event_id, event_type, message, status, attempts, last_error, created_at, updated_at
+	from notification_delivery where app_id = ? order by created_at desc, id desc`
+	if limit > 0 {
+		query = fmt.Sprintf("%s limit %d", query, limit)
+	}
+
+	rows, err := db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: []interface{}{appID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %v: %v", err, rows.Err)
+	}
+
+	deliveries := []*notificationtypes.Delivery{}
+	for rows.Next() {
+		delivery := notificationtypes.Delivery{}
+
+		var eventType, status string
+		var message, lastError gorqlite.NullString
+		var createdAt, updatedAt int64
+		if err := rows.Scan(&delivery.ID, &delivery.AppID, &delivery.SinkID, &delivery.EventID, &eventType, &message, &status, &delivery.Attempts, &lastError, &createdAt, &updatedAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+
+		delivery.EventType = notificationtypes.EventType(eventType)
+		delivery.Message = message.String
+		delivery.Status = notificationtypes.DeliveryStatus(status)
+		delivery.LastError = lastError.String
+		delivery.CreatedAt = time.Unix(createdAt, 0)
+		delivery.UpdatedAt = time.Unix(updatedAt, 0)
+
+		deliveries = append(deliveries, &delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (s *KOTSStore) DeleteNotificationDeliveriesBefore(before time.Time) error {
+	db := persistence.MustGetDBSession()
+
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `delete from notification_delivery where created_at < ?`,
+		Arguments: []interface{}{before.Unix()},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return nil
+}
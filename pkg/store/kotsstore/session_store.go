@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -70,15 +71,15 @@ func (s *KOTSStore) migrateSessionsFromRqlite() error {
 			session.Roles = metadata.Roles
 		}
 
+		session.ExpiresAt = expiresAt
+
 		// sessions created before this change will not have IssuedAt
 		if issuedAt.Valid {
 			session.IssuedAt = issuedAt.Time
 		} else {
-			session.IssuedAt = session.ExpiresAt.AddDate(0, 0, -14)
+			session.IssuedAt = legacySessionIssuedAt(session.ExpiresAt)
 		}
 
-		session.ExpiresAt = expiresAt
-
 		b, err := json.Marshal(session)
 		if err != nil {
 			return errors.Wrap(err, "failed to encoded session")
@@ -105,7 +106,7 @@ func (s *KOTSStore) migrateSessionsFromRqlite() error {
 
 }
 
-func (s *KOTSStore) CreateSession(forUser *usertypes.User, issuedAt time.Time, expiresAt time.Time, roles []string) (*sessiontypes.Session, error) {
+func (s *KOTSStore) CreateSession(forUser *usertypes.User, issuedAt time.Time, expiresAt time.Time, roles []string, source sessiontypes.Source) (*sessiontypes.Session, error) {
 	sessionLock.Lock()
 	defer sessionLock.Unlock()
 
@@ -125,10 +126,12 @@ func (s *KOTSStore) CreateSession(forUser *usertypes.User, issuedAt time.Time, e
 
 	session := sessiontypes.Session{
 		ID:        id,
+		UserID:    forUser.ID,
 		IssuedAt:  issuedAt,
 		ExpiresAt: expiresAt,
 		Roles:     roles,
 		HasRBAC:   true,
+		Source:    source,
 	}
 
 	b, err := json.Marshal(session)
@@ -170,12 +173,46 @@ func (s *KOTSStore) GetSession(id string) (*sessiontypes.Session, error) {
 
 	// sessions created before this change will not have IssuedAt
 	if session.IssuedAt.IsZero() {
-		session.IssuedAt = session.ExpiresAt.AddDate(0, 0, -14)
+		session.IssuedAt = legacySessionIssuedAt(session.ExpiresAt)
 	}
 
 	return &session, nil
 }
 
+// ListSessions - returns all sessions that have not expired, newest first
+func (s *KOTSStore) ListSessions() ([]*sessiontypes.Session, error) {
+	sessionLock.Lock()
+	defer sessionLock.Unlock()
+
+	secret, err := s.getSessionSecret()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get session secret")
+	}
+
+	sessions := []*sessiontypes.Session{}
+	for _, data := range secret.Data {
+		session := sessiontypes.Session{}
+		if err := json.Unmarshal(data, &session); err != nil {
+			logger.Error(errors.Wrap(err, "failed to unmarshal session while listing sessions"))
+			continue
+		}
+		if time.Now().After(session.ExpiresAt) {
+			continue
+		}
+		// sessions created before this change will not have IssuedAt
+		if session.IssuedAt.IsZero() {
+			session.IssuedAt = legacySessionIssuedAt(session.ExpiresAt)
+		}
+		sessions = append(sessions, &session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].IssuedAt.After(sessions[j].IssuedAt)
+	})
+
+	return sessions, nil
+}
+
 func (s *KOTSStore) DeleteSession(id string) error {
 	sessionLock.Lock()
 	defer sessionLock.Unlock()
@@ -332,3 +369,64 @@ func (s *KOTSStore) DeleteExpiredSessions() error {
 
 	return nil
 }
+
+// DeleteAllSessions - delete every session, signing out all users
+func (s *KOTSStore) DeleteAllSessions() error {
+	sessionLock.Lock()
+	defer sessionLock.Unlock()
+
+	s.sessionSecret = nil
+
+	secret, err := s.getSessionSecret()
+	if err != nil {
+		return errors.Wrap(err, "failed to get session secret")
+	}
+
+	secret.Data = map[string][]byte{}
+
+	if err := s.saveSessionSecret(secret); err != nil {
+		return errors.Wrap(err, "failed to update session secret")
+	}
+
+	return nil
+}
+
+// DeleteSessionsForUser - delete all sessions that were created for the given user
+func (s *KOTSStore) DeleteSessionsForUser(userID string) error {
+	sessionLock.Lock()
+	defer sessionLock.Unlock()
+
+	s.sessionSecret = nil
+
+	secret, err := s.getSessionSecret()
+	if err != nil {
+		return errors.Wrap(err, "failed to get session secret")
+	}
+
+	updateSessionSecret := false
+	for id, data := range secret.Data {
+		session := sessiontypes.Session{}
+		if err := json.Unmarshal(data, &session); err != nil {
+			logger.Error(errors.Wrap(err, "failed to unmarshal session while deleting user sessions"))
+			continue
+		}
+		if session.UserID == userID {
+			updateSessionSecret = true
+			delete(secret.Data, id)
+		}
+	}
+
+	if updateSessionSecret {
+		if err := s.saveSessionSecret(secret); err != nil {
+			return errors.Wrap(err, "failed to update session secret")
+		}
+	}
+
+	return nil
+}
+
+// legacySessionIssuedAt returns the issue time of a session that was created before the issue time was stored.
+// The time the session was last refreshed is used instead.
+func legacySessionIssuedAt(expiresAt time.Time) time.Time {
+	return expiresAt.Add(-sessiontypes.SessionTimeout)
+}
@@ -0,0 +1,118 @@
+package kotsstore
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/k8sutil"
+	"github.com/replicatedhq/kots/pkg/kotsadm/types"
+	twofactortypes "github.com/replicatedhq/kots/pkg/twofactor/types"
+	"github.com/replicatedhq/kots/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+/* TwoFactorStore
+   Two-factor authentication state is stored in a single Kubernetes secret so that it can be reset
+   with the kots cli without access to the database. The keys in the secret.data are user ids.
+*/
+
+func (s *KOTSStore) GetTwoFactorConfig(userID string) (*twofactortypes.Config, error) {
+	clientset, err := k8sutil.GetClientset()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get clientset")
+	}
+
+	secret, err := clientset.CoreV1().Secrets(util.PodNamespace).Get(context.TODO(), util.TwoFactorSecretName, metav1.GetOptions{})
+	if kuberneteserrors.IsNotFound(err) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to get secret")
+	}
+
+	data, ok := secret.Data[userID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	config := twofactortypes.Config{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal two-factor config")
+	}
+
+	return &config, nil
+}
+
+func (s *KOTSStore) SetTwoFactorConfig(userID string, config *twofactortypes.Config) error {
+	clientset, err := k8sutil.GetClientset()
+	if err != nil {
+		return errors.Wrap(err, "failed to get clientset")
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal two-factor config")
+	}
+
+	secret, err := clientset.CoreV1().Secrets(util.PodNamespace).Get(context.TODO(), util.TwoFactorSecretName, metav1.GetOptions{})
+	if kuberneteserrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Secret",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      util.TwoFactorSecretName,
+				Namespace: util.PodNamespace,
+				Labels:    types.GetKotsadmLabels(),
+			},
+			Data: map[string][]byte{
+				userID: data,
+			},
+		}
+		if _, err := clientset.CoreV1().Secrets(util.PodNamespace).Create(context.TODO(), secret, metav1.CreateOptions{}); err != nil {
+			return errors.Wrap(err, "failed to create secret")
+		}
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to get secret")
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[userID] = data
+
+	if _, err := clientset.CoreV1().Secrets(util.PodNamespace).Update(context.TODO(), secret, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to update secret")
+	}
+
+	return nil
+}
+
+func (s *KOTSStore) DeleteTwoFactorConfig(userID string) error {
+	clientset, err := k8sutil.GetClientset()
+	if err != nil {
+		return errors.Wrap(err, "failed to get clientset")
+	}
+
+	secret, err := clientset.CoreV1().Secrets(util.PodNamespace).Get(context.TODO(), util.TwoFactorSecretName, metav1.GetOptions{})
+	if kuberneteserrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to get secret")
+	}
+
+	if _, ok := secret.Data[userID]; !ok {
+		return nil
+	}
+	delete(secret.Data, userID)
+
+	if _, err := clientset.CoreV1().Secrets(util.PodNamespace).Update(context.TODO(), secret, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to update secret")
+	}
+
+	return nil
+}
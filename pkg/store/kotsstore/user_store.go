@@ -2,6 +2,7 @@ package kotsstore
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
@@ -10,14 +11,17 @@ import (
 	"github.com/pkg/errors"
 	"github.com/replicatedhq/kots/pkg/k8sutil"
 	"github.com/replicatedhq/kots/pkg/persistence"
+	usertypes "github.com/replicatedhq/kots/pkg/user/types"
 	"github.com/replicatedhq/kots/pkg/util"
 	"github.com/rqlite/gorqlite"
+	"github.com/segmentio/ksuid"
 	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var (
 	ErrTooManyAttempts = errors.New("too many attempts")
+	ErrUserExists      = errors.New("user already exists")
 	passwordSecretName = "kotsadm-password"
 )
 
@@ -273,3 +277,292 @@ func (s *KOTSStore) GetPasswordUpdatedAt() (*time.Time, error) {
 
 	return passwordUpdatedAt, nil
 }
+
+func (s *KOTSStore) CreateLocalUser(email string, firstName string, lastName string, passwordBcrypt []byte, roles []string) (*usertypes.User, error) {
+	db := persistence.MustGetDBSession()
+
+	rows, err := db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `select count(1) as count from ship_user_local where email = ?`,
+		Arguments: []interface{}{email},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %v: %v", err, rows.Err)
+	}
+	if !rows.Next() {
+		return nil, ErrNotFound
+	}
+
+	var count int
+	if err := rows.Scan(&count); err != nil {
+		return nil, errors.Wrap(err, "failed to scan")
+	}
+	if count > 0 {
+		return nil, ErrUserExists
+	}
+
+	marshalledRoles, err := json.Marshal(roles)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal roles")
+	}
+
+	userID := ksuid.New().String()
+	createdAt := time.Now()
+
+	statements := []gorqlite.ParameterizedStatement{
+		{
+			Query:     `insert into ship_user (id, created_at) values (?, ?)`,
+			Arguments: []interface{}{userID, createdAt.Unix()},
+		},
+		{
+			Query:     `insert into ship_user_local (user_id, password_bcrypt, first_name, last_name, email, roles, is_disabled, failed_login_count) values (?, ?, ?, ?, ?, ?, ?, ?)`,
+			Arguments: []interface{}{userID, string(passwordBcrypt), firstName, lastName, email, string(marshalledRoles), false, 0},
+		},
+	}
+
+	if wrs, err := db.WriteParameterized(statements); err != nil {
+		wrErrs := []error{}
+		for _, wr := range wrs {
+			wrErrs = append(wrErrs, wr.Err)
+		}
+		return nil, fmt.Errorf("failed to write: %v: %v", err, wrErrs)
+	}
+
+	return &usertypes.User{
+		ID:        userID,
+		Email:     email,
+		FirstName: firstName,
+		LastName:  lastName,
+		Roles:     roles,
+		CreatedAt: &createdAt,
+	}, nil
+}
+
+func (s *KOTSStore) GetUser(userID string) (*usertypes.User, error) {
+	users, err := s.listLocalUsers(`where u.id = ?`, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list local users")
+	}
+	if len(users) == 0 {
+		return nil, ErrNotFound
+	}
+	return users[0], nil
+}
+
+func (s *KOTSStore) GetLocalUserByEmail(email string) (*usertypes.User, error) {
+	users, err := s.listLocalUsers(`where l.email = ?`, email)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list local users")
+	}
+	if len(users) == 0 {
+		return nil, ErrNotFound
+	}
+	return users[0], nil
+}
+
+func (s *KOTSStore) ListLocalUsers() ([]*usertypes.User, error) {
+	return s.listLocalUsers(``)
+}
+
+func (s *KOTSStore) listLocalUsers(where string, args ...interface{}) ([]*usertypes.User, error) {
+	db := persistence.MustGetDBSession()
+
+	query := fmt.Sprintf(`select u.id, u.created_at, u.last_login, l.email, l.first_name, l.last_name, l.roles, l.is_disabled
+	from ship_user u
+	inner join ship_user_local l on l.user_id = u.id
+	%s
+	order by l.email`, where)
+	rows, err := db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     query,
+		Arguments: args,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %v: %v", err, rows.Err)
+	}
+
+	users := []*usertypes.User{}
+	for rows.Next() {
+		user := usertypes.User{}
+
+		var createdAt gorqlite.NullTime
+		var lastLogin gorqlite.NullTime
+		var firstName gorqlite.NullString
+		var lastName gorqlite.NullString
+		var rolesStr gorqlite.NullString
+		var isDisabled gorqlite.NullBool
+
+		if err := rows.Scan(&user.ID, &createdAt, &lastLogin, &user.Email, &firstName, &lastName, &rolesStr, &isDisabled); err != nil {
+			return nil, errors.Wrap(err, "failed to scan user")
+		}
+
+		user.FirstName = firstName.String
+		user.LastName = lastName.String
+		user.IsDisabled = isDisabled.Bool
+
+		if createdAt.Valid {
+			user.CreatedAt = &createdAt.Time
+		}
+		if lastLogin.Valid {
+			user.LastLogin = &lastLogin.Time
+		}
+
+		if rolesStr.String != "" {
+			if err := json.Unmarshal([]byte(rolesStr.String), &user.Roles); err != nil {
+				return nil, errors.Wrap(err, "failed to unmarshal roles")
+			}
+		}
+
+		users = append(users, &user)
+	}
+
+	return users, nil
+}
+
+// GetLocalUserPasswordBcrypt will return the hash of the local user's password.
+// An account with too many consecutive failed logins is locked until its
+// password is reset.
+func (s *KOTSStore) GetLocalUserPasswordBcrypt(userID string) ([]byte, error) {
+	db := persistence.MustGetDBSession()
+
+	rows, err := db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `select password_bcrypt, failed_login_count from ship_user_local where user_id = ?`,
+		Arguments: []interface{}{userID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %v: %v", err, rows.Err)
+	}
+	if !rows.Next() {
+		return nil, ErrNotFound
+	}
+
+	var passwordBcrypt string
+	var failedLoginCount gorqlite.NullInt64
+	if err := rows.Scan(&passwordBcrypt, &failedLoginCount); err != nil {
+		return nil, errors.Wrap(err, "failed to scan password")
+	}
+
+	if failedLoginCount.Int64 > 10 {
+		return nil, ErrTooManyAttempts
+	}
+
+	return []byte(passwordBcrypt), nil
+}
+
+func (s *KOTSStore) SetLocalUserPasswordBcrypt(userID string, passwordBcrypt []byte) error {
+	db := persistence.MustGetDBSession()
+
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `update ship_user_local set password_bcrypt = ?, failed_login_count = 0 where user_id = ?`,
+		Arguments: []interface{}{string(passwordBcrypt), userID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update password: %v: %v", err, wr.Err)
+	}
+	if wr.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *KOTSStore) SetLocalUserRoles(userID string, roles []string) error {
+	marshalledRoles, err := json.Marshal(roles)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal roles")
+	}
+
+	db := persistence.MustGetDBSession()
+
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `update ship_user_local set roles = ? where user_id = ?`,
+		Arguments: []interface{}{string(marshalledRoles), userID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update roles: %v: %v", err, wr.Err)
+	}
+	if wr.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *KOTSStore) SetLocalUserDisabled(userID string, isDisabled bool) error {
+	db := persistence.MustGetDBSession()
+
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `update ship_user_local set is_disabled = ? where user_id = ?`,
+		Arguments: []interface{}{isDisabled, userID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update disabled: %v: %v", err, wr.Err)
+	}
+	if wr.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (s *KOTSStore) FlagLocalUserInvalidPassword(userID string) error {
+	db := persistence.MustGetDBSession()
+
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `update ship_user_local set failed_login_count = coalesce(failed_login_count, 0) + 1 where user_id = ?`,
+		Arguments: []interface{}{userID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update failed login count: %v: %v", err, wr.Err)
+	}
+
+	return nil
+}
+
+func (s *KOTSStore) FlagLocalUserSuccessfulLogin(userID string) error {
+	db := persistence.MustGetDBSession()
+
+	statements := []gorqlite.ParameterizedStatement{
+		{
+			Query:     `update ship_user_local set failed_login_count = 0 where user_id = ?`,
+			Arguments: []interface{}{userID},
+		},
+		{
+			Query:     `update ship_user set last_login = ? where id = ?`,
+			Arguments: []interface{}{time.Now().Unix(), userID},
+		},
+	}
+
+	if wrs, err := db.WriteParameterized(statements); err != nil {
+		wrErrs := []error{}
+		for _, wr := range wrs {
+			wrErrs = append(wrErrs, wr.Err)
+		}
+		return fmt.Errorf("failed to write: %v: %v", err, wrErrs)
+	}
+
+	return nil
+}
+
+func (s *KOTSStore) DeleteLocalUser(userID string) error {
+	db := persistence.MustGetDBSession()
+
+	statements := []gorqlite.ParameterizedStatement{
+		{
+			Query:     `delete from ship_user_local where user_id = ?`,
+			Arguments: []interface{}{userID},
+		},
+		{
+			Query:     `delete from ship_user where id = ?`,
+			Arguments: []interface{}{userID},
+		},
+	}
+
+	if wrs, err := db.WriteParameterized(statements); err != nil {
+		wrErrs := []error{}
+		for _, wr := range wrs {
+			wrErrs = append(wrErrs, wr.Err)
+		}
+		return fmt.Errorf("failed to write: %v: %v", err, wrErrs)
+	}
+
+	return nil
+}
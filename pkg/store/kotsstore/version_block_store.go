@@ -0,0 +1,127 @@
+package kotsstore
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
+	apptypes "github.com/replicatedhq/kots/pkg/app/types"
+	"github.com/replicatedhq/kots/pkg/persistence"
+	"github.com/rqlite/gorqlite"
+	"github.com/segmentio/ksuid"
+)
+
+func (s *KOTSStore) CreateVersionBlock(appID string, versionLabel string, sequence *int64, reason string, createdBy string) (*apptypes.VersionBlock, error) {
+	db := persistence.MustGetDBSession()
+
+	block := apptypes.VersionBlock{
+		ID:           ksuid.New().String(),
+		VersionLabel: versionLabel,
+		Sequence:     sequence,
+		Reason:       reason,
+		CreatedBy:    createdBy,
+		CreatedAt:    time.Now(),
+	}
+
+	var versionLabelArg interface{}
+	if versionLabel != "" {
+		versionLabelArg = versionLabel
+	}
+	var sequenceArg interface{}
+	if sequence != nil {
+		sequenceArg = *sequence
+	}
+
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `insert into app_version_block (id, app_id, version_label, sequence, reason, created_by, created_at) values (?, ?, ?, ?, ?, ?, ?)`,
+		Arguments: []interface{}{block.ID, appID, versionLabelArg, sequenceArg, reason, createdBy, block.CreatedAt.Unix()},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+
+	return &block, nil
+}
+
+func (s *KOTSStore) ListVersionBlocks(appID string) (apptypes.VersionBlocks, error) {
+	db := persistence.MustGetDBSession()
+
+	rows, err := db.QueryOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `select id, version_label, sequence, reason, created_by, created_at from app_version_block where app_id = ? order by created_at`,
+		Arguments: []interface{}{appID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query: %v: %v", err, rows.Err)
+	}
+
+	blocks := apptypes.VersionBlocks{}
+	for rows.Next() {
+		block := apptypes.VersionBlock{}
+
+		var versionLabel gorqlite.NullString
+		var sequence gorqlite.NullInt64
+		var createdBy gorqlite.NullString
+		var createdAt int64
+		if err := rows.Scan(&block.ID, &versionLabel, &sequence, &block.Reason, &createdBy, &createdAt); err != nil {
+			return nil, errors.Wrap(err, "failed to scan")
+		}
+
+		block.VersionLabel = versionLabel.String
+		if sequence.Valid {
+			block.Sequence = &sequence.Int64
+		}
+		block.CreatedBy = createdBy.String
+		block.CreatedAt = time.Unix(createdAt, 0)
+
+		blocks = append(blocks, block)
+	}
+
+	return blocks, nil
+}
+
+func (s *KOTSStore) DeleteVersionBlock(appID string, blockID string) error {
+	db := persistence.MustGetDBSession()
+
+	wr, err := db.WriteOneParameterized(gorqlite.ParameterizedStatement{
+		Query:     `delete from app_version_block where app_id = ? and id = ?`,
+		Arguments: []interface{}{appID, blockID},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write: %v: %v", err, wr.Err)
+	}
+	if wr.RowsAffected == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// setVersionsBlocked marks the versions that match one of the blocks
+func setVersionsBlocked(versions []*downstreamtypes.DownstreamVersion, blocks apptypes.VersionBlocks) {
+	for _, v := range versions {
+		if v == nil {
+			continue
+		}
+		block := blocks.Find(v.VersionLabel, v.Sequence)
+		v.IsBlocked = block != nil
+		v.BlockedReason = ""
+		if block != nil {
+			v.BlockedReason = block.Reason
+		}
+	}
+}
+
+// applyVersionBlock marks a blocked version as not deployable.
+// Required versions cannot be skipped, so the cause explains that later versions are held back as well.
+func applyVersionBlock(v *downstreamtypes.DownstreamVersion) {
+	if !v.IsBlocked {
+		return
+	}
+	v.IsDeployable = false
+	if v.IsRequired {
+		v.NonDeployableCause = fmt.Sprintf("Version %s is blocked (%s), but it is a required version and cannot be skipped. Later versions cannot be deployed until the block is removed.", v.VersionLabel, v.BlockedReason)
+	} else {
+		v.NonDeployableCause = fmt.Sprintf("Version %s is blocked: %s", v.VersionLabel, v.BlockedReason)
+	}
+}
@@ -0,0 +1,70 @@
+package kotsstore
+
+import (
+	"testing"
+
+	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
+	apptypes "github.com/replicatedhq/kots/pkg/app/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_setVersionsBlocked(t *testing.T) {
+	sequence := int64(2)
+	blocks := apptypes.VersionBlocks{
+		{ID: "by-label", VersionLabel: "1.0.3", Reason: "breaks ingress"},
+		{ID: "by-sequence", VersionLabel: "1.0.1", Sequence: &sequence, Reason: "bad migration"},
+	}
+
+	versions := []*downstreamtypes.DownstreamVersion{
+		{VersionLabel: "1.0.3", Sequence: 4},
+		{VersionLabel: "1.0.2", Sequence: 3},
+		{VersionLabel: "1.0.1", Sequence: 2},
+		// the label matches the sequence block, but the sequence takes precedence
+		{VersionLabel: "1.0.1", Sequence: 1, IsBlocked: true, BlockedReason: "stale"},
+	}
+
+	setVersionsBlocked(versions, blocks)
+
+	assert.True(t, versions[0].IsBlocked)
+	assert.Equal(t, "breaks ingress", versions[0].BlockedReason)
+	assert.False(t, versions[1].IsBlocked)
+	assert.True(t, versions[2].IsBlocked)
+	assert.Equal(t, "bad migration", versions[2].BlockedReason)
+	assert.False(t, versions[3].IsBlocked)
+	assert.Empty(t, versions[3].BlockedReason)
+}
+
+func Test_applyVersionBlock(t *testing.T) {
+	tests := []struct {
+		name                 string
+		version              *downstreamtypes.DownstreamVersion
+		expectedIsDeployable bool
+		expectedCause        string
+	}{
+		{
+			name:                 "not blocked",
+			version:              &downstreamtypes.DownstreamVersion{VersionLabel: "1.0.0", IsDeployable: true},
+			expectedIsDeployable: true,
+			expectedCause:        "",
+		},
+		{
+			name:                 "blocked",
+			version:              &downstreamtypes.DownstreamVersion{VersionLabel: "1.0.0", IsDeployable: true, IsBlocked: true, BlockedReason: "breaks ingress"},
+			expectedIsDeployable: false,
+			expectedCause:        "Version 1.0.0 is blocked: breaks ingress",
+		},
+		{
+			name:                 "blocked required version",
+			version:              &downstreamtypes.DownstreamVersion{VersionLabel: "1.0.0", IsDeployable: true, IsRequired: true, IsBlocked: true, BlockedReason: "breaks ingress"},
+			expectedIsDeployable: false,
+			expectedCause:        "Version 1.0.0 is blocked (breaks ingress), but it is a required version and cannot be skipped. Later versions cannot be deployed until the block is removed.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applyVersionBlock(tt.version)
+			assert.Equal(t, tt.expectedIsDeployable, tt.version.IsDeployable)
+			assert.Equal(t, tt.expectedCause, tt.version.NonDeployableCause)
+		})
+	}
+}
@@ -15,18 +15,22 @@ import (
 	types0 "github.com/replicatedhq/kots/pkg/api/downstream/types"
 	types1 "github.com/replicatedhq/kots/pkg/api/reporting/types"
 	types2 "github.com/replicatedhq/kots/pkg/api/version/types"
-	types3 "github.com/replicatedhq/kots/pkg/app/types"
-	types4 "github.com/replicatedhq/kots/pkg/appstate/types"
-	types5 "github.com/replicatedhq/kots/pkg/kotsadmsnapshot/types"
-	types6 "github.com/replicatedhq/kots/pkg/online/types"
-	types7 "github.com/replicatedhq/kots/pkg/preflight/types"
-	types8 "github.com/replicatedhq/kots/pkg/registry/types"
-	types9 "github.com/replicatedhq/kots/pkg/render/types"
-	types10 "github.com/replicatedhq/kots/pkg/session/types"
-	types11 "github.com/replicatedhq/kots/pkg/store/types"
-	types12 "github.com/replicatedhq/kots/pkg/supportbundle/types"
-	types13 "github.com/replicatedhq/kots/pkg/upstream/types"
-	types14 "github.com/replicatedhq/kots/pkg/user/types"
+	types3 "github.com/replicatedhq/kots/pkg/apitoken/types"
+	types4 "github.com/replicatedhq/kots/pkg/app/types"
+	types5 "github.com/replicatedhq/kots/pkg/appstate/types"
+	types6 "github.com/replicatedhq/kots/pkg/audit/types"
+	types7 "github.com/replicatedhq/kots/pkg/kotsadmsnapshot/types"
+	types8 "github.com/replicatedhq/kots/pkg/notifications/types"
+	types9 "github.com/replicatedhq/kots/pkg/online/types"
+	types10 "github.com/replicatedhq/kots/pkg/preflight/types"
+	types11 "github.com/replicatedhq/kots/pkg/registry/types"
+	types12 "github.com/replicatedhq/kots/pkg/render/types"
+	types13 "github.com/replicatedhq/kots/pkg/session/types"
+	types14 "github.com/replicatedhq/kots/pkg/store/types"
+	types15 "github.com/replicatedhq/kots/pkg/supportbundle/types"
+	types16 "github.com/replicatedhq/kots/pkg/twofactor/types"
+	types17 "github.com/replicatedhq/kots/pkg/upstream/types"
+	types18 "github.com/replicatedhq/kots/pkg/user/types"
 	v1beta10 "github.com/replicatedhq/kotskinds/apis/kots/v1beta1"
 	licensewrapper "github.com/replicatedhq/kotskinds/pkg/licensewrapper"
 	redact "github.com/replicatedhq/troubleshoot/pkg/redact"
@@ -97,11 +101,56 @@ func (mr *MockStoreMockRecorder) AddDownstreamVersionsDetails(appID, clusterID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDownstreamVersionsDetails", reflect.TypeOf((*MockStore)(nil).AddDownstreamVersionsDetails), appID, clusterID, versions, checkIfDeployable)
 }
 
+// AssignAppToCluster mocks base method.
+func (m *MockStore) AssignAppToCluster(appID, clusterID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignAppToCluster", appID, clusterID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignAppToCluster indicates an expected call of AssignAppToCluster.
+func (mr *MockStoreMockRecorder) AssignAppToCluster(appID, clusterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignAppToCluster", reflect.TypeOf((*MockStore)(nil).AssignAppToCluster), appID, clusterID)
+}
+
+// CreateAPIToken mocks base method.
+func (m *MockStore) CreateAPIToken(name, tokenSHA256 string, roles []string, createdBy string, expiresAt *time.Time) (*types3.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIToken", name, tokenSHA256, roles, createdBy, expiresAt)
+	ret0, _ := ret[0].(*types3.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIToken indicates an expected call of CreateAPIToken.
+func (mr *MockStoreMockRecorder) CreateAPIToken(name, tokenSHA256, roles, createdBy, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIToken", reflect.TypeOf((*MockStore)(nil).CreateAPIToken), name, tokenSHA256, roles, createdBy, expiresAt)
+}
+
+// CreateAgentCluster mocks base method.
+func (m *MockStore) CreateAgentCluster(title string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAgentCluster", title)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAgentCluster indicates an expected call of CreateAgentCluster.
+func (mr *MockStoreMockRecorder) CreateAgentCluster(title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAgentCluster", reflect.TypeOf((*MockStore)(nil).CreateAgentCluster), title)
+}
+
 // CreateApp mocks base method.
-func (m *MockStore) CreateApp(name, channelID, upstreamURI, licenseData string, isAirgapEnabled, skipImagePush, registryIsReadOnly bool) (*types3.App, error) {
+func (m *MockStore) CreateApp(name, channelID, upstreamURI, licenseData string, isAirgapEnabled, skipImagePush, registryIsReadOnly bool) (*types4.App, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateApp", name, channelID, upstreamURI, licenseData, isAirgapEnabled, skipImagePush, registryIsReadOnly)
-	ret0, _ := ret[0].(*types3.App)
+	ret0, _ := ret[0].(*types4.App)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -127,8 +176,22 @@ func (mr *MockStoreMockRecorder) CreateAppVersion(appID, baseSequence, filesInDi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAppVersion", reflect.TypeOf((*MockStore)(nil).CreateAppVersion), appID, baseSequence, filesInDir, source, isInstall, isAutomated, skipPreflights)
 }
 
+// CreateAuditEvent mocks base method.
+func (m *MockStore) CreateAuditEvent(event *types6.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditEvent", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuditEvent indicates an expected call of CreateAuditEvent.
+func (mr *MockStoreMockRecorder) CreateAuditEvent(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditEvent", reflect.TypeOf((*MockStore)(nil).CreateAuditEvent), event)
+}
+
 // CreateInProgressSupportBundle mocks base method.
-func (m *MockStore) CreateInProgressSupportBundle(supportBundle *types12.SupportBundle) error {
+func (m *MockStore) CreateInProgressSupportBundle(supportBundle *types15.SupportBundle) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateInProgressSupportBundle", supportBundle)
 	ret0, _ := ret[0].(error)
@@ -156,6 +219,21 @@ func (mr *MockStoreMockRecorder) CreateInitialBranding(brandingArchive interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInitialBranding", reflect.TypeOf((*MockStore)(nil).CreateInitialBranding), brandingArchive)
 }
 
+// CreateLocalUser mocks base method.
+func (m *MockStore) CreateLocalUser(email, firstName, lastName string, passwordBcrypt []byte, roles []string) (*types18.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLocalUser", email, firstName, lastName, passwordBcrypt, roles)
+	ret0, _ := ret[0].(*types18.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLocalUser indicates an expected call of CreateLocalUser.
+func (mr *MockStoreMockRecorder) CreateLocalUser(email, firstName, lastName, passwordBcrypt, roles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLocalUser", reflect.TypeOf((*MockStore)(nil).CreateLocalUser), email, firstName, lastName, passwordBcrypt, roles)
+}
+
 // CreateNewCluster mocks base method.
 func (m *MockStore) CreateNewCluster(userID string, isAllUsers bool, title, token string) (string, error) {
 	m.ctrl.T.Helper()
@@ -171,8 +249,36 @@ func (mr *MockStoreMockRecorder) CreateNewCluster(userID, isAllUsers, title, tok
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewCluster", reflect.TypeOf((*MockStore)(nil).CreateNewCluster), userID, isAllUsers, title, token)
 }
 
+// CreateNotificationDelivery mocks base method.
+func (m *MockStore) CreateNotificationDelivery(delivery *types8.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotificationDelivery", delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotificationDelivery indicates an expected call of CreateNotificationDelivery.
+func (mr *MockStoreMockRecorder) CreateNotificationDelivery(delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotificationDelivery", reflect.TypeOf((*MockStore)(nil).CreateNotificationDelivery), delivery)
+}
+
+// CreateNotificationSink mocks base method.
+func (m *MockStore) CreateNotificationSink(sink *types8.Sink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotificationSink", sink)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotificationSink indicates an expected call of CreateNotificationSink.
+func (mr *MockStoreMockRecorder) CreateNotificationSink(sink interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotificationSink", reflect.TypeOf((*MockStore)(nil).CreateNotificationSink), sink)
+}
+
 // CreatePendingDownloadAppVersion mocks base method.
-func (m *MockStore) CreatePendingDownloadAppVersion(appID string, update types13.Update, kotsApplication *v1beta10.Application, license *licensewrapper.LicenseWrapper) (int64, error) {
+func (m *MockStore) CreatePendingDownloadAppVersion(appID string, update types17.Update, kotsApplication *v1beta10.Application, license *licensewrapper.LicenseWrapper) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreatePendingDownloadAppVersion", appID, update, kotsApplication, license)
 	ret0, _ := ret[0].(int64)
@@ -215,25 +321,25 @@ func (mr *MockStoreMockRecorder) CreateScheduledSnapshot(snapshotID, appID, time
 }
 
 // CreateSession mocks base method.
-func (m *MockStore) CreateSession(user *types14.User, issuedAt, expiresAt time.Time, roles []string) (*types10.Session, error) {
+func (m *MockStore) CreateSession(user *types18.User, issuedAt, expiresAt time.Time, roles []string, source types13.Source) (*types13.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", user, issuedAt, expiresAt, roles)
-	ret0, _ := ret[0].(*types10.Session)
+	ret := m.ctrl.Call(m, "CreateSession", user, issuedAt, expiresAt, roles, source)
+	ret0, _ := ret[0].(*types13.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateSession indicates an expected call of CreateSession.
-func (mr *MockStoreMockRecorder) CreateSession(user, issuedAt, expiresAt, roles interface{}) *gomock.Call {
+func (mr *MockStoreMockRecorder) CreateSession(user, issuedAt, expiresAt, roles, source interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), user, issuedAt, expiresAt, roles)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockStore)(nil).CreateSession), user, issuedAt, expiresAt, roles, source)
 }
 
 // CreateSupportBundle mocks base method.
-func (m *MockStore) CreateSupportBundle(bundleID, appID, archivePath string, marshalledTree []byte) (*types12.SupportBundle, error) {
+func (m *MockStore) CreateSupportBundle(bundleID, appID, archivePath string, marshalledTree []byte) (*types15.SupportBundle, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateSupportBundle", bundleID, appID, archivePath, marshalledTree)
-	ret0, _ := ret[0].(*types12.SupportBundle)
+	ret0, _ := ret[0].(*types15.SupportBundle)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -244,6 +350,63 @@ func (mr *MockStoreMockRecorder) CreateSupportBundle(bundleID, appID, archivePat
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSupportBundle", reflect.TypeOf((*MockStore)(nil).CreateSupportBundle), bundleID, appID, archivePath, marshalledTree)
 }
 
+// CreateVersionBlock mocks base method.
+func (m *MockStore) CreateVersionBlock(appID, versionLabel string, sequence *int64, reason, createdBy string) (*types4.VersionBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVersionBlock", appID, versionLabel, sequence, reason, createdBy)
+	ret0, _ := ret[0].(*types4.VersionBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVersionBlock indicates an expected call of CreateVersionBlock.
+func (mr *MockStoreMockRecorder) CreateVersionBlock(appID, versionLabel, sequence, reason, createdBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVersionBlock", reflect.TypeOf((*MockStore)(nil).CreateVersionBlock), appID, versionLabel, sequence, reason, createdBy)
+}
+
+// DeleteAPIToken mocks base method.
+func (m *MockStore) DeleteAPIToken(tokenID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAPIToken", tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAPIToken indicates an expected call of DeleteAPIToken.
+func (mr *MockStoreMockRecorder) DeleteAPIToken(tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIToken", reflect.TypeOf((*MockStore)(nil).DeleteAPIToken), tokenID)
+}
+
+// DeleteAllSessions mocks base method.
+func (m *MockStore) DeleteAllSessions() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllSessions")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAllSessions indicates an expected call of DeleteAllSessions.
+func (mr *MockStoreMockRecorder) DeleteAllSessions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllSessions", reflect.TypeOf((*MockStore)(nil).DeleteAllSessions))
+}
+
+// DeleteAppStatusHistoryBefore mocks base method.
+func (m *MockStore) DeleteAppStatusHistoryBefore(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAppStatusHistoryBefore", before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAppStatusHistoryBefore indicates an expected call of DeleteAppStatusHistoryBefore.
+func (mr *MockStoreMockRecorder) DeleteAppStatusHistoryBefore(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAppStatusHistoryBefore", reflect.TypeOf((*MockStore)(nil).DeleteAppStatusHistoryBefore), before)
+}
+
 // DeleteAppVersion mocks base method.
 func (m *MockStore) DeleteAppVersion(appID string, sequence int64) error {
 	m.ctrl.T.Helper()
@@ -258,6 +421,20 @@ func (mr *MockStoreMockRecorder) DeleteAppVersion(appID, sequence interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAppVersion", reflect.TypeOf((*MockStore)(nil).DeleteAppVersion), appID, sequence)
 }
 
+// DeleteAuditEventsBefore mocks base method.
+func (m *MockStore) DeleteAuditEventsBefore(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAuditEventsBefore", before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAuditEventsBefore indicates an expected call of DeleteAuditEventsBefore.
+func (mr *MockStoreMockRecorder) DeleteAuditEventsBefore(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuditEventsBefore", reflect.TypeOf((*MockStore)(nil).DeleteAuditEventsBefore), before)
+}
+
 // DeleteDownstreamDeployStatus mocks base method.
 func (m *MockStore) DeleteDownstreamDeployStatus(appID, clusterID string, sequence int64) error {
 	m.ctrl.T.Helper()
@@ -286,6 +463,48 @@ func (mr *MockStoreMockRecorder) DeleteExpiredSessions() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredSessions", reflect.TypeOf((*MockStore)(nil).DeleteExpiredSessions))
 }
 
+// DeleteLocalUser mocks base method.
+func (m *MockStore) DeleteLocalUser(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLocalUser", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLocalUser indicates an expected call of DeleteLocalUser.
+func (mr *MockStoreMockRecorder) DeleteLocalUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLocalUser", reflect.TypeOf((*MockStore)(nil).DeleteLocalUser), userID)
+}
+
+// DeleteNotificationDeliveriesBefore mocks base method.
+func (m *MockStore) DeleteNotificationDeliveriesBefore(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotificationDeliveriesBefore", before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotificationDeliveriesBefore indicates an expected call of DeleteNotificationDeliveriesBefore.
+func (mr *MockStoreMockRecorder) DeleteNotificationDeliveriesBefore(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationDeliveriesBefore", reflect.TypeOf((*MockStore)(nil).DeleteNotificationDeliveriesBefore), before)
+}
+
+// DeleteNotificationSink mocks base method.
+func (m *MockStore) DeleteNotificationSink(appID, sinkID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotificationSink", appID, sinkID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotificationSink indicates an expected call of DeleteNotificationSink.
+func (mr *MockStoreMockRecorder) DeleteNotificationSink(appID, sinkID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationSink", reflect.TypeOf((*MockStore)(nil).DeleteNotificationSink), appID, sinkID)
+}
+
 // DeletePendingScheduledInstanceSnapshots mocks base method.
 func (m *MockStore) DeletePendingScheduledInstanceSnapshots(clusterID string) error {
 	m.ctrl.T.Helper()
@@ -328,6 +547,20 @@ func (mr *MockStoreMockRecorder) DeleteSession(sessionID interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockStore)(nil).DeleteSession), sessionID)
 }
 
+// DeleteSessionsForUser mocks base method.
+func (m *MockStore) DeleteSessionsForUser(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionsForUser", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionsForUser indicates an expected call of DeleteSessionsForUser.
+func (mr *MockStoreMockRecorder) DeleteSessionsForUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionsForUser", reflect.TypeOf((*MockStore)(nil).DeleteSessionsForUser), userID)
+}
+
 // DeleteSupportBundle mocks base method.
 func (m *MockStore) DeleteSupportBundle(bundleID, appID string) error {
 	m.ctrl.T.Helper()
@@ -342,6 +575,34 @@ func (mr *MockStoreMockRecorder) DeleteSupportBundle(bundleID, appID interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSupportBundle", reflect.TypeOf((*MockStore)(nil).DeleteSupportBundle), bundleID, appID)
 }
 
+// DeleteTwoFactorConfig mocks base method.
+func (m *MockStore) DeleteTwoFactorConfig(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTwoFactorConfig", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTwoFactorConfig indicates an expected call of DeleteTwoFactorConfig.
+func (mr *MockStoreMockRecorder) DeleteTwoFactorConfig(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTwoFactorConfig", reflect.TypeOf((*MockStore)(nil).DeleteTwoFactorConfig), userID)
+}
+
+// DeleteVersionBlock mocks base method.
+func (m *MockStore) DeleteVersionBlock(appID, blockID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVersionBlock", appID, blockID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVersionBlock indicates an expected call of DeleteVersionBlock.
+func (mr *MockStoreMockRecorder) DeleteVersionBlock(appID, blockID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVersionBlock", reflect.TypeOf((*MockStore)(nil).DeleteVersionBlock), appID, blockID)
+}
+
 // FindDownstreamVersions mocks base method.
 func (m *MockStore) FindDownstreamVersions(appID string, downloadedOnly bool) (*types0.DownstreamVersions, error) {
 	m.ctrl.T.Helper()
@@ -371,6 +632,34 @@ func (mr *MockStoreMockRecorder) FlagInvalidPassword() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlagInvalidPassword", reflect.TypeOf((*MockStore)(nil).FlagInvalidPassword))
 }
 
+// FlagLocalUserInvalidPassword mocks base method.
+func (m *MockStore) FlagLocalUserInvalidPassword(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlagLocalUserInvalidPassword", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlagLocalUserInvalidPassword indicates an expected call of FlagLocalUserInvalidPassword.
+func (mr *MockStoreMockRecorder) FlagLocalUserInvalidPassword(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlagLocalUserInvalidPassword", reflect.TypeOf((*MockStore)(nil).FlagLocalUserInvalidPassword), userID)
+}
+
+// FlagLocalUserSuccessfulLogin mocks base method.
+func (m *MockStore) FlagLocalUserSuccessfulLogin(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlagLocalUserSuccessfulLogin", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlagLocalUserSuccessfulLogin indicates an expected call of FlagLocalUserSuccessfulLogin.
+func (mr *MockStoreMockRecorder) FlagLocalUserSuccessfulLogin(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlagLocalUserSuccessfulLogin", reflect.TypeOf((*MockStore)(nil).FlagLocalUserSuccessfulLogin), userID)
+}
+
 // FlagSuccessfulLogin mocks base method.
 func (m *MockStore) FlagSuccessfulLogin() error {
 	m.ctrl.T.Helper()
@@ -385,6 +674,36 @@ func (mr *MockStoreMockRecorder) FlagSuccessfulLogin() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlagSuccessfulLogin", reflect.TypeOf((*MockStore)(nil).FlagSuccessfulLogin))
 }
 
+// GetAPITokenBySHA256 mocks base method.
+func (m *MockStore) GetAPITokenBySHA256(tokenSHA256 string) (*types3.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPITokenBySHA256", tokenSHA256)
+	ret0, _ := ret[0].(*types3.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPITokenBySHA256 indicates an expected call of GetAPITokenBySHA256.
+func (mr *MockStoreMockRecorder) GetAPITokenBySHA256(tokenSHA256 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPITokenBySHA256", reflect.TypeOf((*MockStore)(nil).GetAPITokenBySHA256), tokenSHA256)
+}
+
+// GetAgentClusterIDFromToken mocks base method.
+func (m *MockStore) GetAgentClusterIDFromToken(deployToken string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAgentClusterIDFromToken", deployToken)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAgentClusterIDFromToken indicates an expected call of GetAgentClusterIDFromToken.
+func (mr *MockStoreMockRecorder) GetAgentClusterIDFromToken(deployToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgentClusterIDFromToken", reflect.TypeOf((*MockStore)(nil).GetAgentClusterIDFromToken), deployToken)
+}
+
 // GetAirgapInstallStatus mocks base method.
 func (m *MockStore) GetAirgapInstallStatus(appID string) (*types.InstallStatus, error) {
 	m.ctrl.T.Helper()
@@ -416,10 +735,10 @@ func (mr *MockStoreMockRecorder) GetAllAppLicenses() *gomock.Call {
 }
 
 // GetApp mocks base method.
-func (m *MockStore) GetApp(appID string) (*types3.App, error) {
+func (m *MockStore) GetApp(appID string) (*types4.App, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetApp", appID)
-	ret0, _ := ret[0].(*types3.App)
+	ret0, _ := ret[0].(*types4.App)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -431,10 +750,10 @@ func (mr *MockStoreMockRecorder) GetApp(appID interface{}) *gomock.Call {
 }
 
 // GetAppFromSlug mocks base method.
-func (m *MockStore) GetAppFromSlug(slug string) (*types3.App, error) {
+func (m *MockStore) GetAppFromSlug(slug string) (*types4.App, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAppFromSlug", slug)
-	ret0, _ := ret[0].(*types3.App)
+	ret0, _ := ret[0].(*types4.App)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -492,10 +811,10 @@ func (mr *MockStoreMockRecorder) GetAppInstanceID(appID interface{}) *gomock.Cal
 }
 
 // GetAppStatus mocks base method.
-func (m *MockStore) GetAppStatus(appID string) (*types4.AppStatus, error) {
+func (m *MockStore) GetAppStatus(appID string) (*types5.AppStatus, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAppStatus", appID)
-	ret0, _ := ret[0].(*types4.AppStatus)
+	ret0, _ := ret[0].(*types5.AppStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -731,10 +1050,10 @@ func (mr *MockStoreMockRecorder) GetDownstreamVersionSource(appID, sequence inte
 }
 
 // GetDownstreamVersionStatus mocks base method.
-func (m *MockStore) GetDownstreamVersionStatus(appID string, sequence int64) (types11.DownstreamVersionStatus, error) {
+func (m *MockStore) GetDownstreamVersionStatus(appID string, sequence int64) (types14.DownstreamVersionStatus, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetDownstreamVersionStatus", appID, sequence)
-	ret0, _ := ret[0].(types11.DownstreamVersionStatus)
+	ret0, _ := ret[0].(types14.DownstreamVersionStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -942,6 +1261,36 @@ func (mr *MockStoreMockRecorder) GetLicenseForAppVersion(appID, sequence interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLicenseForAppVersion", reflect.TypeOf((*MockStore)(nil).GetLicenseForAppVersion), appID, sequence)
 }
 
+// GetLocalUserByEmail mocks base method.
+func (m *MockStore) GetLocalUserByEmail(email string) (*types18.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocalUserByEmail", email)
+	ret0, _ := ret[0].(*types18.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocalUserByEmail indicates an expected call of GetLocalUserByEmail.
+func (mr *MockStoreMockRecorder) GetLocalUserByEmail(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocalUserByEmail", reflect.TypeOf((*MockStore)(nil).GetLocalUserByEmail), email)
+}
+
+// GetLocalUserPasswordBcrypt mocks base method.
+func (m *MockStore) GetLocalUserPasswordBcrypt(userID string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocalUserPasswordBcrypt", userID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocalUserPasswordBcrypt indicates an expected call of GetLocalUserPasswordBcrypt.
+func (mr *MockStoreMockRecorder) GetLocalUserPasswordBcrypt(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocalUserPasswordBcrypt", reflect.TypeOf((*MockStore)(nil).GetLocalUserPasswordBcrypt), userID)
+}
+
 // GetNextAppSequence mocks base method.
 func (m *MockStore) GetNextAppSequence(appID string) (int64, error) {
 	m.ctrl.T.Helper()
@@ -1003,10 +1352,10 @@ func (mr *MockStoreMockRecorder) GetPendingAirgapUploadApp() *gomock.Call {
 }
 
 // GetPendingInstallationStatus mocks base method.
-func (m *MockStore) GetPendingInstallationStatus() (*types6.InstallStatus, error) {
+func (m *MockStore) GetPendingInstallationStatus() (*types9.InstallStatus, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPendingInstallationStatus")
-	ret0, _ := ret[0].(*types6.InstallStatus)
+	ret0, _ := ret[0].(*types9.InstallStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1033,10 +1382,10 @@ func (mr *MockStoreMockRecorder) GetPreflightProgress(appID, sequence interface{
 }
 
 // GetPreflightResults mocks base method.
-func (m *MockStore) GetPreflightResults(appID string, sequence int64) (*types7.PreflightResult, error) {
+func (m *MockStore) GetPreflightResults(appID string, sequence int64) (*types10.PreflightResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPreflightResults", appID, sequence)
-	ret0, _ := ret[0].(*types7.PreflightResult)
+	ret0, _ := ret[0].(*types10.PreflightResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1093,10 +1442,10 @@ func (mr *MockStoreMockRecorder) GetRedactions(bundleID interface{}) *gomock.Cal
 }
 
 // GetRegistryDetailsForApp mocks base method.
-func (m *MockStore) GetRegistryDetailsForApp(appID string) (types8.RegistrySettings, error) {
+func (m *MockStore) GetRegistryDetailsForApp(appID string) (types11.RegistrySettings, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetRegistryDetailsForApp", appID)
-	ret0, _ := ret[0].(types8.RegistrySettings)
+	ret0, _ := ret[0].(types11.RegistrySettings)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1108,10 +1457,10 @@ func (mr *MockStoreMockRecorder) GetRegistryDetailsForApp(appID interface{}) *go
 }
 
 // GetSession mocks base method.
-func (m *MockStore) GetSession(sessionID string) (*types10.Session, error) {
+func (m *MockStore) GetSession(sessionID string) (*types13.Session, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSession", sessionID)
-	ret0, _ := ret[0].(*types10.Session)
+	ret0, _ := ret[0].(*types13.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1138,10 +1487,10 @@ func (mr *MockStoreMockRecorder) GetSharedPasswordBcrypt() *gomock.Call {
 }
 
 // GetStatusForVersion mocks base method.
-func (m *MockStore) GetStatusForVersion(appID, clusterID string, sequence int64) (types11.DownstreamVersionStatus, error) {
+func (m *MockStore) GetStatusForVersion(appID, clusterID string, sequence int64) (types14.DownstreamVersionStatus, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStatusForVersion", appID, clusterID, sequence)
-	ret0, _ := ret[0].(types11.DownstreamVersionStatus)
+	ret0, _ := ret[0].(types14.DownstreamVersionStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1153,10 +1502,10 @@ func (mr *MockStoreMockRecorder) GetStatusForVersion(appID, clusterID, sequence
 }
 
 // GetSupportBundle mocks base method.
-func (m *MockStore) GetSupportBundle(bundleID string) (*types12.SupportBundle, error) {
+func (m *MockStore) GetSupportBundle(bundleID string) (*types15.SupportBundle, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSupportBundle", bundleID)
-	ret0, _ := ret[0].(*types12.SupportBundle)
+	ret0, _ := ret[0].(*types15.SupportBundle)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1168,10 +1517,10 @@ func (mr *MockStoreMockRecorder) GetSupportBundle(bundleID interface{}) *gomock.
 }
 
 // GetSupportBundleAnalysis mocks base method.
-func (m *MockStore) GetSupportBundleAnalysis(bundleID string) (*types12.SupportBundleAnalysis, error) {
+func (m *MockStore) GetSupportBundleAnalysis(bundleID string) (*types15.SupportBundleAnalysis, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSupportBundleAnalysis", bundleID)
-	ret0, _ := ret[0].(*types12.SupportBundleAnalysis)
+	ret0, _ := ret[0].(*types15.SupportBundleAnalysis)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1212,6 +1561,36 @@ func (mr *MockStoreMockRecorder) GetTargetKotsVersionForVersion(appID, sequence
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTargetKotsVersionForVersion", reflect.TypeOf((*MockStore)(nil).GetTargetKotsVersionForVersion), appID, sequence)
 }
 
+// GetTwoFactorConfig mocks base method.
+func (m *MockStore) GetTwoFactorConfig(userID string) (*types16.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTwoFactorConfig", userID)
+	ret0, _ := ret[0].(*types16.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTwoFactorConfig indicates an expected call of GetTwoFactorConfig.
+func (mr *MockStoreMockRecorder) GetTwoFactorConfig(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwoFactorConfig", reflect.TypeOf((*MockStore)(nil).GetTwoFactorConfig), userID)
+}
+
+// GetUser mocks base method.
+func (m *MockStore) GetUser(userID string) (*types18.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", userID)
+	ret0, _ := ret[0].(*types18.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockStoreMockRecorder) GetUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockStore)(nil).GetUser), userID)
+}
+
 // HasStrictPreflights mocks base method.
 func (m *MockStore) HasStrictPreflights(appID string, sequence int64) (bool, error) {
 	m.ctrl.T.Helper()
@@ -1347,7 +1726,7 @@ func (mr *MockStoreMockRecorder) IsRollbackSupportedForVersion(appID, sequence i
 }
 
 // IsSnapshotsSupportedForVersion mocks base method.
-func (m *MockStore) IsSnapshotsSupportedForVersion(a *types3.App, sequence int64, renderer types9.Renderer) (bool, error) {
+func (m *MockStore) IsSnapshotsSupportedForVersion(a *types4.App, sequence int64, renderer types12.Renderer) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "IsSnapshotsSupportedForVersion", a, sequence, renderer)
 	ret0, _ := ret[0].(bool)
@@ -1361,11 +1740,56 @@ func (mr *MockStoreMockRecorder) IsSnapshotsSupportedForVersion(a, sequence, ren
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSnapshotsSupportedForVersion", reflect.TypeOf((*MockStore)(nil).IsSnapshotsSupportedForVersion), a, sequence, renderer)
 }
 
+// ListAPITokens mocks base method.
+func (m *MockStore) ListAPITokens() ([]*types3.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPITokens")
+	ret0, _ := ret[0].([]*types3.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPITokens indicates an expected call of ListAPITokens.
+func (mr *MockStoreMockRecorder) ListAPITokens() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPITokens", reflect.TypeOf((*MockStore)(nil).ListAPITokens))
+}
+
+// ListAgentClusters mocks base method.
+func (m *MockStore) ListAgentClusters() ([]*types0.AgentCluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAgentClusters")
+	ret0, _ := ret[0].([]*types0.AgentCluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAgentClusters indicates an expected call of ListAgentClusters.
+func (mr *MockStoreMockRecorder) ListAgentClusters() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAgentClusters", reflect.TypeOf((*MockStore)(nil).ListAgentClusters))
+}
+
+// ListAppStatusTransitions mocks base method.
+func (m *MockStore) ListAppStatusTransitions(appID string, start, end time.Time) ([]types5.StateTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAppStatusTransitions", appID, start, end)
+	ret0, _ := ret[0].([]types5.StateTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAppStatusTransitions indicates an expected call of ListAppStatusTransitions.
+func (mr *MockStoreMockRecorder) ListAppStatusTransitions(appID, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAppStatusTransitions", reflect.TypeOf((*MockStore)(nil).ListAppStatusTransitions), appID, start, end)
+}
+
 // ListAppsForDownstream mocks base method.
-func (m *MockStore) ListAppsForDownstream(clusterID string) ([]*types3.App, error) {
+func (m *MockStore) ListAppsForDownstream(clusterID string) ([]*types4.App, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListAppsForDownstream", clusterID)
-	ret0, _ := ret[0].([]*types3.App)
+	ret0, _ := ret[0].([]*types4.App)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1376,6 +1800,21 @@ func (mr *MockStoreMockRecorder) ListAppsForDownstream(clusterID interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAppsForDownstream", reflect.TypeOf((*MockStore)(nil).ListAppsForDownstream), clusterID)
 }
 
+// ListAuditEvents mocks base method.
+func (m *MockStore) ListAuditEvents(opts types6.ListOptions) (*types6.EventList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEvents", opts)
+	ret0, _ := ret[0].(*types6.EventList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEvents indicates an expected call of ListAuditEvents.
+func (mr *MockStoreMockRecorder) ListAuditEvents(opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEvents", reflect.TypeOf((*MockStore)(nil).ListAuditEvents), opts)
+}
+
 // ListClusters mocks base method.
 func (m *MockStore) ListClusters() ([]*types0.Downstream, error) {
 	m.ctrl.T.Helper()
@@ -1407,10 +1846,10 @@ func (mr *MockStoreMockRecorder) ListDownstreamsForApp(appID interface{}) *gomoc
 }
 
 // ListFailedApps mocks base method.
-func (m *MockStore) ListFailedApps() ([]*types3.App, error) {
+func (m *MockStore) ListFailedApps() ([]*types4.App, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListFailedApps")
-	ret0, _ := ret[0].([]*types3.App)
+	ret0, _ := ret[0].([]*types4.App)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1437,10 +1876,10 @@ func (mr *MockStoreMockRecorder) ListInstalledAppSlugs() *gomock.Call {
 }
 
 // ListInstalledApps mocks base method.
-func (m *MockStore) ListInstalledApps() ([]*types3.App, error) {
+func (m *MockStore) ListInstalledApps() ([]*types4.App, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListInstalledApps")
-	ret0, _ := ret[0].([]*types3.App)
+	ret0, _ := ret[0].([]*types4.App)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1451,41 +1890,101 @@ func (mr *MockStoreMockRecorder) ListInstalledApps() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListInstalledApps", reflect.TypeOf((*MockStore)(nil).ListInstalledApps))
 }
 
-// ListPendingScheduledInstanceSnapshots mocks base method.
-func (m *MockStore) ListPendingScheduledInstanceSnapshots(clusterID string) ([]types5.ScheduledInstanceSnapshot, error) {
+// ListLocalUsers mocks base method.
+func (m *MockStore) ListLocalUsers() ([]*types18.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPendingScheduledInstanceSnapshots", clusterID)
-	ret0, _ := ret[0].([]types5.ScheduledInstanceSnapshot)
+	ret := m.ctrl.Call(m, "ListLocalUsers")
+	ret0, _ := ret[0].([]*types18.User)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPendingScheduledInstanceSnapshots indicates an expected call of ListPendingScheduledInstanceSnapshots.
-func (mr *MockStoreMockRecorder) ListPendingScheduledInstanceSnapshots(clusterID interface{}) *gomock.Call {
+// ListLocalUsers indicates an expected call of ListLocalUsers.
+func (mr *MockStoreMockRecorder) ListLocalUsers() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingScheduledInstanceSnapshots", reflect.TypeOf((*MockStore)(nil).ListPendingScheduledInstanceSnapshots), clusterID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLocalUsers", reflect.TypeOf((*MockStore)(nil).ListLocalUsers))
 }
 
-// ListPendingScheduledSnapshots mocks base method.
-func (m *MockStore) ListPendingScheduledSnapshots(appID string) ([]types5.ScheduledSnapshot, error) {
+// ListNotificationDeliveries mocks base method.
+func (m *MockStore) ListNotificationDeliveries(appID string, limit int) ([]*types8.Delivery, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListPendingScheduledSnapshots", appID)
-	ret0, _ := ret[0].([]types5.ScheduledSnapshot)
+	ret := m.ctrl.Call(m, "ListNotificationDeliveries", appID, limit)
+	ret0, _ := ret[0].([]*types8.Delivery)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListPendingScheduledSnapshots indicates an expected call of ListPendingScheduledSnapshots.
-func (mr *MockStoreMockRecorder) ListPendingScheduledSnapshots(appID interface{}) *gomock.Call {
+// ListNotificationDeliveries indicates an expected call of ListNotificationDeliveries.
+func (mr *MockStoreMockRecorder) ListNotificationDeliveries(appID, limit interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingScheduledSnapshots", reflect.TypeOf((*MockStore)(nil).ListPendingScheduledSnapshots), appID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationDeliveries", reflect.TypeOf((*MockStore)(nil).ListNotificationDeliveries), appID, limit)
 }
 
-// ListSupportBundles mocks base method.
-func (m *MockStore) ListSupportBundles(appID string) ([]*types12.SupportBundle, error) {
+// ListNotificationSinks mocks base method.
+func (m *MockStore) ListNotificationSinks(appID string) ([]*types8.Sink, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListSupportBundles", appID)
-	ret0, _ := ret[0].([]*types12.SupportBundle)
+	ret := m.ctrl.Call(m, "ListNotificationSinks", appID)
+	ret0, _ := ret[0].([]*types8.Sink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationSinks indicates an expected call of ListNotificationSinks.
+func (mr *MockStoreMockRecorder) ListNotificationSinks(appID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationSinks", reflect.TypeOf((*MockStore)(nil).ListNotificationSinks), appID)
+}
+
+// ListPendingScheduledInstanceSnapshots mocks base method.
+func (m *MockStore) ListPendingScheduledInstanceSnapshots(clusterID string) ([]types7.ScheduledInstanceSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingScheduledInstanceSnapshots", clusterID)
+	ret0, _ := ret[0].([]types7.ScheduledInstanceSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingScheduledInstanceSnapshots indicates an expected call of ListPendingScheduledInstanceSnapshots.
+func (mr *MockStoreMockRecorder) ListPendingScheduledInstanceSnapshots(clusterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingScheduledInstanceSnapshots", reflect.TypeOf((*MockStore)(nil).ListPendingScheduledInstanceSnapshots), clusterID)
+}
+
+// ListPendingScheduledSnapshots mocks base method.
+func (m *MockStore) ListPendingScheduledSnapshots(appID string) ([]types7.ScheduledSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPendingScheduledSnapshots", appID)
+	ret0, _ := ret[0].([]types7.ScheduledSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPendingScheduledSnapshots indicates an expected call of ListPendingScheduledSnapshots.
+func (mr *MockStoreMockRecorder) ListPendingScheduledSnapshots(appID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPendingScheduledSnapshots", reflect.TypeOf((*MockStore)(nil).ListPendingScheduledSnapshots), appID)
+}
+
+// ListSessions mocks base method.
+func (m *MockStore) ListSessions() ([]*types13.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions")
+	ret0, _ := ret[0].([]*types13.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockStoreMockRecorder) ListSessions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockStore)(nil).ListSessions))
+}
+
+// ListSupportBundles mocks base method.
+func (m *MockStore) ListSupportBundles(appID string) ([]*types15.SupportBundle, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSupportBundles", appID)
+	ret0, _ := ret[0].([]*types15.SupportBundle)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -1496,6 +1995,21 @@ func (mr *MockStoreMockRecorder) ListSupportBundles(appID interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSupportBundles", reflect.TypeOf((*MockStore)(nil).ListSupportBundles), appID)
 }
 
+// ListVersionBlocks mocks base method.
+func (m *MockStore) ListVersionBlocks(appID string) (types4.VersionBlocks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVersionBlocks", appID)
+	ret0, _ := ret[0].(types4.VersionBlocks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVersionBlocks indicates an expected call of ListVersionBlocks.
+func (mr *MockStoreMockRecorder) ListVersionBlocks(appID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVersionBlocks", reflect.TypeOf((*MockStore)(nil).ListVersionBlocks), appID)
+}
+
 // MarkAsCurrentDownstreamVersion mocks base method.
 func (m *MockStore) MarkAsCurrentDownstreamVersion(appID string, sequence int64) error {
 	m.ctrl.T.Helper()
@@ -1564,6 +2078,20 @@ func (mr *MockStoreMockRecorder) RunMigrations() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RunMigrations", reflect.TypeOf((*MockStore)(nil).RunMigrations))
 }
 
+// SetAgentClusterLastSeen mocks base method.
+func (m *MockStore) SetAgentClusterLastSeen(clusterID string, lastSeenAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAgentClusterLastSeen", clusterID, lastSeenAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAgentClusterLastSeen indicates an expected call of SetAgentClusterLastSeen.
+func (mr *MockStoreMockRecorder) SetAgentClusterLastSeen(clusterID, lastSeenAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAgentClusterLastSeen", reflect.TypeOf((*MockStore)(nil).SetAgentClusterLastSeen), clusterID, lastSeenAt)
+}
+
 // SetAppChannelChanged mocks base method.
 func (m *MockStore) SetAppChannelChanged(appID string, channelChanged bool) error {
 	m.ctrl.T.Helper()
@@ -1578,6 +2106,20 @@ func (mr *MockStoreMockRecorder) SetAppChannelChanged(appID, channelChanged inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAppChannelChanged", reflect.TypeOf((*MockStore)(nil).SetAppChannelChanged), appID, channelChanged)
 }
 
+// SetAppDriftStatus mocks base method.
+func (m *MockStore) SetAppDriftStatus(appID string, drift *types5.DriftStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAppDriftStatus", appID, drift)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAppDriftStatus indicates an expected call of SetAppDriftStatus.
+func (mr *MockStoreMockRecorder) SetAppDriftStatus(appID, drift interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAppDriftStatus", reflect.TypeOf((*MockStore)(nil).SetAppDriftStatus), appID, drift)
+}
+
 // SetAppInstallState mocks base method.
 func (m *MockStore) SetAppInstallState(appID, state string) error {
 	m.ctrl.T.Helper()
@@ -1635,7 +2177,7 @@ func (mr *MockStoreMockRecorder) SetAppSelectedChannelID(appID, channelID interf
 }
 
 // SetAppStatus mocks base method.
-func (m *MockStore) SetAppStatus(appID string, resourceStates types4.ResourceStates, updatedAt time.Time, sequence int64) error {
+func (m *MockStore) SetAppStatus(appID string, resourceStates types5.ResourceStates, updatedAt time.Time, sequence int64) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetAppStatus", appID, resourceStates, updatedAt, sequence)
 	ret0, _ := ret[0].(error)
@@ -1649,7 +2191,7 @@ func (mr *MockStoreMockRecorder) SetAppStatus(appID, resourceStates, updatedAt,
 }
 
 // SetAutoDeploy mocks base method.
-func (m *MockStore) SetAutoDeploy(appID string, autoDeploy types3.AutoDeploy) error {
+func (m *MockStore) SetAutoDeploy(appID string, autoDeploy types4.AutoDeploy) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetAutoDeploy", appID, autoDeploy)
 	ret0, _ := ret[0].(error)
@@ -1662,8 +2204,22 @@ func (mr *MockStoreMockRecorder) SetAutoDeploy(appID, autoDeploy interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAutoDeploy", reflect.TypeOf((*MockStore)(nil).SetAutoDeploy), appID, autoDeploy)
 }
 
+// SetDeployWindow mocks base method.
+func (m *MockStore) SetDeployWindow(appID string, deployWindow *types4.DeployWindow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeployWindow", appID, deployWindow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDeployWindow indicates an expected call of SetDeployWindow.
+func (mr *MockStoreMockRecorder) SetDeployWindow(appID, deployWindow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeployWindow", reflect.TypeOf((*MockStore)(nil).SetDeployWindow), appID, deployWindow)
+}
+
 // SetDownstreamVersionStatus mocks base method.
-func (m *MockStore) SetDownstreamVersionStatus(appID string, sequence int64, status types11.DownstreamVersionStatus, statusInfo string) error {
+func (m *MockStore) SetDownstreamVersionStatus(appID string, sequence int64, status types14.DownstreamVersionStatus, statusInfo string) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetDownstreamVersionStatus", appID, sequence, status, statusInfo)
 	ret0, _ := ret[0].(error)
@@ -1676,6 +2232,20 @@ func (mr *MockStoreMockRecorder) SetDownstreamVersionStatus(appID, sequence, sta
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDownstreamVersionStatus", reflect.TypeOf((*MockStore)(nil).SetDownstreamVersionStatus), appID, sequence, status, statusInfo)
 }
 
+// SetDriftDetection mocks base method.
+func (m *MockStore) SetDriftDetection(appID string, driftDetection *types4.DriftDetection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDriftDetection", appID, driftDetection)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDriftDetection indicates an expected call of SetDriftDetection.
+func (mr *MockStoreMockRecorder) SetDriftDetection(appID, driftDetection interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDriftDetection", reflect.TypeOf((*MockStore)(nil).SetDriftDetection), appID, driftDetection)
+}
+
 // SetEmbeddedClusterAuthToken mocks base method.
 func (m *MockStore) SetEmbeddedClusterAuthToken(token string) error {
 	m.ctrl.T.Helper()
@@ -1719,6 +2289,20 @@ func (mr *MockStoreMockRecorder) SetEnvironmentFingerprint(fingerprint interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEnvironmentFingerprint", reflect.TypeOf((*MockStore)(nil).SetEnvironmentFingerprint), fingerprint)
 }
 
+// SetHealthGate mocks base method.
+func (m *MockStore) SetHealthGate(appID string, healthGate *types4.HealthGate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHealthGate", appID, healthGate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHealthGate indicates an expected call of SetHealthGate.
+func (mr *MockStoreMockRecorder) SetHealthGate(appID, healthGate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHealthGate", reflect.TypeOf((*MockStore)(nil).SetHealthGate), appID, healthGate)
+}
+
 // SetIgnorePreflightPermissionErrors mocks base method.
 func (m *MockStore) SetIgnorePreflightPermissionErrors(appID string, sequence int64) error {
 	m.ctrl.T.Helper()
@@ -1775,6 +2359,48 @@ func (mr *MockStoreMockRecorder) SetIsKotsadmIDGenerated() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIsKotsadmIDGenerated", reflect.TypeOf((*MockStore)(nil).SetIsKotsadmIDGenerated))
 }
 
+// SetLocalUserDisabled mocks base method.
+func (m *MockStore) SetLocalUserDisabled(userID string, isDisabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLocalUserDisabled", userID, isDisabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLocalUserDisabled indicates an expected call of SetLocalUserDisabled.
+func (mr *MockStoreMockRecorder) SetLocalUserDisabled(userID, isDisabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLocalUserDisabled", reflect.TypeOf((*MockStore)(nil).SetLocalUserDisabled), userID, isDisabled)
+}
+
+// SetLocalUserPasswordBcrypt mocks base method.
+func (m *MockStore) SetLocalUserPasswordBcrypt(userID string, passwordBcrypt []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLocalUserPasswordBcrypt", userID, passwordBcrypt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLocalUserPasswordBcrypt indicates an expected call of SetLocalUserPasswordBcrypt.
+func (mr *MockStoreMockRecorder) SetLocalUserPasswordBcrypt(userID, passwordBcrypt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLocalUserPasswordBcrypt", reflect.TypeOf((*MockStore)(nil).SetLocalUserPasswordBcrypt), userID, passwordBcrypt)
+}
+
+// SetLocalUserRoles mocks base method.
+func (m *MockStore) SetLocalUserRoles(userID string, roles []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLocalUserRoles", userID, roles)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLocalUserRoles indicates an expected call of SetLocalUserRoles.
+func (mr *MockStoreMockRecorder) SetLocalUserRoles(userID, roles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLocalUserRoles", reflect.TypeOf((*MockStore)(nil).SetLocalUserRoles), userID, roles)
+}
+
 // SetPreflightProgress mocks base method.
 func (m *MockStore) SetPreflightProgress(appID string, sequence int64, progress string) error {
 	m.ctrl.T.Helper()
@@ -1831,6 +2457,20 @@ func (mr *MockStoreMockRecorder) SetRedactions(bundleID, redacts interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRedactions", reflect.TypeOf((*MockStore)(nil).SetRedactions), bundleID, redacts)
 }
 
+// SetScheduledDeploy mocks base method.
+func (m *MockStore) SetScheduledDeploy(appID string, scheduledDeploy *types4.ScheduledDeploy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetScheduledDeploy", appID, scheduledDeploy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetScheduledDeploy indicates an expected call of SetScheduledDeploy.
+func (mr *MockStoreMockRecorder) SetScheduledDeploy(appID, scheduledDeploy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetScheduledDeploy", reflect.TypeOf((*MockStore)(nil).SetScheduledDeploy), appID, scheduledDeploy)
+}
+
 // SetSnapshotSchedule mocks base method.
 func (m *MockStore) SetSnapshotSchedule(appID, snapshotSchedule string) error {
 	m.ctrl.T.Helper()
@@ -1859,6 +2499,20 @@ func (mr *MockStoreMockRecorder) SetSnapshotTTL(appID, snapshotTTL interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSnapshotTTL", reflect.TypeOf((*MockStore)(nil).SetSnapshotTTL), appID, snapshotTTL)
 }
 
+// SetSoakDuration mocks base method.
+func (m *MockStore) SetSoakDuration(appID, soakDuration string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSoakDuration", appID, soakDuration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSoakDuration indicates an expected call of SetSoakDuration.
+func (mr *MockStoreMockRecorder) SetSoakDuration(appID, soakDuration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSoakDuration", reflect.TypeOf((*MockStore)(nil).SetSoakDuration), appID, soakDuration)
+}
+
 // SetSupportBundleAnalysis mocks base method.
 func (m *MockStore) SetSupportBundleAnalysis(bundleID string, insights []byte) error {
 	m.ctrl.T.Helper()
@@ -1873,6 +2527,20 @@ func (mr *MockStoreMockRecorder) SetSupportBundleAnalysis(bundleID, insights int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSupportBundleAnalysis", reflect.TypeOf((*MockStore)(nil).SetSupportBundleAnalysis), bundleID, insights)
 }
 
+// SetTwoFactorConfig mocks base method.
+func (m *MockStore) SetTwoFactorConfig(userID string, config *types16.Config) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTwoFactorConfig", userID, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTwoFactorConfig indicates an expected call of SetTwoFactorConfig.
+func (mr *MockStoreMockRecorder) SetTwoFactorConfig(userID, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTwoFactorConfig", reflect.TypeOf((*MockStore)(nil).SetTwoFactorConfig), userID, config)
+}
+
 // SetUpdateCheckerSpec mocks base method.
 func (m *MockStore) SetUpdateCheckerSpec(appID, updateCheckerSpec string) error {
 	m.ctrl.T.Helper()
@@ -1887,8 +2555,22 @@ func (mr *MockStoreMockRecorder) SetUpdateCheckerSpec(appID, updateCheckerSpec i
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUpdateCheckerSpec", reflect.TypeOf((*MockStore)(nil).SetUpdateCheckerSpec), appID, updateCheckerSpec)
 }
 
+// UpdateAPITokenLastUsedAt mocks base method.
+func (m *MockStore) UpdateAPITokenLastUsedAt(tokenID string, lastUsedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAPITokenLastUsedAt", tokenID, lastUsedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAPITokenLastUsedAt indicates an expected call of UpdateAPITokenLastUsedAt.
+func (mr *MockStoreMockRecorder) UpdateAPITokenLastUsedAt(tokenID, lastUsedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPITokenLastUsedAt", reflect.TypeOf((*MockStore)(nil).UpdateAPITokenLastUsedAt), tokenID, lastUsedAt)
+}
+
 // UpdateAppLicense mocks base method.
-func (m *MockStore) UpdateAppLicense(appID string, sequence int64, archiveDir string, newLicense *licensewrapper.LicenseWrapper, originalLicenseData string, channelChanged, failOnVersionCreate bool, renderer types9.Renderer, reportingInfo *types1.ReportingInfo) (int64, error) {
+func (m *MockStore) UpdateAppLicense(appID string, sequence int64, archiveDir string, newLicense *licensewrapper.LicenseWrapper, originalLicenseData string, channelChanged, failOnVersionCreate bool, renderer types12.Renderer, reportingInfo *types1.ReportingInfo) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateAppLicense", appID, sequence, archiveDir, newLicense, originalLicenseData, channelChanged, failOnVersionCreate, renderer, reportingInfo)
 	ret0, _ := ret[0].(int64)
@@ -1945,7 +2627,7 @@ func (mr *MockStoreMockRecorder) UpdateAppVersionDemotion(appID, channelID, curs
 }
 
 // UpdateAppVersionMetadata mocks base method.
-func (m *MockStore) UpdateAppVersionMetadata(appID string, update types13.Update) error {
+func (m *MockStore) UpdateAppVersionMetadata(appID string, update types17.Update) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateAppVersionMetadata", appID, update)
 	ret0, _ := ret[0].(error)
@@ -1986,6 +2668,20 @@ func (mr *MockStoreMockRecorder) UpdateNextAppVersionDiffSummary(appID, baseSequ
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNextAppVersionDiffSummary", reflect.TypeOf((*MockStore)(nil).UpdateNextAppVersionDiffSummary), appID, baseSequence)
 }
 
+// UpdateNotificationDelivery mocks base method.
+func (m *MockStore) UpdateNotificationDelivery(delivery *types8.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationDelivery", delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotificationDelivery indicates an expected call of UpdateNotificationDelivery.
+func (mr *MockStoreMockRecorder) UpdateNotificationDelivery(delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationDelivery", reflect.TypeOf((*MockStore)(nil).UpdateNotificationDelivery), delivery)
+}
+
 // UpdateRegistry mocks base method.
 func (m *MockStore) UpdateRegistry(appID, hostname, username, password, namespace string, isReadOnly bool) error {
 	m.ctrl.T.Helper()
@@ -2043,7 +2739,7 @@ func (mr *MockStoreMockRecorder) UpdateSessionExpiresAt(sessionID, expiresAt int
 }
 
 // UpdateSupportBundle mocks base method.
-func (m *MockStore) UpdateSupportBundle(bundle *types12.SupportBundle) error {
+func (m *MockStore) UpdateSupportBundle(bundle *types15.SupportBundle) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateSupportBundle", bundle)
 	ret0, _ := ret[0].(error)
@@ -2158,10 +2854,10 @@ func (mr *MockRegistryStoreMockRecorder) GetAppIDsFromRegistry(hostname interfac
 }
 
 // GetRegistryDetailsForApp mocks base method.
-func (m *MockRegistryStore) GetRegistryDetailsForApp(appID string) (types8.RegistrySettings, error) {
+func (m *MockRegistryStore) GetRegistryDetailsForApp(appID string) (types11.RegistrySettings, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetRegistryDetailsForApp", appID)
-	ret0, _ := ret[0].(types8.RegistrySettings)
+	ret0, _ := ret[0].(types11.RegistrySettings)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -2210,7 +2906,7 @@ func (m *MockSupportBundleStore) EXPECT() *MockSupportBundleStoreMockRecorder {
 }
 
 // CreateInProgressSupportBundle mocks base method.
-func (m *MockSupportBundleStore) CreateInProgressSupportBundle(supportBundle *types12.SupportBundle) error {
+func (m *MockSupportBundleStore) CreateInProgressSupportBundle(supportBundle *types15.SupportBundle) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateInProgressSupportBundle", supportBundle)
 	ret0, _ := ret[0].(error)
@@ -2224,10 +2920,10 @@ func (mr *MockSupportBundleStoreMockRecorder) CreateInProgressSupportBundle(supp
 }
 
 // CreateSupportBundle mocks base method.
-func (m *MockSupportBundleStore) CreateSupportBundle(bundleID, appID, archivePath string, marshalledTree []byte) (*types12.SupportBundle, error) {
+func (m *MockSupportBundleStore) CreateSupportBundle(bundleID, appID, archivePath string, marshalledTree []byte) (*types15.SupportBundle, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateSupportBundle", bundleID, appID, archivePath, marshalledTree)
-	ret0, _ := ret[0].(*types12.SupportBundle)
+	ret0, _ := ret[0].(*types15.SupportBundle)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -2268,10 +2964,10 @@ func (mr *MockSupportBundleStoreMockRecorder) GetRedactions(bundleID interface{}
 }
 
 // GetSupportBundle mocks base method.
-func (m *MockSupportBundleStore) GetSupportBundle(bundleID string) (*types12.SupportBundle, error) {
+func (m *MockSupportBundleStore) GetSupportBundle(bundleID string) (*types15.SupportBundle, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSupportBundle", bundleID)
-	ret0, _ := ret[0].(*types12.SupportBundle)
+	ret0, _ := ret[0].(*types15.SupportBundle)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -2283,10 +2979,10 @@ func (mr *MockSupportBundleStoreMockRecorder) GetSupportBundle(bundleID interfac
 }
 
 // GetSupportBundleAnalysis mocks base method.
-func (m *MockSupportBundleStore) GetSupportBundleAnalysis(bundleID string) (*types12.SupportBundleAnalysis, error) {
+func (m *MockSupportBundleStore) GetSupportBundleAnalysis(bundleID string) (*types15.SupportBundleAnalysis, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSupportBundleAnalysis", bundleID)
-	ret0, _ := ret[0].(*types12.SupportBundleAnalysis)
+	ret0, _ := ret[0].(*types15.SupportBundleAnalysis)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -2313,10 +3009,10 @@ func (mr *MockSupportBundleStoreMockRecorder) GetSupportBundleArchive(bundleID i
 }
 
 // ListSupportBundles mocks base method.
-func (m *MockSupportBundleStore) ListSupportBundles(appID string) ([]*types12.SupportBundle, error) {
+func (m *MockSupportBundleStore) ListSupportBundles(appID string) ([]*types15.SupportBundle, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListSupportBundles", appID)
-	ret0, _ := ret[0].([]*types12.SupportBundle)
+	ret0, _ := ret[0].([]*types15.SupportBundle)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -2356,7 +3052,7 @@ func (mr *MockSupportBundleStoreMockRecorder) SetSupportBundleAnalysis(bundleID,
 }
 
 // UpdateSupportBundle mocks base method.
-func (m *MockSupportBundleStore) UpdateSupportBundle(bundle *types12.SupportBundle) error {
+func (m *MockSupportBundleStore) UpdateSupportBundle(bundle *types15.SupportBundle) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateSupportBundle", bundle)
 	ret0, _ := ret[0].(error)
@@ -2422,10 +3118,10 @@ func (mr *MockPreflightStoreMockRecorder) GetPreflightProgress(appID, sequence i
 }
 
 // GetPreflightResults mocks base method.
-func (m *MockPreflightStore) GetPreflightResults(appID string, sequence int64) (*types7.PreflightResult, error) {
+func (m *MockPreflightStore) GetPreflightResults(appID string, sequence int64) (*types10.PreflightResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPreflightResults", appID, sequence)
-	ret0, _ := ret[0].(*types7.PreflightResult)
+	ret0, _ := ret[0].(*types10.PreflightResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -2649,18 +3345,32 @@ func (m *MockSessionStore) EXPECT() *MockSessionStoreMockRecorder {
 }
 
 // CreateSession mocks base method.
-func (m *MockSessionStore) CreateSession(user *types14.User, issuedAt, expiresAt time.Time, roles []string) (*types10.Session, error) {
+func (m *MockSessionStore) CreateSession(user *types18.User, issuedAt, expiresAt time.Time, roles []string, source types13.Source) (*types13.Session, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateSession", user, issuedAt, expiresAt, roles)
-	ret0, _ := ret[0].(*types10.Session)
+	ret := m.ctrl.Call(m, "CreateSession", user, issuedAt, expiresAt, roles, source)
+	ret0, _ := ret[0].(*types13.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateSession indicates an expected call of CreateSession.
-func (mr *MockSessionStoreMockRecorder) CreateSession(user, issuedAt, expiresAt, roles interface{}) *gomock.Call {
+func (mr *MockSessionStoreMockRecorder) CreateSession(user, issuedAt, expiresAt, roles, source interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSessionStore)(nil).CreateSession), user, issuedAt, expiresAt, roles, source)
+}
+
+// DeleteAllSessions mocks base method.
+func (m *MockSessionStore) DeleteAllSessions() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAllSessions")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAllSessions indicates an expected call of DeleteAllSessions.
+func (mr *MockSessionStoreMockRecorder) DeleteAllSessions() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockSessionStore)(nil).CreateSession), user, issuedAt, expiresAt, roles)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAllSessions", reflect.TypeOf((*MockSessionStore)(nil).DeleteAllSessions))
 }
 
 // DeleteExpiredSessions mocks base method.
@@ -2691,11 +3401,25 @@ func (mr *MockSessionStoreMockRecorder) DeleteSession(sessionID interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSession", reflect.TypeOf((*MockSessionStore)(nil).DeleteSession), sessionID)
 }
 
+// DeleteSessionsForUser mocks base method.
+func (m *MockSessionStore) DeleteSessionsForUser(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionsForUser", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionsForUser indicates an expected call of DeleteSessionsForUser.
+func (mr *MockSessionStoreMockRecorder) DeleteSessionsForUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionsForUser", reflect.TypeOf((*MockSessionStore)(nil).DeleteSessionsForUser), userID)
+}
+
 // GetSession mocks base method.
-func (m *MockSessionStore) GetSession(sessionID string) (*types10.Session, error) {
+func (m *MockSessionStore) GetSession(sessionID string) (*types13.Session, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetSession", sessionID)
-	ret0, _ := ret[0].(*types10.Session)
+	ret0, _ := ret[0].(*types13.Session)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -2706,6 +3430,21 @@ func (mr *MockSessionStoreMockRecorder) GetSession(sessionID interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockSessionStore)(nil).GetSession), sessionID)
 }
 
+// ListSessions mocks base method.
+func (m *MockSessionStore) ListSessions() ([]*types13.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions")
+	ret0, _ := ret[0].([]*types13.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockSessionStoreMockRecorder) ListSessions() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockSessionStore)(nil).ListSessions))
+}
+
 // UpdateSessionExpiresAt mocks base method.
 func (m *MockSessionStore) UpdateSessionExpiresAt(sessionID string, expiresAt time.Time) error {
 	m.ctrl.T.Helper()
@@ -2743,11 +3482,25 @@ func (m *MockAppStatusStore) EXPECT() *MockAppStatusStoreMockRecorder {
 	return m.recorder
 }
 
+// DeleteAppStatusHistoryBefore mocks base method.
+func (m *MockAppStatusStore) DeleteAppStatusHistoryBefore(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAppStatusHistoryBefore", before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAppStatusHistoryBefore indicates an expected call of DeleteAppStatusHistoryBefore.
+func (mr *MockAppStatusStoreMockRecorder) DeleteAppStatusHistoryBefore(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAppStatusHistoryBefore", reflect.TypeOf((*MockAppStatusStore)(nil).DeleteAppStatusHistoryBefore), before)
+}
+
 // GetAppStatus mocks base method.
-func (m *MockAppStatusStore) GetAppStatus(appID string) (*types4.AppStatus, error) {
+func (m *MockAppStatusStore) GetAppStatus(appID string) (*types5.AppStatus, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAppStatus", appID)
-	ret0, _ := ret[0].(*types4.AppStatus)
+	ret0, _ := ret[0].(*types5.AppStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -2758,8 +3511,37 @@ func (mr *MockAppStatusStoreMockRecorder) GetAppStatus(appID interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAppStatus", reflect.TypeOf((*MockAppStatusStore)(nil).GetAppStatus), appID)
 }
 
+// ListAppStatusTransitions mocks base method.
+func (m *MockAppStatusStore) ListAppStatusTransitions(appID string, start, end time.Time) ([]types5.StateTransition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAppStatusTransitions", appID, start, end)
+	ret0, _ := ret[0].([]types5.StateTransition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAppStatusTransitions indicates an expected call of ListAppStatusTransitions.
+func (mr *MockAppStatusStoreMockRecorder) ListAppStatusTransitions(appID, start, end interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAppStatusTransitions", reflect.TypeOf((*MockAppStatusStore)(nil).ListAppStatusTransitions), appID, start, end)
+}
+
+// SetAppDriftStatus mocks base method.
+func (m *MockAppStatusStore) SetAppDriftStatus(appID string, drift *types5.DriftStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAppDriftStatus", appID, drift)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAppDriftStatus indicates an expected call of SetAppDriftStatus.
+func (mr *MockAppStatusStoreMockRecorder) SetAppDriftStatus(appID, drift interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAppDriftStatus", reflect.TypeOf((*MockAppStatusStore)(nil).SetAppDriftStatus), appID, drift)
+}
+
 // SetAppStatus mocks base method.
-func (m *MockAppStatusStore) SetAppStatus(appID string, resourceStates types4.ResourceStates, updatedAt time.Time, sequence int64) error {
+func (m *MockAppStatusStore) SetAppStatus(appID string, resourceStates types5.ResourceStates, updatedAt time.Time, sequence int64) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetAppStatus", appID, resourceStates, updatedAt, sequence)
 	ret0, _ := ret[0].(error)
@@ -2810,10 +3592,10 @@ func (mr *MockAppStoreMockRecorder) AddAppToAllDownstreams(appID interface{}) *g
 }
 
 // CreateApp mocks base method.
-func (m *MockAppStore) CreateApp(name, channelID, upstreamURI, licenseData string, isAirgapEnabled, skipImagePush, registryIsReadOnly bool) (*types3.App, error) {
+func (m *MockAppStore) CreateApp(name, channelID, upstreamURI, licenseData string, isAirgapEnabled, skipImagePush, registryIsReadOnly bool) (*types4.App, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreateApp", name, channelID, upstreamURI, licenseData, isAirgapEnabled, skipImagePush, registryIsReadOnly)
-	ret0, _ := ret[0].(*types3.App)
+	ret0, _ := ret[0].(*types4.App)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -2825,10 +3607,10 @@ func (mr *MockAppStoreMockRecorder) CreateApp(name, channelID, upstreamURI, lice
 }
 
 // GetApp mocks base method.
-func (m *MockAppStore) GetApp(appID string) (*types3.App, error) {
+func (m *MockAppStore) GetApp(appID string) (*types4.App, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetApp", appID)
-	ret0, _ := ret[0].(*types3.App)
+	ret0, _ := ret[0].(*types4.App)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -2840,10 +3622,10 @@ func (mr *MockAppStoreMockRecorder) GetApp(appID interface{}) *gomock.Call {
 }
 
 // GetAppFromSlug mocks base method.
-func (m *MockAppStore) GetAppFromSlug(slug string) (*types3.App, error) {
+func (m *MockAppStore) GetAppFromSlug(slug string) (*types4.App, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAppFromSlug", slug)
-	ret0, _ := ret[0].(*types3.App)
+	ret0, _ := ret[0].(*types4.App)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -2916,10 +3698,10 @@ func (mr *MockAppStoreMockRecorder) IsGitOpsEnabledForApp(appID interface{}) *go
 }
 
 // ListAppsForDownstream mocks base method.
-func (m *MockAppStore) ListAppsForDownstream(clusterID string) ([]*types3.App, error) {
+func (m *MockAppStore) ListAppsForDownstream(clusterID string) ([]*types4.App, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListAppsForDownstream", clusterID)
-	ret0, _ := ret[0].([]*types3.App)
+	ret0, _ := ret[0].([]*types4.App)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -2946,10 +3728,10 @@ func (mr *MockAppStoreMockRecorder) ListDownstreamsForApp(appID interface{}) *go
 }
 
 // ListFailedApps mocks base method.
-func (m *MockAppStore) ListFailedApps() ([]*types3.App, error) {
+func (m *MockAppStore) ListFailedApps() ([]*types4.App, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListFailedApps")
-	ret0, _ := ret[0].([]*types3.App)
+	ret0, _ := ret[0].([]*types4.App)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -2976,10 +3758,10 @@ func (mr *MockAppStoreMockRecorder) ListInstalledAppSlugs() *gomock.Call {
 }
 
 // ListInstalledApps mocks base method.
-func (m *MockAppStore) ListInstalledApps() ([]*types3.App, error) {
+func (m *MockAppStore) ListInstalledApps() ([]*types4.App, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListInstalledApps")
-	ret0, _ := ret[0].([]*types3.App)
+	ret0, _ := ret[0].([]*types4.App)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -3061,7 +3843,7 @@ func (mr *MockAppStoreMockRecorder) SetAppSelectedChannelID(appID, channelID int
 }
 
 // SetAutoDeploy mocks base method.
-func (m *MockAppStore) SetAutoDeploy(appID string, autoDeploy types3.AutoDeploy) error {
+func (m *MockAppStore) SetAutoDeploy(appID string, autoDeploy types4.AutoDeploy) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetAutoDeploy", appID, autoDeploy)
 	ret0, _ := ret[0].(error)
@@ -3074,6 +3856,62 @@ func (mr *MockAppStoreMockRecorder) SetAutoDeploy(appID, autoDeploy interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAutoDeploy", reflect.TypeOf((*MockAppStore)(nil).SetAutoDeploy), appID, autoDeploy)
 }
 
+// SetDeployWindow mocks base method.
+func (m *MockAppStore) SetDeployWindow(appID string, deployWindow *types4.DeployWindow) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDeployWindow", appID, deployWindow)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDeployWindow indicates an expected call of SetDeployWindow.
+func (mr *MockAppStoreMockRecorder) SetDeployWindow(appID, deployWindow interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDeployWindow", reflect.TypeOf((*MockAppStore)(nil).SetDeployWindow), appID, deployWindow)
+}
+
+// SetDriftDetection mocks base method.
+func (m *MockAppStore) SetDriftDetection(appID string, driftDetection *types4.DriftDetection) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDriftDetection", appID, driftDetection)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetDriftDetection indicates an expected call of SetDriftDetection.
+func (mr *MockAppStoreMockRecorder) SetDriftDetection(appID, driftDetection interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDriftDetection", reflect.TypeOf((*MockAppStore)(nil).SetDriftDetection), appID, driftDetection)
+}
+
+// SetHealthGate mocks base method.
+func (m *MockAppStore) SetHealthGate(appID string, healthGate *types4.HealthGate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHealthGate", appID, healthGate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHealthGate indicates an expected call of SetHealthGate.
+func (mr *MockAppStoreMockRecorder) SetHealthGate(appID, healthGate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHealthGate", reflect.TypeOf((*MockAppStore)(nil).SetHealthGate), appID, healthGate)
+}
+
+// SetScheduledDeploy mocks base method.
+func (m *MockAppStore) SetScheduledDeploy(appID string, scheduledDeploy *types4.ScheduledDeploy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetScheduledDeploy", appID, scheduledDeploy)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetScheduledDeploy indicates an expected call of SetScheduledDeploy.
+func (mr *MockAppStoreMockRecorder) SetScheduledDeploy(appID, scheduledDeploy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetScheduledDeploy", reflect.TypeOf((*MockAppStore)(nil).SetScheduledDeploy), appID, scheduledDeploy)
+}
+
 // SetSnapshotSchedule mocks base method.
 func (m *MockAppStore) SetSnapshotSchedule(appID, snapshotSchedule string) error {
 	m.ctrl.T.Helper()
@@ -3102,6 +3940,20 @@ func (mr *MockAppStoreMockRecorder) SetSnapshotTTL(appID, snapshotTTL interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSnapshotTTL", reflect.TypeOf((*MockAppStore)(nil).SetSnapshotTTL), appID, snapshotTTL)
 }
 
+// SetSoakDuration mocks base method.
+func (m *MockAppStore) SetSoakDuration(appID, soakDuration string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSoakDuration", appID, soakDuration)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSoakDuration indicates an expected call of SetSoakDuration.
+func (mr *MockAppStoreMockRecorder) SetSoakDuration(appID, soakDuration interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSoakDuration", reflect.TypeOf((*MockAppStore)(nil).SetSoakDuration), appID, soakDuration)
+}
+
 // SetUpdateCheckerSpec mocks base method.
 func (m *MockAppStore) SetUpdateCheckerSpec(appID, updateCheckerSpec string) error {
 	m.ctrl.T.Helper()
@@ -3287,10 +4139,10 @@ func (mr *MockDownstreamStoreMockRecorder) GetDownstreamVersionSource(appID, seq
 }
 
 // GetDownstreamVersionStatus mocks base method.
-func (m *MockDownstreamStore) GetDownstreamVersionStatus(appID string, sequence int64) (types11.DownstreamVersionStatus, error) {
+func (m *MockDownstreamStore) GetDownstreamVersionStatus(appID string, sequence int64) (types14.DownstreamVersionStatus, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetDownstreamVersionStatus", appID, sequence)
-	ret0, _ := ret[0].(types11.DownstreamVersionStatus)
+	ret0, _ := ret[0].(types14.DownstreamVersionStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -3379,10 +4231,10 @@ func (mr *MockDownstreamStoreMockRecorder) GetPreviouslyDeployedSequence(appID,
 }
 
 // GetStatusForVersion mocks base method.
-func (m *MockDownstreamStore) GetStatusForVersion(appID, clusterID string, sequence int64) (types11.DownstreamVersionStatus, error) {
+func (m *MockDownstreamStore) GetStatusForVersion(appID, clusterID string, sequence int64) (types14.DownstreamVersionStatus, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetStatusForVersion", appID, clusterID, sequence)
-	ret0, _ := ret[0].(types11.DownstreamVersionStatus)
+	ret0, _ := ret[0].(types14.DownstreamVersionStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -3439,7 +4291,7 @@ func (mr *MockDownstreamStoreMockRecorder) MarkAsCurrentDownstreamVersion(appID,
 }
 
 // SetDownstreamVersionStatus mocks base method.
-func (m *MockDownstreamStore) SetDownstreamVersionStatus(appID string, sequence int64, status types11.DownstreamVersionStatus, statusInfo string) error {
+func (m *MockDownstreamStore) SetDownstreamVersionStatus(appID string, sequence int64, status types14.DownstreamVersionStatus, statusInfo string) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SetDownstreamVersionStatus", appID, sequence, status, statusInfo)
 	ret0, _ := ret[0].(error)
@@ -3546,10 +4398,10 @@ func (mr *MockSnapshotStoreMockRecorder) DeletePendingScheduledSnapshots(appID i
 }
 
 // ListPendingScheduledInstanceSnapshots mocks base method.
-func (m *MockSnapshotStore) ListPendingScheduledInstanceSnapshots(clusterID string) ([]types5.ScheduledInstanceSnapshot, error) {
+func (m *MockSnapshotStore) ListPendingScheduledInstanceSnapshots(clusterID string) ([]types7.ScheduledInstanceSnapshot, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListPendingScheduledInstanceSnapshots", clusterID)
-	ret0, _ := ret[0].([]types5.ScheduledInstanceSnapshot)
+	ret0, _ := ret[0].([]types7.ScheduledInstanceSnapshot)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -3561,10 +4413,10 @@ func (mr *MockSnapshotStoreMockRecorder) ListPendingScheduledInstanceSnapshots(c
 }
 
 // ListPendingScheduledSnapshots mocks base method.
-func (m *MockSnapshotStore) ListPendingScheduledSnapshots(appID string) ([]types5.ScheduledSnapshot, error) {
+func (m *MockSnapshotStore) ListPendingScheduledSnapshots(appID string) ([]types7.ScheduledSnapshot, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListPendingScheduledSnapshots", appID)
-	ret0, _ := ret[0].([]types5.ScheduledSnapshot)
+	ret0, _ := ret[0].([]types7.ScheduledSnapshot)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -3642,7 +4494,7 @@ func (mr *MockVersionStoreMockRecorder) CreateAppVersion(appID, baseSequence, fi
 }
 
 // CreatePendingDownloadAppVersion mocks base method.
-func (m *MockVersionStore) CreatePendingDownloadAppVersion(appID string, update types13.Update, kotsApplication *v1beta10.Application, license *licensewrapper.LicenseWrapper) (int64, error) {
+func (m *MockVersionStore) CreatePendingDownloadAppVersion(appID string, update types17.Update, kotsApplication *v1beta10.Application, license *licensewrapper.LicenseWrapper) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "CreatePendingDownloadAppVersion", appID, update, kotsApplication, license)
 	ret0, _ := ret[0].(int64)
@@ -3851,7 +4703,7 @@ func (mr *MockVersionStoreMockRecorder) IsRollbackSupportedForVersion(appID, seq
 }
 
 // IsSnapshotsSupportedForVersion mocks base method.
-func (m *MockVersionStore) IsSnapshotsSupportedForVersion(a *types3.App, sequence int64, renderer types9.Renderer) (bool, error) {
+func (m *MockVersionStore) IsSnapshotsSupportedForVersion(a *types4.App, sequence int64, renderer types12.Renderer) (bool, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "IsSnapshotsSupportedForVersion", a, sequence, renderer)
 	ret0, _ := ret[0].(bool)
@@ -3894,7 +4746,7 @@ func (mr *MockVersionStoreMockRecorder) UpdateAppVersionDemotion(appID, channelI
 }
 
 // UpdateAppVersionMetadata mocks base method.
-func (m *MockVersionStore) UpdateAppVersionMetadata(appID string, update types13.Update) error {
+func (m *MockVersionStore) UpdateAppVersionMetadata(appID string, update types17.Update) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateAppVersionMetadata", appID, update)
 	ret0, _ := ret[0].(error)
@@ -3990,7 +4842,7 @@ func (mr *MockLicenseStoreMockRecorder) GetLicenseForAppVersion(appID, sequence
 }
 
 // UpdateAppLicense mocks base method.
-func (m *MockLicenseStore) UpdateAppLicense(appID string, sequence int64, archiveDir string, newLicense *licensewrapper.LicenseWrapper, originalLicenseData string, channelChanged, failOnVersionCreate bool, renderer types9.Renderer, reportingInfo *types1.ReportingInfo) (int64, error) {
+func (m *MockLicenseStore) UpdateAppLicense(appID string, sequence int64, archiveDir string, newLicense *licensewrapper.LicenseWrapper, originalLicenseData string, channelChanged, failOnVersionCreate bool, renderer types12.Renderer, reportingInfo *types1.ReportingInfo) (int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "UpdateAppLicense", appID, sequence, archiveDir, newLicense, originalLicenseData, channelChanged, failOnVersionCreate, renderer, reportingInfo)
 	ret0, _ := ret[0].(int64)
@@ -4041,22 +4893,79 @@ func (m *MockUserStore) EXPECT() *MockUserStoreMockRecorder {
 	return m.recorder
 }
 
-// FlagInvalidPassword mocks base method.
-func (m *MockUserStore) FlagInvalidPassword() error {
+// CreateLocalUser mocks base method.
+func (m *MockUserStore) CreateLocalUser(email, firstName, lastName string, passwordBcrypt []byte, roles []string) (*types18.User, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FlagInvalidPassword")
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "CreateLocalUser", email, firstName, lastName, passwordBcrypt, roles)
+	ret0, _ := ret[0].(*types18.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// FlagInvalidPassword indicates an expected call of FlagInvalidPassword.
-func (mr *MockUserStoreMockRecorder) FlagInvalidPassword() *gomock.Call {
+// CreateLocalUser indicates an expected call of CreateLocalUser.
+func (mr *MockUserStoreMockRecorder) CreateLocalUser(email, firstName, lastName, passwordBcrypt, roles interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlagInvalidPassword", reflect.TypeOf((*MockUserStore)(nil).FlagInvalidPassword))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLocalUser", reflect.TypeOf((*MockUserStore)(nil).CreateLocalUser), email, firstName, lastName, passwordBcrypt, roles)
 }
 
-// FlagSuccessfulLogin mocks base method.
-func (m *MockUserStore) FlagSuccessfulLogin() error {
+// DeleteLocalUser mocks base method.
+func (m *MockUserStore) DeleteLocalUser(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteLocalUser", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteLocalUser indicates an expected call of DeleteLocalUser.
+func (mr *MockUserStoreMockRecorder) DeleteLocalUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteLocalUser", reflect.TypeOf((*MockUserStore)(nil).DeleteLocalUser), userID)
+}
+
+// FlagInvalidPassword mocks base method.
+func (m *MockUserStore) FlagInvalidPassword() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlagInvalidPassword")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlagInvalidPassword indicates an expected call of FlagInvalidPassword.
+func (mr *MockUserStoreMockRecorder) FlagInvalidPassword() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlagInvalidPassword", reflect.TypeOf((*MockUserStore)(nil).FlagInvalidPassword))
+}
+
+// FlagLocalUserInvalidPassword mocks base method.
+func (m *MockUserStore) FlagLocalUserInvalidPassword(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlagLocalUserInvalidPassword", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlagLocalUserInvalidPassword indicates an expected call of FlagLocalUserInvalidPassword.
+func (mr *MockUserStoreMockRecorder) FlagLocalUserInvalidPassword(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlagLocalUserInvalidPassword", reflect.TypeOf((*MockUserStore)(nil).FlagLocalUserInvalidPassword), userID)
+}
+
+// FlagLocalUserSuccessfulLogin mocks base method.
+func (m *MockUserStore) FlagLocalUserSuccessfulLogin(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlagLocalUserSuccessfulLogin", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FlagLocalUserSuccessfulLogin indicates an expected call of FlagLocalUserSuccessfulLogin.
+func (mr *MockUserStoreMockRecorder) FlagLocalUserSuccessfulLogin(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlagLocalUserSuccessfulLogin", reflect.TypeOf((*MockUserStore)(nil).FlagLocalUserSuccessfulLogin), userID)
+}
+
+// FlagSuccessfulLogin mocks base method.
+func (m *MockUserStore) FlagSuccessfulLogin() error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "FlagSuccessfulLogin")
 	ret0, _ := ret[0].(error)
@@ -4069,6 +4978,36 @@ func (mr *MockUserStoreMockRecorder) FlagSuccessfulLogin() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlagSuccessfulLogin", reflect.TypeOf((*MockUserStore)(nil).FlagSuccessfulLogin))
 }
 
+// GetLocalUserByEmail mocks base method.
+func (m *MockUserStore) GetLocalUserByEmail(email string) (*types18.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocalUserByEmail", email)
+	ret0, _ := ret[0].(*types18.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocalUserByEmail indicates an expected call of GetLocalUserByEmail.
+func (mr *MockUserStoreMockRecorder) GetLocalUserByEmail(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocalUserByEmail", reflect.TypeOf((*MockUserStore)(nil).GetLocalUserByEmail), email)
+}
+
+// GetLocalUserPasswordBcrypt mocks base method.
+func (m *MockUserStore) GetLocalUserPasswordBcrypt(userID string) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLocalUserPasswordBcrypt", userID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLocalUserPasswordBcrypt indicates an expected call of GetLocalUserPasswordBcrypt.
+func (mr *MockUserStoreMockRecorder) GetLocalUserPasswordBcrypt(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLocalUserPasswordBcrypt", reflect.TypeOf((*MockUserStore)(nil).GetLocalUserPasswordBcrypt), userID)
+}
+
 // GetPasswordUpdatedAt mocks base method.
 func (m *MockUserStore) GetPasswordUpdatedAt() (*time.Time, error) {
 	m.ctrl.T.Helper()
@@ -4099,6 +5038,496 @@ func (mr *MockUserStoreMockRecorder) GetSharedPasswordBcrypt() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSharedPasswordBcrypt", reflect.TypeOf((*MockUserStore)(nil).GetSharedPasswordBcrypt))
 }
 
+// GetUser mocks base method.
+func (m *MockUserStore) GetUser(userID string) (*types18.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", userID)
+	ret0, _ := ret[0].(*types18.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockUserStoreMockRecorder) GetUser(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockUserStore)(nil).GetUser), userID)
+}
+
+// ListLocalUsers mocks base method.
+func (m *MockUserStore) ListLocalUsers() ([]*types18.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLocalUsers")
+	ret0, _ := ret[0].([]*types18.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLocalUsers indicates an expected call of ListLocalUsers.
+func (mr *MockUserStoreMockRecorder) ListLocalUsers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLocalUsers", reflect.TypeOf((*MockUserStore)(nil).ListLocalUsers))
+}
+
+// SetLocalUserDisabled mocks base method.
+func (m *MockUserStore) SetLocalUserDisabled(userID string, isDisabled bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLocalUserDisabled", userID, isDisabled)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLocalUserDisabled indicates an expected call of SetLocalUserDisabled.
+func (mr *MockUserStoreMockRecorder) SetLocalUserDisabled(userID, isDisabled interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLocalUserDisabled", reflect.TypeOf((*MockUserStore)(nil).SetLocalUserDisabled), userID, isDisabled)
+}
+
+// SetLocalUserPasswordBcrypt mocks base method.
+func (m *MockUserStore) SetLocalUserPasswordBcrypt(userID string, passwordBcrypt []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLocalUserPasswordBcrypt", userID, passwordBcrypt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLocalUserPasswordBcrypt indicates an expected call of SetLocalUserPasswordBcrypt.
+func (mr *MockUserStoreMockRecorder) SetLocalUserPasswordBcrypt(userID, passwordBcrypt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLocalUserPasswordBcrypt", reflect.TypeOf((*MockUserStore)(nil).SetLocalUserPasswordBcrypt), userID, passwordBcrypt)
+}
+
+// SetLocalUserRoles mocks base method.
+func (m *MockUserStore) SetLocalUserRoles(userID string, roles []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetLocalUserRoles", userID, roles)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetLocalUserRoles indicates an expected call of SetLocalUserRoles.
+func (mr *MockUserStoreMockRecorder) SetLocalUserRoles(userID, roles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetLocalUserRoles", reflect.TypeOf((*MockUserStore)(nil).SetLocalUserRoles), userID, roles)
+}
+
+// MockAPITokenStore is a mock of APITokenStore interface.
+type MockAPITokenStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockAPITokenStoreMockRecorder
+}
+
+// MockAPITokenStoreMockRecorder is the mock recorder for MockAPITokenStore.
+type MockAPITokenStoreMockRecorder struct {
+	mock *MockAPITokenStore
+}
+
+// NewMockAPITokenStore creates a new mock instance.
+func NewMockAPITokenStore(ctrl *gomock.Controller) *MockAPITokenStore {
+	mock := &MockAPITokenStore{ctrl: ctrl}
+	mock.recorder = &MockAPITokenStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAPITokenStore) EXPECT() *MockAPITokenStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateAPIToken mocks base method.
+func (m *MockAPITokenStore) CreateAPIToken(name, tokenSHA256 string, roles []string, createdBy string, expiresAt *time.Time) (*types3.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAPIToken", name, tokenSHA256, roles, createdBy, expiresAt)
+	ret0, _ := ret[0].(*types3.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAPIToken indicates an expected call of CreateAPIToken.
+func (mr *MockAPITokenStoreMockRecorder) CreateAPIToken(name, tokenSHA256, roles, createdBy, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAPIToken", reflect.TypeOf((*MockAPITokenStore)(nil).CreateAPIToken), name, tokenSHA256, roles, createdBy, expiresAt)
+}
+
+// DeleteAPIToken mocks base method.
+func (m *MockAPITokenStore) DeleteAPIToken(tokenID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAPIToken", tokenID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAPIToken indicates an expected call of DeleteAPIToken.
+func (mr *MockAPITokenStoreMockRecorder) DeleteAPIToken(tokenID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAPIToken", reflect.TypeOf((*MockAPITokenStore)(nil).DeleteAPIToken), tokenID)
+}
+
+// GetAPITokenBySHA256 mocks base method.
+func (m *MockAPITokenStore) GetAPITokenBySHA256(tokenSHA256 string) (*types3.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAPITokenBySHA256", tokenSHA256)
+	ret0, _ := ret[0].(*types3.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAPITokenBySHA256 indicates an expected call of GetAPITokenBySHA256.
+func (mr *MockAPITokenStoreMockRecorder) GetAPITokenBySHA256(tokenSHA256 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAPITokenBySHA256", reflect.TypeOf((*MockAPITokenStore)(nil).GetAPITokenBySHA256), tokenSHA256)
+}
+
+// ListAPITokens mocks base method.
+func (m *MockAPITokenStore) ListAPITokens() ([]*types3.APIToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAPITokens")
+	ret0, _ := ret[0].([]*types3.APIToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAPITokens indicates an expected call of ListAPITokens.
+func (mr *MockAPITokenStoreMockRecorder) ListAPITokens() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAPITokens", reflect.TypeOf((*MockAPITokenStore)(nil).ListAPITokens))
+}
+
+// UpdateAPITokenLastUsedAt mocks base method.
+func (m *MockAPITokenStore) UpdateAPITokenLastUsedAt(tokenID string, lastUsedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAPITokenLastUsedAt", tokenID, lastUsedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAPITokenLastUsedAt indicates an expected call of UpdateAPITokenLastUsedAt.
+func (mr *MockAPITokenStoreMockRecorder) UpdateAPITokenLastUsedAt(tokenID, lastUsedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAPITokenLastUsedAt", reflect.TypeOf((*MockAPITokenStore)(nil).UpdateAPITokenLastUsedAt), tokenID, lastUsedAt)
+}
+
+// MockVersionBlockStore is a mock of VersionBlockStore interface.
+type MockVersionBlockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockVersionBlockStoreMockRecorder
+}
+
+// MockVersionBlockStoreMockRecorder is the mock recorder for MockVersionBlockStore.
+type MockVersionBlockStoreMockRecorder struct {
+	mock *MockVersionBlockStore
+}
+
+// NewMockVersionBlockStore creates a new mock instance.
+func NewMockVersionBlockStore(ctrl *gomock.Controller) *MockVersionBlockStore {
+	mock := &MockVersionBlockStore{ctrl: ctrl}
+	mock.recorder = &MockVersionBlockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockVersionBlockStore) EXPECT() *MockVersionBlockStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateVersionBlock mocks base method.
+func (m *MockVersionBlockStore) CreateVersionBlock(appID, versionLabel string, sequence *int64, reason, createdBy string) (*types4.VersionBlock, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateVersionBlock", appID, versionLabel, sequence, reason, createdBy)
+	ret0, _ := ret[0].(*types4.VersionBlock)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateVersionBlock indicates an expected call of CreateVersionBlock.
+func (mr *MockVersionBlockStoreMockRecorder) CreateVersionBlock(appID, versionLabel, sequence, reason, createdBy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVersionBlock", reflect.TypeOf((*MockVersionBlockStore)(nil).CreateVersionBlock), appID, versionLabel, sequence, reason, createdBy)
+}
+
+// DeleteVersionBlock mocks base method.
+func (m *MockVersionBlockStore) DeleteVersionBlock(appID, blockID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteVersionBlock", appID, blockID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteVersionBlock indicates an expected call of DeleteVersionBlock.
+func (mr *MockVersionBlockStoreMockRecorder) DeleteVersionBlock(appID, blockID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteVersionBlock", reflect.TypeOf((*MockVersionBlockStore)(nil).DeleteVersionBlock), appID, blockID)
+}
+
+// ListVersionBlocks mocks base method.
+func (m *MockVersionBlockStore) ListVersionBlocks(appID string) (types4.VersionBlocks, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVersionBlocks", appID)
+	ret0, _ := ret[0].(types4.VersionBlocks)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVersionBlocks indicates an expected call of ListVersionBlocks.
+func (mr *MockVersionBlockStoreMockRecorder) ListVersionBlocks(appID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVersionBlocks", reflect.TypeOf((*MockVersionBlockStore)(nil).ListVersionBlocks), appID)
+}
+
+// MockNotificationStore is a mock of NotificationStore interface.
+type MockNotificationStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationStoreMockRecorder
+}
+
+// MockNotificationStoreMockRecorder is the mock recorder for MockNotificationStore.
+type MockNotificationStoreMockRecorder struct {
+	mock *MockNotificationStore
+}
+
+// NewMockNotificationStore creates a new mock instance.
+func NewMockNotificationStore(ctrl *gomock.Controller) *MockNotificationStore {
+	mock := &MockNotificationStore{ctrl: ctrl}
+	mock.recorder = &MockNotificationStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationStore) EXPECT() *MockNotificationStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateNotificationDelivery mocks base method.
+func (m *MockNotificationStore) CreateNotificationDelivery(delivery *types8.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotificationDelivery", delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotificationDelivery indicates an expected call of CreateNotificationDelivery.
+func (mr *MockNotificationStoreMockRecorder) CreateNotificationDelivery(delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotificationDelivery", reflect.TypeOf((*MockNotificationStore)(nil).CreateNotificationDelivery), delivery)
+}
+
+// CreateNotificationSink mocks base method.
+func (m *MockNotificationStore) CreateNotificationSink(sink *types8.Sink) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNotificationSink", sink)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateNotificationSink indicates an expected call of CreateNotificationSink.
+func (mr *MockNotificationStoreMockRecorder) CreateNotificationSink(sink interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNotificationSink", reflect.TypeOf((*MockNotificationStore)(nil).CreateNotificationSink), sink)
+}
+
+// DeleteNotificationDeliveriesBefore mocks base method.
+func (m *MockNotificationStore) DeleteNotificationDeliveriesBefore(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotificationDeliveriesBefore", before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotificationDeliveriesBefore indicates an expected call of DeleteNotificationDeliveriesBefore.
+func (mr *MockNotificationStoreMockRecorder) DeleteNotificationDeliveriesBefore(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationDeliveriesBefore", reflect.TypeOf((*MockNotificationStore)(nil).DeleteNotificationDeliveriesBefore), before)
+}
+
+// DeleteNotificationSink mocks base method.
+func (m *MockNotificationStore) DeleteNotificationSink(appID, sinkID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotificationSink", appID, sinkID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotificationSink indicates an expected call of DeleteNotificationSink.
+func (mr *MockNotificationStoreMockRecorder) DeleteNotificationSink(appID, sinkID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationSink", reflect.TypeOf((*MockNotificationStore)(nil).DeleteNotificationSink), appID, sinkID)
+}
+
+// ListNotificationDeliveries mocks base method.
+func (m *MockNotificationStore) ListNotificationDeliveries(appID string, limit int) ([]*types8.Delivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationDeliveries", appID, limit)
+	ret0, _ := ret[0].([]*types8.Delivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationDeliveries indicates an expected call of ListNotificationDeliveries.
+func (mr *MockNotificationStoreMockRecorder) ListNotificationDeliveries(appID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationDeliveries", reflect.TypeOf((*MockNotificationStore)(nil).ListNotificationDeliveries), appID, limit)
+}
+
+// ListNotificationSinks mocks base method.
+func (m *MockNotificationStore) ListNotificationSinks(appID string) ([]*types8.Sink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotificationSinks", appID)
+	ret0, _ := ret[0].([]*types8.Sink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotificationSinks indicates an expected call of ListNotificationSinks.
+func (mr *MockNotificationStoreMockRecorder) ListNotificationSinks(appID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotificationSinks", reflect.TypeOf((*MockNotificationStore)(nil).ListNotificationSinks), appID)
+}
+
+// UpdateNotificationDelivery mocks base method.
+func (m *MockNotificationStore) UpdateNotificationDelivery(delivery *types8.Delivery) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationDelivery", delivery)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotificationDelivery indicates an expected call of UpdateNotificationDelivery.
+func (mr *MockNotificationStoreMockRecorder) UpdateNotificationDelivery(delivery interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationDelivery", reflect.TypeOf((*MockNotificationStore)(nil).UpdateNotificationDelivery), delivery)
+}
+
+// MockAuditStore is a mock of AuditStore interface.
+type MockAuditStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditStoreMockRecorder
+}
+
+// MockAuditStoreMockRecorder is the mock recorder for MockAuditStore.
+type MockAuditStoreMockRecorder struct {
+	mock *MockAuditStore
+}
+
+// NewMockAuditStore creates a new mock instance.
+func NewMockAuditStore(ctrl *gomock.Controller) *MockAuditStore {
+	mock := &MockAuditStore{ctrl: ctrl}
+	mock.recorder = &MockAuditStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditStore) EXPECT() *MockAuditStoreMockRecorder {
+	return m.recorder
+}
+
+// CreateAuditEvent mocks base method.
+func (m *MockAuditStore) CreateAuditEvent(event *types6.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditEvent", event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuditEvent indicates an expected call of CreateAuditEvent.
+func (mr *MockAuditStoreMockRecorder) CreateAuditEvent(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditEvent", reflect.TypeOf((*MockAuditStore)(nil).CreateAuditEvent), event)
+}
+
+// DeleteAuditEventsBefore mocks base method.
+func (m *MockAuditStore) DeleteAuditEventsBefore(before time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAuditEventsBefore", before)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAuditEventsBefore indicates an expected call of DeleteAuditEventsBefore.
+func (mr *MockAuditStoreMockRecorder) DeleteAuditEventsBefore(before interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuditEventsBefore", reflect.TypeOf((*MockAuditStore)(nil).DeleteAuditEventsBefore), before)
+}
+
+// ListAuditEvents mocks base method.
+func (m *MockAuditStore) ListAuditEvents(opts types6.ListOptions) (*types6.EventList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEvents", opts)
+	ret0, _ := ret[0].(*types6.EventList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEvents indicates an expected call of ListAuditEvents.
+func (mr *MockAuditStoreMockRecorder) ListAuditEvents(opts interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEvents", reflect.TypeOf((*MockAuditStore)(nil).ListAuditEvents), opts)
+}
+
+// MockTwoFactorStore is a mock of TwoFactorStore interface.
+type MockTwoFactorStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorStoreMockRecorder
+}
+
+// MockTwoFactorStoreMockRecorder is the mock recorder for MockTwoFactorStore.
+type MockTwoFactorStoreMockRecorder struct {
+	mock *MockTwoFactorStore
+}
+
+// NewMockTwoFactorStore creates a new mock instance.
+func NewMockTwoFactorStore(ctrl *gomock.Controller) *MockTwoFactorStore {
+	mock := &MockTwoFactorStore{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactorStore) EXPECT() *MockTwoFactorStoreMockRecorder {
+	return m.recorder
+}
+
+// DeleteTwoFactorConfig mocks base method.
+func (m *MockTwoFactorStore) DeleteTwoFactorConfig(userID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTwoFactorConfig", userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTwoFactorConfig indicates an expected call of DeleteTwoFactorConfig.
+func (mr *MockTwoFactorStoreMockRecorder) DeleteTwoFactorConfig(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTwoFactorConfig", reflect.TypeOf((*MockTwoFactorStore)(nil).DeleteTwoFactorConfig), userID)
+}
+
+// GetTwoFactorConfig mocks base method.
+func (m *MockTwoFactorStore) GetTwoFactorConfig(userID string) (*types16.Config, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTwoFactorConfig", userID)
+	ret0, _ := ret[0].(*types16.Config)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTwoFactorConfig indicates an expected call of GetTwoFactorConfig.
+func (mr *MockTwoFactorStoreMockRecorder) GetTwoFactorConfig(userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTwoFactorConfig", reflect.TypeOf((*MockTwoFactorStore)(nil).GetTwoFactorConfig), userID)
+}
+
+// SetTwoFactorConfig mocks base method.
+func (m *MockTwoFactorStore) SetTwoFactorConfig(userID string, config *types16.Config) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTwoFactorConfig", userID, config)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTwoFactorConfig indicates an expected call of SetTwoFactorConfig.
+func (mr *MockTwoFactorStoreMockRecorder) SetTwoFactorConfig(userID, config interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTwoFactorConfig", reflect.TypeOf((*MockTwoFactorStore)(nil).SetTwoFactorConfig), userID, config)
+}
+
 // MockClusterStore is a mock of ClusterStore interface.
 type MockClusterStore struct {
 	ctrl     *gomock.Controller
@@ -4122,6 +5551,36 @@ func (m *MockClusterStore) EXPECT() *MockClusterStoreMockRecorder {
 	return m.recorder
 }
 
+// AssignAppToCluster mocks base method.
+func (m *MockClusterStore) AssignAppToCluster(appID, clusterID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignAppToCluster", appID, clusterID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignAppToCluster indicates an expected call of AssignAppToCluster.
+func (mr *MockClusterStoreMockRecorder) AssignAppToCluster(appID, clusterID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignAppToCluster", reflect.TypeOf((*MockClusterStore)(nil).AssignAppToCluster), appID, clusterID)
+}
+
+// CreateAgentCluster mocks base method.
+func (m *MockClusterStore) CreateAgentCluster(title string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAgentCluster", title)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateAgentCluster indicates an expected call of CreateAgentCluster.
+func (mr *MockClusterStoreMockRecorder) CreateAgentCluster(title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAgentCluster", reflect.TypeOf((*MockClusterStore)(nil).CreateAgentCluster), title)
+}
+
 // CreateNewCluster mocks base method.
 func (m *MockClusterStore) CreateNewCluster(userID string, isAllUsers bool, title, token string) (string, error) {
 	m.ctrl.T.Helper()
@@ -4137,6 +5596,21 @@ func (mr *MockClusterStoreMockRecorder) CreateNewCluster(userID, isAllUsers, tit
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNewCluster", reflect.TypeOf((*MockClusterStore)(nil).CreateNewCluster), userID, isAllUsers, title, token)
 }
 
+// GetAgentClusterIDFromToken mocks base method.
+func (m *MockClusterStore) GetAgentClusterIDFromToken(deployToken string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAgentClusterIDFromToken", deployToken)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAgentClusterIDFromToken indicates an expected call of GetAgentClusterIDFromToken.
+func (mr *MockClusterStoreMockRecorder) GetAgentClusterIDFromToken(deployToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAgentClusterIDFromToken", reflect.TypeOf((*MockClusterStore)(nil).GetAgentClusterIDFromToken), deployToken)
+}
+
 // GetClusterID mocks base method.
 func (m *MockClusterStore) GetClusterID() string {
 	m.ctrl.T.Helper()
@@ -4181,6 +5655,21 @@ func (mr *MockClusterStoreMockRecorder) GetClusterIDFromSlug(slug interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetClusterIDFromSlug", reflect.TypeOf((*MockClusterStore)(nil).GetClusterIDFromSlug), slug)
 }
 
+// ListAgentClusters mocks base method.
+func (m *MockClusterStore) ListAgentClusters() ([]*types0.AgentCluster, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAgentClusters")
+	ret0, _ := ret[0].([]*types0.AgentCluster)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAgentClusters indicates an expected call of ListAgentClusters.
+func (mr *MockClusterStoreMockRecorder) ListAgentClusters() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAgentClusters", reflect.TypeOf((*MockClusterStore)(nil).ListAgentClusters))
+}
+
 // ListClusters mocks base method.
 func (m *MockClusterStore) ListClusters() ([]*types0.Downstream, error) {
 	m.ctrl.T.Helper()
@@ -4196,6 +5685,20 @@ func (mr *MockClusterStoreMockRecorder) ListClusters() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClusters", reflect.TypeOf((*MockClusterStore)(nil).ListClusters))
 }
 
+// SetAgentClusterLastSeen mocks base method.
+func (m *MockClusterStore) SetAgentClusterLastSeen(clusterID string, lastSeenAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetAgentClusterLastSeen", clusterID, lastSeenAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetAgentClusterLastSeen indicates an expected call of SetAgentClusterLastSeen.
+func (mr *MockClusterStoreMockRecorder) SetAgentClusterLastSeen(clusterID, lastSeenAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAgentClusterLastSeen", reflect.TypeOf((*MockClusterStore)(nil).SetAgentClusterLastSeen), clusterID, lastSeenAt)
+}
+
 // SetInstanceSnapshotSchedule mocks base method.
 func (m *MockClusterStore) SetInstanceSnapshotSchedule(clusterID, snapshotSchedule string) error {
 	m.ctrl.T.Helper()
@@ -4248,10 +5751,10 @@ func (m *MockInstallationStore) EXPECT() *MockInstallationStoreMockRecorder {
 }
 
 // GetPendingInstallationStatus mocks base method.
-func (m *MockInstallationStore) GetPendingInstallationStatus() (*types6.InstallStatus, error) {
+func (m *MockInstallationStore) GetPendingInstallationStatus() (*types9.InstallStatus, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetPendingInstallationStatus")
-	ret0, _ := ret[0].(*types6.InstallStatus)
+	ret0, _ := ret[0].(*types9.InstallStatus)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
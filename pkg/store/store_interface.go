@@ -9,9 +9,12 @@ import (
 	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
 	reportingtypes "github.com/replicatedhq/kots/pkg/api/reporting/types"
 	versiontypes "github.com/replicatedhq/kots/pkg/api/version/types"
+	apitokentypes "github.com/replicatedhq/kots/pkg/apitoken/types"
 	apptypes "github.com/replicatedhq/kots/pkg/app/types"
 	appstatetypes "github.com/replicatedhq/kots/pkg/appstate/types"
+	audittypes "github.com/replicatedhq/kots/pkg/audit/types"
 	snapshottypes "github.com/replicatedhq/kots/pkg/kotsadmsnapshot/types"
+	notificationtypes "github.com/replicatedhq/kots/pkg/notifications/types"
 	installationtypes "github.com/replicatedhq/kots/pkg/online/types"
 	preflighttypes "github.com/replicatedhq/kots/pkg/preflight/types"
 	registrytypes "github.com/replicatedhq/kots/pkg/registry/types"
@@ -19,6 +22,7 @@ import (
 	sessiontypes "github.com/replicatedhq/kots/pkg/session/types"
 	"github.com/replicatedhq/kots/pkg/store/types"
 	supportbundletypes "github.com/replicatedhq/kots/pkg/supportbundle/types"
+	twofactortypes "github.com/replicatedhq/kots/pkg/twofactor/types"
 	upstreamtypes "github.com/replicatedhq/kots/pkg/upstream/types"
 	usertypes "github.com/replicatedhq/kots/pkg/user/types"
 	kotsv1beta1 "github.com/replicatedhq/kotskinds/apis/kots/v1beta1"
@@ -40,6 +44,11 @@ type Store interface {
 	VersionStore
 	LicenseStore
 	UserStore
+	APITokenStore
+	AuditStore
+	TwoFactorStore
+	VersionBlockStore
+	NotificationStore
 	ClusterStore
 	SnapshotStore
 	InstallationStore
@@ -100,16 +109,22 @@ type AirgapStore interface {
 }
 
 type SessionStore interface {
-	CreateSession(user *usertypes.User, issuedAt time.Time, expiresAt time.Time, roles []string) (*sessiontypes.Session, error)
+	CreateSession(user *usertypes.User, issuedAt time.Time, expiresAt time.Time, roles []string, source sessiontypes.Source) (*sessiontypes.Session, error)
 	DeleteSession(sessionID string) error
 	GetSession(sessionID string) (*sessiontypes.Session, error)
+	ListSessions() ([]*sessiontypes.Session, error)
+	DeleteAllSessions() error
 	UpdateSessionExpiresAt(sessionID string, expiresAt time.Time) error
 	DeleteExpiredSessions() error
+	DeleteSessionsForUser(userID string) error
 }
 
 type AppStatusStore interface {
 	GetAppStatus(appID string) (*appstatetypes.AppStatus, error)
 	SetAppStatus(appID string, resourceStates appstatetypes.ResourceStates, updatedAt time.Time, sequence int64) error
+	ListAppStatusTransitions(appID string, start time.Time, end time.Time) ([]appstatetypes.StateTransition, error)
+	DeleteAppStatusHistoryBefore(before time.Time) error
+	SetAppDriftStatus(appID string, drift *appstatetypes.DriftStatus) error
 }
 
 type AppStore interface {
@@ -128,6 +143,11 @@ type AppStore interface {
 	IsGitOpsEnabledForApp(appID string) (bool, error)
 	SetUpdateCheckerSpec(appID string, updateCheckerSpec string) error
 	SetAutoDeploy(appID string, autoDeploy apptypes.AutoDeploy) error
+	SetDeployWindow(appID string, deployWindow *apptypes.DeployWindow) error
+	SetScheduledDeploy(appID string, scheduledDeploy *apptypes.ScheduledDeploy) error
+	SetSoakDuration(appID string, soakDuration string) error
+	SetHealthGate(appID string, healthGate *apptypes.HealthGate) error
+	SetDriftDetection(appID string, driftDetection *apptypes.DriftDetection) error
 	SetSnapshotTTL(appID string, snapshotTTL string) error
 	SetSnapshotSchedule(appID string, snapshotSchedule string) error
 	RemoveApp(appID string) error
@@ -218,6 +238,55 @@ type UserStore interface {
 	GetPasswordUpdatedAt() (*time.Time, error)
 	FlagInvalidPassword() error
 	FlagSuccessfulLogin() error
+
+	CreateLocalUser(email string, firstName string, lastName string, passwordBcrypt []byte, roles []string) (*usertypes.User, error)
+	GetUser(userID string) (*usertypes.User, error)
+	GetLocalUserByEmail(email string) (*usertypes.User, error)
+	ListLocalUsers() ([]*usertypes.User, error)
+	// GetLocalUserPasswordBcrypt returns ErrTooManyAttempts when the account has been locked out
+	GetLocalUserPasswordBcrypt(userID string) ([]byte, error)
+	SetLocalUserPasswordBcrypt(userID string, passwordBcrypt []byte) error
+	SetLocalUserRoles(userID string, roles []string) error
+	SetLocalUserDisabled(userID string, isDisabled bool) error
+	FlagLocalUserInvalidPassword(userID string) error
+	FlagLocalUserSuccessfulLogin(userID string) error
+	DeleteLocalUser(userID string) error
+}
+
+type APITokenStore interface {
+	CreateAPIToken(name string, tokenSHA256 string, roles []string, createdBy string, expiresAt *time.Time) (*apitokentypes.APIToken, error)
+	GetAPITokenBySHA256(tokenSHA256 string) (*apitokentypes.APIToken, error)
+	ListAPITokens() ([]*apitokentypes.APIToken, error)
+	UpdateAPITokenLastUsedAt(tokenID string, lastUsedAt time.Time) error
+	DeleteAPIToken(tokenID string) error
+}
+
+type VersionBlockStore interface {
+	CreateVersionBlock(appID string, versionLabel string, sequence *int64, reason string, createdBy string) (*apptypes.VersionBlock, error)
+	ListVersionBlocks(appID string) (apptypes.VersionBlocks, error)
+	DeleteVersionBlock(appID string, blockID string) error
+}
+
+type NotificationStore interface {
+	CreateNotificationSink(sink *notificationtypes.Sink) error
+	ListNotificationSinks(appID string) ([]*notificationtypes.Sink, error)
+	DeleteNotificationSink(appID string, sinkID string) error
+	CreateNotificationDelivery(delivery *notificationtypes.Delivery) error
+	UpdateNotificationDelivery(delivery *notificationtypes.Delivery) error
+	ListNotificationDeliveries(appID string, limit int) ([]*notificationtypes.Delivery, error)
+	DeleteNotificationDeliveriesBefore(before time.Time) error
+}
+
+type AuditStore interface {
+	CreateAuditEvent(event *audittypes.Event) error
+	ListAuditEvents(opts audittypes.ListOptions) (*audittypes.EventList, error)
+	DeleteAuditEventsBefore(before time.Time) error
+}
+
+type TwoFactorStore interface {
+	GetTwoFactorConfig(userID string) (*twofactortypes.Config, error)
+	SetTwoFactorConfig(userID string, config *twofactortypes.Config) error
+	DeleteTwoFactorConfig(userID string) error
 }
 
 type ClusterStore interface {
@@ -226,6 +295,11 @@ type ClusterStore interface {
 	GetClusterIDFromSlug(slug string) (clusterID string, err error)
 	GetClusterIDFromDeployToken(deployToken string) (clusterID string, err error)
 	CreateNewCluster(userID string, isAllUsers bool, title string, token string) (clusterID string, err error)
+	CreateAgentCluster(title string) (clusterID string, token string, err error)
+	ListAgentClusters() ([]*downstreamtypes.AgentCluster, error)
+	GetAgentClusterIDFromToken(deployToken string) (clusterID string, err error)
+	SetAgentClusterLastSeen(clusterID string, lastSeenAt time.Time) error
+	AssignAppToCluster(appID string, clusterID string) error
 	SetInstanceSnapshotTTL(clusterID string, snapshotTTL string) error
 	SetInstanceSnapshotSchedule(clusterID string, snapshotSchedule string) error
 }
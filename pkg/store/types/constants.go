@@ -12,4 +12,5 @@ const (
 	VersionDeploying                DownstreamVersionStatus = "deploying"                  // is being deployed
 	VersionDeployed                 DownstreamVersionStatus = "deployed"                   // did deploy successfully
 	VersionFailed                   DownstreamVersionStatus = "failed"                     // did not deploy successfully
+	VersionRolledBack               DownstreamVersionStatus = "rolled_back"                // deployed, but was rolled back automatically because the app did not become healthy
 )
@@ -0,0 +1,70 @@
+package twofactor
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// test vectors from RFC 6238 appendix B for the SHA1 mode
+func Test_generateCode(t *testing.T) {
+	key := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "94287082"},
+		{unix: 1111111109, want: "07081804"},
+		{unix: 1111111111, want: "14050471"},
+		{unix: 1234567890, want: "89005924"},
+		{unix: 2000000000, want: "69279037"},
+		{unix: 20000000000, want: "65353130"},
+	}
+	for _, tt := range tests {
+		got := generateCode(key, timeStep(time.Unix(tt.unix, 0)), 8)
+		assert.Equal(t, tt.want, got, "unix time %d", tt.unix)
+	}
+}
+
+func Test_validateCode(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(1111111109, 0)
+	key, err := decodeSecret(secret)
+	require.NoError(t, err)
+
+	current := generateCode(key, timeStep(now), totpDigits)
+	previous := generateCode(key, timeStep(now)-1, totpDigits)
+	tooOld := generateCode(key, timeStep(now)-2, totpDigits)
+
+	step, ok := validateCode(secret, current, now, 0)
+	assert.True(t, ok)
+	assert.Equal(t, timeStep(now), step)
+
+	_, ok = validateCode(secret, previous, now, 0)
+	assert.True(t, ok, "clock skew of one period is accepted")
+
+	_, ok = validateCode(secret, tooOld, now, 0)
+	assert.False(t, ok)
+
+	_, ok = validateCode(secret, current, now, timeStep(now))
+	assert.False(t, ok, "replayed code is rejected")
+
+	_, ok = validateCode(secret, "12345", now, 0)
+	assert.False(t, ok)
+}
+
+func Test_otpauthURI(t *testing.T) {
+	uri := otpauthURI("KOTS Admin Console", "admin", "JBSWY3DPEHPK3PXP")
+
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/KOTS Admin Console:admin", u.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", u.Query().Get("secret"))
+	assert.Equal(t, "KOTS Admin Console", u.Query().Get("issuer"))
+}
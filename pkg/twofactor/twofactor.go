@@ -0,0 +1,261 @@
+package twofactor
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/crypto"
+	"github.com/replicatedhq/kots/pkg/store"
+	"github.com/replicatedhq/kots/pkg/twofactor/types"
+	"github.com/replicatedhq/kots/pkg/util"
+	kuberneteserrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// Issuer is shown as the account issuer in authenticator apps
+	Issuer = "KOTS Admin Console"
+
+	recoveryCodeCount = 10
+	maxFailedAttempts = 10
+)
+
+var (
+	ErrAlreadyEnabled  = errors.New("two-factor authentication is already enabled")
+	ErrNotEnrolled     = errors.New("two-factor authentication enrollment has not been started")
+	ErrNotEnabled      = errors.New("two-factor authentication is not enabled")
+	ErrInvalidCode     = errors.New("invalid two-factor authentication code")
+	ErrTooManyAttempts = errors.New("too many failed two-factor authentication attempts")
+)
+
+// lock serializes read-modify-write updates of two-factor configs
+var lock sync.Mutex
+
+// IsEnabled returns true if the user has confirmed two-factor enrollment
+func IsEnabled(userID string) (bool, error) {
+	config, err := store.GetStore().GetTwoFactorConfig(userID)
+	if store.GetStore().IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Wrap(err, "failed to get two-factor config")
+	}
+	return config.IsEnabled, nil
+}
+
+// Enroll starts two-factor enrollment for the user by generating a new secret. Enrollment is not
+// active until it is confirmed with a code from the authenticator app. Returns the base32 secret
+// and an otpauth uri for the authenticator app.
+func Enroll(userID string, accountName string) (string, string, error) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	existing, err := store.GetStore().GetTwoFactorConfig(userID)
+	if err != nil && !store.GetStore().IsNotFound(err) {
+		return "", "", errors.Wrap(err, "failed to get two-factor config")
+	}
+	if existing != nil && existing.IsEnabled {
+		return "", "", ErrAlreadyEnabled
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to generate secret")
+	}
+
+	config := &types.Config{
+		SecretEncrypted: base64.StdEncoding.EncodeToString(crypto.Encrypt([]byte(secret))),
+		CreatedAt:       time.Now(),
+	}
+	if err := store.GetStore().SetTwoFactorConfig(userID, config); err != nil {
+		return "", "", errors.Wrap(err, "failed to set two-factor config")
+	}
+
+	return secret, otpauthURI(Issuer, accountName, secret), nil
+}
+
+// Confirm completes enrollment with a code from the authenticator app and returns the recovery
+// codes. The recovery codes are only returned once.
+func Confirm(userID string, code string) ([]string, error) {
+	lock.Lock()
+	defer lock.Unlock()
+
+	config, err := store.GetStore().GetTwoFactorConfig(userID)
+	if store.GetStore().IsNotFound(err) {
+		return nil, ErrNotEnrolled
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to get two-factor config")
+	}
+	if config.IsEnabled {
+		return nil, ErrAlreadyEnabled
+	}
+
+	secret, err := decryptSecret(config)
+	if err != nil {
+		return nil, err
+	}
+
+	step, ok := validateCode(secret, code, time.Now(), config.LastUsedStep)
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+
+	recoveryCodes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate recovery codes")
+	}
+
+	now := time.Now()
+	config.IsEnabled = true
+	config.EnabledAt = &now
+	config.LastUsedStep = step
+	config.RecoveryCodeHashes = hashes
+	if err := store.GetStore().SetTwoFactorConfig(userID, config); err != nil {
+		return nil, errors.Wrap(err, "failed to set two-factor config")
+	}
+
+	return recoveryCodes, nil
+}
+
+// Verify checks a totp code or an unused recovery code for a user with two-factor authentication enabled.
+// Recovery codes can only be used once. After too many failed attempts, two-factor authentication must be reset.
+func Verify(userID string, code string) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	return verify(userID, code)
+}
+
+// verify must be called with the lock held
+func verify(userID string, code string) error {
+	config, err := store.GetStore().GetTwoFactorConfig(userID)
+	if store.GetStore().IsNotFound(err) {
+		return ErrNotEnabled
+	} else if err != nil {
+		return errors.Wrap(err, "failed to get two-factor config")
+	}
+	if !config.IsEnabled {
+		return ErrNotEnabled
+	}
+	if config.FailedAttempts >= maxFailedAttempts {
+		return ErrTooManyAttempts
+	}
+
+	secret, err := decryptSecret(config)
+	if err != nil {
+		return err
+	}
+
+	if step, ok := validateCode(secret, code, time.Now(), config.LastUsedStep); ok {
+		config.LastUsedStep = step
+	} else if i := findRecoveryCode(config.RecoveryCodeHashes, code); i >= 0 {
+		config.RecoveryCodeHashes = append(config.RecoveryCodeHashes[:i], config.RecoveryCodeHashes[i+1:]...)
+	} else {
+		config.FailedAttempts++
+		if err := store.GetStore().SetTwoFactorConfig(userID, config); err != nil {
+			return errors.Wrap(err, "failed to flag failed two-factor attempt")
+		}
+		return ErrInvalidCode
+	}
+
+	config.FailedAttempts = 0
+	if err := store.GetStore().SetTwoFactorConfig(userID, config); err != nil {
+		return errors.Wrap(err, "failed to set two-factor config")
+	}
+
+	return nil
+}
+
+// Disable turns off two-factor authentication for a user after verifying a current code or recovery code
+func Disable(userID string, code string) error {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if err := verify(userID, code); err != nil {
+		return err
+	}
+
+	if err := store.GetStore().DeleteTwoFactorConfig(userID); err != nil {
+		return errors.Wrap(err, "failed to delete two-factor config")
+	}
+
+	return nil
+}
+
+// Reset removes two-factor authentication for a user directly from the cluster. This is the
+// escape hatch used by the kots cli when the authenticator and recovery codes have been lost.
+func Reset(clientset kubernetes.Interface, namespace string, userID string) error {
+	secret, err := clientset.CoreV1().Secrets(namespace).Get(context.TODO(), util.TwoFactorSecretName, metav1.GetOptions{})
+	if kuberneteserrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Wrap(err, "failed to get secret")
+	}
+
+	if _, ok := secret.Data[userID]; !ok {
+		return nil
+	}
+	delete(secret.Data, userID)
+
+	if _, err := clientset.CoreV1().Secrets(namespace).Update(context.TODO(), secret, metav1.UpdateOptions{}); err != nil {
+		return errors.Wrap(err, "failed to update secret")
+	}
+
+	return nil
+}
+
+func decryptSecret(config *types.Config) (string, error) {
+	encrypted, err := base64.StdEncoding.DecodeString(config.SecretEncrypted)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode secret")
+	}
+
+	decrypted, err := crypto.Decrypt(encrypted)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decrypt secret")
+	}
+
+	return string(decrypted), nil
+}
+
+// generateRecoveryCodes returns the recovery codes and their hashes. Codes are formatted as xxxxx-xxxxx.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := []string{}
+	hashes := []string{}
+	for i := 0; i < recoveryCodeCount; i++ {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to read random bytes")
+		}
+		encoded := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(b))[:10]
+		code := encoded[:5] + "-" + encoded[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+func findRecoveryCode(hashes []string, code string) int {
+	hash := hashRecoveryCode(code)
+	for i, h := range hashes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1 {
+			return i
+		}
+	}
+	return -1
+}
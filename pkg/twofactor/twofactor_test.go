@@ -0,0 +1,122 @@
+package twofactor
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/replicatedhq/kots/pkg/crypto"
+	"github.com/replicatedhq/kots/pkg/store"
+	mock_store "github.com/replicatedhq/kots/pkg/store/mock"
+	"github.com/replicatedhq/kots/pkg/twofactor/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errNotFound = errors.New("not found")
+
+func newTestStore(t *testing.T) map[string]*types.Config {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	configs := map[string]*types.Config{}
+
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().IsNotFound(gomock.Any()).DoAndReturn(func(err error) bool {
+		return err == errNotFound
+	}).AnyTimes()
+	mockStore.EXPECT().GetTwoFactorConfig(gomock.Any()).DoAndReturn(func(userID string) (*types.Config, error) {
+		config, ok := configs[userID]
+		if !ok {
+			return nil, errNotFound
+		}
+		copied := *config
+		copied.RecoveryCodeHashes = append([]string{}, config.RecoveryCodeHashes...)
+		return &copied, nil
+	}).AnyTimes()
+	mockStore.EXPECT().SetTwoFactorConfig(gomock.Any(), gomock.Any()).DoAndReturn(func(userID string, config *types.Config) error {
+		configs[userID] = config
+		return nil
+	}).AnyTimes()
+	mockStore.EXPECT().DeleteTwoFactorConfig(gomock.Any()).DoAndReturn(func(userID string) error {
+		delete(configs, userID)
+		return nil
+	}).AnyTimes()
+
+	store.SetStore(mockStore)
+	t.Cleanup(func() { store.SetStore(nil) })
+
+	return configs
+}
+
+func currentCode(t *testing.T, secret string) string {
+	key, err := decodeSecret(secret)
+	require.NoError(t, err)
+	return generateCode(key, timeStep(time.Now()), totpDigits)
+}
+
+func TestEnrollAndVerify(t *testing.T) {
+	require.NoError(t, crypto.NewAESCipher())
+	configs := newTestStore(t)
+
+	secret, uri, err := Enroll("user-1", "user@example.com")
+	require.NoError(t, err)
+	assert.Contains(t, uri, "otpauth://totp/")
+
+	enabled, err := IsEnabled("user-1")
+	require.NoError(t, err)
+	assert.False(t, enabled, "enrollment must be confirmed before it is enabled")
+
+	_, err = Confirm("user-1", "000000x")
+	assert.ErrorIs(t, err, ErrInvalidCode)
+
+	recoveryCodes, err := Confirm("user-1", currentCode(t, secret))
+	require.NoError(t, err)
+	assert.Len(t, recoveryCodes, recoveryCodeCount)
+
+	enabled, err = IsEnabled("user-1")
+	require.NoError(t, err)
+	assert.True(t, enabled)
+
+	_, _, err = Enroll("user-1", "user@example.com")
+	assert.ErrorIs(t, err, ErrAlreadyEnabled)
+
+	// the code used to confirm cannot be replayed
+	assert.ErrorIs(t, Verify("user-1", currentCode(t, secret)), ErrInvalidCode)
+
+	// recovery codes can only be used once
+	require.NoError(t, Verify("user-1", recoveryCodes[0]))
+	assert.ErrorIs(t, Verify("user-1", recoveryCodes[0]), ErrInvalidCode)
+	assert.Len(t, configs["user-1"].RecoveryCodeHashes, recoveryCodeCount-1)
+
+	// a successful verification resets the failure count
+	require.NoError(t, Verify("user-1", recoveryCodes[1]))
+	assert.Equal(t, 0, configs["user-1"].FailedAttempts)
+
+	require.NoError(t, Disable("user-1", recoveryCodes[2]))
+	enabled, err = IsEnabled("user-1")
+	require.NoError(t, err)
+	assert.False(t, enabled)
+}
+
+func TestVerifyLocksAfterTooManyAttempts(t *testing.T) {
+	require.NoError(t, crypto.NewAESCipher())
+	newTestStore(t)
+
+	secret, _, err := Enroll("user-1", "user@example.com")
+	require.NoError(t, err)
+	recoveryCodes, err := Confirm("user-1", currentCode(t, secret))
+	require.NoError(t, err)
+
+	for i := 0; i < maxFailedAttempts; i++ {
+		assert.ErrorIs(t, Verify("user-1", "nope"), ErrInvalidCode)
+	}
+	assert.ErrorIs(t, Verify("user-1", recoveryCodes[0]), ErrTooManyAttempts)
+}
+
+func TestVerifyNotEnabled(t *testing.T) {
+	newTestStore(t)
+
+	assert.ErrorIs(t, Verify("user-1", "123456"), ErrNotEnabled)
+}
@@ -0,0 +1,17 @@
+package types
+
+import "time"
+
+// Config is the two-factor authentication state of a single user
+type Config struct {
+	// SecretEncrypted is the base64 encoded, encrypted base32 totp secret
+	SecretEncrypted string `json:"secretEncrypted"`
+	IsEnabled       bool   `json:"isEnabled"`
+	// RecoveryCodeHashes are the sha256 hashes of the unused recovery codes
+	RecoveryCodeHashes []string `json:"recoveryCodeHashes,omitempty"`
+	// LastUsedStep is the totp time step of the last accepted code, used to reject replayed codes
+	LastUsedStep   int64      `json:"lastUsedStep,omitempty"`
+	FailedAttempts int        `json:"failedAttempts,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	EnabledAt      *time.Time `json:"enabledAt,omitempty"`
+}
@@ -11,4 +11,5 @@ type AvailableUpdate struct {
 	ReleaseNotes       string     `json:"releaseNotes,omitempty"`
 	IsDeployable       bool       `json:"isDeployable,omitempty"`
 	NonDeployableCause string     `json:"nonDeployableCause,omitempty"`
+	EligibleAt         *time.Time `json:"eligibleAt,omitempty"`
 }
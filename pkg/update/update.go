@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/pkg/errors"
+	apppkg "github.com/replicatedhq/kots/pkg/app"
 	apptypes "github.com/replicatedhq/kots/pkg/app/types"
 	"github.com/replicatedhq/kots/pkg/kotsutil"
 	"github.com/replicatedhq/kots/pkg/logger"
@@ -63,6 +64,15 @@ func GetAvailableUpdates(kotsStore storepkg.Store, app *apptypes.App, license *l
 
 	availableUpdates := getAvailableUpdates(updates.Updates, currentECVersion)
 
+	// updates that are still soaking can be deployed manually, but will not be picked up by automatic deployments until then
+	soakDuration, err := apppkg.ParseSoakDuration(app.SoakDuration)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse soak duration")
+	}
+	for i := range availableUpdates {
+		availableUpdates[i].EligibleAt = apppkg.GetEligibleAt(availableUpdates[i].UpstreamReleasedAt, soakDuration)
+	}
+
 	// additional deployable checks against current version
 	downstreams, err := kotsStore.ListDownstreamsForApp(app.ID)
 	if err != nil {
@@ -0,0 +1,69 @@
+package updatechecker
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	apptypes "github.com/replicatedhq/kots/pkg/app/types"
+	cron "github.com/robfig/cron/v3"
+)
+
+// ValidateDeployWindow returns an error if the deploy window schedule or timezone cannot be parsed
+func ValidateDeployWindow(deployWindow *apptypes.DeployWindow) error {
+	if deployWindow == nil {
+		return nil
+	}
+	if _, _, err := parseDeployWindow(deployWindow); err != nil {
+		return err
+	}
+	return nil
+}
+
+// NextDeployWindow returns the time at which automatic deployments are next allowed.
+// This is "now" if the window is currently open or if there is no window.
+func NextDeployWindow(deployWindow *apptypes.DeployWindow, now time.Time) (time.Time, error) {
+	if deployWindow == nil {
+		return now, nil
+	}
+
+	schedule, location, err := parseDeployWindow(deployWindow)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// the window is open for every minute that matches the schedule
+	minute := now.In(location).Truncate(time.Minute)
+	if schedule.Next(minute.Add(-time.Second)).Equal(minute) {
+		return now, nil
+	}
+
+	return schedule.Next(minute), nil
+}
+
+func isInDeployWindow(deployWindow *apptypes.DeployWindow, now time.Time) (bool, time.Time, error) {
+	next, err := NextDeployWindow(deployWindow, now)
+	if err != nil {
+		return false, time.Time{}, err
+	}
+	return !next.After(now), next, nil
+}
+
+func parseDeployWindow(deployWindow *apptypes.DeployWindow) (cron.Schedule, *time.Location, error) {
+	schedule, err := cron.ParseStandard(deployWindow.Schedule)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse deploy window schedule")
+	}
+	if _, ok := schedule.(*cron.SpecSchedule); !ok {
+		return nil, nil, errors.New("deploy window schedule must be a cron expression")
+	}
+
+	location := time.UTC
+	if deployWindow.Timezone != "" {
+		location, err = time.LoadLocation(deployWindow.Timezone)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "failed to load deploy window timezone")
+		}
+	}
+
+	return schedule, location, nil
+}
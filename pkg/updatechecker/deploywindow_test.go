@@ -0,0 +1,170 @@
+package updatechecker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	downstreamtypes "github.com/replicatedhq/kots/pkg/api/downstream/types"
+	apptypes "github.com/replicatedhq/kots/pkg/app/types"
+	"github.com/replicatedhq/kots/pkg/cursor"
+	mock_store "github.com/replicatedhq/kots/pkg/store/mock"
+	storetypes "github.com/replicatedhq/kots/pkg/store/types"
+	"github.com/replicatedhq/kots/pkg/updatechecker/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextDeployWindow(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		deployWindow *apptypes.DeployWindow
+		now          time.Time
+		want         time.Time
+		wantErr      bool
+	}{
+		{
+			name:         "no window is always open",
+			deployWindow: nil,
+			now:          time.Date(2024, 1, 3, 12, 30, 15, 0, time.UTC),
+			want:         time.Date(2024, 1, 3, 12, 30, 15, 0, time.UTC),
+		},
+		{
+			name:         "inside the window",
+			deployWindow: &apptypes.DeployWindow{Schedule: "* 1-4 * * *"},
+			now:          time.Date(2024, 1, 3, 4, 59, 30, 0, time.UTC),
+			want:         time.Date(2024, 1, 3, 4, 59, 30, 0, time.UTC),
+		},
+		{
+			name:         "outside the window",
+			deployWindow: &apptypes.DeployWindow{Schedule: "* 1-4 * * *"},
+			now:          time.Date(2024, 1, 3, 5, 0, 0, 0, time.UTC),
+			want:         time.Date(2024, 1, 4, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "weekends only",
+			deployWindow: &apptypes.DeployWindow{Schedule: "* * * * 6,0"},
+			now:          time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC), // wednesday
+			want:         time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:         "timezone",
+			deployWindow: &apptypes.DeployWindow{Schedule: "* 1-4 * * *", Timezone: "America/New_York"},
+			now:          time.Date(2024, 1, 3, 5, 0, 0, 0, time.UTC), // 00:00 in new york
+			want:         time.Date(2024, 1, 3, 1, 0, 0, 0, newYork),
+		},
+		{
+			name:         "invalid schedule",
+			deployWindow: &apptypes.DeployWindow{Schedule: "not a schedule"},
+			wantErr:      true,
+		},
+		{
+			name:         "interval schedules are not windows",
+			deployWindow: &apptypes.DeployWindow{Schedule: "@every 1h"},
+			wantErr:      true,
+		},
+		{
+			name:         "invalid timezone",
+			deployWindow: &apptypes.DeployWindow{Schedule: "* 1-4 * * *", Timezone: "Mars/Olympus_Mons"},
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextDeployWindow(tt.deployWindow, tt.now)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "want %s, got %s", tt.want, got)
+		})
+	}
+}
+
+func TestAutoDeploySchedulesVersionOutsideDeployWindow(t *testing.T) {
+	var appID = "some-app"
+	var clusterID = "some-cluster-id"
+	var opts = types.CheckForUpdatesOpts{AppID: appID}
+	var currentCursor = cursor.MustParse("1")
+	var upgradeCursor = cursor.MustParse("2")
+	var downstreamVersions = &downstreamtypes.DownstreamVersions{
+		CurrentVersion: &downstreamtypes.DownstreamVersion{
+			Cursor:   &currentCursor,
+			Sequence: 1,
+		},
+		AllVersions: []*downstreamtypes.DownstreamVersion{
+			{
+				Cursor:       &upgradeCursor,
+				Sequence:     2,
+				VersionLabel: "1.0.1",
+			},
+		},
+	}
+
+	// a window that is open for one minute a year and is not open now
+	now := time.Now().UTC()
+	closed := now.AddDate(0, 6, 0)
+	deployWindow := &apptypes.DeployWindow{
+		Schedule: closed.Format("4 15 2 1 *"),
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().GetDownstreamVersions(opts.AppID, clusterID, true).Return(downstreamVersions, nil)
+	mockStore.EXPECT().SetScheduledDeploy(appID, gomock.Any()).DoAndReturn(func(appID string, scheduledDeploy *apptypes.ScheduledDeploy) error {
+		require.NotNil(t, scheduledDeploy)
+		assert.Equal(t, int64(2), scheduledDeploy.Sequence)
+		assert.Equal(t, "1.0.1", scheduledDeploy.VersionLabel)
+		assert.True(t, scheduledDeploy.DeployAt.After(now))
+		return nil
+	})
+
+	store = mockStore
+
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: apptypes.AutoDeploySequence, DeployWindow: deployWindow})
+	require.NoError(t, err)
+}
+
+func TestAutoDeployKeepsScheduleWhenPreflightsFail(t *testing.T) {
+	var appID = "some-app"
+	var clusterID = "some-cluster-id"
+	var opts = types.CheckForUpdatesOpts{AppID: appID}
+	var currentCursor = cursor.MustParse("1")
+	var upgradeCursor = cursor.MustParse("2")
+	var downstreamVersions = &downstreamtypes.DownstreamVersions{
+		CurrentVersion: &downstreamtypes.DownstreamVersion{
+			Cursor:   &currentCursor,
+			Sequence: 1,
+		},
+		AllVersions: []*downstreamtypes.DownstreamVersion{
+			{
+				Cursor:       &upgradeCursor,
+				Sequence:     2,
+				VersionLabel: "1.0.1",
+			},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	// the strict mock fails the test if the scheduled deploy is cleared
+	mockStore := mock_store.NewMockStore(ctrl)
+	mockStore.EXPECT().GetDownstreamVersions(opts.AppID, clusterID, true).Return(downstreamVersions, nil)
+	mockStore.EXPECT().GetApp(appID).Return(&apptypes.App{HasPreflight: true}, nil)
+	mockStore.EXPECT().GetDownstreamVersionStatus(appID, int64(2)).Return(storetypes.VersionPending, nil)
+	mockStore.EXPECT().GetPreflightResults(appID, int64(2)).Return(nil, nil)
+
+	store = mockStore
+
+	a := &apptypes.App{
+		AutoDeploy:      apptypes.AutoDeploySequence,
+		ScheduledDeploy: &apptypes.ScheduledDeploy{Sequence: 2, VersionLabel: "1.0.1"},
+	}
+	err := autoDeploy(opts, clusterID, a)
+	require.Error(t, err)
+}
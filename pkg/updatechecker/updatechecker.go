@@ -3,7 +3,9 @@ package updatechecker
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -13,9 +15,12 @@ import (
 	"github.com/replicatedhq/kots/pkg/app"
 	apptypes "github.com/replicatedhq/kots/pkg/app/types"
 	license "github.com/replicatedhq/kots/pkg/kotsadmlicense"
+	"github.com/replicatedhq/kots/pkg/kotsadmmetrics"
 	upstream "github.com/replicatedhq/kots/pkg/kotsadmupstream"
 	"github.com/replicatedhq/kots/pkg/kotsutil"
 	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/notifications"
+	notificationtypes "github.com/replicatedhq/kots/pkg/notifications/types"
 	"github.com/replicatedhq/kots/pkg/preflight"
 	preflighttypes "github.com/replicatedhq/kots/pkg/preflight/types"
 	kotspull "github.com/replicatedhq/kots/pkg/pull"
@@ -35,6 +40,9 @@ import (
 
 // jobs maps app ids to their cron jobs
 var jobs = make(map[string]*cron.Cron)
+
+// scheduledDeployJob deploys versions that were queued outside of their app's deploy window
+var scheduledDeployJob *cron.Cron
 var mtx sync.Mutex
 var store storepkg.Store
 
@@ -62,9 +70,101 @@ func Start() error {
 		}
 	}
 
+	if err := startScheduledDeploys(); err != nil {
+		return errors.Wrap(err, "failed to start scheduled deploys")
+	}
+
 	return nil
 }
 
+// startScheduledDeploys checks every minute for apps with a scheduled deploy that is due and whose deploy window is open
+func startScheduledDeploys() error {
+	if scheduledDeployJob != nil {
+		return nil
+	}
+
+	job := cron.New(cron.WithChain(
+		cron.Recover(cron.DefaultLogger),
+	))
+	if _, err := job.AddFunc("* * * * *", deployScheduledVersions); err != nil {
+		return errors.Wrap(err, "failed to add func")
+	}
+	job.Start()
+	scheduledDeployJob = job
+
+	return nil
+}
+
+func deployScheduledVersions() {
+	appsList, err := store.ListInstalledApps()
+	if err != nil {
+		logger.Error(errors.Wrap(err, "failed to list installed apps for scheduled deploys"))
+		return
+	}
+
+	for _, a := range appsList {
+		if a.ScheduledDeploy == nil || time.Now().Before(a.ScheduledDeploy.DeployAt) {
+			continue
+		}
+
+		inWindow, _, err := isInDeployWindow(a.DeployWindow, time.Now())
+		if err != nil {
+			logger.Error(errors.Wrapf(err, "failed to check deploy window for app %s", a.Slug))
+			continue
+		}
+		if !inWindow {
+			continue
+		}
+
+		downstreams, err := store.ListDownstreamsForApp(a.ID)
+		if err != nil {
+			logger.Error(errors.Wrapf(err, "failed to list downstreams for app %s", a.Slug))
+			continue
+		}
+		if len(downstreams) == 0 {
+			continue
+		}
+
+		if err := deployScheduledVersion(a, downstreams[0].ClusterID); err != nil {
+			logger.Error(errors.Wrapf(err, "failed to deploy scheduled version for app %s", a.Slug))
+			continue
+		}
+	}
+}
+
+// deployScheduledVersion runs the scheduled deploy of the app as the update-download task, the same as an update check,
+// so that it does not run at the same time as an update check that can deploy a version too. If an update check is
+// running, the scheduled deploy is left for the next run.
+func deployScheduledVersion(a *apptypes.App, clusterID string) (finalError error) {
+	currentStatus, _, err := tasks.GetTaskStatus("update-download")
+	if err != nil {
+		return errors.Wrap(err, "failed to get task status")
+	}
+	if currentStatus == "running" {
+		logger.Infof("an update check is running for %s, deploying the scheduled version later", a.Slug)
+		return nil
+	}
+
+	if err := tasks.SetTaskStatus("update-download", "Deploying scheduled version...", "running"); err != nil {
+		return errors.Wrap(err, "failed to set task status")
+	}
+
+	finishedChan := make(chan error, 1)
+	defer func() {
+		finishedChan <- finalError
+		close(finishedChan)
+	}()
+	tasks.StartTaskMonitor("update-download", finishedChan)
+
+	// the version to deploy is selected again, so a newer version found since it was queued is deployed instead
+	logger.Infof("deploy window is open for app %s, deploying scheduled version %s", a.Slug, a.ScheduledDeploy.VersionLabel)
+	opts := types.CheckForUpdatesOpts{
+		AppID:       a.ID,
+		IsAutomatic: true,
+	}
+	return autoDeploy(opts, clusterID, a)
+}
+
 // Configure will check if the app has scheduled update checks enabled and:
 // if enabled, and cron job was NOT found: add a new cron job to check app updates
 // if enabled, and a cron job was found, update the existing cron job with the latest cron spec
@@ -195,6 +295,10 @@ func CheckForUpdates(opts types.CheckForUpdatesOpts) (ucr *types.UpdateCheckResp
 		return nil, errors.Wrap(err, "failed to set task status")
 	}
 
+	defer func() {
+		kotsadmmetrics.RecordUpdateCheck(opts.AppID, finalError)
+	}()
+
 	finishedChan := make(chan error, 1)
 	defer func() {
 		// When "wait" is not set, the go routine will close this channel
@@ -318,6 +422,8 @@ func checkForKotsAppUpdates(opts types.CheckForUpdatesOpts, finishedChan chan<-
 		return &ucr, nil
 	}
 
+	notifyUpdateAvailable(a, availableReleases)
+
 	// this is to avoid a race condition where the UI polls the task status before it is set by the goroutine
 	status := fmt.Sprintf("%d Updates available...", ucr.AvailableUpdates)
 	if err := tasks.SetTaskStatus("update-download", status, "running"); err != nil {
@@ -476,7 +582,7 @@ func ensureDesiredVersionIsDeployed(opts types.CheckForUpdatesOpts, clusterID st
 	if err != nil {
 		return errors.Wrap(err, "failed to get app")
 	}
-	if err := autoDeploy(opts, clusterID, a.AutoDeploy); err != nil {
+	if err := autoDeploy(opts, clusterID, a); err != nil {
 		return errors.Wrap(err, "failed to auto deploy")
 	}
 	return nil
@@ -496,10 +602,19 @@ func getVersionToDeploy(opts types.CheckForUpdatesOpts, clusterID string, availa
 		appVersions.AllVersions = append([]*downstreamtypes.DownstreamVersion{{VersionLabel: u.Version, Sequence: u.Sequence}}, appVersions.AllVersions...)
 	}
 
-	if opts.DeployLatest && appVersions.AllVersions[0].Sequence != appVersions.CurrentVersion.Sequence {
-		return &types.UpdateCheckRelease{
-			Sequence: appVersions.AllVersions[0].Sequence,
-			Version:  appVersions.AllVersions[0].VersionLabel,
+	if opts.DeployLatest {
+		// blocked versions are skipped in favor of the latest version that is not blocked
+		for _, v := range appVersions.AllVersions {
+			if v.IsBlocked {
+				continue
+			}
+			if v.Sequence != appVersions.CurrentVersion.Sequence {
+				return &types.UpdateCheckRelease{
+					Sequence: v.Sequence,
+					Version:  v.VersionLabel,
+				}
+			}
+			break
 		}
 	}
 
@@ -512,7 +627,8 @@ func getVersionToDeploy(opts types.CheckForUpdatesOpts, clusterID string, availa
 			}
 		}
 
-		if versionToDeploy != nil && versionToDeploy.Sequence != appVersions.CurrentVersion.Sequence {
+		// blocked versions are rejected when deployed
+		if versionToDeploy != nil && !versionToDeploy.IsBlocked && versionToDeploy.Sequence != appVersions.CurrentVersion.Sequence {
 			return &types.UpdateCheckRelease{
 				Sequence: versionToDeploy.Sequence,
 				Version:  versionToDeploy.VersionLabel,
@@ -533,7 +649,10 @@ func deployLatestVersion(opts types.CheckForUpdatesOpts, clusterID string) error
 	if len(appVersions.AllVersions) == 0 {
 		return errors.Errorf("no app versions found for app %s in downstream %s", opts.AppID, clusterID)
 	}
-	latestVersion := appVersions.AllVersions[0]
+	latestVersion := downstreamtypes.FirstUnblockedVersion(appVersions.AllVersions)
+	if latestVersion == nil {
+		return errors.Errorf("all versions of app %s are blocked", opts.AppID)
+	}
 
 	if err := deployVersion(opts, clusterID, appVersions, latestVersion); err != nil {
 		return errors.Wrapf(err, "failed to deploy sequence %d with version label %s", latestVersion.Sequence, latestVersion.VersionLabel)
@@ -571,11 +690,19 @@ func deployVersionLabel(opts types.CheckForUpdatesOpts, clusterID string, versio
 	return nil
 }
 
-func autoDeploy(opts types.CheckForUpdatesOpts, clusterID string, autoDeploy apptypes.AutoDeploy) error {
-	if autoDeploy == "" || autoDeploy == apptypes.AutoDeployDisabled {
+// autoDeploy deploys the newest version allowed by the app's auto deploy policy that has been published for at least the soak duration.
+// If a deploy window is set and it is currently closed, the version is recorded as a scheduled deploy instead and deployed when the window opens.
+// If the only versions allowed by the policy are still soaking, the first one to finish soaking is scheduled to deploy at that time.
+func autoDeploy(opts types.CheckForUpdatesOpts, clusterID string, a *apptypes.App) error {
+	if a.AutoDeploy == "" || a.AutoDeploy == apptypes.AutoDeployDisabled {
 		return nil
 	}
 
+	soakDuration, err := app.ParseSoakDuration(a.SoakDuration)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse soak duration")
+	}
+
 	appVersions, err := store.GetDownstreamVersions(opts.AppID, clusterID, true)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get app versions for app %s", opts.AppID)
@@ -584,68 +711,181 @@ func autoDeploy(opts types.CheckForUpdatesOpts, clusterID string, autoDeploy app
 		return errors.Errorf("no app versions found for app %s in downstream %s", opts.AppID, clusterID)
 	}
 
+	if appVersions.CurrentVersion == nil {
+		return nil
+	}
+
+	deployWindow := a.DeployWindow
+	versionToDeploy := getAutoDeployVersion(a.AutoDeploy, appVersions, soakDuration, time.Now())
+
+	if versionToDeploy == nil {
+		var scheduledDeploy *apptypes.ScheduledDeploy
+		if soakingVersion, eligibleAt := getNextSoakedVersion(a.AutoDeploy, appVersions, soakDuration, time.Now()); soakingVersion != nil {
+			deployAt, err := NextDeployWindow(deployWindow, eligibleAt)
+			if err != nil {
+				return errors.Wrap(err, "failed to get next deploy window")
+			}
+			scheduledDeploy = &apptypes.ScheduledDeploy{
+				Sequence:     soakingVersion.Sequence,
+				VersionLabel: soakingVersion.VersionLabel,
+				QueuedAt:     time.Now(),
+				DeployAt:     deployAt,
+			}
+			logger.Infof("version %s of app %s is soaking and is scheduled to deploy at %s", soakingVersion.VersionLabel, opts.AppID, deployAt.Format(time.RFC3339))
+		}
+		// a previously scheduled version may have been deployed manually since
+		if scheduledDeploy != nil || a.ScheduledDeploy != nil {
+			if err := store.SetScheduledDeploy(opts.AppID, scheduledDeploy); err != nil {
+				return errors.Wrap(err, "failed to set scheduled deploy")
+			}
+		}
+		return nil
+	}
+
+	if deployWindow != nil {
+		inWindow, nextWindow, err := isInDeployWindow(deployWindow, time.Now())
+		if err != nil {
+			return errors.Wrap(err, "failed to check deploy window")
+		}
+		if !inWindow {
+			scheduledDeploy := &apptypes.ScheduledDeploy{
+				Sequence:     versionToDeploy.Sequence,
+				VersionLabel: versionToDeploy.VersionLabel,
+				QueuedAt:     time.Now(),
+				DeployAt:     nextWindow,
+			}
+			if err := store.SetScheduledDeploy(opts.AppID, scheduledDeploy); err != nil {
+				return errors.Wrap(err, "failed to set scheduled deploy")
+			}
+			logger.Infof("version %s of app %s is outside of the deploy window and is scheduled to deploy at %s", versionToDeploy.VersionLabel, opts.AppID, nextWindow.Format(time.RFC3339))
+			return nil
+		}
+	}
+
+	if err := waitForPreflightsToFinish(opts.AppID, versionToDeploy.Sequence); err != nil {
+		return errors.Wrap(err, "not able to auto-deploy due to failed preflight check")
+	}
+
+	if err := deployVersion(opts, clusterID, appVersions, versionToDeploy); err != nil {
+		return errors.Wrapf(err, "failed to deploy sequence %d with version label %s", versionToDeploy.Sequence, versionToDeploy.VersionLabel)
+	}
+
+	// the schedule is kept until the deploy is queued, so that a scheduled version whose preflights fail or time out
+	// is retried instead of being dropped
+	if a.ScheduledDeploy != nil || deployWindow != nil {
+		if err := store.SetScheduledDeploy(opts.AppID, nil); err != nil {
+			return errors.Wrap(err, "failed to clear scheduled deploy")
+		}
+	}
+
+	return nil
+}
+
+// getAutoDeployVersion returns the newest version that is allowed by the auto deploy policy and has finished soaking
+func getAutoDeployVersion(autoDeploy apptypes.AutoDeploy, appVersions *downstreamtypes.DownstreamVersions, soakDuration time.Duration, now time.Time) *downstreamtypes.DownstreamVersion {
 	currentVersion := appVersions.CurrentVersion
 	if currentVersion == nil {
 		return nil
 	}
 
-	var versionToDeploy *downstreamtypes.DownstreamVersion
-
 	if autoDeploy == apptypes.AutoDeploySequence {
 		// semver is not required/enabled, we only need to check if the newest app version is newer than the current version.
 		// use cursor instead of sequence in order to only deploy newer upstream versions, and not versions created by config changes, license changes, etc...
+		// versions that are still soaking, blocked or were rolled back are skipped in favor of older newer-than-current versions.
 		currentCursor := currentVersion.Cursor
-		latestCursor := appVersions.AllVersions[0].Cursor
-		if currentCursor != nil && latestCursor != nil && (*currentCursor).Before(*latestCursor) {
-			versionToDeploy = appVersions.AllVersions[0]
-		} else {
-			return nil
-		}
-	} else if currentVersion.Semver != nil { // semver is required
-	Loop:
 		for _, v := range appVersions.AllVersions {
-			if v == nil || v.Semver == nil {
-				continue
-			}
-
-			if v.Semver.LTE(*currentVersion.Semver) {
-				// remaining versions are all gonna have lower semvers
+			if currentCursor == nil || v.Cursor == nil || !(*currentCursor).Before(*v.Cursor) {
 				break
 			}
+			if isEligibleForAutoDeploy(v, soakDuration, now) {
+				return v
+			}
+		}
+		return nil
+	}
 
-			switch autoDeploy {
-			case apptypes.AutoDeploySemverPatch:
-				if v.Semver.Major == currentVersion.Semver.Major && v.Semver.Minor == currentVersion.Semver.Minor {
-					versionToDeploy = v
-					break Loop
-				}
+	if currentVersion.Semver == nil { // semver is required
+		return nil
+	}
 
-			case apptypes.AutoDeploySemverMinorPatch:
-				if v.Semver.Major == currentVersion.Semver.Major {
-					versionToDeploy = v
-					break Loop
-				}
+	for _, v := range appVersions.AllVersions {
+		if v == nil || v.Semver == nil {
+			continue
+		}
 
-			case apptypes.AutoDeploySemverMajorMinorPatch:
-				versionToDeploy = v
-				break Loop
+		if v.Semver.LTE(*currentVersion.Semver) {
+			// remaining versions are all gonna have lower semvers
+			break
+		}
+
+		if !isEligibleForAutoDeploy(v, soakDuration, now) {
+			continue
+		}
+
+		switch autoDeploy {
+		case apptypes.AutoDeploySemverPatch:
+			if v.Semver.Major == currentVersion.Semver.Major && v.Semver.Minor == currentVersion.Semver.Minor {
+				return v
+			}
+
+		case apptypes.AutoDeploySemverMinorPatch:
+			if v.Semver.Major == currentVersion.Semver.Major {
+				return v
 			}
+
+		case apptypes.AutoDeploySemverMajorMinorPatch:
+			return v
 		}
 	}
 
-	if versionToDeploy == nil {
-		return nil
+	return nil
+}
+
+// getNextSoakedVersion returns the version that will be deployed automatically when the versions that are still soaking
+// finish soaking, and the time it becomes eligible. Returns nil if no soaking version will be deployed.
+func getNextSoakedVersion(autoDeploy apptypes.AutoDeploy, appVersions *downstreamtypes.DownstreamVersions, soakDuration time.Duration, now time.Time) (*downstreamtypes.DownstreamVersion, time.Time) {
+	eligibleTimes := []time.Time{}
+	for _, v := range appVersions.AllVersions {
+		if v == nil {
+			continue
+		}
+		if eligibleAt := GetEligibleAt(v, soakDuration); eligibleAt != nil && eligibleAt.After(now) {
+			eligibleTimes = append(eligibleTimes, *eligibleAt)
+		}
 	}
+	sort.Slice(eligibleTimes, func(i, j int) bool {
+		return eligibleTimes[i].Before(eligibleTimes[j])
+	})
 
-	if err := waitForPreflightsToFinish(opts.AppID, versionToDeploy.Sequence); err != nil {
-		return errors.Wrap(err, "not able to auto-deploy due to failed preflight check")
+	for _, eligibleAt := range eligibleTimes {
+		if v := getAutoDeployVersion(autoDeploy, appVersions, soakDuration, eligibleAt); v != nil {
+			return v, eligibleAt
+		}
 	}
 
-	if err := deployVersion(opts, clusterID, appVersions, versionToDeploy); err != nil {
-		return errors.Wrapf(err, "failed to deploy sequence %d with version label %s", versionToDeploy.Sequence, versionToDeploy.VersionLabel)
+	return nil, time.Time{}
+}
+
+// GetEligibleAt returns when a version can be deployed automatically given the app's soak duration.
+// The upstream release time is used when known, otherwise the time the version was downloaded.
+func GetEligibleAt(v *downstreamtypes.DownstreamVersion, soakDuration time.Duration) *time.Time {
+	releasedAt := v.UpstreamReleasedAt
+	if releasedAt == nil {
+		releasedAt = v.CreatedOn
 	}
+	return app.GetEligibleAt(releasedAt, soakDuration)
+}
 
-	return nil
+func isEligibleForAutoDeploy(v *downstreamtypes.DownstreamVersion, soakDuration time.Duration, now time.Time) bool {
+	if v.Status == storetypes.VersionRolledBack {
+		// this version was already deployed and did not become healthy
+		return false
+	}
+	if v.IsBlocked {
+		return false
+	}
+	eligibleAt := GetEligibleAt(v, soakDuration)
+	return eligibleAt == nil || !eligibleAt.After(now)
 }
 
 func waitForPreflightsToFinish(appID string, sequence int64) error {
@@ -776,6 +1016,28 @@ func (v bySemver) Swap(i, j int) {
 	v[i], v[j] = v[j], v[i]
 }
 
+// notifyUpdateAvailable raises an update-available event for updates that were not downloaded before
+func notifyUpdateAvailable(a *apptypes.App, releases []types.UpdateCheckRelease) {
+	if len(releases) == 0 {
+		return
+	}
+
+	versionLabels := []string{}
+	for _, r := range releases {
+		versionLabels = append(versionLabels, r.Version)
+	}
+
+	notifications.Notify(notificationtypes.Event{
+		Type:    notificationtypes.EventUpdateAvailable,
+		AppID:   a.ID,
+		AppSlug: a.Slug,
+		Message: fmt.Sprintf("%d update(s) available: %s.", len(releases), strings.Join(versionLabels, ", ")),
+		Data: map[string]string{
+			"latestVersion": releases[len(releases)-1].Version,
+		},
+	})
+}
+
 // Removes updates that are older than the first release installed in the cluster
 func removeOldUpdates(updates []upstreamtypes.Update, appVersions *downstreamtypes.DownstreamVersions, isSemverRequired bool) []upstreamtypes.Update {
 	if !isSemverRequired {
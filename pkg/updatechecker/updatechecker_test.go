@@ -3,6 +3,7 @@ package updatechecker
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/golang/mock/gomock"
@@ -27,7 +28,7 @@ func TestAutoDeployDoesNotExecuteIfDisabled(t *testing.T) {
 	var autoDeployType = apptypes.AutoDeployDisabled
 	var opts = types.CheckForUpdatesOpts{}
 
-	err := autoDeploy(opts, "cluster-id", autoDeployType)
+	err := autoDeploy(opts, "cluster-id", &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil {
 		t.Errorf("autoDeploy() returned error = %v, wanted to nil", err)
 	}
@@ -555,7 +556,7 @@ func TestAutoDeployDoesNotExecuteIfNotSet(t *testing.T) {
 	var opts = types.CheckForUpdatesOpts{}
 	var clusterID = "some-cluster-id"
 
-	err := autoDeploy(opts, clusterID, "")
+	err := autoDeploy(opts, clusterID, &apptypes.App{})
 	if err != nil {
 		t.Errorf("autoDeploy() returned error = %v, wanted to nil", err)
 	}
@@ -575,7 +576,7 @@ func TestAutoDeployFailedToGetAppVersionsErrors(t *testing.T) {
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil && !strings.Contains(err.Error(), "app version error") {
 		t.Errorf("autoDeploy() returned error = %v, wanted to include %s", err, "app version error")
 	}
@@ -600,7 +601,7 @@ func TestAutoDeployAppVersionsIsEmptyErrors(t *testing.T) {
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil && !strings.Contains(err.Error(), "no app versions found for app "+appID) {
 		t.Errorf("autoDeploy() returned error = %v, wanted to include %s", err, "no app versions found for app "+appID)
 	}
@@ -625,7 +626,7 @@ func TestAutoDeployCurrentVersionIsNilDoesNothing(t *testing.T) {
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil {
 		t.Errorf("autoDeploy() returned error = %v, wanted nil", err)
 	}
@@ -652,7 +653,7 @@ func TestAutoDeployCurrentVersionSemverIsNilDoesNothing(t *testing.T) {
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil {
 		t.Errorf("autoDeploy() returned error = %v, wanted nil", err)
 	}
@@ -684,7 +685,7 @@ func TestAutoDeploySequenceQuitsIfCurrentVersionSequenceIsGreaterThanOrEqualToMo
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil {
 		t.Errorf("autoDeploy() returned error = %v, wanted nil", err)
 	}
@@ -718,7 +719,7 @@ func TestAutoDeploySequenceDeploysSequenceUpgradeIfCurrentVersionLessThanMostRec
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil && !strings.Contains(err.Error(), "quitting early so as not to test the waitForPreflightsToFinish method") {
 		t.Errorf("autoDeploy() returned error = %v, wanted %s", err, "quitting early so as not to test the waitForPreflightsToFinish method")
 	}
@@ -751,7 +752,7 @@ func TestAutoDeploySequenceDoesNotDeployIfCurrentVersionIsSameUpstream(t *testin
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil {
 		t.Errorf("autoDeploy() returned error = %v, wanted nil", err)
 	}
@@ -777,7 +778,7 @@ func TestAutoDeploySemverRequiredAllVersionsIndexIsNil(t *testing.T) {
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil {
 		t.Errorf("autoDeploy() returned error = %v, wanted nil", err)
 	}
@@ -807,7 +808,7 @@ func TestAutoDeploySemverRequiredAllVersionsHasNilSemver(t *testing.T) {
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil {
 		t.Errorf("autoDeploy() returned error = %v, wanted nil", err)
 	}
@@ -848,7 +849,7 @@ func TestAutoDeploySemverRequiredNoNewVersionToDeploy(t *testing.T) {
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil {
 		t.Errorf("autoDeploy() returned error = %v, wanted nil", err)
 	}
@@ -884,7 +885,7 @@ func TestAutoDeploySemverRequiredPatchUpdateMajorsDontMatch(t *testing.T) {
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil {
 		t.Errorf("autoDeploy() returned error = %v, wanted nil", err)
 	}
@@ -923,7 +924,7 @@ func TestAutoDeploySemverRequiredPatchUpdateMajorsMatchMinorsDontMatch(t *testin
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil {
 		t.Errorf("autoDeploy() returned error = %v, wanted nil", err)
 	}
@@ -967,7 +968,7 @@ func TestAutoDeploySemverRequiredPatchUpdateMajorsMatchMinorsMatchWillUpgrade(t
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil && !strings.Contains(err.Error(), "quitting early so as not to test the waitForPreflightsToFinish method") {
 		t.Errorf("autoDeploy() returned error = %v, wanted %s", err, "quitting early so as not to test the waitForPreflightsToFinish method")
 	}
@@ -1005,7 +1006,7 @@ func TestAutoDeploySemverRequiredMinorUpdateMajorsDontMatch(t *testing.T) {
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil {
 		t.Errorf("autoDeploy() returned error = %v, wanted nil", err)
 	}
@@ -1046,7 +1047,7 @@ func TestAutoDeploySemverRequiredMinorUpdateMajorsMatchWillUpgrade(t *testing.T)
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil && !strings.Contains(err.Error(), "quitting early so as not to test the waitForPreflightsToFinish method") {
 		t.Errorf("autoDeploy() returned error = %v, wanted %s", err, "quitting early so as not to test the waitForPreflightsToFinish method")
 	}
@@ -1084,12 +1085,220 @@ func TestAutoDeploySemverRequiredMajorUpdateWillUpgrade(t *testing.T) {
 
 	store = mockStore
 
-	err := autoDeploy(opts, clusterID, autoDeployType)
+	err := autoDeploy(opts, clusterID, &apptypes.App{AutoDeploy: autoDeployType})
 	if err != nil && !strings.Contains(err.Error(), "quitting early so as not to test the waitForPreflightsToFinish method") {
 		t.Errorf("autoDeploy() returned error = %v, wanted %s", err, "quitting early so as not to test the waitForPreflightsToFinish method")
 	}
 }
 
+func Test_getAutoDeployVersion(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	twoDaysAgo := now.Add(-48 * time.Hour)
+	oneHourAgo := now.Add(-time.Hour)
+
+	cursor1 := cursor.MustParse("1")
+	cursor2 := cursor.MustParse("2")
+	cursor3 := cursor.MustParse("3")
+
+	semver100 := semver.MustParse("1.0.0")
+	semver101 := semver.MustParse("1.0.1")
+	semver102 := semver.MustParse("1.0.2")
+
+	sequenceVersions := &downstreamtypes.DownstreamVersions{
+		CurrentVersion: &downstreamtypes.DownstreamVersion{Cursor: &cursor1, Sequence: 1},
+		AllVersions: []*downstreamtypes.DownstreamVersion{
+			{Cursor: &cursor3, Sequence: 3, UpstreamReleasedAt: &oneHourAgo},
+			{Cursor: &cursor2, Sequence: 2, UpstreamReleasedAt: &twoDaysAgo},
+			{Cursor: &cursor1, Sequence: 1, UpstreamReleasedAt: &twoDaysAgo},
+		},
+	}
+
+	semverVersions := &downstreamtypes.DownstreamVersions{
+		CurrentVersion: &downstreamtypes.DownstreamVersion{Semver: &semver100, Sequence: 1},
+		AllVersions: []*downstreamtypes.DownstreamVersion{
+			{Semver: &semver102, Sequence: 3, CreatedOn: &oneHourAgo},
+			{Semver: &semver101, Sequence: 2, CreatedOn: &twoDaysAgo},
+			{Semver: &semver100, Sequence: 1, CreatedOn: &twoDaysAgo},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		autoDeploy   apptypes.AutoDeploy
+		appVersions  *downstreamtypes.DownstreamVersions
+		soakDuration time.Duration
+		wantSequence *int64
+	}{
+		{
+			name:         "sequence without soak time deploys the latest version",
+			autoDeploy:   apptypes.AutoDeploySequence,
+			appVersions:  sequenceVersions,
+			wantSequence: int64Ptr(3),
+		},
+		{
+			name:         "sequence skips versions that are still soaking",
+			autoDeploy:   apptypes.AutoDeploySequence,
+			appVersions:  sequenceVersions,
+			soakDuration: 24 * time.Hour,
+			wantSequence: int64Ptr(2),
+		},
+		{
+			name:         "sequence deploys nothing when all newer versions are soaking",
+			autoDeploy:   apptypes.AutoDeploySequence,
+			appVersions:  sequenceVersions,
+			soakDuration: 72 * time.Hour,
+			wantSequence: nil,
+		},
+		{
+			name:       "sequence skips versions that were rolled back",
+			autoDeploy: apptypes.AutoDeploySequence,
+			appVersions: &downstreamtypes.DownstreamVersions{
+				CurrentVersion: &downstreamtypes.DownstreamVersion{Cursor: &cursor1, Sequence: 1},
+				AllVersions: []*downstreamtypes.DownstreamVersion{
+					{Cursor: &cursor3, Sequence: 3, Status: storetypes.VersionRolledBack},
+					{Cursor: &cursor2, Sequence: 2, Status: storetypes.VersionPending},
+					{Cursor: &cursor1, Sequence: 1, Status: storetypes.VersionDeployed},
+				},
+			},
+			wantSequence: int64Ptr(2),
+		},
+		{
+			name:       "sequence skips blocked versions",
+			autoDeploy: apptypes.AutoDeploySequence,
+			appVersions: &downstreamtypes.DownstreamVersions{
+				CurrentVersion: &downstreamtypes.DownstreamVersion{Cursor: &cursor1, Sequence: 1},
+				AllVersions: []*downstreamtypes.DownstreamVersion{
+					{Cursor: &cursor3, Sequence: 3, IsBlocked: true, BlockedReason: "known bad release"},
+					{Cursor: &cursor2, Sequence: 2},
+					{Cursor: &cursor1, Sequence: 1},
+				},
+			},
+			wantSequence: int64Ptr(2),
+		},
+		{
+			name:         "semver falls back to the download time when the release time is unknown",
+			autoDeploy:   apptypes.AutoDeploySemverPatch,
+			appVersions:  semverVersions,
+			soakDuration: 24 * time.Hour,
+			wantSequence: int64Ptr(2),
+		},
+		{
+			name:         "semver deploys nothing when all newer versions are soaking",
+			autoDeploy:   apptypes.AutoDeploySemverMajorMinorPatch,
+			appVersions:  semverVersions,
+			soakDuration: 72 * time.Hour,
+			wantSequence: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getAutoDeployVersion(tt.autoDeploy, tt.appVersions, tt.soakDuration, now)
+			if tt.wantSequence == nil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, *tt.wantSequence, got.Sequence)
+		})
+	}
+}
+
+func Test_getNextSoakedVersion(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	twoDaysAgo := now.Add(-48 * time.Hour)
+	oneDayAgo := now.Add(-24 * time.Hour)
+	oneHourAgo := now.Add(-time.Hour)
+
+	cursor1 := cursor.MustParse("1")
+	cursor2 := cursor.MustParse("2")
+	cursor3 := cursor.MustParse("3")
+
+	semver100 := semver.MustParse("1.0.0")
+	semver101 := semver.MustParse("1.0.1")
+	semver200 := semver.MustParse("2.0.0")
+
+	tests := []struct {
+		name           string
+		autoDeploy     apptypes.AutoDeploy
+		appVersions    *downstreamtypes.DownstreamVersions
+		soakDuration   time.Duration
+		wantSequence   *int64
+		wantEligibleAt time.Time
+	}{
+		{
+			name:       "sequence schedules the first version to finish soaking",
+			autoDeploy: apptypes.AutoDeploySequence,
+			appVersions: &downstreamtypes.DownstreamVersions{
+				CurrentVersion: &downstreamtypes.DownstreamVersion{Cursor: &cursor1, Sequence: 1},
+				AllVersions: []*downstreamtypes.DownstreamVersion{
+					{Cursor: &cursor3, Sequence: 3, UpstreamReleasedAt: &oneHourAgo},
+					{Cursor: &cursor2, Sequence: 2, UpstreamReleasedAt: &oneDayAgo},
+					{Cursor: &cursor1, Sequence: 1, UpstreamReleasedAt: &twoDaysAgo},
+				},
+			},
+			soakDuration:   36 * time.Hour,
+			wantSequence:   int64Ptr(2),
+			wantEligibleAt: oneDayAgo.Add(36 * time.Hour),
+		},
+		{
+			name:       "semver skips soaking versions that are not allowed by the policy",
+			autoDeploy: apptypes.AutoDeploySemverPatch,
+			appVersions: &downstreamtypes.DownstreamVersions{
+				CurrentVersion: &downstreamtypes.DownstreamVersion{Semver: &semver100, Sequence: 1},
+				AllVersions: []*downstreamtypes.DownstreamVersion{
+					{Semver: &semver200, Sequence: 3, UpstreamReleasedAt: &oneDayAgo},
+					{Semver: &semver101, Sequence: 2, UpstreamReleasedAt: &oneHourAgo},
+					{Semver: &semver100, Sequence: 1, UpstreamReleasedAt: &twoDaysAgo},
+				},
+			},
+			soakDuration:   36 * time.Hour,
+			wantSequence:   int64Ptr(2),
+			wantEligibleAt: oneHourAgo.Add(36 * time.Hour),
+		},
+		{
+			name:       "nothing is scheduled without soak time",
+			autoDeploy: apptypes.AutoDeploySequence,
+			appVersions: &downstreamtypes.DownstreamVersions{
+				CurrentVersion: &downstreamtypes.DownstreamVersion{Cursor: &cursor1, Sequence: 1},
+				AllVersions: []*downstreamtypes.DownstreamVersion{
+					{Cursor: &cursor2, Sequence: 2, UpstreamReleasedAt: &oneHourAgo},
+					{Cursor: &cursor1, Sequence: 1, UpstreamReleasedAt: &twoDaysAgo},
+				},
+			},
+			wantSequence: nil,
+		},
+		{
+			name:       "nothing is scheduled when no newer version is soaking",
+			autoDeploy: apptypes.AutoDeploySequence,
+			appVersions: &downstreamtypes.DownstreamVersions{
+				CurrentVersion: &downstreamtypes.DownstreamVersion{Cursor: &cursor2, Sequence: 2},
+				AllVersions: []*downstreamtypes.DownstreamVersion{
+					{Cursor: &cursor2, Sequence: 2, UpstreamReleasedAt: &oneHourAgo},
+					{Cursor: &cursor1, Sequence: 1, UpstreamReleasedAt: &twoDaysAgo},
+				},
+			},
+			soakDuration: 36 * time.Hour,
+			wantSequence: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, eligibleAt := getNextSoakedVersion(tt.autoDeploy, tt.appVersions, tt.soakDuration, now)
+			if tt.wantSequence == nil {
+				assert.Nil(t, got)
+				return
+			}
+			require.NotNil(t, got)
+			assert.Equal(t, *tt.wantSequence, got.Sequence)
+			assert.Equal(t, tt.wantEligibleAt, eligibleAt)
+		})
+	}
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
 func TestWaitForPreflightsToFinishGetAppErrors(t *testing.T) {
 	var appID = "some-app"
 	var sequence = int64(0)
@@ -1,5 +1,14 @@
 package types
 
+import "time"
+
 type User struct {
-	ID string
+	ID         string     `json:"id"`
+	Email      string     `json:"email,omitempty"`
+	FirstName  string     `json:"firstName,omitempty"`
+	LastName   string     `json:"lastName,omitempty"`
+	Roles      []string   `json:"roles,omitempty"`
+	IsDisabled bool       `json:"isDisabled"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty"`
+	LastLogin  *time.Time `json:"lastLogin,omitempty"`
 }
@@ -1,19 +1,35 @@
 package user
 
 import (
+	"strings"
 	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/password"
+	"github.com/replicatedhq/kots/pkg/rbac"
 	"github.com/replicatedhq/kots/pkg/store"
 	usertypes "github.com/replicatedhq/kots/pkg/user/types"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	// SharedPasswordUserID is the user ID of sessions created with the shared admin password
+	SharedPasswordUserID = "000000"
+
+	// dummyPasswordBcrypt is compared against when logging in to an unknown account, so that the login takes
+	// as long as for an existing account. It has the same cost as the stored passwords.
+	dummyPasswordBcrypt = "$2a$10$RIZuP1ZZ0aYuvvBgE.WFM.UNEpiqS2foh8pS6Ecpw3kpnpcZvxr8u"
+)
+
 var (
 	loginMutex         sync.Mutex
 	ErrInvalidPassword = errors.New("invalid password")
 	ErrTooManyAttempts = errors.New("too many attempts")
+	ErrUserExists      = errors.New("user already exists")
+	ErrInvalidEmail    = errors.New("a valid email address is required")
+	ErrNoRoles         = errors.New("at least one role is required")
+	ErrUnknownRole     = errors.New("unknown role")
 )
 
 func LogIn(password string) (*usertypes.User, error) {
@@ -46,6 +62,180 @@ func LogIn(password string) (*usertypes.User, error) {
 	}
 
 	return &usertypes.User{
-		ID: "000000",
+		ID: SharedPasswordUserID,
 	}, nil
 }
+
+// LogInLocalUser authenticates a named local account. Unknown and disabled
+// accounts are reported as an invalid password so that account names cannot
+// be discovered through the login endpoint.
+func LogInLocalUser(email string, password string) (*usertypes.User, error) {
+	loginMutex.Lock()
+	defer loginMutex.Unlock()
+
+	kotsStore := store.GetStore()
+
+	foundUser, err := kotsStore.GetLocalUserByEmail(normalizeEmail(email))
+	if kotsStore.IsNotFound(err) {
+		_ = bcrypt.CompareHashAndPassword([]byte(dummyPasswordBcrypt), []byte(password))
+		return nil, ErrInvalidPassword
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to get user")
+	}
+
+	shaBytes, err := kotsStore.GetLocalUserPasswordBcrypt(foundUser.ID)
+	if err != nil && err.Error() == ErrTooManyAttempts.Error() {
+		return nil, ErrTooManyAttempts
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get user password bcrypt")
+	}
+
+	if err := bcrypt.CompareHashAndPassword(shaBytes, []byte(password)); err != nil {
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			if err := kotsStore.FlagLocalUserInvalidPassword(foundUser.ID); err != nil {
+				logger.Infof("failed to flag failed login for user %s: %v", foundUser.ID, err)
+			}
+			return nil, ErrInvalidPassword
+		}
+
+		return nil, errors.Wrap(err, "failed to compare password")
+	}
+
+	if foundUser.IsDisabled {
+		return nil, ErrInvalidPassword
+	}
+
+	if err := kotsStore.FlagLocalUserSuccessfulLogin(foundUser.ID); err != nil {
+		logger.Error(errors.Wrap(err, "failed to flag successful login"))
+	}
+
+	return foundUser, nil
+}
+
+// CreateLocalUser validates and creates a named local account
+func CreateLocalUser(email string, firstName string, lastName string, newPassword string, roles []string) (*usertypes.User, error) {
+	email = normalizeEmail(email)
+	if email == "" || !strings.Contains(email, "@") {
+		return nil, ErrInvalidEmail
+	}
+
+	if err := password.ValidateNewPassword(newPassword); err != nil {
+		return nil, err
+	}
+
+	if err := ValidateRoles(roles); err != nil {
+		return nil, err
+	}
+
+	shaBytes, err := bcrypt.GenerateFromPassword([]byte(newPassword), 10)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate encrypted password")
+	}
+
+	createdUser, err := store.GetStore().CreateLocalUser(email, firstName, lastName, shaBytes, roles)
+	if err != nil && errors.Cause(err).Error() == ErrUserExists.Error() {
+		return nil, ErrUserExists
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to create user")
+	}
+
+	return createdUser, nil
+}
+
+// ResetLocalUserPassword sets a new password for a local account, unlocks it
+// and signs out all of its existing sessions
+func ResetLocalUserPassword(userID string, newPassword string) error {
+	if err := password.ValidateNewPassword(newPassword); err != nil {
+		return err
+	}
+
+	shaBytes, err := bcrypt.GenerateFromPassword([]byte(newPassword), 10)
+	if err != nil {
+		return errors.Wrap(err, "failed to generate encrypted password")
+	}
+
+	if err := store.GetStore().SetLocalUserPasswordBcrypt(userID, shaBytes); err != nil {
+		return errors.Wrap(err, "failed to set user password")
+	}
+
+	if err := store.GetStore().DeleteSessionsForUser(userID); err != nil {
+		return errors.Wrap(err, "failed to delete user sessions")
+	}
+
+	return nil
+}
+
+// SetLocalUserDisabled enables or disables a local account. Disabling an
+// account signs out all of its existing sessions.
+func SetLocalUserDisabled(userID string, isDisabled bool) error {
+	if err := store.GetStore().SetLocalUserDisabled(userID, isDisabled); err != nil {
+		return errors.Wrap(err, "failed to set user disabled")
+	}
+
+	if isDisabled {
+		if err := store.GetStore().DeleteSessionsForUser(userID); err != nil {
+			return errors.Wrap(err, "failed to delete user sessions")
+		}
+	}
+
+	return nil
+}
+
+// SetLocalUserRoles replaces the roles assigned to a local account. Existing
+// sessions keep the roles they were issued with, so they are signed out.
+func SetLocalUserRoles(userID string, roles []string) error {
+	if err := ValidateRoles(roles); err != nil {
+		return err
+	}
+
+	if err := store.GetStore().SetLocalUserRoles(userID, roles); err != nil {
+		return errors.Wrap(err, "failed to set user roles")
+	}
+
+	if err := store.GetStore().DeleteSessionsForUser(userID); err != nil {
+		return errors.Wrap(err, "failed to delete user sessions")
+	}
+
+	return nil
+}
+
+// DeleteLocalUser deletes a local account and all of its sessions
+func DeleteLocalUser(userID string) error {
+	if err := store.GetStore().DeleteLocalUser(userID); err != nil {
+		return errors.Wrap(err, "failed to delete user")
+	}
+
+	if err := store.GetStore().DeleteSessionsForUser(userID); err != nil {
+		return errors.Wrap(err, "failed to delete user sessions")
+	}
+
+	return nil
+}
+
+// ValidateRoles checks that at least one role is assigned and that every role
+// ID refers to a known role
+func ValidateRoles(roleIDs []string) error {
+	if len(roleIDs) == 0 {
+		return ErrNoRoles
+	}
+
+	for _, roleID := range roleIDs {
+		found := false
+		for _, role := range rbac.Roles() {
+			if role.ID == roleID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Wrapf(ErrUnknownRole, "role %q", roleID)
+		}
+	}
+
+	return nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,54 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestValidateRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		roleIDs []string
+		wantErr error
+	}{
+		{
+			name:    "cluster-admin",
+			roleIDs: []string{"cluster-admin"},
+		},
+		{
+			name:    "multiple known roles",
+			roleIDs: []string{"cluster-admin", "support"},
+		},
+		{
+			name:    "no roles",
+			roleIDs: nil,
+			wantErr: ErrNoRoles,
+		},
+		{
+			name:    "unknown role",
+			roleIDs: []string{"support", "not-a-role"},
+			wantErr: ErrUnknownRole,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRoles(tt.roleIDs)
+			if errors.Cause(err) != tt.wantErr {
+				t.Errorf("ValidateRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDummyPasswordBcrypt(t *testing.T) {
+	// the unknown account path must do the same bcrypt work as comparing a stored password
+	cost, err := bcrypt.Cost([]byte(dummyPasswordBcrypt))
+	if err != nil {
+		t.Fatalf("invalid dummy password bcrypt: %v", err)
+	}
+	if cost != 10 {
+		t.Errorf("dummy password bcrypt cost = %d, want 10", cost)
+	}
+}
@@ -3,8 +3,9 @@ package util
 import "os"
 
 const (
-	PasswordSecretName = "kotsadm-password"
-	SessionsSecretName = "kotsadm-sessions"
+	PasswordSecretName  = "kotsadm-password"
+	SessionsSecretName  = "kotsadm-sessions"
+	TwoFactorSecretName = "kotsadm-2fa"
 )
 
 var (
@@ -51,10 +51,6 @@ func DeployVersion(appID string, sequence int64) error {
 
 	logger.Info("deploying app version", zap.String("appId", appID), zap.Int64("sequence", sequence))
 
-	if err := store.GetStore().MarkAsCurrentDownstreamVersion(appID, sequence); err != nil {
-		return errors.Wrap(err, "failed to mark as current downstream version")
-	}
-
 	if err := operator.MustGetOperator().GoDeployApp(appID, sequence); err != nil {
 		return errors.Wrap(err, "failed to start app deployment")
 	}
@@ -0,0 +1,72 @@
+package watchers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/replicatedhq/kots/pkg/logger"
+	"github.com/replicatedhq/kots/pkg/rbac"
+	"github.com/replicatedhq/kots/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// watchRBACRoles loads custom rbac roles from the kotsadm roles configmap and reloads them
+// whenever the configmap changes. Invalid roles are logged and the previously loaded roles are kept.
+func watchRBACRoles(clientset kubernetes.Interface) error {
+	logger.Info("starting rbac roles watcher")
+
+	factory := informers.NewSharedInformerFactoryWithOptions(clientset, 0,
+		informers.WithNamespace(util.PodNamespace),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", rbac.CustomRolesConfigMapName).String()
+		}),
+	)
+	configMapInformer := factory.Core().V1().ConfigMaps().Informer()
+
+	handler, err := configMapInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			loadRBACRoles(obj.(*corev1.ConfigMap))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			loadRBACRoles(newObj.(*corev1.ConfigMap))
+		},
+		DeleteFunc: func(obj interface{}) {
+			logger.Infof("rbac roles configmap deleted, removing custom roles")
+			if err := rbac.SetCustomRoles(nil); err != nil {
+				logger.Error(errors.Wrap(err, "failed to remove custom rbac roles"))
+			}
+		},
+	})
+	if err != nil {
+		return errors.Wrap(err, "add event handler")
+	}
+
+	ctx := context.Background()
+	go configMapInformer.Run(ctx.Done())
+
+	if !cache.WaitForCacheSync(ctx.Done(), handler.HasSynced) {
+		return errors.New("sync rbac roles configmap cache")
+	}
+
+	return nil
+}
+
+func loadRBACRoles(configMap *corev1.ConfigMap) {
+	roles, err := rbac.ParseCustomRoles([]byte(configMap.Data[rbac.CustomRolesConfigMapKey]))
+	if err != nil {
+		logger.Error(errors.Wrapf(err, "invalid custom rbac roles in configmap %s, keeping previously loaded roles", configMap.Name))
+		return
+	}
+
+	if err := rbac.SetCustomRoles(roles); err != nil {
+		logger.Error(errors.Wrap(err, "failed to set custom rbac roles"))
+		return
+	}
+
+	logger.Infof("loaded %d custom rbac roles", len(roles))
+}
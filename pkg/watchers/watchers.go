@@ -30,6 +30,10 @@ func Start(clusterID string) error {
 		return errors.Wrap(err, "watch embedded cluster nodes")
 	}
 
+	if err := watchRBACRoles(clientset); err != nil {
+		return errors.Wrap(err, "watch rbac roles")
+	}
+
 	return nil
 }
 
@@ -18,7 +18,10 @@ type Props = {
 };
 
 type State = {
+  username: string;
   password: string;
+  twoFactorToken: string;
+  twoFactorCode: string;
   loginErr: boolean;
   loginErrMessage: string;
   authLoading: boolean;
@@ -29,6 +32,8 @@ type State = {
 type LoginResponse = {
   expires?: number;
   sessionRoles: string;
+  twoFactorRequired?: boolean;
+  twoFactorToken?: string;
 };
 class SecureAdminConsole extends Component<Props, State> {
   loginText: RefObject<HTMLDivElement>;
@@ -37,7 +42,10 @@ class SecureAdminConsole extends Component<Props, State> {
     super(props);
 
     this.state = {
+      username: "",
       password: "",
+      twoFactorToken: "",
+      twoFactorCode: "",
       loginErr: false,
       loginErrMessage: "",
       authLoading: false,
@@ -112,6 +120,8 @@ class SecureAdminConsole extends Component<Props, State> {
         },
         method: "POST",
         body: JSON.stringify({
+          // local user accounts log in with their email, the shared admin password is used without one
+          username: this.state.username.trim() || undefined,
           password: this.state.password,
         }),
         credentials: "include",
@@ -123,7 +133,9 @@ class SecureAdminConsole extends Component<Props, State> {
             if (!msg) {
               msg =
                 res.status === 401
-                  ? "Invalid password. Please try again"
+                  ? this.state.username.trim()
+                    ? "Invalid email or password. Please try again"
+                    : "Invalid password. Please try again"
                   : "There was an error logging in. Please try again.";
             }
             this.setState({
@@ -135,7 +147,17 @@ class SecureAdminConsole extends Component<Props, State> {
           }
           // TODO: refactor this fetch function to return the result instead of using the callback in the fetch
           // TODO: remove "as" and use type Promise<LoginResponse> on loginWithSharedPassword
-          this.completeLogin((await res.json()) as LoginResponse);
+          const body = (await res.json()) as LoginResponse;
+          if (body.twoFactorRequired && body.twoFactorToken) {
+            // the password was accepted, a second factor is needed to get a session
+            this.setState({
+              authLoading: false,
+              twoFactorToken: body.twoFactorToken,
+              twoFactorCode: "",
+            });
+            return;
+          }
+          this.completeLogin(body);
         })
         .catch((err) => {
           console.log("Login failed:", err);
@@ -148,6 +170,59 @@ class SecureAdminConsole extends Component<Props, State> {
     }
   };
 
+  loginWithTwoFactorCode = async () => {
+    if (!this.state.twoFactorCode) {
+      this.setState({
+        loginErr: true,
+        loginErrMessage: "Please provide your authentication code",
+      });
+      return;
+    }
+
+    this.setState({
+      authLoading: true,
+      loginErr: false,
+      loginErrMessage: "",
+    });
+    try {
+      const res = await fetch(`${process.env.API_ENDPOINT}/login/2fa`, {
+        headers: {
+          "Content-Type": "application/json",
+        },
+        method: "POST",
+        body: JSON.stringify({
+          twoFactorToken: this.state.twoFactorToken,
+          code: this.state.twoFactorCode.trim(),
+        }),
+        credentials: "include",
+      });
+      if (res.status >= 400) {
+        const body = await res.json();
+        const msg =
+          body.error || "There was an error logging in. Please try again.";
+        // unless another code can be tried, an expired login or locked two-factor authentication needs the password again
+        this.setState({
+          authLoading: false,
+          loginErr: true,
+          loginErrMessage: msg,
+          twoFactorToken: body.twoFactorRequired
+            ? this.state.twoFactorToken
+            : "",
+          twoFactorCode: "",
+        });
+        return;
+      }
+      this.completeLogin((await res.json()) as LoginResponse);
+    } catch (err) {
+      console.log("Login failed:", err);
+      this.setState({
+        authLoading: false,
+        loginErr: true,
+        loginErrMessage: "There was an error logging in. Please try again",
+      });
+    }
+  };
+
   loginWithIdentityProvider = async () => {
     try {
       this.setState({ loginErr: false, loginErrMessage: "" });
@@ -189,7 +264,11 @@ class SecureAdminConsole extends Component<Props, State> {
     if (enterKey) {
       e.preventDefault();
       e.stopPropagation();
-      this.loginWithSharedPassword();
+      if (this.state.twoFactorToken) {
+        this.loginWithTwoFactorCode();
+      } else {
+        this.loginWithSharedPassword();
+      }
     }
   };
 
@@ -297,8 +376,16 @@ class SecureAdminConsole extends Component<Props, State> {
 
   render() {
     const { appName, logo, fetchingMetadata } = this.props;
-    const { password, authLoading, loginErr, loginErrMessage, loginInfo } =
-      this.state;
+    const {
+      username,
+      password,
+      twoFactorToken,
+      twoFactorCode,
+      authLoading,
+      loginErr,
+      loginErrMessage,
+      loginInfo,
+    } = this.state;
 
     if (fetchingMetadata || !loginInfo) {
       // secure-console url can receive an error message as url parameter.
@@ -365,7 +452,9 @@ class SecureAdminConsole extends Component<Props, State> {
             </div>
             <div className="flex-auto flex-column justifyContent--center">
               <p className="u-marginTop--10 u-marginTop--5 u-fontSize--large u-textAlign--center u-fontWeight--medium u-lineHeight--normal u-textColor--bodyCopy break-word">
-                Enter the password to access the {appName} Admin Console.
+                {twoFactorToken
+                  ? "Enter the code from your authenticator app or one of your recovery codes."
+                  : `Enter your email and password, or only the admin password, to access the ${appName} Admin Console.`}
               </p>
               <div className="u-marginTop--20 flex-column">
                 {loginErr && (
@@ -375,26 +464,61 @@ class SecureAdminConsole extends Component<Props, State> {
                 )}
                 <div>
                   <div className="component-wrapper">
-                    <input
-                      type="password"
-                      className="Input"
-                      data-testid="login-password-input"
-                      placeholder="password"
-                      autoComplete="current-password"
-                      value={password}
-                      onChange={(e) => {
-                        this.setState({ password: e.target.value });
-                      }}
-                    />
+                    {twoFactorToken ? (
+                      <input
+                        type="text"
+                        className="Input"
+                        data-testid="login-2fa-code-input"
+                        placeholder="authentication code"
+                        autoComplete="one-time-code"
+                        value={twoFactorCode}
+                        onChange={(e) => {
+                          this.setState({ twoFactorCode: e.target.value });
+                        }}
+                      />
+                    ) : (
+                      <>
+                        <input
+                          type="text"
+                          className="Input"
+                          data-testid="login-username-input"
+                          placeholder="email (optional)"
+                          autoComplete="username"
+                          value={username}
+                          onChange={(e) => {
+                            this.setState({ username: e.target.value });
+                          }}
+                        />
+                        <input
+                          type="password"
+                          className="Input u-marginTop--10"
+                          data-testid="login-password-input"
+                          placeholder="password"
+                          autoComplete="current-password"
+                          value={password}
+                          onChange={(e) => {
+                            this.setState({ password: e.target.value });
+                          }}
+                        />
+                      </>
+                    )}
                   </div>
                   <div className="u-marginTop--20 flex justifyContent--center">
                     <button
                       type="submit"
                       className="btn primary"
                       disabled={authLoading}
-                      onClick={this.loginWithSharedPassword}
+                      onClick={
+                        twoFactorToken
+                          ? this.loginWithTwoFactorCode
+                          : this.loginWithSharedPassword
+                      }
                     >
-                      {authLoading ? "Logging in" : "Log in"}
+                      {authLoading
+                        ? "Logging in"
+                        : twoFactorToken
+                          ? "Verify"
+                          : "Log in"}
                     </button>
                   </div>
                 </div>
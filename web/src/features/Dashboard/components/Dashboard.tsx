@@ -835,6 +835,11 @@ const Dashboard = (props: Props) => {
                               {resource?.namespace}/{resource?.kind}/
                               {resource?.name}
                             </p>
+                            {resource?.message && (
+                              <p className="u-fontSize--small u-textColor--bodyCopy u-marginTop--5">
+                                {resource.message}
+                              </p>
+                            )}
                           </div>
                         )
                       )}
@@ -388,6 +388,20 @@ const DashboardVersionCard = (props: Props) => {
           </span>
         </div>
       );
+    } else if (version?.status === "rolled_back") {
+      return (
+        <div className="flex alignItems--center">
+          <span className="status-tag failed flex-auto u-marginRight--10">
+            Rolled back
+          </span>
+          <span
+            className="link u-fontSize--small"
+            onClick={() => handleViewLogs(version, true)}
+          >
+            View deploy logs
+          </span>
+        </div>
+      );
     } else if (version?.status === "deploying") {
       return (
         <span className="flex alignItems--center u-fontSize--small u-lineHeight--normal u-textColor--bodyCopy u-fontWeight--medium">
@@ -49,6 +49,7 @@ const AppGitops = () => {
 
   const gitops = selectedApp?.downstream.gitops;
   const deployKey = gitops?.deployKey;
+  const isSSH = !gitops?.authType || gitops?.authType === "ssh";
   const addKeyUri = getAddKeyUri(gitops, ownerRepo);
 
   const selectedService = SERVICES.find((service) => {
@@ -65,6 +66,11 @@ const AppGitops = () => {
       return "";
     }
 
+    if (gitops?.provider === "generic") {
+      // generic clone urls may be scp-style ssh urls that can't be parsed
+      return "";
+    }
+
     let ownerRepo = "";
     const parsed = new URL(gitops?.uri);
     if (gitops?.provider === "bitbucket_server") {
@@ -235,25 +241,49 @@ const AppGitops = () => {
                 Access to your repository is needed to push application updates
               </p>
             </Flex>
-            <p
-              className="u-fontSize--normal u-fontWeight--normal u-marginBottom--15"
-              style={{ color: "#585858" }}
-            >
-              Add this SSH key on your
-              <a href={addKeyUri} target="_blank" rel="noopener noreferrer">
-                {selectedApp.downstream.gitops.provider === "bitbucket_server"
-                  ? " account settings page, "
-                  : " repository settings page, "}
-              </a>
-              and grant it write access.
-            </p>
-            <CodeSnippet
-              canCopy={true}
-              copyText="Copy key"
-              onCopyText={<span className="u-textColor--success">Copied</span>}
-            >
-              {deployKey}
-            </CodeSnippet>
+            {isSSH ? (
+              <>
+                <p
+                  className="u-fontSize--normal u-fontWeight--normal u-marginBottom--15"
+                  style={{ color: "#585858" }}
+                >
+                  {gitops?.provider === "generic" ? (
+                    "Add this SSH key to your Git server "
+                  ) : (
+                    <>
+                      Add this SSH key on your
+                      <a
+                        href={addKeyUri}
+                        target="_blank"
+                        rel="noopener noreferrer"
+                      >
+                        {gitops?.provider === "bitbucket_server"
+                          ? " account settings page, "
+                          : " repository settings page, "}
+                      </a>
+                    </>
+                  )}
+                  and grant it write access.
+                </p>
+                <CodeSnippet
+                  canCopy={true}
+                  copyText="Copy key"
+                  onCopyText={
+                    <span className="u-textColor--success">Copied</span>
+                  }
+                >
+                  {deployKey}
+                </CodeSnippet>
+              </>
+            ) : (
+              <p
+                className="u-fontSize--normal u-fontWeight--normal u-marginBottom--15"
+                style={{ color: "#585858" }}
+              >
+                Commits are pushed over HTTPS with the configured credentials.
+                Make sure they have write access to the repository.
+              </p>
+            )}
           </div>
 
           <div className="flex justifyContent--spaceBetween alignItems--center">
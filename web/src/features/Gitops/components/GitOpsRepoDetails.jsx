@@ -1,5 +1,7 @@
 import { useState, useContext } from "react";
+import Select from "react-select";
 import { GitOpsContext, withGitOpsConsumer } from "../context";
+import { AUTH_TYPES, FORMATS } from "../constants";
 import { Flex } from "../../../styles/common";
 import Loader from "../../../components/shared/Loader";
 import { usePrevious } from "../../../hooks/usePrevious";
@@ -23,17 +25,44 @@ const GitopsRepoDetails = () => {
     providerError,
     setProviderError,
     stepFrom,
+    authType,
+    setAuthType,
+    username,
+    setUsername,
+    password,
+    setPassword,
+    cloneUrl,
+    setCloneUrl,
+    commitUrlTemplate,
+    setCommitUrlTemplate,
+    format,
+    setFormat,
   } = useContext(GitOpsContext);
   const [action] = useState("commit");
-  const [format] = useState("single");
   const previousOwner = usePrevious(owner);
   const previousRepo = usePrevious(repo);
   const previousBranch = usePrevious(branch);
   const previousPath = usePrevious(path);
+  const previousAuthType = usePrevious(authType);
+  const previousUsername = usePrevious(username);
+  const previousCloneUrl = usePrevious(cloneUrl);
+  const previousCommitUrlTemplate = usePrevious(commitUrlTemplate);
+  const previousFormat = usePrevious(format);
   const provider = selectedService?.value;
   const isBitbucketServer = provider === "bitbucket_server";
+  const isGeneric = provider === "generic";
+  const isSSH = authType === "ssh";
+  const selectedAuthType = AUTH_TYPES.find((t) => t.value === authType);
+  const selectedFormat = FORMATS.find((f) => f.value === format);
 
   const isValid = () => {
+    if (isGeneric) {
+      if (!cloneUrl.length) {
+        setProviderError({ field: "cloneUrl" });
+        return false;
+      }
+      return true;
+    }
     if (provider !== "other" && !owner.length) {
       setProviderError({ field: "owner" });
       return false;
@@ -77,7 +106,13 @@ const GitopsRepoDetails = () => {
       owner !== previousOwner ||
       repo !== previousRepo ||
       branch !== previousBranch ||
-      path !== previousPath
+      path !== previousPath ||
+      authType !== previousAuthType ||
+      username !== previousUsername ||
+      password.length > 0 ||
+      cloneUrl !== previousCloneUrl ||
+      commitUrlTemplate !== previousCommitUrlTemplate ||
+      format !== previousFormat
     ) {
       return true;
     }
@@ -88,49 +123,141 @@ const GitopsRepoDetails = () => {
   return (
     <>
       <Flex key={`action-active`} width="100%" direction="column">
-        <Flex flex="1" mt="30" mb="20" width="100%">
-          <div className="flex flex1 flex-column u-marginRight--20">
+        {isGeneric ? (
+          <Flex flex="1" mt="30" mb="20" width="100%" direction="column">
             <p className="card-item-title">
-              {isBitbucketServer ? "Project" : "Owner"}
-              <span className="card-item-title"> (Required)</span>
+              Clone URL <span className="card-item-title">(Required)</span>
             </p>
             <input
               type="text"
               className={`Input ${
-                providerError?.field === "owner" && "has-error"
+                providerError?.field === "cloneUrl" && "has-error"
               }`}
-              placeholder={isBitbucketServer ? "project" : "owner"}
-              value={owner}
-              onChange={(e) => setOwner(e.target.value)}
+              placeholder={
+                isSSH
+                  ? "git@git.example.com:owner/repo.git"
+                  : "https://git.example.com/owner/repo.git"
+              }
+              value={cloneUrl}
+              onChange={(e) => setCloneUrl(e.target.value)}
             />
-            {providerError?.field === "owner" && (
+            {providerError?.field === "cloneUrl" && (
               <p className="u-fontSize--small u-marginTop--5 u-color--chestnut u-fontWeight--medium u-lineHeight--normal">
-                {isBitbucketServer
-                  ? "A project must be provided"
-                  : "An owner must be provided"}
+                A clone URL must be provided
               </p>
             )}
+          </Flex>
+        ) : (
+          <Flex flex="1" mt="30" mb="20" width="100%">
+            <div className="flex flex1 flex-column u-marginRight--20">
+              <p className="card-item-title">
+                {isBitbucketServer ? "Project" : "Owner"}
+                <span className="card-item-title"> (Required)</span>
+              </p>
+              <input
+                type="text"
+                className={`Input ${
+                  providerError?.field === "owner" && "has-error"
+                }`}
+                placeholder={isBitbucketServer ? "project" : "owner"}
+                value={owner}
+                onChange={(e) => setOwner(e.target.value)}
+              />
+              {providerError?.field === "owner" && (
+                <p className="u-fontSize--small u-marginTop--5 u-color--chestnut u-fontWeight--medium u-lineHeight--normal">
+                  {isBitbucketServer
+                    ? "A project must be provided"
+                    : "An owner must be provided"}
+                </p>
+              )}
+            </div>
+            <Flex flex="1" direction="column">
+              <p className="card-item-title">
+                Repository <span className="card-item-title">(Required)</span>
+              </p>
+              <input
+                type="text"
+                className={`Input ${
+                  providerError?.field === "repo" && "has-error"
+                }`}
+                placeholder={"Repository"}
+                value={repo}
+                onChange={(e) => setRepo(e.target.value)}
+              />
+              {providerError?.field === "owner" && (
+                <p className="u-fontSize--small u-marginTop--5 u-color--chestnut u-fontWeight--medium u-lineHeight--normal">
+                  A repository must be provided
+                </p>
+              )}
+            </Flex>
+          </Flex>
+        )}
+
+        <Flex width="100%" mb="20">
+          <div className="flex flex1 flex-column u-marginRight--20">
+            <p className="card-item-title">Authentication</p>
+            <p className="u-fontSize--normal help-text-color u-fontWeight--medium u-lineHeight--normal u-marginBottom--10">
+              How commits are pushed to the repository.
+            </p>
+            <Select
+              className="replicated-select-container"
+              classNamePrefix="replicated-select"
+              options={AUTH_TYPES}
+              value={selectedAuthType}
+              getOptionValue={(t) => t.label}
+              isOptionSelected={(option) => option.value === authType}
+              onChange={(t) => setAuthType(t.value)}
+            />
           </div>
-          <Flex flex="1" direction="column">
-            <p className="card-item-title">
-              Repository <span className="card-item-title">(Required)</span>
+          <div className="flex flex1 flex-column">
+            <p className="card-item-title">Commit URL template</p>
+            <p className="u-fontSize--normal help-text-color u-fontWeight--medium u-lineHeight--normal u-marginBottom--10">
+              Optional. {"{hash}"} is replaced with the commit hash.
             </p>
             <input
               type="text"
-              className={`Input ${
-                providerError?.field === "repo" && "has-error"
-              }`}
-              placeholder={"Repository"}
-              value={repo}
-              onChange={(e) => setRepo(e.target.value)}
+              className="Input"
+              placeholder="https://git.example.com/owner/repo/commit/{hash}"
+              value={commitUrlTemplate}
+              onChange={(e) => setCommitUrlTemplate(e.target.value)}
             />
-            {providerError?.field === "owner" && (
-              <p className="u-fontSize--small u-marginTop--5 u-color--chestnut u-fontWeight--medium u-lineHeight--normal">
-                A repository must be provided
+          </div>
+        </Flex>
+
+        {!isSSH && (
+          <Flex width="100%" mb="20">
+            <div className="flex flex1 flex-column u-marginRight--20">
+              <p className="card-item-title">Username</p>
+              <p className="u-fontSize--normal help-text-color u-fontWeight--medium u-lineHeight--normal u-marginBottom--10">
+                {authType === "token"
+                  ? "Leave blank to use the default for access tokens."
+                  : "The user to push commits as."}
               </p>
-            )}
+              <input
+                type="text"
+                className="Input"
+                placeholder="username"
+                value={username}
+                onChange={(e) => setUsername(e.target.value)}
+              />
+            </div>
+            <div className="flex flex1 flex-column">
+              <p className="card-item-title">
+                {authType === "token" ? "Access token" : "Password"}
+              </p>
+              <p className="u-fontSize--normal help-text-color u-fontWeight--medium u-lineHeight--normal u-marginBottom--10">
+                Leave blank to keep the saved value.
+              </p>
+              <input
+                type="password"
+                className="Input"
+                autoComplete="new-password"
+                value={password}
+                onChange={(e) => setPassword(e.target.value)}
+              />
+            </div>
           </Flex>
-        </Flex>
+        )}
 
         <Flex width="100%">
           <div className="flex flex1 flex-column u-marginRight--20">
@@ -160,6 +287,25 @@ const GitopsRepoDetails = () => {
             />
           </div>
         </Flex>
+
+        <Flex width="100%" mt="20">
+          <div className="flex flex1 flex-column u-marginRight--20">
+            <p className="card-item-title">Format</p>
+            <p className="u-fontSize--normal help-text-color u-fontWeight--medium u-lineHeight--normal u-marginBottom--10">
+              How the application is written to the repository.
+            </p>
+            <Select
+              className="replicated-select-container"
+              classNamePrefix="replicated-select"
+              options={FORMATS}
+              value={selectedFormat}
+              getOptionValue={(f) => f.label}
+              isOptionSelected={(option) => option.value === format}
+              onChange={(f) => setFormat(f.value)}
+            />
+          </div>
+          <div className="flex flex1" />
+        </Flex>
       </Flex>
       <div
         className="flex justifyContent--flexEnd u-marginTop--30"
@@ -167,7 +313,7 @@ const GitopsRepoDetails = () => {
       >
         {finishingSetup ? (
           <Loader className="u-marginLeft--5" size="30" />
-        ) : gitopsConnected && gitopsEnabled ? (
+        ) : (gitopsConnected && gitopsEnabled) || !isSSH ? (
           <button
             className="btn primary blue"
             type="button"
@@ -25,12 +25,50 @@ export const SERVICES = [
     value: "bitbucket_server",
     label: "Bitbucket Server",
   },
+  {
+    value: "generic",
+    label: "Other Git server",
+  },
   // {
   //   value: "other",
   //   label: "Other",
   // }
 ];
 
+export const AUTH_TYPES = [
+  {
+    value: "ssh",
+    label: "SSH deploy key",
+  },
+  {
+    value: "token",
+    label: "HTTPS access token",
+  },
+  {
+    value: "basic",
+    label: "HTTPS username and password",
+  },
+];
+
+export const FORMATS = [
+  {
+    value: "single",
+    label: "Single file",
+  },
+  {
+    value: "files",
+    label: "One file per resource",
+  },
+  {
+    value: "kustomize",
+    label: "Kustomize base and overlays",
+  },
+  {
+    value: "helm",
+    label: "Helm charts and values",
+  },
+];
+
 export const BITBUCKET_SERVER_DEFAULT_HTTP_PORT = "7990";
 export const BITBUCKET_SERVER_DEFAULT_SSH_PORT = "7999";
 
@@ -34,6 +34,13 @@ const GitOpsProvider = ({ children }) => {
   const [path, setPath] = useState("");
   const [gitopsConnected, setGitopsConnected] = useState(false);
   const [gitopsEnabled, setGitopsEnabled] = useState(false);
+  const [authType, setAuthType] = useState("ssh");
+  const [username, setUsername] = useState("");
+  // the password or access token is never returned by the api, it is only sent when changed
+  const [password, setPassword] = useState("");
+  const [cloneUrl, setCloneUrl] = useState("");
+  const [commitUrlTemplate, setCommitUrlTemplate] = useState("");
+  const [format, setFormat] = useState("single");
 
   const provider = selectedService?.value;
 
@@ -52,6 +59,7 @@ const GitOpsProvider = ({ children }) => {
     }
 
     const currentGitops = app.downstream.gitops;
+    setFormat(currentGitops?.format || "single");
     if (!currentGitops?.uri) {
       setOwner("");
       setRepo("");
@@ -62,6 +70,17 @@ const GitOpsProvider = ({ children }) => {
       return "";
     }
 
+    if (currentGitops?.provider === "generic") {
+      // generic providers are identified by their clone url, which may not be an http url
+      setOwner("");
+      setRepo("");
+      setBranch(currentGitops.branch);
+      setPath(currentGitops.path);
+      setGitopsConnected(currentGitops.enabled);
+      setGitopsEnabled(currentGitops.isConnected);
+      return "";
+    }
+
     const parsed = new URL(currentGitops?.uri);
     if (currentGitops?.provider === "bitbucket_server") {
       const tempProject =
@@ -141,6 +160,11 @@ const GitOpsProvider = ({ children }) => {
       setHostname(freshGitops.hostname || "");
       setHttpPort(freshGitops.httpPort || "");
       setSshPort(freshGitops.sshPort || "");
+      setAuthType(freshGitops.authType || "ssh");
+      setUsername(freshGitops.username || "");
+      setPassword("");
+      setCloneUrl(freshGitops.cloneUrl || "");
+      setCommitUrlTemplate(freshGitops.commitUrlTemplate || "");
       setGitops(freshGitops);
     } else {
       setGitops(freshGitops);
@@ -179,6 +203,19 @@ const GitOpsProvider = ({ children }) => {
       gitOpsInput.sshPort = sshPort;
     }
 
+    gitOpsInput.authType = authType;
+    if (authType !== "ssh") {
+      gitOpsInput.username = username;
+      if (password) {
+        // the stored password or token is kept when a new one is not sent
+        gitOpsInput.password = password;
+      }
+    }
+    if (provider === "generic") {
+      gitOpsInput.cloneUrl = cloneUrl;
+    }
+    gitOpsInput.commitUrlTemplate = commitUrlTemplate;
+
     return gitOpsInput;
   };
 
@@ -263,7 +300,10 @@ const GitOpsProvider = ({ children }) => {
     const newHttpPort = httpPort || BITBUCKET_SERVER_DEFAULT_HTTP_PORT;
     const newSshPort = sshPort || BITBUCKET_SERVER_DEFAULT_SSH_PORT;
 
-    const repoUri = getGitOpsUri(provider, ownerRepo, hostname, httpPort);
+    const repoUri =
+      provider === "generic"
+        ? cloneUrl
+        : getGitOpsUri(provider, ownerRepo, hostname, httpPort);
     const gitOpsInput = getGitOpsInput(
       repoUri,
       tempBranch,
@@ -377,6 +417,18 @@ const GitOpsProvider = ({ children }) => {
         setGitopsConnected,
         gitopsEnabled,
         setGitopsEnabled,
+        authType,
+        setAuthType,
+        username,
+        setUsername,
+        password,
+        setPassword,
+        cloneUrl,
+        setCloneUrl,
+        commitUrlTemplate,
+        setCommitUrlTemplate,
+        format,
+        setFormat,
         handleServiceChange,
         finishSetup,
         handleAppChange,
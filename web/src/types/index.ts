@@ -177,6 +177,8 @@ export type ResourceStates = {
   namespace: string;
   // from https://github.com/replicatedhq/kots/blob/84b7e4e0e9275bb200a36be69691c4944eb8cf8f/pkg/appstate/types/types.go#L10-L14
   state: AppStatusState;
+  reason?: string;
+  message?: string;
 };
 
 export type SupportBundle = {
@@ -274,6 +276,7 @@ export type VersionStatus =
   | "pending_config"
   | "pending_download"
   | "pending_preflight"
+  | "rolled_back"
   | "waiting"
   | "unknown";
 